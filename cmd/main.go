@@ -21,15 +21,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"support-app-backend/docs"
 	"support-app-backend/internal/config"
 	"support-app-backend/internal/handlers"
+	"support-app-backend/internal/mail"
 	"support-app-backend/internal/middleware"
 	"support-app-backend/internal/models"
 	"support-app-backend/internal/repositories"
 	"support-app-backend/internal/services"
+	"support-app-backend/internal/storage"
 
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
@@ -42,13 +45,69 @@ import (
 
 // Application holds all application dependencies
 type Application struct {
-	Config         *config.Config
-	DB             *gorm.DB
-	AuthService    services.AuthService
-	SupportService services.SupportRequestService
-	AuthHandler    *handlers.AuthHandler
-	SupportHandler *handlers.SupportRequestHandler
-	Router         *gin.Engine
+	Config               *config.Config
+	DB                   *gorm.DB
+	AuthService          services.AuthService
+	SupportService       services.SupportRequestService
+	MessageService       services.SupportRequestMessageService
+	AttachmentService    services.AttachmentService
+	AssignmentService    services.AssignmentService
+	BulkService          services.SupportRequestBulkService
+	TrackingService      services.SupportRequestTrackingService
+	TagService           services.TagService
+	AuditService         services.AuditService
+	WebhookService       services.WebhookService
+	WebhookDispatcher    *services.WebhookDispatcher
+	SubmitterNotifier    *services.SubmitterNotifier
+	NotificationService  services.NotificationService
+	InboundEmailService  services.InboundEmailService
+	InboundEmailPoller   *services.InboundEmailPoller
+	IdempotencyService   services.IdempotencyService
+	IdempotencyCleaner   *services.IdempotencyKeyCleaner
+	PasswordResetService services.PasswordResetService
+	MFAService           services.MFAService
+	RoleService          services.RoleService
+	OrganizationService  services.OrganizationService
+	AppService           services.AppService
+	AuthHandler          *handlers.AuthHandler
+	SupportHandler       *handlers.SupportRequestHandler
+	MessageHandler       *handlers.SupportRequestMessageHandler
+	AttachmentHandler    *handlers.AttachmentHandler
+	AssignmentHandler    *handlers.AssignmentHandler
+	BulkHandler          *handlers.SupportRequestBulkHandler
+	TrackingHandler      *handlers.SupportRequestTrackingHandler
+	TagHandler           *handlers.TagHandler
+	AuditHandler         *handlers.AuditEventHandler
+	WebhookHandler       *handlers.WebhookHandler
+	PasswordResetHandler *handlers.PasswordResetHandler
+	MFAHandler           *handlers.MFAHandler
+	RoleHandler          *handlers.RoleHandler
+	OrganizationHandler  *handlers.OrganizationHandler
+	AppHandler           *handlers.AppHandler
+	NotificationHandler  *handlers.NotificationHandler
+	InboundEmailHandler  *handlers.InboundEmailHandler
+	Router               *gin.Engine
+}
+
+// routeHandlers groups the HTTP handlers registered by setupRouter
+type routeHandlers struct {
+	Support       *handlers.SupportRequestHandler
+	Auth          *handlers.AuthHandler
+	Message       *handlers.SupportRequestMessageHandler
+	Attachment    *handlers.AttachmentHandler
+	Assignment    *handlers.AssignmentHandler
+	Bulk          *handlers.SupportRequestBulkHandler
+	Tracking      *handlers.SupportRequestTrackingHandler
+	Tag           *handlers.TagHandler
+	Audit         *handlers.AuditEventHandler
+	Webhook       *handlers.WebhookHandler
+	PasswordReset *handlers.PasswordResetHandler
+	MFA           *handlers.MFAHandler
+	Role          *handlers.RoleHandler
+	Organization  *handlers.OrganizationHandler
+	App           *handlers.AppHandler
+	Notification  *handlers.NotificationHandler
+	InboundEmail  *handlers.InboundEmailHandler
 }
 
 func main() {
@@ -126,10 +185,77 @@ func (app *Application) initializeServices() error {
 	// Initialize repositories
 	supportRepo := repositories.NewSupportRequestRepository(app.DB)
 	userRepo := repositories.NewUserRepository(app.DB)
+	messageRepo := repositories.NewSupportRequestMessageRepository(app.DB)
+	attachmentRepo := repositories.NewAttachmentRepository(app.DB)
+	tagRepo := repositories.NewTagRepository(app.DB)
+	auditRepo := repositories.NewAuditEventRepository(app.DB)
+	webhookRepo := repositories.NewWebhookRepository(app.DB)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository(app.DB)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(app.DB)
+	passwordResetRepo := repositories.NewPasswordResetTokenRepository(app.DB)
+	loginThrottleRepo := repositories.NewLoginThrottleRepository(app.DB)
+	mfaRecoveryCodeRepo := repositories.NewMFARecoveryCodeRepository(app.DB)
+	roleRepo := repositories.NewRoleRepository(app.DB)
+	orgRepo := repositories.NewOrganizationRepository(app.DB)
+	appRepo := repositories.NewAppRepository(app.DB)
+	appKeyRepo := repositories.NewAppKeyRepository(app.DB)
+	notificationTemplateRepo := repositories.NewNotificationTemplateRepository(app.DB)
+	inboundEmailRepo := repositories.NewInboundEmailRepository(app.DB)
+	idempotencyKeyRepo := repositories.NewIdempotencyKeyRepository(app.DB)
+
+	// Initialize storage
+	blobStorage := storage.NewLocalStorage(app.Config.Storage.LocalPath)
+
+	// Initialize mail
+	mailer, err := newMailer(app.Config.Mail)
+	if err != nil {
+		return fmt.Errorf("invalid mail configuration: %w", err)
+	}
+
+	// Initialize SLA policy
+	slaPolicy, err := services.NewSLAPolicy(app.Config.SLA)
+	if err != nil {
+		return fmt.Errorf("invalid SLA policy: %w", err)
+	}
+
+	// Initialize webhook delivery
+	app.WebhookDispatcher = services.NewWebhookDispatcher(webhookRepo, webhookDeliveryRepo, app.Config.Webhook)
+
+	// Initialize submitter notifications, which follow the same support request events as webhooks
+	app.SubmitterNotifier = services.NewSubmitterNotifier(appRepo, notificationTemplateRepo, mailer, app.Config.Mail.From, app.Config.Notification)
+	events := services.NewEventPublishers(app.WebhookDispatcher, app.SubmitterNotifier)
+
+	// Initialize login brute-force protection
+	loginLimiter := services.NewLoginLimiter(loginThrottleRepo, app.Config.Login)
 
 	// Initialize services
-	app.AuthService = services.NewAuthService(userRepo, app.Config.JWT.SecretKey)
-	app.SupportService = services.NewSupportRequestService(supportRepo)
+	app.MFAService = services.NewMFAService(userRepo, mfaRecoveryCodeRepo, app.Config.MFA)
+	app.RoleService = services.NewRoleService(roleRepo)
+	app.AuthService = services.NewAuthService(userRepo, refreshTokenRepo, roleRepo, loginLimiter, app.MFAService, app.Config.JWT)
+	app.OrganizationService = services.NewOrganizationService(orgRepo, appRepo, userRepo, roleRepo)
+	app.AppService = services.NewAppService(appRepo, appKeyRepo)
+	app.SupportService = services.NewSupportRequestService(supportRepo, appRepo, slaPolicy, services.NewDuplicatePolicy(app.Config.Intake), events)
+	app.IdempotencyService = services.NewIdempotencyService(idempotencyKeyRepo, app.Config.Intake.IdempotencyKeyTTL)
+	app.IdempotencyCleaner = services.NewIdempotencyKeyCleaner(idempotencyKeyRepo)
+	// Submitters already get the reply itself, so status changes caused by replies only go to webhooks
+	app.MessageService = services.NewSupportRequestMessageService(messageRepo, supportRepo, app.SubmitterNotifier, app.WebhookDispatcher)
+	app.TrackingService = services.NewSupportRequestTrackingService(supportRepo, messageRepo, app.MessageService)
+	app.AttachmentService = services.NewAttachmentService(attachmentRepo, supportRepo, blobStorage, services.AttachmentLimits{
+		MaxSize:      app.Config.Storage.MaxAttachmentSize,
+		MaxCount:     app.Config.Storage.MaxAttachmentsPerRequest,
+		AllowedTypes: app.Config.Storage.AllowedMIMETypes,
+	})
+	app.AssignmentService = services.NewAssignmentService(supportRepo, userRepo, orgRepo)
+	app.BulkService = services.NewSupportRequestBulkService(supportRepo, tagRepo, userRepo, orgRepo, slaPolicy, events)
+	app.TagService = services.NewTagService(tagRepo, supportRepo)
+	app.AuditService = services.NewAuditService(auditRepo)
+	app.WebhookService = services.NewWebhookService(webhookRepo, webhookDeliveryRepo, app.WebhookDispatcher.Wake)
+	app.PasswordResetService = services.NewPasswordResetService(userRepo, passwordResetRepo, refreshTokenRepo, mailer, app.Config.PasswordReset)
+	app.NotificationService = services.NewNotificationService(appRepo, notificationTemplateRepo, supportRepo, app.Config.Notification)
+	app.InboundEmailService = services.NewInboundEmailService(inboundEmailRepo, supportRepo, appRepo, app.SupportService, app.MessageService, app.Config.InboundEmail, app.Config.Mail.From)
+	if app.Config.InboundEmail.MaildirPath != "" {
+		app.InboundEmailPoller = services.NewInboundEmailPoller(app.InboundEmailService, app.Config.InboundEmail)
+	}
 
 	// Create default admin account
 	if err := app.createDefaultAdmin(); err != nil {
@@ -148,19 +274,61 @@ func (app *Application) createDefaultAdmin() error {
 
 // initializeHandlers creates and initializes all HTTP handlers
 func (app *Application) initializeHandlers() error {
-	app.SupportHandler = handlers.NewSupportRequestHandler(app.SupportService)
+	app.SupportHandler = handlers.NewSupportRequestHandler(app.SupportService, app.AttachmentService)
 	app.AuthHandler = handlers.NewAuthHandler(app.AuthService)
+	app.MessageHandler = handlers.NewSupportRequestMessageHandler(app.MessageService)
+	app.AttachmentHandler = handlers.NewAttachmentHandler(app.AttachmentService)
+	app.AssignmentHandler = handlers.NewAssignmentHandler(app.AssignmentService)
+	app.BulkHandler = handlers.NewSupportRequestBulkHandler(app.BulkService)
+	app.TrackingHandler = handlers.NewSupportRequestTrackingHandler(app.TrackingService)
+	app.TagHandler = handlers.NewTagHandler(app.TagService)
+	app.AuditHandler = handlers.NewAuditEventHandler(app.AuditService)
+	app.WebhookHandler = handlers.NewWebhookHandler(app.WebhookService)
+	app.PasswordResetHandler = handlers.NewPasswordResetHandler(app.PasswordResetService)
+	app.MFAHandler = handlers.NewMFAHandler(app.MFAService)
+	app.RoleHandler = handlers.NewRoleHandler(app.RoleService)
+	app.OrganizationHandler = handlers.NewOrganizationHandler(app.OrganizationService)
+	app.AppHandler = handlers.NewAppHandler(app.AppService)
+	app.NotificationHandler = handlers.NewNotificationHandler(app.NotificationService)
+	app.InboundEmailHandler = handlers.NewInboundEmailHandler(app.InboundEmailService, app.Config.InboundEmail.Token)
 	return nil
 }
 
 // setupRouter configures and sets up the HTTP router
 func (app *Application) setupRouter() error {
-	app.Router = setupRouter(app.Config, app.SupportHandler, app.AuthHandler, app.AuthService)
+	app.Router = setupRouter(app.Config, routeHandlers{
+		Support:       app.SupportHandler,
+		Auth:          app.AuthHandler,
+		Message:       app.MessageHandler,
+		Attachment:    app.AttachmentHandler,
+		Assignment:    app.AssignmentHandler,
+		Bulk:          app.BulkHandler,
+		Tracking:      app.TrackingHandler,
+		Tag:           app.TagHandler,
+		Audit:         app.AuditHandler,
+		Webhook:       app.WebhookHandler,
+		PasswordReset: app.PasswordResetHandler,
+		MFA:           app.MFAHandler,
+		Role:          app.RoleHandler,
+		Organization:  app.OrganizationHandler,
+		App:           app.AppHandler,
+		Notification:  app.NotificationHandler,
+		InboundEmail:  app.InboundEmailHandler,
+	}, app.AuthService, app.RoleService, app.OrganizationService, app.AppService, app.IdempotencyService)
 	return nil
 }
 
-// Run starts the HTTP server
+// Run starts the webhook dispatcher, the submitter notifier, the inbound Maildir poller and the HTTP server
 func (app *Application) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go app.WebhookDispatcher.Run(ctx)
+	go app.SubmitterNotifier.Run(ctx)
+	go app.IdempotencyCleaner.Run(ctx)
+	if app.InboundEmailPoller != nil {
+		go app.InboundEmailPoller.Run(ctx)
+	}
+
 	log.Printf("Starting server on port %s", app.Config.Server.Port)
 
 	// Log Swagger documentation URL
@@ -217,27 +385,52 @@ func connectDatabase(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	return db, nil
 }
 
+// newMailer creates the mailer selected by the mail driver
+func newMailer(cfg config.MailConfig) (mail.Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+		}, cfg.From)
+	case "memory":
+		return mail.NewMemoryMailer(), nil
+	default:
+		return mail.NewFileMailer(cfg.FileDir, cfg.From), nil
+	}
+}
+
 func autoMigrate(db *gorm.DB) error {
-	// Run migrations for both tables
+	// Run migrations for all tables
 	// GORM will handle schema changes gracefully
-	return db.AutoMigrate(&models.SupportRequest{}, &models.User{})
+	return db.AutoMigrate(&models.Organization{}, &models.OrganizationMember{}, &models.App{}, &models.AppKey{}, &models.SupportRequest{}, &models.User{}, &models.SupportRequestMessage{}, &models.Attachment{}, &models.Tag{}, &models.AuditEvent{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.RefreshToken{}, &models.PasswordResetToken{}, &models.LoginThrottle{}, &models.MFARecoveryCode{}, &models.Role{}, &models.NotificationTemplate{}, &models.InboundEmail{}, &models.IdempotencyKey{})
 }
 
-func setupRouter(cfg *config.Config, supportHandler *handlers.SupportRequestHandler, authHandler *handlers.AuthHandler, authService services.AuthService) *gin.Engine {
+func setupRouter(cfg *config.Config, h routeHandlers, authService services.AuthService, roleService services.RoleService, organizationService services.OrganizationService, appService services.AppService, idempotencyService services.IdempotencyService) *gin.Engine {
 	// Set Gin mode based on environment
 	if cfg.Server.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	// Tracking and unsubscribe tokens are path segments and the inbound email token can be sent in the
+	// query string, so these routes are logged without them
+	router.Use(middleware.RequestLogger(
+		"/api/v1/support-request/track/:token",
+		"/api/v1/support-request/track/:token/messages",
+		"/api/v1/support-request/unsubscribe/:token",
+		"/api/v1/inbound/email",
+	), gin.Recovery())
 
 	// Add CORS middleware
 	router.Use(func(c *gin.Context) {
-		
+
 		// Set CORS headers
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Accept, X-Requested-With")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Accept, X-Requested-With, X-Organization-ID, X-App-Key")
 		c.Header("Access-Control-Expose-Headers", "Content-Length")
 		c.Header("Access-Control-Allow-Credentials", "false")
 		c.Header("Access-Control-Max-Age", "86400")
@@ -263,51 +456,217 @@ func setupRouter(cfg *config.Config, supportHandler *handlers.SupportRequestHand
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	// Health check endpoint (no authentication required)
-	router.GET("/health", supportHandler.HealthCheck)
+	router.GET("/health", h.Support.HealthCheck)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
 		// Public endpoints (with rate limiting)
 		rateLimiter := middleware.NewRateLimitMiddleware(cfg.Server.RateLimit, cfg.Server.RateBurst)
-		v1.POST("/support-request", rateLimiter.Middleware(), supportHandler.CreateSupportRequest)
-		
-		// Public support request viewing endpoints
-		v1.GET("/support-requests", supportHandler.GetAllSupportRequests)
-		v1.GET("/support-requests/:id", supportHandler.GetSupportRequest)
+
+		// Permission checks, run after AuthMiddleware
+		canReadTickets := middleware.RequirePermission(roleService, models.PermissionTicketsRead)
+		canUpdateTickets := middleware.RequirePermission(roleService, models.PermissionTicketsUpdate)
+		canDeleteTickets := middleware.RequirePermission(roleService, models.PermissionTicketsDelete)
+		canManageTags := middleware.RequirePermission(roleService, models.PermissionTagsManage)
+		canManageUsers := middleware.RequirePermission(roleService, models.PermissionUsersManage)
+		canManageWebhooks := middleware.RequirePermission(roleService, models.PermissionWebhooksManage)
+		canReadAudit := middleware.RequirePermission(roleService, models.PermissionAuditRead)
+		canManageOrganizations := middleware.RequirePermission(roleService, models.PermissionOrganizationsManage)
+		canManageApps := middleware.RequirePermission(roleService, models.PermissionAppsManage)
+		canReadStats := middleware.RequirePermission(roleService, models.PermissionStatsRead)
+
+		// Limits support request routes to the caller's organization and applies their
+		// membership role. It runs after authentication and before permission checks.
+		organizationScope := middleware.OrganizationScopeMiddleware(organizationService)
+
+		maxUploadSize := cfg.Storage.MaxAttachmentSize*int64(cfg.Storage.MaxAttachmentsPerRequest) + 1<<20 // plus 1 MB for form fields
+		// The app key is resolved before rate limiting so that each app gets its own limit
+		appKey := middleware.AppKeyMiddleware(appService, cfg.Intake.RequireAppKey)
+		// Retries with the same Idempotency-Key replay the first response instead of creating a duplicate
+		idempotency := middleware.IdempotencyMiddleware(idempotencyService)
+		v1.POST("/support-request", appKey, rateLimiter.Middleware(), middleware.BodySizeLimitMiddleware(maxUploadSize), idempotency, h.Support.CreateSupportRequest)
+
+		// Submitters follow and reply to their request with the tracking token returned on creation
+		v1.GET("/support-request/track/:token", rateLimiter.Middleware(), h.Tracking.TrackSupportRequest)
+		v1.POST("/support-request/track/:token/messages", rateLimiter.Middleware(), h.Tracking.ReplyToSupportRequest)
+
+		// Unsubscribe links of notification emails; mail clients POST for one-click unsubscribe
+		v1.GET("/support-request/unsubscribe/:token", rateLimiter.Middleware(), h.Notification.ConfirmUnsubscribe)
+		v1.POST("/support-request/unsubscribe/:token", rateLimiter.Middleware(), h.Notification.Unsubscribe)
+
+		// Emails sent to the support address, posted by the mail provider's inbound-parse webhook.
+		// Providers retry deliveries that were rate limited.
+		v1.POST("/inbound/email", rateLimiter.Middleware(), middleware.BodySizeLimitMiddleware(cfg.InboundEmail.MaxSize+1<<20), h.InboundEmail.ReceiveEmail) // plus 1 MB for form fields
 
 		// Authentication endpoints
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/login", authHandler.Login)
+			auth.POST("/login", rateLimiter.Middleware(), h.Auth.Login)
+			auth.POST("/login/mfa", rateLimiter.Middleware(), h.Auth.LoginMFA)
+			auth.POST("/login/mfa/enroll", rateLimiter.Middleware(), h.Auth.BeginLoginMFAEnrollment)
+			auth.POST("/refresh", rateLimiter.Middleware(), h.Auth.Refresh)
+			auth.POST("/logout", rateLimiter.Middleware(), h.Auth.Logout)
+			auth.POST("/password-reset/request", rateLimiter.Middleware(), h.PasswordReset.RequestReset)
+			auth.POST("/password-reset/confirm", rateLimiter.Middleware(), h.PasswordReset.ConfirmReset)
 
 			// Protected auth endpoints (require authentication)
 			authProtected := auth.Group("")
 			authProtected.Use(middleware.AuthMiddleware(authService))
 			{
-				authProtected.GET("/me", authHandler.GetCurrentUser)
-				authProtected.PATCH("/password", authHandler.ChangePassword)
-
-				// Admin-only user management endpoints
-				adminAuth := authProtected.Group("")
-				adminAuth.Use(middleware.AdminOnlyMiddleware())
+				authProtected.GET("/me", h.Auth.GetCurrentUser)
+				authProtected.GET("/me/organizations", h.Organization.ListMyOrganizations)
+				authProtected.PATCH("/password", h.Auth.ChangePassword)
+
+				// Two-factor authentication for the current user
+				authProtected.POST("/mfa/enroll", h.MFA.Enroll)
+				authProtected.POST("/mfa/confirm", h.MFA.Confirm)
+				authProtected.POST("/mfa/disable", h.MFA.Disable)
+				authProtected.POST("/mfa/recovery-codes", h.MFA.RegenerateRecoveryCodes)
+
+				// User management endpoints
+				users := authProtected.Group("/users")
+				users.Use(canManageUsers)
 				{
-					adminAuth.POST("/users", authHandler.CreateUser)
-					adminAuth.GET("/users", authHandler.GetAllUsers)
-					adminAuth.GET("/users/:id", authHandler.GetUser)
-					adminAuth.PATCH("/users/:id", authHandler.UpdateUser)
-					adminAuth.DELETE("/users/:id", authHandler.DeleteUser)
+					users.POST("", h.Auth.CreateUser)
+					users.GET("", h.Auth.GetAllUsers)
+					users.GET("/:id", h.Auth.GetUser)
+					users.PATCH("/:id", h.Auth.UpdateUser)
+					users.DELETE("/:id", h.Auth.DeleteUser)
+					users.GET("/:id/lock", h.Auth.GetUserLockStatus)
+					users.POST("/:id/unlock", h.Auth.UnlockUser)
 				}
 			}
 		}
 
-		// Admin endpoints for support requests (require authentication)
+		// Staff endpoints for support requests (require authentication and a permission)
 		admin := v1.Group("/support-requests")
 		admin.Use(middleware.AuthMiddleware(authService))
-		admin.Use(middleware.AdminOnlyMiddleware())
+		admin.Use(organizationScope)
+		{
+			// Listing and search include submitter emails, so they are never public
+			admin.GET("", canReadTickets, h.Support.GetAllSupportRequests)
+			admin.GET("/search", canReadTickets, h.Support.SearchSupportRequests)
+
+			// Streamed CSV/NDJSON export with the list endpoint filters
+			admin.GET("/export", canReadTickets, h.Support.ExportSupportRequests)
+
+			// Bulk changes by ID or filter, each in a single transaction
+			admin.POST("/bulk/update", canUpdateTickets, h.Bulk.BulkUpdateSupportRequests)
+			admin.POST("/bulk/delete", canDeleteTickets, h.Bulk.BulkDeleteSupportRequests)
+
+			admin.GET("/:id", canReadTickets, h.Support.GetSupportRequest)
+			admin.PATCH("/:id", canUpdateTickets, h.Support.UpdateSupportRequest)
+			admin.DELETE("/:id", canDeleteTickets, h.Support.DeleteSupportRequest)
+
+			// Merging duplicates, leaving the merged request as a redirecting stub
+			admin.POST("/:id/merge", canUpdateTickets, h.Support.MergeSupportRequest)
+
+			// Conversation replies
+			admin.GET("/:id/messages", canReadTickets, h.Message.ListMessages)
+			admin.POST("/:id/messages", canUpdateTickets, h.Message.CreateMessage)
+
+			// Attachment downloads
+			admin.GET("/:id/attachments", canReadTickets, h.Attachment.ListAttachments)
+			admin.GET("/:id/attachments/:attachmentId", canReadTickets, h.Attachment.DownloadAttachment)
+
+			// Assignment
+			admin.PUT("/:id/assignee", canUpdateTickets, h.Assignment.AssignSupportRequest)
+			admin.POST("/:id/assignee/me", canUpdateTickets, h.Assignment.SelfAssignSupportRequest)
+			admin.DELETE("/:id/assignee", canUpdateTickets, h.Assignment.UnassignSupportRequest)
+
+			// Tagging
+			admin.POST("/:id/tags", canUpdateTickets, h.Tag.AddSupportRequestTags)
+			admin.DELETE("/:id/tags/:name", canUpdateTickets, h.Tag.RemoveSupportRequestTag)
+		}
+
+		// Endpoints for managing tags. Anyone who can read tickets can list them.
+		tags := v1.Group("/tags")
+		tags.Use(middleware.AuthMiddleware(authService))
+		{
+			tags.GET("", organizationScope, canReadTickets, h.Tag.ListTags)
+			tags.POST("", canManageTags, h.Tag.CreateTag)
+			tags.PATCH("/:id", canManageTags, h.Tag.UpdateTag)
+			tags.DELETE("/:id", canManageTags, h.Tag.DeleteTag)
+		}
+
+		// Support request statistics, limited to the caller's organization
+		stats := v1.Group("/stats")
+		stats.Use(middleware.AuthMiddleware(authService))
+		stats.Use(organizationScope)
+		stats.Use(canReadStats)
+		{
+			stats.GET("", h.Support.GetSupportRequestStats)
+		}
+
+		// Audit log (read-only)
+		auditEvents := v1.Group("/audit-events")
+		auditEvents.Use(middleware.AuthMiddleware(authService))
+		auditEvents.Use(canReadAudit)
+		{
+			auditEvents.GET("", h.Audit.ListAuditEvents)
+		}
+
+		// Built-in and custom roles
+		roles := v1.Group("/roles")
+		roles.Use(middleware.AuthMiddleware(authService))
+		roles.Use(canManageUsers)
+		{
+			roles.GET("", h.Role.ListRoles)
+			roles.POST("", h.Role.CreateRole)
+			roles.GET("/:name", h.Role.GetRole)
+			roles.PATCH("/:name", h.Role.UpdateRole)
+			roles.DELETE("/:name", h.Role.DeleteRole)
+		}
+
+		// Organizations, their members and the apps they own
+		organizations := v1.Group("/organizations")
+		organizations.Use(middleware.AuthMiddleware(authService))
+		organizations.Use(canManageOrganizations)
+		{
+			organizations.GET("", h.Organization.ListOrganizations)
+			organizations.POST("", h.Organization.CreateOrganization)
+			organizations.GET("/:id", h.Organization.GetOrganization)
+			organizations.PATCH("/:id", h.Organization.UpdateOrganization)
+			organizations.DELETE("/:id", h.Organization.DeleteOrganization)
+			organizations.GET("/:id/members", h.Organization.ListMembers)
+			organizations.PUT("/:id/members/:userId", h.Organization.SetMember)
+			organizations.DELETE("/:id/members/:userId", h.Organization.RemoveMember)
+			organizations.GET("/:id/apps", h.Organization.ListApps)
+			organizations.POST("/:id/apps", h.Organization.AddApp)
+			organizations.DELETE("/:id/apps/:app", h.Organization.RemoveApp)
+		}
+
+		// App registry and app intake keys
+		apps := v1.Group("/apps")
+		apps.Use(middleware.AuthMiddleware(authService))
+		apps.Use(canManageApps)
+		{
+			apps.GET("", h.App.ListApps)
+			apps.POST("", h.App.CreateApp)
+			apps.GET("/:id", h.App.GetApp)
+			apps.PATCH("/:id", h.App.UpdateApp)
+			apps.DELETE("/:id", h.App.DeleteApp)
+			apps.GET("/:id/keys", h.App.ListAppKeys)
+			apps.POST("/:id/keys", h.App.CreateAppKey)
+			apps.DELETE("/:id/keys/:keyId", h.App.RevokeAppKey)
+			apps.GET("/:id/notification-templates", h.Notification.ListTemplates)
+			apps.PUT("/:id/notification-templates/:event", h.Notification.SetTemplate)
+			apps.DELETE("/:id/notification-templates/:event", h.Notification.DeleteTemplate)
+		}
+
+		// Endpoints for managing webhooks and their delivery log
+		webhooks := v1.Group("/webhooks")
+		webhooks.Use(middleware.AuthMiddleware(authService))
+		webhooks.Use(canManageWebhooks)
 		{
-			admin.PATCH("/:id", supportHandler.UpdateSupportRequest)
-			admin.DELETE("/:id", supportHandler.DeleteSupportRequest)
+			webhooks.GET("", h.Webhook.ListWebhooks)
+			webhooks.POST("", h.Webhook.CreateWebhook)
+			webhooks.GET("/:id", h.Webhook.GetWebhook)
+			webhooks.PATCH("/:id", h.Webhook.UpdateWebhook)
+			webhooks.DELETE("/:id", h.Webhook.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", h.Webhook.ListWebhookDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.Webhook.RedeliverWebhookDelivery)
 		}
 	}
 
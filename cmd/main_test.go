@@ -133,7 +133,7 @@ func TestSetupRouter_Development(t *testing.T) {
 	// Create a mock auth service
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	assert.NotNil(t, router)
 }
@@ -156,7 +156,7 @@ func TestSetupRouter_Production(t *testing.T) {
 	// Create a mock auth service
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	assert.NotNil(t, router)
 }
@@ -179,7 +179,7 @@ func TestSetupRouter_Routes(t *testing.T) {
 	// Create a mock auth service
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	// Get routes
 	routes := router.Routes()
@@ -251,10 +251,26 @@ func TestMain_Environment(t *testing.T) {
 // MockAuthServiceForRouter is a minimal mock for testing router setup
 type MockAuthServiceForRouter struct{}
 
-func (m *MockAuthServiceForRouter) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
+func (m *MockAuthServiceForRouter) Login(req *models.LoginRequest, clientIP string) (*models.LoginResponse, error) {
 	return nil, nil
 }
 
+func (m *MockAuthServiceForRouter) CompleteMFALogin(req *models.MFALoginRequest, clientIP string) (*models.LoginResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAuthServiceForRouter) BeginMFALoginEnrollment(mfaToken string) (*models.MFAEnrollmentResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAuthServiceForRouter) Refresh(refreshToken string) (*models.LoginResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAuthServiceForRouter) Logout(refreshToken string) error {
+	return nil
+}
+
 func (m *MockAuthServiceForRouter) CreateUser(req *models.CreateUserRequest) (*models.UserInfo, error) {
 	return nil, nil
 }
@@ -267,11 +283,19 @@ func (m *MockAuthServiceForRouter) GetAllUsers(page, pageSize int) ([]*models.Us
 	return nil, 0, nil
 }
 
-func (m *MockAuthServiceForRouter) UpdateUser(id uint, req *models.UpdateUserRequest) (*models.UserInfo, error) {
+func (m *MockAuthServiceForRouter) UpdateUser(id uint, req *models.UpdateUserRequest, actor models.AuditActor) (*models.UserInfo, error) {
+	return nil, nil
+}
+
+func (m *MockAuthServiceForRouter) DeleteUser(id uint, actor models.AuditActor) error {
+	return nil
+}
+
+func (m *MockAuthServiceForRouter) GetUserLockStatus(id uint) (*models.UserLockStatus, error) {
 	return nil, nil
 }
 
-func (m *MockAuthServiceForRouter) DeleteUser(id uint) error {
+func (m *MockAuthServiceForRouter) UnlockUser(id uint, actor models.AuditActor) error {
 	return nil
 }
 
@@ -292,6 +316,150 @@ func (m *MockAuthServiceForRouter) ValidateToken(tokenString string) (*models.Us
 	}, nil
 }
 
+// MockRoleServiceForRouter is a minimal mock for testing router setup
+type MockRoleServiceForRouter struct{}
+
+func (m *MockRoleServiceForRouter) ListRoles() ([]*models.RoleResponse, error) {
+	return models.BuiltinRoleResponses(), nil
+}
+
+func (m *MockRoleServiceForRouter) GetRole(name models.UserRole) (*models.RoleResponse, error) {
+	return nil, nil
+}
+
+func (m *MockRoleServiceForRouter) CreateRole(req *models.CreateRoleRequest) (*models.RoleResponse, error) {
+	return nil, nil
+}
+
+func (m *MockRoleServiceForRouter) UpdateRole(name models.UserRole, req *models.UpdateRoleRequest) (*models.RoleResponse, error) {
+	return nil, nil
+}
+
+func (m *MockRoleServiceForRouter) DeleteRole(name models.UserRole) error {
+	return nil
+}
+
+func (m *MockRoleServiceForRouter) HasPermission(role models.UserRole, permission models.Permission) (bool, error) {
+	return role == models.UserRoleAdmin, nil
+}
+
+// MockOrganizationServiceForRouter is a minimal mock for testing router setup
+type MockOrganizationServiceForRouter struct{}
+
+func (m *MockOrganizationServiceForRouter) ListOrganizations() ([]*models.OrganizationResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) GetOrganization(id uint) (*models.OrganizationResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) CreateOrganization(req *models.CreateOrganizationRequest) (*models.OrganizationResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) UpdateOrganization(id uint, req *models.UpdateOrganizationRequest) (*models.OrganizationResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) DeleteOrganization(id uint) error {
+	return nil
+}
+
+func (m *MockOrganizationServiceForRouter) ListMembers(organizationID uint) ([]*models.OrganizationMemberResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) SetMember(organizationID, userID uint, req *models.SetOrganizationMemberRequest) (*models.OrganizationMemberResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) RemoveMember(organizationID, userID uint) error {
+	return nil
+}
+
+func (m *MockOrganizationServiceForRouter) ListApps(organizationID uint) ([]*models.AppResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) AddApp(organizationID uint, req *models.AddOrganizationAppRequest) (*models.AppResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) RemoveApp(organizationID uint, slug string) error {
+	return nil
+}
+
+func (m *MockOrganizationServiceForRouter) ListUserOrganizations(userID uint) ([]*models.UserOrganizationResponse, error) {
+	return nil, nil
+}
+
+func (m *MockOrganizationServiceForRouter) ResolveScope(userID uint, requestedID *uint) (models.OrganizationScope, models.UserRole, error) {
+	return models.OrganizationScope{}, "", nil
+}
+
+// MockAppServiceForRouter is a minimal mock for testing router setup
+type MockAppServiceForRouter struct{}
+
+func (m *MockAppServiceForRouter) ListApps() ([]*models.AppResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAppServiceForRouter) GetApp(id uint) (*models.AppResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAppServiceForRouter) CreateApp(req *models.CreateAppRequest) (*models.AppResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAppServiceForRouter) UpdateApp(id uint, req *models.UpdateAppRequest) (*models.AppResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAppServiceForRouter) DeleteApp(id uint) error {
+	return nil
+}
+
+func (m *MockAppServiceForRouter) ListAppKeys(appID uint) ([]*models.AppKeyResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAppServiceForRouter) CreateAppKey(appID uint, req *models.CreateAppKeyRequest) (*models.AppKeyResponse, error) {
+	return nil, nil
+}
+
+func (m *MockAppServiceForRouter) RevokeAppKey(appID, keyID uint) error {
+	return nil
+}
+
+func (m *MockAppServiceForRouter) AuthenticateAppKey(key string) (*models.App, error) {
+	return nil, nil
+}
+
+// MockIdempotencyServiceForRouter is a minimal mock for testing router setup
+type MockIdempotencyServiceForRouter struct{}
+
+func (m *MockIdempotencyServiceForRouter) Begin(scope, key string) (*models.IdempotencyKey, error) {
+	return &models.IdempotencyKey{Scope: scope, Key: key}, nil
+}
+
+func (m *MockIdempotencyServiceForRouter) Check(key *models.IdempotencyKey, requestHash string) error {
+	return nil
+}
+
+func (m *MockIdempotencyServiceForRouter) Complete(key *models.IdempotencyKey, idempotencyKey, requestHash string, status int, body []byte) error {
+	return nil
+}
+
+func (m *MockIdempotencyServiceForRouter) Response(key *models.IdempotencyKey, idempotencyKey string) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *MockIdempotencyServiceForRouter) Release(key *models.IdempotencyKey) error {
+	return nil
+}
+
 func TestSetupRouter_CORS(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -308,7 +476,7 @@ func TestSetupRouter_CORS(t *testing.T) {
 	authHandler := &handlers.AuthHandler{}
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	// Test that CORS middleware is properly set up by checking routes
 	routes := router.Routes()
@@ -357,7 +525,7 @@ func TestSetupRouter_ProductionGinMode(t *testing.T) {
 	authHandler := &handlers.AuthHandler{}
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	assert.NotNil(t, router)
 	// The production mode should have been set during setupRouter execution
@@ -378,7 +546,7 @@ func TestSetupRouter_CORSMiddleware(t *testing.T) {
 	authHandler := &handlers.AuthHandler{}
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	// Verify router is created with CORS middleware
 	assert.NotNil(t, router)
@@ -403,7 +571,7 @@ func TestSetupRouter_RateLimitingConfiguration(t *testing.T) {
 	authHandler := &handlers.AuthHandler{}
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	// Verify router is created and has the rate-limited route
 	assert.NotNil(t, router)
@@ -434,7 +602,7 @@ func TestSetupRouter_AllEndpointsRegistered(t *testing.T) {
 	authHandler := &handlers.AuthHandler{}
 	mockAuthService := &MockAuthServiceForRouter{}
 
-	router := setupRouter(cfg, supportHandler, authHandler, mockAuthService)
+	router := setupRouter(cfg, routeHandlers{Support: supportHandler, Auth: authHandler}, mockAuthService, &MockRoleServiceForRouter{}, &MockOrganizationServiceForRouter{}, &MockAppServiceForRouter{}, &MockIdempotencyServiceForRouter{})
 
 	routes := router.Routes()
 	routeMap := make(map[string]bool)
@@ -448,17 +616,89 @@ func TestSetupRouter_AllEndpointsRegistered(t *testing.T) {
 		"GET /health",
 		"POST /api/v1/support-request",
 		"POST /api/v1/auth/login",
+		"POST /api/v1/auth/login/mfa",
+		"POST /api/v1/auth/login/mfa/enroll",
+		"POST /api/v1/auth/refresh",
+		"POST /api/v1/auth/logout",
+		"POST /api/v1/auth/password-reset/request",
+		"POST /api/v1/auth/password-reset/confirm",
 		"GET /api/v1/auth/me",
+		"GET /api/v1/auth/me/organizations",
 		"PATCH /api/v1/auth/password",
+		"POST /api/v1/auth/mfa/enroll",
+		"POST /api/v1/auth/mfa/confirm",
+		"POST /api/v1/auth/mfa/disable",
+		"POST /api/v1/auth/mfa/recovery-codes",
 		"POST /api/v1/auth/users",
 		"GET /api/v1/auth/users",
 		"GET /api/v1/auth/users/:id",
 		"PATCH /api/v1/auth/users/:id",
 		"DELETE /api/v1/auth/users/:id",
+		"GET /api/v1/auth/users/:id/lock",
+		"POST /api/v1/auth/users/:id/unlock",
+		"GET /api/v1/roles",
+		"POST /api/v1/roles",
+		"GET /api/v1/roles/:name",
+		"PATCH /api/v1/roles/:name",
+		"DELETE /api/v1/roles/:name",
+		"GET /api/v1/organizations",
+		"POST /api/v1/organizations",
+		"GET /api/v1/organizations/:id",
+		"PATCH /api/v1/organizations/:id",
+		"DELETE /api/v1/organizations/:id",
+		"GET /api/v1/organizations/:id/members",
+		"PUT /api/v1/organizations/:id/members/:userId",
+		"DELETE /api/v1/organizations/:id/members/:userId",
+		"GET /api/v1/organizations/:id/apps",
+		"POST /api/v1/organizations/:id/apps",
+		"DELETE /api/v1/organizations/:id/apps/:app",
+		"GET /api/v1/apps",
+		"POST /api/v1/apps",
+		"GET /api/v1/apps/:id",
+		"PATCH /api/v1/apps/:id",
+		"DELETE /api/v1/apps/:id",
+		"GET /api/v1/apps/:id/keys",
+		"POST /api/v1/apps/:id/keys",
+		"DELETE /api/v1/apps/:id/keys/:keyId",
+		"GET /api/v1/support-request/track/:token",
+		"POST /api/v1/support-request/track/:token/messages",
+		"GET /api/v1/apps/:id/notification-templates",
+		"PUT /api/v1/apps/:id/notification-templates/:event",
+		"DELETE /api/v1/apps/:id/notification-templates/:event",
+		"GET /api/v1/support-request/unsubscribe/:token",
+		"POST /api/v1/support-request/unsubscribe/:token",
+		"POST /api/v1/inbound/email",
 		"GET /api/v1/support-requests",
 		"GET /api/v1/support-requests/:id",
+		"GET /api/v1/support-requests/search",
+		"GET /api/v1/support-requests/export",
+		"POST /api/v1/support-requests/bulk/update",
+		"POST /api/v1/support-requests/bulk/delete",
 		"PATCH /api/v1/support-requests/:id",
 		"DELETE /api/v1/support-requests/:id",
+		"POST /api/v1/support-requests/:id/merge",
+		"GET /api/v1/support-requests/:id/messages",
+		"POST /api/v1/support-requests/:id/messages",
+		"GET /api/v1/support-requests/:id/attachments",
+		"GET /api/v1/support-requests/:id/attachments/:attachmentId",
+		"PUT /api/v1/support-requests/:id/assignee",
+		"POST /api/v1/support-requests/:id/assignee/me",
+		"DELETE /api/v1/support-requests/:id/assignee",
+		"POST /api/v1/support-requests/:id/tags",
+		"DELETE /api/v1/support-requests/:id/tags/:name",
+		"GET /api/v1/tags",
+		"POST /api/v1/tags",
+		"PATCH /api/v1/tags/:id",
+		"DELETE /api/v1/tags/:id",
+		"GET /api/v1/stats",
+		"GET /api/v1/audit-events",
+		"GET /api/v1/webhooks",
+		"POST /api/v1/webhooks",
+		"GET /api/v1/webhooks/:id",
+		"PATCH /api/v1/webhooks/:id",
+		"DELETE /api/v1/webhooks/:id",
+		"GET /api/v1/webhooks/:id/deliveries",
+		"POST /api/v1/webhooks/:id/deliveries/:deliveryId/redeliver",
 	}
 
 	for _, expectedRoute := range expectedRoutes {
@@ -1,20 +1,34 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	JWT      JWTConfig
+	Database      DatabaseConfig
+	Server        ServerConfig
+	Intake        IntakeConfig
+	JWT           JWTConfig
+	Login         LoginConfig
+	MFA           MFAConfig
+	Storage       StorageConfig
+	SLA           SLAConfig
+	Webhook       WebhookConfig
+	Mail          MailConfig
+	PasswordReset PasswordResetConfig
+	Notification  NotificationConfig
+	InboundEmail  InboundEmailConfig
 }
 
 // DatabaseConfig holds database configuration
@@ -36,9 +50,116 @@ type ServerConfig struct {
 	PublicDomain string // For Railway deployment or custom domain
 }
 
+// IntakeConfig holds settings for public support request submissions
+type IntakeConfig struct {
+	RequireAppKey       bool          // Reject submissions without an X-App-Key header
+	IdempotencyKeyTTL   time.Duration // How long the response to a submission with an Idempotency-Key is replayed
+	DuplicateWindow     time.Duration // How far back submissions are compared to flag duplicates, zero to disable
+	DuplicateSimilarity float64       // Minimum similarity of the messages of duplicates, from 0 to 1
+}
+
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	SecretKey string
+	SecretKey       string
+	AccessTokenTTL  time.Duration // Lifetime of access tokens
+	RefreshTokenTTL time.Duration // Lifetime of refresh tokens, renewed on every refresh
+	MFAChallengeTTL time.Duration // Lifetime of the challenge token between the password and the second factor
+}
+
+// LoginConfig holds brute-force protection settings for password logins
+type LoginConfig struct {
+	MaxFailures      int           // Failed logins per username before it is locked
+	MaxFailuresPerIP int           // Failed logins per client IP before it is locked
+	FailureWindow    time.Duration // Failures older than this are forgotten
+	Lockout          time.Duration // Length of the first lockout, doubled for each further lockout in a row
+	MaxLockout       time.Duration // Upper bound of a single lockout
+}
+
+// MFAConfig holds two-factor authentication settings
+type MFAConfig struct {
+	Issuer           string // Account issuer shown in authenticator apps
+	RequireForAdmins bool   // Admins must set up MFA before they can log in
+}
+
+// StorageConfig holds attachment storage configuration
+type StorageConfig struct {
+	LocalPath                string   // Root directory of the local filesystem blob storage
+	MaxAttachmentSize        int64    // Maximum size of a single attachment in bytes
+	MaxAttachmentsPerRequest int      // Maximum number of attachments per support request
+	AllowedMIMETypes         []string // Content types accepted for attachments (detected from file content)
+}
+
+// SLAConfig holds the service level targets for support requests
+type SLAConfig struct {
+	// Targets are keyed by priority ("urgent") or by request type and priority ("bug_report:urgent"),
+	// the latter overriding the former for that type
+	Targets       map[string]SLATarget
+	DueSoonWindow time.Duration // How long before a deadline a request counts as due soon
+}
+
+// SLATarget holds the response deadlines for one priority. A zero duration means no deadline.
+type SLATarget struct {
+	FirstResponse time.Duration
+	Resolution    time.Duration
+}
+
+// WebhookConfig holds outbound webhook delivery configuration
+type WebhookConfig struct {
+	MaxAttempts  int           // Attempts per delivery before it is marked as failed
+	RetryBackoff time.Duration // Delay before the first retry, doubled for every further retry
+	Timeout      time.Duration // HTTP timeout of a single delivery attempt
+	PollInterval time.Duration // How often the dispatcher looks for due retries
+}
+
+// MailConfig holds outgoing email configuration
+type MailConfig struct {
+	Driver       string // How mail is delivered: "file" writes every message into FileDir, "smtp" sends it through SMTPHost and "memory" keeps it in memory
+	From         string // Sender address of outgoing mail
+	FileDir      string // Directory the file driver writes messages to
+	SMTPHost     string // SMTP server of the smtp driver
+	SMTPPort     int
+	SMTPUsername string // Empty to send without authentication
+	SMTPPassword string
+}
+
+// PasswordResetConfig holds self-service password reset configuration
+type PasswordResetConfig struct {
+	TokenTTL time.Duration // How long a mailed reset token stays valid
+	URL      string        // Reset link mailed to users, "{token}" is replaced by the token. Empty mails the bare token.
+}
+
+// NotificationConfig holds configuration of the emails sent to support request submitters
+type NotificationConfig struct {
+	Enabled           bool   // Whether submitters are emailed about status changes and public replies
+	UnsubscribeURL    string // Unsubscribe link in notifications, "{token}" is replaced by the token
+	UnsubscribeSecret string // Key that signs unsubscribe tokens, derived from the JWT secret by default
+}
+
+// InboundEmailConfig holds configuration of support requests received by email
+type InboundEmailConfig struct {
+	Token        string        // Shared secret inbound-parse webhooks must send, empty disables the HTTP endpoint
+	DefaultApp   string        // Slug of the app for mail not addressed to an app with a plus address such as support+my-app@
+	MaildirPath  string        // Maildir polled for new mail, empty disables polling
+	PollInterval time.Duration // How often the Maildir is polled
+	MaxSize      int64         // Maximum size of a raw message in bytes
+}
+
+// defaultSLAPolicy is used for any priority not configured through SLA_POLICY
+const defaultSLAPolicy = "low=72h/168h,normal=24h/72h,high=4h/24h,urgent=1h/4h"
+
+// defaultSLADueSoonWindow is used when SLA_DUE_SOON_WINDOW isn't set
+const defaultSLADueSoonWindow = 2 * time.Hour
+
+// DefaultSLAConfig returns the SLA targets used when SLA_POLICY and SLA_DUE_SOON_WINDOW aren't set
+func DefaultSLAConfig() SLAConfig {
+	targets, err := parseSLAPolicy(defaultSLAPolicy)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default SLA policy: %v", err))
+	}
+	return SLAConfig{
+		Targets:       targets,
+		DueSoonWindow: defaultSLADueSoonWindow,
+	}
 }
 
 // Load loads configuration from environment variables
@@ -79,9 +200,125 @@ func Load() (*Config, error) {
 			RateBurst:    getEnvAsInt("RATE_BURST", 20),     // burst of 20 requests
 			PublicDomain: getPublicDomain(),
 		},
+		Intake: IntakeConfig{
+			RequireAppKey:       getEnvAsBool("INTAKE_REQUIRE_APP_KEY", false),
+			IdempotencyKeyTTL:   getEnvAsDuration("INTAKE_IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+			DuplicateWindow:     getEnvAsDuration("INTAKE_DUPLICATE_WINDOW", 24*time.Hour),
+			DuplicateSimilarity: getEnvAsFloat("INTAKE_DUPLICATE_SIMILARITY", 0.8),
+		},
 		JWT: JWTConfig{
-			SecretKey: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			SecretKey:       getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			AccessTokenTTL:  getEnvAsDuration("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getEnvAsDuration("JWT_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			MFAChallengeTTL: getEnvAsDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+		},
+		Login: LoginConfig{
+			MaxFailures:      getEnvAsInt("LOGIN_MAX_FAILURES", 5),
+			MaxFailuresPerIP: getEnvAsInt("LOGIN_MAX_FAILURES_PER_IP", 20),
+			FailureWindow:    getEnvAsDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+			Lockout:          getEnvAsDuration("LOGIN_LOCKOUT", time.Minute),
+			MaxLockout:       getEnvAsDuration("LOGIN_MAX_LOCKOUT", time.Hour),
+		},
+		MFA: MFAConfig{
+			Issuer:           getEnv("MFA_ISSUER", "Support App"),
+			RequireForAdmins: getEnvAsBool("MFA_REQUIRE_FOR_ADMINS", false),
+		},
+		Storage: StorageConfig{
+			LocalPath:                getEnv("STORAGE_LOCAL_PATH", "./data/attachments"),
+			MaxAttachmentSize:        int64(getEnvAsInt("ATTACHMENT_MAX_SIZE", 10*1024*1024)), // 10 MB
+			MaxAttachmentsPerRequest: getEnvAsInt("ATTACHMENT_MAX_COUNT", 5),
+			AllowedMIMETypes: getEnvAsList("ATTACHMENT_ALLOWED_TYPES", []string{
+				"image/png",
+				"image/jpeg",
+				"image/gif",
+				"image/webp",
+				"text/plain",
+				"application/pdf",
+				"application/zip",
+			}),
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts:  getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+			RetryBackoff: getEnvAsDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+			Timeout:      getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			PollInterval: getEnvAsDuration("WEBHOOK_POLL_INTERVAL", 15*time.Second),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "file"),
+			From:         getEnv("MAIL_FROM", "Support App <no-reply@supportapp.local>"),
+			FileDir:      getEnv("MAIL_FILE_DIR", "./data/mail"),
+			SMTPHost:     getEnv("MAIL_SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("MAIL_SMTP_PORT", 587),
+			SMTPUsername: getEnv("MAIL_SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("MAIL_SMTP_PASSWORD", ""),
+		},
+		PasswordReset: PasswordResetConfig{
+			TokenTTL: getEnvAsDuration("PASSWORD_RESET_TOKEN_TTL", time.Hour),
+			URL:      getEnv("PASSWORD_RESET_URL", ""),
 		},
+		Notification: NotificationConfig{
+			Enabled:           getEnvAsBool("NOTIFY_SUBMITTERS", true),
+			UnsubscribeURL:    getEnv("NOTIFY_UNSUBSCRIBE_URL", ""),
+			UnsubscribeSecret: getEnv("NOTIFY_UNSUBSCRIBE_SECRET", ""),
+		},
+		InboundEmail: InboundEmailConfig{
+			Token:        getEnv("INBOUND_EMAIL_TOKEN", ""),
+			DefaultApp:   getEnv("INBOUND_EMAIL_DEFAULT_APP", ""),
+			MaildirPath:  getEnv("INBOUND_EMAIL_MAILDIR", ""),
+			PollInterval: getEnvAsDuration("INBOUND_EMAIL_POLL_INTERVAL", time.Minute),
+			MaxSize:      int64(getEnvAsInt("INBOUND_EMAIL_MAX_SIZE", 10*1024*1024)), // 10 MB
+		},
+	}
+
+	if config.Notification.UnsubscribeURL == "" {
+		config.Notification.UnsubscribeURL = defaultUnsubscribeURL(config.Server)
+	}
+	if config.Notification.UnsubscribeSecret == "" {
+		config.Notification.UnsubscribeSecret = deriveSecret(config.JWT.SecretKey, "unsubscribe")
+	}
+
+	config.SLA = DefaultSLAConfig()
+	config.SLA.DueSoonWindow = getEnvAsDuration("SLA_DUE_SOON_WINDOW", defaultSLADueSoonWindow)
+	if value := os.Getenv("SLA_POLICY"); value != "" {
+		overrides, err := parseSLAPolicy(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SLA_POLICY: %w", err)
+		}
+		for key, target := range overrides {
+			config.SLA.Targets[key] = target
+		}
+	}
+
+	if config.JWT.AccessTokenTTL <= 0 || config.JWT.RefreshTokenTTL <= 0 || config.JWT.MFAChallengeTTL <= 0 {
+		return nil, fmt.Errorf("invalid token lifetimes: JWT_ACCESS_TOKEN_TTL, JWT_REFRESH_TOKEN_TTL and MFA_CHALLENGE_TTL must be positive")
+	}
+	if config.Login.MaxFailures < 1 || config.Login.MaxFailuresPerIP < 1 || config.Login.FailureWindow <= 0 ||
+		config.Login.Lockout <= 0 || config.Login.MaxLockout < config.Login.Lockout {
+		return nil, fmt.Errorf("invalid login lockout configuration: limits and durations must be positive and LOGIN_MAX_LOCKOUT at least LOGIN_LOCKOUT")
+	}
+	if config.Intake.IdempotencyKeyTTL <= 0 {
+		return nil, fmt.Errorf("invalid INTAKE_IDEMPOTENCY_KEY_TTL: must be positive")
+	}
+	if config.Intake.DuplicateWindow < 0 || config.Intake.DuplicateSimilarity <= 0 || config.Intake.DuplicateSimilarity > 1 {
+		return nil, fmt.Errorf("invalid duplicate detection: INTAKE_DUPLICATE_WINDOW must not be negative and INTAKE_DUPLICATE_SIMILARITY must be above 0 and at most 1")
+	}
+	if config.Webhook.MaxAttempts < 1 || config.Webhook.RetryBackoff <= 0 || config.Webhook.Timeout <= 0 || config.Webhook.PollInterval <= 0 {
+		return nil, fmt.Errorf("invalid webhook configuration: attempts and durations must be positive")
+	}
+	switch config.Mail.Driver {
+	case "file", "memory":
+	case "smtp":
+		if config.Mail.SMTPHost == "" || config.Mail.SMTPPort < 1 {
+			return nil, fmt.Errorf("invalid mail configuration: MAIL_DRIVER smtp requires MAIL_SMTP_HOST and a positive MAIL_SMTP_PORT")
+		}
+	default:
+		return nil, fmt.Errorf("invalid MAIL_DRIVER %q: must be file, smtp or memory", config.Mail.Driver)
+	}
+	if config.InboundEmail.PollInterval <= 0 || config.InboundEmail.MaxSize <= 0 {
+		return nil, fmt.Errorf("invalid inbound email configuration: INBOUND_EMAIL_POLL_INTERVAL and INBOUND_EMAIL_MAX_SIZE must be positive")
+	}
+	if config.PasswordReset.TokenTTL <= 0 {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_TOKEN_TTL: must be positive")
 	}
 
 	// Validate configuration for security
@@ -179,6 +416,100 @@ func getEnvAsFloat(key string, fallback float64) float64 {
 	return fallback
 }
 
+// getEnvAsBool gets an environment variable as a boolean (e.g. "true", "1") with a fallback value
+func getEnvAsBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return fallback
+}
+
+// getEnvAsDuration gets an environment variable as a duration (e.g. "90m") with a fallback value
+func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return fallback
+}
+
+// getEnvAsList gets a comma-separated environment variable as a string slice with a fallback value
+func getEnvAsList(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	if len(values) == 0 {
+		return fallback
+	}
+	return values
+}
+
+// parseSLAPolicy parses a comma-separated SLA policy such as "urgent=1h/4h,feedback:low=168h/0".
+// Each entry maps a priority, optionally prefixed with a request type, to its first response and
+// resolution durations. A duration of 0 disables that deadline.
+func parseSLAPolicy(value string) (map[string]SLATarget, error) {
+	targets := make(map[string]SLATarget)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, durations, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid entry %q: expected <priority>=<first response>/<resolution>", entry)
+		}
+		firstResponse, resolution, found := strings.Cut(durations, "/")
+		if !found {
+			return nil, fmt.Errorf("invalid entry %q: expected <priority>=<first response>/<resolution>", entry)
+		}
+
+		var target SLATarget
+		var err error
+		if target.FirstResponse, err = time.ParseDuration(strings.TrimSpace(firstResponse)); err != nil || target.FirstResponse < 0 {
+			return nil, fmt.Errorf("invalid first response duration in %q", entry)
+		}
+		if target.Resolution, err = time.ParseDuration(strings.TrimSpace(resolution)); err != nil || target.Resolution < 0 {
+			return nil, fmt.Errorf("invalid resolution duration in %q", entry)
+		}
+
+		targets[strings.TrimSpace(key)] = target
+	}
+	return targets, nil
+}
+
+// defaultUnsubscribeURL returns the unsubscribe endpoint of this server on its public domain
+func defaultUnsubscribeURL(server ServerConfig) string {
+	domain := server.PublicDomain
+	if domain == "" {
+		domain = "localhost:" + server.Port
+	}
+	scheme := "https"
+	if strings.HasPrefix(domain, "localhost") {
+		scheme = "http"
+	}
+	return scheme + "://" + domain + "/api/v1/support-request/unsubscribe/{token}"
+}
+
+// deriveSecret derives a key for one purpose from a secret, so that the secret itself doesn't sign
+// anything else
+func deriveSecret(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // getPublicDomain determines the public domain for the application
 // Returns Railway domain if deployed there, otherwise localhost for development
 func getPublicDomain() string {
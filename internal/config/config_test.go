@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -390,3 +391,300 @@ func TestGetEnvAsFloat_WithFallback(t *testing.T) {
 	result := getEnvAsFloat("MISSING_FLOAT", 1.0)
 	assert.Equal(t, 1.0, result)
 }
+
+func TestGetEnvAsBool(t *testing.T) {
+	os.Setenv("TEST_BOOL", "true")
+	os.Setenv("INVALID_BOOL", "sometimes")
+	defer os.Unsetenv("TEST_BOOL")
+	defer os.Unsetenv("INVALID_BOOL")
+	os.Unsetenv("MISSING_BOOL")
+
+	assert.True(t, getEnvAsBool("TEST_BOOL", false))
+	assert.True(t, getEnvAsBool("INVALID_BOOL", true))
+	assert.False(t, getEnvAsBool("MISSING_BOOL", false))
+}
+
+func TestLoad_MFADefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "Support App", config.MFA.Issuer)
+	assert.False(t, config.MFA.RequireForAdmins)
+	assert.Equal(t, 5*time.Minute, config.JWT.MFAChallengeTTL)
+}
+
+func TestLoad_MFARequiredForAdmins(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("MFA_REQUIRE_FOR_ADMINS", "true")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("MFA_REQUIRE_FOR_ADMINS")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.True(t, config.MFA.RequireForAdmins)
+}
+
+func TestLoad_StorageDefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "./data/attachments", config.Storage.LocalPath)
+	assert.Equal(t, int64(10*1024*1024), config.Storage.MaxAttachmentSize)
+	assert.Equal(t, 5, config.Storage.MaxAttachmentsPerRequest)
+	assert.Contains(t, config.Storage.AllowedMIMETypes, "image/png")
+}
+
+func TestLoad_SLADefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, SLATarget{FirstResponse: time.Hour, Resolution: 4 * time.Hour}, config.SLA.Targets["urgent"])
+	assert.Equal(t, SLATarget{FirstResponse: 24 * time.Hour, Resolution: 72 * time.Hour}, config.SLA.Targets["normal"])
+	assert.Equal(t, 2*time.Hour, config.SLA.DueSoonWindow)
+}
+
+func TestLoad_SLAPolicyOverrides(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("SLA_POLICY", "urgent=30m/2h, feedback:low=0/0")
+	os.Setenv("SLA_DUE_SOON_WINDOW", "45m")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("SLA_POLICY")
+	defer os.Unsetenv("SLA_DUE_SOON_WINDOW")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, SLATarget{FirstResponse: 30 * time.Minute, Resolution: 2 * time.Hour}, config.SLA.Targets["urgent"])
+	assert.Equal(t, SLATarget{}, config.SLA.Targets["feedback:low"])
+	// Priorities not mentioned keep their defaults
+	assert.Equal(t, SLATarget{FirstResponse: 4 * time.Hour, Resolution: 24 * time.Hour}, config.SLA.Targets["high"])
+	assert.Equal(t, 45*time.Minute, config.SLA.DueSoonWindow)
+}
+
+func TestLoad_InvalidSLAPolicy(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("SLA_POLICY", "urgent=soon")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("SLA_POLICY")
+
+	config, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func TestLoad_TokenLifetimes(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("JWT_ACCESS_TOKEN_TTL", "5m")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("JWT_ACCESS_TOKEN_TTL")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, config.JWT.AccessTokenTTL)
+	assert.Equal(t, 30*24*time.Hour, config.JWT.RefreshTokenTTL)
+}
+
+func TestLoad_LoginDefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5, config.Login.MaxFailures)
+	assert.Equal(t, 20, config.Login.MaxFailuresPerIP)
+	assert.Equal(t, 15*time.Minute, config.Login.FailureWindow)
+	assert.Equal(t, time.Minute, config.Login.Lockout)
+	assert.Equal(t, time.Hour, config.Login.MaxLockout)
+}
+
+func TestLoad_InvalidLoginConfig(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("LOGIN_LOCKOUT", "2h")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("LOGIN_LOCKOUT")
+
+	config, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func TestLoad_WebhookDefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5, config.Webhook.MaxAttempts)
+	assert.Equal(t, 30*time.Second, config.Webhook.RetryBackoff)
+	assert.Equal(t, 10*time.Second, config.Webhook.Timeout)
+	assert.Equal(t, 15*time.Second, config.Webhook.PollInterval)
+}
+
+func TestLoad_WebhookOverrides(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("WEBHOOK_MAX_ATTEMPTS", "8")
+	os.Setenv("WEBHOOK_RETRY_BACKOFF", "1m")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("WEBHOOK_MAX_ATTEMPTS")
+	defer os.Unsetenv("WEBHOOK_RETRY_BACKOFF")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 8, config.Webhook.MaxAttempts)
+	assert.Equal(t, time.Minute, config.Webhook.RetryBackoff)
+}
+
+func TestLoad_InvalidWebhookConfig(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("WEBHOOK_POLL_INTERVAL", "0s")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("WEBHOOK_POLL_INTERVAL")
+
+	config, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func TestLoad_MailAndPasswordResetDefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "file", config.Mail.Driver)
+	assert.Equal(t, "./data/mail", config.Mail.FileDir)
+	assert.NotEmpty(t, config.Mail.From)
+	assert.Equal(t, time.Hour, config.PasswordReset.TokenTTL)
+	assert.Empty(t, config.PasswordReset.URL)
+}
+
+func TestLoad_InvalidMailDriver(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("MAIL_DRIVER", "carrier-pigeon")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("MAIL_DRIVER")
+
+	config, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func TestLoad_InvalidPasswordResetTTL(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("PASSWORD_RESET_TOKEN_TTL", "0s")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("PASSWORD_RESET_TOKEN_TTL")
+
+	config, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func TestParseSLAPolicy_InvalidEntries(t *testing.T) {
+	for _, value := range []string{"urgent", "=1h/2h", "urgent=1h", "urgent=1h/two", "urgent=-1h/2h"} {
+		_, err := parseSLAPolicy(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestGetEnvAsList_WithValue(t *testing.T) {
+	os.Setenv("TEST_LIST", " image/png, text/plain ,,")
+	defer os.Unsetenv("TEST_LIST")
+
+	assert.Equal(t, []string{"image/png", "text/plain"}, getEnvAsList("TEST_LIST", []string{"fallback"}))
+}
+
+func TestGetEnvAsList_WithFallback(t *testing.T) {
+	os.Unsetenv("TEST_LIST")
+
+	assert.Equal(t, []string{"fallback"}, getEnvAsList("TEST_LIST", []string{"fallback"}))
+}
+
+func TestLoad_SMTPMailDriver(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("MAIL_DRIVER", "smtp")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("MAIL_DRIVER")
+
+	// The smtp driver needs a host
+	config, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, config)
+
+	os.Setenv("MAIL_SMTP_HOST", "smtp.example.com")
+	defer os.Unsetenv("MAIL_SMTP_HOST")
+
+	config, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com", config.Mail.SMTPHost)
+	assert.Equal(t, 587, config.Mail.SMTPPort)
+}
+
+func TestLoad_NotificationDefaults(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	os.Setenv("PUBLIC_DOMAIN", "support.example.com")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("PUBLIC_DOMAIN")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.True(t, config.Notification.Enabled)
+	assert.Equal(t, "https://support.example.com/api/v1/support-request/unsubscribe/{token}", config.Notification.UnsubscribeURL)
+	assert.NotEmpty(t, config.Notification.UnsubscribeSecret)
+	assert.NotEqual(t, config.JWT.SecretKey, config.Notification.UnsubscribeSecret, "JWTs and unsubscribe tokens are signed with different keys")
+	assert.Equal(t, deriveSecret(config.JWT.SecretKey, "unsubscribe"), config.Notification.UnsubscribeSecret)
+}
+
+func TestLoad_InboundEmail(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, config.InboundEmail.Token, "the HTTP endpoint is disabled by default")
+	assert.Empty(t, config.InboundEmail.MaildirPath, "polling is disabled by default")
+	assert.Equal(t, time.Minute, config.InboundEmail.PollInterval)
+	assert.Equal(t, int64(10*1024*1024), config.InboundEmail.MaxSize)
+
+	os.Setenv("INBOUND_EMAIL_POLL_INTERVAL", "0s")
+	defer os.Unsetenv("INBOUND_EMAIL_POLL_INTERVAL")
+
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_IdempotencyKeyTTL(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, config.Intake.IdempotencyKeyTTL)
+
+	os.Setenv("INTAKE_IDEMPOTENCY_KEY_TTL", "0s")
+	defer os.Unsetenv("INTAKE_IDEMPOTENCY_KEY_TTL")
+
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_DuplicateDetection(t *testing.T) {
+	os.Setenv("JWT_SECRET", "development-secret-key-that-is-long-enough-to-pass-validation")
+	defer os.Unsetenv("JWT_SECRET")
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, config.Intake.DuplicateWindow)
+	assert.Equal(t, 0.8, config.Intake.DuplicateSimilarity)
+
+	os.Setenv("INTAKE_DUPLICATE_SIMILARITY", "1.5")
+	defer os.Unsetenv("INTAKE_DUPLICATE_SIMILARITY")
+
+	_, err = Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AppHandler handles HTTP requests for the app registry and app intake keys
+type AppHandler struct {
+	service services.AppService
+}
+
+// NewAppHandler creates a new app handler
+func NewAppHandler(service services.AppService) *AppHandler {
+	return &AppHandler{
+		service: service,
+	}
+}
+
+// ListApps handles GET /api/v1/apps
+// @Summary List apps
+// @Description Retrieve the registered apps ordered by slug (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Apps retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Router /apps [get]
+func (h *AppHandler) ListApps(c *gin.Context) {
+	apps, err := h.service.ListApps()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve apps"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": apps})
+}
+
+// GetApp handles GET /api/v1/apps/:id
+// @Summary Get app
+// @Description Retrieve a registered app by ID (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Success 200 {object} map[string]interface{} "App retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id} [get]
+func (h *AppHandler) GetApp(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GetApp(id)
+	if err != nil {
+		respondAppError(c, err, "Failed to retrieve app")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// CreateApp handles POST /api/v1/apps
+// @Summary Register app
+// @Description Register an app so it can submit support requests (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateAppRequest true "App data"
+// @Success 201 {object} map[string]interface{} "App registered successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request, slug or platform"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 409 {object} map[string]interface{} "App already exists"
+// @Router /apps [post]
+func (h *AppHandler) CreateApp(c *gin.Context) {
+	var req models.CreateAppRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.CreateApp(&req)
+	if err != nil {
+		respondAppError(c, err, "Failed to register app")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+// UpdateApp handles PATCH /api/v1/apps/:id
+// @Summary Update app
+// @Description Change the display name or allowed platforms of an app, or disable it to reject new support requests (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Param request body models.UpdateAppRequest true "App update data"
+// @Success 200 {object} map[string]interface{} "App updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or platform"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id} [patch]
+func (h *AppHandler) UpdateApp(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAppRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.UpdateApp(id, &req)
+	if err != nil {
+		respondAppError(c, err, "Failed to update app")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// DeleteApp handles DELETE /api/v1/apps/:id
+// @Summary Unregister app
+// @Description Unregister an app and revoke its keys. Support requests already submitted are kept (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Success 200 {object} map[string]interface{} "App deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id} [delete]
+func (h *AppHandler) DeleteApp(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteApp(id); err != nil {
+		respondAppError(c, err, "Failed to delete app")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "App deleted successfully"})
+}
+
+// ListAppKeys handles GET /api/v1/apps/:id/keys
+// @Summary List app keys
+// @Description Retrieve the intake keys of an app. Only the start of each key is returned (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Success 200 {object} map[string]interface{} "Keys retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id}/keys [get]
+func (h *AppHandler) ListAppKeys(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.ListAppKeys(id)
+	if err != nil {
+		respondAppError(c, err, "Failed to retrieve app keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": keys})
+}
+
+// CreateAppKey handles POST /api/v1/apps/:id/keys
+// @Summary Issue app key
+// @Description Issue an intake key for an app. The full key is only returned in this response (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Param request body models.CreateAppKeyRequest true "Key label"
+// @Success 201 {object} map[string]interface{} "Key issued successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id}/keys [post]
+func (h *AppHandler) CreateAppKey(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CreateAppKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.CreateAppKey(id, &req)
+	if err != nil {
+		respondAppError(c, err, "Failed to issue app key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+// RevokeAppKey handles DELETE /api/v1/apps/:id/keys/:keyId
+// @Summary Revoke app key
+// @Description Revoke an intake key. Clients still sending it are rejected (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Param keyId path int true "Key ID"
+// @Success 200 {object} map[string]interface{} "Key revoked successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App key not found"
+// @Router /apps/{id}/keys/{keyId} [delete]
+func (h *AppHandler) RevokeAppKey(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	keyID, ok := parseUintParam(c, "keyId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeAppKey(id, keyID); err != nil {
+		respondAppError(c, err, "Failed to revoke app key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "App key revoked successfully"})
+}
+
+// respondAppError maps app service errors to HTTP responses
+func respondAppError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrAppNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "App not found"})
+	case errors.Is(err, services.ErrAppKeyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "App key not found"})
+	case errors.Is(err, services.ErrAppExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "App already exists"})
+	case errors.Is(err, services.ErrInvalidAppSlug), errors.Is(err, services.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAppService is a mock implementation of AppService
+type MockAppService struct {
+	mock.Mock
+}
+
+func (m *MockAppService) ListApps() ([]*models.AppResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) GetApp(id uint) (*models.AppResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) CreateApp(req *models.CreateAppRequest) (*models.AppResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) UpdateApp(id uint, req *models.UpdateAppRequest) (*models.AppResponse, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) DeleteApp(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAppService) ListAppKeys(appID uint) ([]*models.AppKeyResponse, error) {
+	args := m.Called(appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AppKeyResponse), args.Error(1)
+}
+
+func (m *MockAppService) CreateAppKey(appID uint, req *models.CreateAppKeyRequest) (*models.AppKeyResponse, error) {
+	args := m.Called(appID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppKeyResponse), args.Error(1)
+}
+
+func (m *MockAppService) RevokeAppKey(appID, keyID uint) error {
+	args := m.Called(appID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAppService) AuthenticateAppKey(key string) (*models.App, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.App), args.Error(1)
+}
+
+func setupAppHandler() (*AppHandler, *MockAppService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockAppService)
+	return NewAppHandler(mockService), mockService
+}
+
+func performAppRequest(handle gin.HandlerFunc, method, body string, params gin.Params) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/apps", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	handle(c)
+	return w
+}
+
+func TestAppHandler_CreateApp_Success(t *testing.T) {
+	handler, mockService := setupAppHandler()
+	mockService.On("CreateApp", &models.CreateAppRequest{Slug: "acme-ios", DisplayName: "Acme", Platforms: []models.Platform{models.PlatformIOS}}).
+		Return(&models.AppResponse{ID: 1, Slug: "acme-ios", DisplayName: "Acme", Platforms: []models.Platform{models.PlatformIOS}, IsActive: true}, nil)
+
+	w := performAppRequest(handler.CreateApp, http.MethodPost, `{"slug":"acme-ios","display_name":"Acme","platforms":["iOS"]}`, nil)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"slug":"acme-ios"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAppHandler_CreateApp_MissingDisplayName(t *testing.T) {
+	handler, mockService := setupAppHandler()
+
+	w := performAppRequest(handler.CreateApp, http.MethodPost, `{"slug":"acme-ios"}`, nil)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateApp", mock.Anything)
+}
+
+func TestAppHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"app not found", services.ErrAppNotFound, http.StatusNotFound},
+		{"app exists", services.ErrAppExists, http.StatusConflict},
+		{"invalid slug", services.ErrInvalidAppSlug, http.StatusBadRequest},
+		{"invalid platform", fmt.Errorf("%w: unknown platform %q", services.ErrInvalidRequest, "Windows"), http.StatusBadRequest},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupAppHandler()
+			mockService.On("UpdateApp", uint(1), mock.Anything).Return(nil, tt.err)
+
+			w := performAppRequest(handler.UpdateApp, http.MethodPatch, `{"is_active":false}`, gin.Params{{Key: "id", Value: "1"}})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAppHandler_UpdateApp_Disable(t *testing.T) {
+	handler, mockService := setupAppHandler()
+	mockService.On("UpdateApp", uint(1), mock.MatchedBy(func(req *models.UpdateAppRequest) bool {
+		return req.IsActive != nil && !*req.IsActive && req.DisplayName == nil
+	})).Return(&models.AppResponse{ID: 1, Slug: "acme-ios", IsActive: false}, nil)
+
+	w := performAppRequest(handler.UpdateApp, http.MethodPatch, `{"is_active":false}`, gin.Params{{Key: "id", Value: "1"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"is_active":false`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAppHandler_CreateAppKey_ReturnsKey(t *testing.T) {
+	handler, mockService := setupAppHandler()
+	mockService.On("CreateAppKey", uint(1), &models.CreateAppKeyRequest{Name: "iOS 2.x"}).
+		Return(&models.AppKeyResponse{ID: 4, AppID: 1, Name: "iOS 2.x", Prefix: "ak_abcdefg", Key: "ak_abcdefghijk"}, nil)
+
+	w := performAppRequest(handler.CreateAppKey, http.MethodPost, `{"name":"iOS 2.x"}`, gin.Params{{Key: "id", Value: "1"}})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"ak_abcdefghijk"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAppHandler_RevokeAppKey_NotFound(t *testing.T) {
+	handler, mockService := setupAppHandler()
+	mockService.On("RevokeAppKey", uint(1), uint(4)).Return(services.ErrAppKeyNotFound)
+
+	w := performAppRequest(handler.RevokeAppKey, http.MethodDelete, "", gin.Params{{Key: "id", Value: "1"}, {Key: "keyId", Value: "4"}})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAppHandler_RevokeAppKey_InvalidKeyID(t *testing.T) {
+	handler, mockService := setupAppHandler()
+
+	w := performAppRequest(handler.RevokeAppKey, http.MethodDelete, "", gin.Params{{Key: "id", Value: "1"}, {Key: "keyId", Value: "abc"}})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "RevokeAppKey", mock.Anything, mock.Anything)
+}
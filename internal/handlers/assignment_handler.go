@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AssignmentHandler handles HTTP requests for assigning support requests to agents
+type AssignmentHandler struct {
+	service services.AssignmentService
+}
+
+// NewAssignmentHandler creates a new assignment handler
+func NewAssignmentHandler(service services.AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{
+		service: service,
+	}
+}
+
+// AssignSupportRequest handles PUT /api/v1/support-requests/:id/assignee
+// @Summary Assign support request (Admin only)
+// @Description Assign a support request to an active user, replacing any previous assignee (requires admin authentication)
+// @Tags Support Request Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Param request body models.AssignSupportRequestRequest true "Assignee"
+// @Success 200 {object} map[string]interface{} "Support request assigned successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Failure 422 {object} map[string]interface{} "Assignee is not an active user or not a member of the organization"
+// @Router /support-requests/{id}/assignee [put]
+func (h *AssignmentHandler) AssignSupportRequest(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req models.AssignSupportRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.AssignSupportRequest(uint(id), organizationScope(c), req.AssigneeID)
+	if err != nil {
+		respondAssignmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// SelfAssignSupportRequest handles POST /api/v1/support-requests/:id/assignee/me
+// @Summary Self-assign support request (Admin only)
+// @Description Assign a support request to the authenticated user (requires admin authentication)
+// @Tags Support Request Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Success 200 {object} map[string]interface{} "Support request assigned successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Router /support-requests/{id}/assignee/me [post]
+func (h *AssignmentHandler) SelfAssignSupportRequest(c *gin.Context) {
+	// Get user ID from JWT claims
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	response, err := h.service.AssignSupportRequest(uint(id), organizationScope(c), userID.(uint))
+	if err != nil {
+		respondAssignmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// UnassignSupportRequest handles DELETE /api/v1/support-requests/:id/assignee
+// @Summary Unassign support request (Admin only)
+// @Description Remove the assignee of a support request (requires admin authentication)
+// @Tags Support Request Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Success 200 {object} map[string]interface{} "Support request unassigned successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Router /support-requests/{id}/assignee [delete]
+func (h *AssignmentHandler) UnassignSupportRequest(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	response, err := h.service.UnassignSupportRequest(uint(id), organizationScope(c))
+	if err != nil {
+		respondAssignmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// respondAssignmentError maps assignment service errors to HTTP responses
+func respondAssignmentError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrSupportRequestNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+	case services.ErrAssigneeNotFound, services.ErrAssigneeInactive, services.ErrAssigneeNotMember:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update assignment"})
+	}
+}
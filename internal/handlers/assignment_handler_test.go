@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAssignmentService is a mock implementation of AssignmentService
+type MockAssignmentService struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentService) AssignSupportRequest(supportRequestID uint, scope models.OrganizationScope, assigneeID uint) (*models.SupportRequestResponse, error) {
+	args := m.Called(supportRequestID, scope, assigneeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
+}
+
+func (m *MockAssignmentService) UnassignSupportRequest(supportRequestID uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error) {
+	args := m.Called(supportRequestID, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
+}
+
+func TestAssignmentHandler_AssignSupportRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockAssignmentService)
+	handler := NewAssignmentHandler(mockService)
+	router := setupTestRouter()
+	router.PUT("/support-requests/:id/assignee", handler.AssignSupportRequest)
+
+	assigneeID := uint(2)
+	mockService.On("AssignSupportRequest", uint(1), models.OrganizationScope{}, uint(2)).Return(&models.SupportRequestResponse{ID: 1, AssigneeID: &assigneeID}, nil)
+
+	req, _ := http.NewRequest("PUT", "/support-requests/1/assignee", bytes.NewBufferString(`{"assignee_id":2}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"assignee_id":2`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAssignmentHandler_AssignSupportRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"support request not found", services.ErrSupportRequestNotFound, http.StatusNotFound},
+		{"unknown assignee", services.ErrAssigneeNotFound, http.StatusUnprocessableEntity},
+		{"inactive assignee", services.ErrAssigneeInactive, http.StatusUnprocessableEntity},
+		{"internal error", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockAssignmentService)
+			handler := NewAssignmentHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/support-requests/:id/assignee", handler.AssignSupportRequest)
+
+			mockService.On("AssignSupportRequest", uint(1), models.OrganizationScope{}, uint(2)).Return(nil, tt.err)
+
+			req, _ := http.NewRequest("PUT", "/support-requests/1/assignee", bytes.NewBufferString(`{"assignee_id":2}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAssignmentHandler_AssignSupportRequest_MissingAssignee(t *testing.T) {
+	// Arrange
+	mockService := new(MockAssignmentService)
+	handler := NewAssignmentHandler(mockService)
+	router := setupTestRouter()
+	router.PUT("/support-requests/:id/assignee", handler.AssignSupportRequest)
+
+	req, _ := http.NewRequest("PUT", "/support-requests/1/assignee", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "AssignSupportRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAssignmentHandler_SelfAssignSupportRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockAssignmentService)
+	handler := NewAssignmentHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/assignee/me", withUserID(7), handler.SelfAssignSupportRequest)
+
+	assigneeID := uint(7)
+	mockService.On("AssignSupportRequest", uint(1), models.OrganizationScope{}, uint(7)).Return(&models.SupportRequestResponse{ID: 1, AssigneeID: &assigneeID}, nil)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/assignee/me", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAssignmentHandler_SelfAssignSupportRequest_Unauthenticated(t *testing.T) {
+	// Arrange
+	mockService := new(MockAssignmentService)
+	handler := NewAssignmentHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/assignee/me", handler.SelfAssignSupportRequest)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/assignee/me", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAssignmentHandler_UnassignSupportRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockAssignmentService)
+	handler := NewAssignmentHandler(mockService)
+	router := setupTestRouter()
+	router.DELETE("/support-requests/:id/assignee", handler.UnassignSupportRequest)
+
+	mockService.On("UnassignSupportRequest", uint(1), models.OrganizationScope{}).Return(&models.SupportRequestResponse{ID: 1}, nil)
+
+	req, _ := http.NewRequest("DELETE", "/support-requests/1/assignee", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "assignee_id")
+	mockService.AssertExpectations(t)
+}
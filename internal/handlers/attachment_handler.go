@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentHandler handles HTTP requests for support request attachments
+type AttachmentHandler struct {
+	service services.AttachmentService
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(service services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+	}
+}
+
+// ListAttachments handles GET /api/v1/support-requests/:id/attachments
+// @Summary List support request attachments (Admin only)
+// @Description Get the metadata of files uploaded with a support request (requires admin authentication)
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Success 200 {object} map[string]interface{} "Attachments list"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Router /support-requests/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	responses, err := h.service.ListAttachments(uint(id), organizationScope(c))
+	if err != nil {
+		if err == services.ErrSupportRequestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get attachments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// DownloadAttachment handles GET /api/v1/support-requests/:id/attachments/:attachmentId
+// @Summary Download attachment (Admin only)
+// @Description Download the contents of a support request attachment (requires admin authentication)
+// @Tags Attachments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {file} file "Attachment contents"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request or attachment not found"
+// @Router /support-requests/{id}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	attachment, content, err := h.service.OpenAttachment(uint(id), organizationScope(c), uint(attachmentID))
+	if err != nil {
+		if err == services.ErrSupportRequestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+			return
+		}
+		if err == services.ErrAttachmentNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get attachment"})
+		return
+	}
+	defer content.Close()
+
+	// Always download rather than render inline, and never let the browser re-sniff the type
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, io.Reader(content), nil)
+}
+
+// respondAttachmentError maps attachment validation errors to HTTP responses. Storage errors are
+// only logged, because they can reveal server paths.
+func respondAttachmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTooManyAttachments):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentTypeNotAllowed):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Warning: Failed to store attachments: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store attachments"})
+	}
+}
+
+// isBodyTooLarge reports whether err was caused by BodySizeLimitMiddleware cutting off the request body
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
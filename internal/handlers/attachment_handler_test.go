@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentService is a mock implementation of AttachmentService
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) StoreUploads(files []*multipart.FileHeader) ([]*models.Attachment, error) {
+	args := m.Called(files)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) DiscardUploads(attachments []*models.Attachment) {
+	m.Called(attachments)
+}
+
+func (m *MockAttachmentService) ListAttachments(supportRequestID uint, scope models.OrganizationScope) ([]*models.AttachmentResponse, error) {
+	args := m.Called(supportRequestID, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AttachmentResponse), args.Error(1)
+}
+
+func (m *MockAttachmentService) OpenAttachment(supportRequestID uint, scope models.OrganizationScope, attachmentID uint) (*models.Attachment, io.ReadCloser, error) {
+	args := m.Called(supportRequestID, scope, attachmentID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.Attachment), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+// newMultipartSupportRequest builds a multipart support request submission with the given files
+func newMultipartSupportRequest(t *testing.T, files map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	fields := map[string]string{
+		"type":         "bug_report",
+		"message":      "App crashes on launch",
+		"platform":     "iOS",
+		"app_version":  "1.0.0",
+		"device_model": "iPhone 13",
+		"app":          "test-app",
+	}
+	for key, value := range fields {
+		assert.NoError(t, writer.WriteField(key, value))
+	}
+	for name, content := range files {
+		part, err := writer.CreateFormFile("attachments", name)
+		assert.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest("POST", "/support-request", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestSupportRequestHandler_CreateSupportRequest_Multipart(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	mockAttachments := new(MockAttachmentService)
+	handler := NewSupportRequestHandler(mockService, mockAttachments)
+	router := setupTestRouter()
+	router.POST("/support-request", handler.CreateSupportRequest)
+
+	attachment := &models.Attachment{FileName: "crash.log", StorageKey: "attachments/abc"}
+	mockAttachments.On("StoreUploads", mock.MatchedBy(func(files []*multipart.FileHeader) bool {
+		return len(files) == 1 && files[0].Filename == "crash.log"
+	})).Return([]*models.Attachment{attachment}, nil)
+	mockService.On("CreateSupportRequest", mock.MatchedBy(func(req *models.CreateSupportRequestRequest) bool {
+		return req.Type == models.SupportRequestTypeBugReport && req.App == "test-app"
+	}), (*models.App)(nil), []*models.Attachment{attachment}).
+		Return(&models.SupportRequestResponse{ID: 1, Attachments: []*models.AttachmentResponse{{ID: 9, FileName: "crash.log"}}}, nil)
+
+	req := newMultipartSupportRequest(t, map[string]string{"crash.log": "panic: boom"})
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"file_name":"crash.log"`)
+	mockService.AssertExpectations(t)
+	mockAttachments.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_CreateSupportRequest_MultipartRejectedUpload(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"too many", services.ErrTooManyAttachments, http.StatusBadRequest},
+		{"too large", services.ErrAttachmentTooLarge, http.StatusRequestEntityTooLarge},
+		{"bad type", services.ErrAttachmentTypeNotAllowed, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestService)
+			mockAttachments := new(MockAttachmentService)
+			handler := NewSupportRequestHandler(mockService, mockAttachments)
+			router := setupTestRouter()
+			router.POST("/support-request", handler.CreateSupportRequest)
+
+			mockAttachments.On("StoreUploads", mock.Anything).Return(nil, fmt.Errorf("%w: file.bin", tt.err))
+
+			req := newMultipartSupportRequest(t, map[string]string{"file.bin": "data"})
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertNotCalled(t, "CreateSupportRequest", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestHandler_CreateSupportRequest_MultipartStorageFailure(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	mockAttachments := new(MockAttachmentService)
+	handler := NewSupportRequestHandler(mockService, mockAttachments)
+	router := setupTestRouter()
+	router.POST("/support-request", handler.CreateSupportRequest)
+
+	mockAttachments.On("StoreUploads", mock.Anything).Return(nil, errors.New("open /var/lib/support/attachments/abc: permission denied"))
+
+	req := newMultipartSupportRequest(t, map[string]string{"crash.log": "panic: boom"})
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert: no request is created, so nothing is announced or needs deleting
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "/var/lib", "storage errors aren't shown to submitters")
+	assert.Empty(t, w.Header().Get("X-Internal-Error"))
+	mockService.AssertNotCalled(t, "CreateSupportRequest", mock.Anything, mock.Anything, mock.Anything)
+	mockService.AssertNotCalled(t, "DeleteSupportRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSupportRequestHandler_CreateSupportRequest_MultipartDiscardsFilesWhenCreateFails(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	mockAttachments := new(MockAttachmentService)
+	handler := NewSupportRequestHandler(mockService, mockAttachments)
+	router := setupTestRouter()
+	router.POST("/support-request", handler.CreateSupportRequest)
+
+	attachments := []*models.Attachment{{FileName: "crash.log", StorageKey: "attachments/abc"}}
+	mockAttachments.On("StoreUploads", mock.Anything).Return(attachments, nil)
+	mockService.On("CreateSupportRequest", mock.Anything, mock.Anything, attachments).Return(nil, services.ErrUnknownApp)
+	mockAttachments.On("DiscardUploads", attachments).Return()
+
+	req := newMultipartSupportRequest(t, map[string]string{"crash.log": "panic: boom"})
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockAttachments.AssertExpectations(t)
+}
+
+func TestAttachmentHandler_ListAttachments(t *testing.T) {
+	// Arrange
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/attachments", handler.ListAttachments)
+
+	mockService.On("ListAttachments", uint(1), models.OrganizationScope{}).Return([]*models.AttachmentResponse{{ID: 1, FileName: "a.png"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/attachments", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAttachmentHandler_ListAttachments_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/attachments", handler.ListAttachments)
+
+	mockService.On("ListAttachments", uint(1), models.OrganizationScope{}).Return(nil, services.ErrSupportRequestNotFound)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/attachments", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAttachmentHandler_DownloadAttachment(t *testing.T) {
+	// Arrange
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/attachments/:attachmentId", handler.DownloadAttachment)
+
+	attachment := &models.Attachment{ID: 2, SupportRequestID: 1, FileName: "crash log.txt", ContentType: "text/plain", Size: 11}
+	mockService.On("OpenAttachment", uint(1), models.OrganizationScope{}, uint(2)).Return(attachment, io.NopCloser(strings.NewReader("panic: boom")), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/attachments/2", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "panic: boom", w.Body.String())
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="crash log.txt"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+}
+
+func TestAttachmentHandler_DownloadAttachment_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/attachments/:attachmentId", handler.DownloadAttachment)
+
+	mockService.On("OpenAttachment", uint(1), models.OrganizationScope{}, uint(2)).Return(nil, nil, services.ErrAttachmentNotFound)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/attachments/2", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAttachmentHandler_DownloadAttachment_SupportRequestNotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/attachments/:attachmentId", handler.DownloadAttachment)
+
+	// Unknown support requests and those of other organizations look the same
+	mockService.On("OpenAttachment", uint(1), models.OrganizationScope{}, uint(2)).Return(nil, nil, services.ErrSupportRequestNotFound)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/attachments/2", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "Support request not found")
+}
+
+func TestAttachmentHandler_DownloadAttachment_InvalidID(t *testing.T) {
+	// Arrange
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/attachments/:attachmentId", handler.DownloadAttachment)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/attachments/abc", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
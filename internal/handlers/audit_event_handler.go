@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"support-app-backend/internal/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditEventHandler handles HTTP requests for the audit log
+type AuditEventHandler struct {
+	service services.AuditService
+}
+
+// NewAuditEventHandler creates a new audit event handler
+func NewAuditEventHandler(service services.AuditService) *AuditEventHandler {
+	return &AuditEventHandler{
+		service: service,
+	}
+}
+
+// ListAuditEvents handles GET /api/v1/audit-events
+// @Summary List audit events (Admin only)
+// @Description Retrieve the audit log of admin actions with pagination and optional filtering, newest first (requires admin authentication)
+// @Tags Audit Log
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param actor_id query int false "ID of the user who acted"
+// @Param action query string false "Action (update, delete, merge)"
+// @Param entity_type query string false "Entity type (support_request, user)"
+// @Param entity_id query int false "Entity ID"
+// @Param created_after query string false "Only events at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param created_before query string false "Only events before this time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} map[string]interface{} "Audit events list"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Router /audit-events [get]
+func (h *AuditEventHandler) ListAuditEvents(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	filter, err := parseAuditEventFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	responses, total, err := h.service.ListAuditEvents(filter, page, pageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get audit events"})
+		return
+	}
+
+	// Calculate pagination metadata
+	totalPages := (int(total) + pageSize - 1) / pageSize
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": responses,
+		"pagination": gin.H{
+			"page":        page,
+			"page_size":   pageSize,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// parseAuditEventFilter builds a repository filter from the audit log query parameters
+func parseAuditEventFilter(c *gin.Context) (repositories.AuditEventFilter, error) {
+	filter := repositories.AuditEventFilter{
+		Action:     models.AuditAction(strings.TrimSpace(c.Query("action"))),
+		EntityType: models.AuditEntityType(strings.TrimSpace(c.Query("entity_type"))),
+	}
+
+	idParams := []struct {
+		key    string
+		target **uint
+	}{
+		{"actor_id", &filter.ActorID},
+		{"entity_id", &filter.EntityID},
+	}
+	for _, param := range idParams {
+		value := c.Query(param.key)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: expected an ID", param.key)
+		}
+		parsed := uint(id)
+		*param.target = &parsed
+	}
+
+	timeParams := []struct {
+		key    string
+		target **time.Time
+	}{
+		{"created_after", &filter.CreatedAfter},
+		{"created_before", &filter.CreatedBefore},
+	}
+	for _, param := range timeParams {
+		value := c.Query(param.key)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseQueryTime(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: expected RFC3339 timestamp or YYYY-MM-DD date", param.key)
+		}
+		*param.target = &parsed
+	}
+
+	return filter, nil
+}
+
+// auditActor describes the caller for the audit log, using the claims set by AuthMiddleware.
+// Unauthenticated callers are recorded by IP address only.
+func auditActor(c *gin.Context) models.AuditActor {
+	actor := models.AuditActor{IPAddress: c.ClientIP()}
+	if userID, exists := c.Get("user_id"); exists {
+		actor.UserID = userID.(uint)
+	}
+	if username, exists := c.Get("username"); exists {
+		actor.Username = username.(string)
+	}
+	return actor
+}
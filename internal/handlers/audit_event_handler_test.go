@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"support-app-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditService is a mock implementation of AuditService
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) ListAuditEvents(filter repositories.AuditEventFilter, page, pageSize int) ([]*models.AuditEventResponse, int64, error) {
+	args := m.Called(filter, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*models.AuditEventResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func TestAuditEventHandler_ListAuditEvents(t *testing.T) {
+	// Arrange
+	mockService := new(MockAuditService)
+	handler := NewAuditEventHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/audit-events", handler.ListAuditEvents)
+
+	actorID := uint(1)
+	entityID := uint(42)
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	expectedFilter := repositories.AuditEventFilter{
+		ActorID:      &actorID,
+		Action:       models.AuditActionUpdate,
+		EntityType:   models.AuditEntitySupportRequest,
+		EntityID:     &entityID,
+		CreatedAfter: &since,
+	}
+	events := []*models.AuditEventResponse{{ID: 1, Action: models.AuditActionUpdate, EntityType: models.AuditEntitySupportRequest, EntityID: 42}}
+	mockService.On("ListAuditEvents", expectedFilter, 2, 10).Return(events, int64(11), nil)
+
+	req, _ := http.NewRequest("GET", "/audit-events?page=2&page_size=10&actor_id=1&action=update&entity_type=support_request&entity_id=42&created_after=2025-06-01", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"entity_id":42`)
+	assert.Contains(t, w.Body.String(), `"total_pages":2`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuditEventHandler_ListAuditEvents_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		serviceErr error
+	}{
+		{"invalid actor id", "actor_id=abc", nil},
+		{"invalid date", "created_before=yesterday", nil},
+		{"rejected by service", "action=create", services.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockAuditService)
+			handler := NewAuditEventHandler(mockService)
+			router := setupTestRouter()
+			router.GET("/audit-events", handler.ListAuditEvents)
+
+			if tt.serviceErr != nil {
+				mockService.On("ListAuditEvents", mock.Anything, 1, 20).Return(nil, int64(0), tt.serviceErr)
+			}
+
+			req, _ := http.NewRequest("GET", "/audit-events?"+tt.query, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestAuditActor(t *testing.T) {
+	// Arrange
+	mockService := new(MockAuthService)
+	handler := NewAuthHandler(mockService)
+	router := setupTestRouter()
+	router.DELETE("/users/:id", func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		c.Set("username", "admin")
+		c.Next()
+	}, handler.DeleteUser)
+
+	mockService.On("DeleteUser", uint(2), models.AuditActor{UserID: 1, Username: "admin", IPAddress: "203.0.113.7"}).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/users/2", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"support-app-backend/internal/models"
@@ -23,14 +24,15 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 
 // Login handles POST /api/v1/auth/login
 // @Summary Login user
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a short-lived JWT access token and a refresh token. When the account needs a second factor, an MFA challenge is returned instead; finish the login with POST /auth/login/mfa
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param request body models.LoginRequest true "Login credentials"
-// @Success 200 {object} map[string]interface{} "Login successful"
+// @Success 200 {object} map[string]interface{} "Login successful or MFA challenge"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Failure 401 {object} map[string]interface{} "Invalid credentials"
+// @Failure 429 {object} map[string]interface{} "Too many failed login attempts or rate limit exceeded"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
@@ -40,11 +42,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(&req)
+	response, err := h.authService.Login(&req, c.ClientIP())
 	if err != nil {
 		switch err {
 		case services.ErrInvalidCredentials:
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		case services.ErrLoginLocked:
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed login attempts. Please try again later."})
 		case services.ErrUserInactive:
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "User account is inactive"})
 		default:
@@ -53,9 +57,158 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if response.MFAChallenge != nil {
+		c.JSON(http.StatusOK, gin.H{"data": response.MFAChallenge})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// LoginMFA handles POST /api/v1/auth/login/mfa
+// @Summary Complete login with a second factor
+// @Description Exchange the MFA challenge token from POST /auth/login and a TOTP or recovery code for an access token and a refresh token. When MFA enrollment was required, the response also carries the new recovery codes
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.MFALoginRequest true "Challenge token and code"
+// @Success 200 {object} map[string]interface{} "Login successful"
+// @Failure 400 {object} map[string]interface{} "Invalid request or MFA enrollment not started"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired challenge token, or invalid code"
+// @Failure 429 {object} map[string]interface{} "Too many failed login attempts or rate limit exceeded"
+// @Router /auth/login/mfa [post]
+func (h *AuthHandler) LoginMFA(c *gin.Context) {
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.authService.CompleteMFALogin(&req, c.ClientIP())
+	if err != nil {
+		switch err {
+		case services.ErrInvalidToken:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		case services.ErrInvalidMFACode:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid MFA code"})
+		case services.ErrLoginLocked:
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed login attempts. Please try again later."})
+		case services.ErrUserInactive:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User account is inactive"})
+		case services.ErrMFANotEnrolling:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MFA enrollment has not been started"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Login failed"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// BeginLoginMFAEnrollment handles POST /api/v1/auth/login/mfa/enroll
+// @Summary Set up MFA during login
+// @Description For accounts that must use MFA but have not set it up: generate a TOTP secret using the MFA challenge token from POST /auth/login. Confirm it by completing the login with POST /auth/login/mfa
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.MFAChallengeRequest true "Challenge token"
+// @Success 200 {object} map[string]interface{} "TOTP secret and provisioning URI"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired challenge token"
+// @Failure 409 {object} map[string]interface{} "MFA is already enabled"
+// @Router /auth/login/mfa/enroll [post]
+func (h *AuthHandler) BeginLoginMFAEnrollment(c *gin.Context) {
+	var req models.MFAChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	enrollment, err := h.authService.BeginMFALoginEnrollment(req.MFAToken)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidToken:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		case services.ErrUserInactive:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User account is inactive"})
+		case services.ErrMFAAlreadyEnabled:
+			c.JSON(http.StatusConflict, gin.H{"error": "MFA is already enabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start MFA enrollment"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": enrollment})
+}
+
+// Refresh handles POST /api/v1/auth/refresh
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a new refresh token. Each refresh token works once; reusing one logs out the whole session
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} map[string]interface{} "Tokens refreshed"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid, expired, revoked or reused refresh token, or MFA enrollment required"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.authService.Refresh(req.RefreshToken)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidToken, services.ErrTokenReused:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		case services.ErrUserInactive:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User account is inactive"})
+		case services.ErrMFAEnrollmentRequired:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "MFA enrollment required. Please log in again."})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Token refresh failed"})
+		}
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"data": response})
 }
 
+// Logout handles POST /api/v1/auth/logout
+// @Summary Logout
+// @Description Revoke the session of a refresh token. Its refresh tokens and the access tokens issued with them stop working immediately
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} map[string]interface{} "Logged out"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid refresh token"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.Logout(req.RefreshToken); err != nil {
+		if err == services.ErrInvalidToken {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
 // CreateUser handles POST /api/v1/auth/users
 // @Summary Create new user (Admin only)
 // @Description Create a new user account (requires admin authentication)
@@ -65,7 +218,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Security BearerAuth
 // @Param request body models.CreateUserRequest true "User creation data"
 // @Success 201 {object} map[string]interface{} "User created successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 400 {object} map[string]interface{} "Invalid request or unknown role"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
 // @Failure 409 {object} map[string]interface{} "User already exists"
@@ -80,10 +233,10 @@ func (h *AuthHandler) CreateUser(c *gin.Context) {
 
 	response, err := h.authService.CreateUser(&req)
 	if err != nil {
-		switch err {
-		case services.ErrUserExists:
+		switch {
+		case errors.Is(err, services.ErrUserExists):
 			c.JSON(http.StatusConflict, gin.H{"error": "Username or email already exists"})
-		case services.ErrInvalidRequest:
+		case errors.Is(err, services.ErrInvalidRequest), errors.Is(err, services.ErrInvalidRole):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
@@ -196,12 +349,12 @@ func (h *AuthHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.UpdateUser(uint(id), &req)
+	response, err := h.authService.UpdateUser(uint(id), &req, auditActor(c))
 	if err != nil {
-		switch err {
-		case services.ErrUserNotFound:
+		switch {
+		case errors.Is(err, services.ErrUserNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-		case services.ErrInvalidRequest:
+		case errors.Is(err, services.ErrInvalidRequest), errors.Is(err, services.ErrInvalidRole):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
@@ -214,7 +367,7 @@ func (h *AuthHandler) UpdateUser(c *gin.Context) {
 
 // ChangePassword handles PATCH /api/v1/auth/password
 // @Summary Change user password
-// @Description Change current user's password (requires authentication)
+// @Description Change current user's password (requires authentication). All of the user's sessions end, including the current one, so the user has to log in again
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -279,7 +432,7 @@ func (h *AuthHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err = h.authService.DeleteUser(uint(id))
+	err = h.authService.DeleteUser(uint(id), auditActor(c))
 	if err != nil {
 		if err == services.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
@@ -292,6 +445,75 @@ func (h *AuthHandler) DeleteUser(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// GetUserLockStatus handles GET /api/v1/auth/users/:id/lock
+// @Summary Get user login lockout status (Admin only)
+// @Description Get whether a user is locked out after failed logins, until when, and how many recent failures count towards the next lockout
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]interface{} "Lockout status"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /auth/users/{id}/lock [get]
+func (h *AuthHandler) GetUserLockStatus(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	status, err := h.authService.GetUserLockStatus(uint(id))
+	if err != nil {
+		if err == services.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve lock status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": status})
+}
+
+// UnlockUser handles POST /api/v1/auth/users/:id/unlock
+// @Summary Unlock user (Admin only)
+// @Description Lift a user's login lockout and clear their failed logins
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]interface{} "User unlocked"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /auth/users/{id}/unlock [post]
+func (h *AuthHandler) UnlockUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.authService.UnlockUser(uint(id), auditActor(c)); err != nil {
+		if err == services.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
 // GetCurrentUser handles GET /api/v1/auth/me
 // @Summary Get current user profile
 // @Description Get current authenticated user's profile information
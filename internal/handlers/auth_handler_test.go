@@ -3,6 +3,8 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"support-app-backend/internal/models"
@@ -21,14 +23,43 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
-	args := m.Called(req)
+func (m *MockAuthService) Login(req *models.LoginRequest, clientIP string) (*models.LoginResponse, error) {
+	args := m.Called(req, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthService) CompleteMFALogin(req *models.MFALoginRequest, clientIP string) (*models.LoginResponse, error) {
+	args := m.Called(req, clientIP)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthService) BeginMFALoginEnrollment(mfaToken string) (*models.MFAEnrollmentResponse, error) {
+	args := m.Called(mfaToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockAuthService) Refresh(refreshToken string) (*models.LoginResponse, error) {
+	args := m.Called(refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(refreshToken string) error {
+	args := m.Called(refreshToken)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) CreateUser(req *models.CreateUserRequest) (*models.UserInfo, error) {
 	args := m.Called(req)
 	if args.Get(0) == nil {
@@ -53,16 +84,29 @@ func (m *MockAuthService) GetAllUsers(page, pageSize int) ([]*models.UserInfo, i
 	return args.Get(0).([]*models.UserInfo), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockAuthService) UpdateUser(id uint, req *models.UpdateUserRequest) (*models.UserInfo, error) {
-	args := m.Called(id, req)
+func (m *MockAuthService) UpdateUser(id uint, req *models.UpdateUserRequest, actor models.AuditActor) (*models.UserInfo, error) {
+	args := m.Called(id, req, actor)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.UserInfo), args.Error(1)
 }
 
-func (m *MockAuthService) DeleteUser(id uint) error {
+func (m *MockAuthService) DeleteUser(id uint, actor models.AuditActor) error {
+	args := m.Called(id, actor)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) GetUserLockStatus(id uint) (*models.UserLockStatus, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserLockStatus), args.Error(1)
+}
+
+func (m *MockAuthService) UnlockUser(id uint, actor models.AuditActor) error {
+	args := m.Called(id, actor)
 	return args.Error(0)
 }
 
@@ -111,7 +155,7 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("Login", loginReq).Return(loginResp, nil)
+	mockService.On("Login", loginReq, "192.0.2.1").Return(loginResp, nil)
 
 	body, _ := json.Marshal(loginReq)
 	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
@@ -134,7 +178,7 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 		Password: "wrongpassword",
 	}
 
-	mockService.On("Login", loginReq).Return(nil, services.ErrInvalidCredentials)
+	mockService.On("Login", loginReq, "192.0.2.1").Return(nil, services.ErrInvalidCredentials)
 
 	body, _ := json.Marshal(loginReq)
 	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
@@ -157,7 +201,7 @@ func TestAuthHandler_Login_UserInactive(t *testing.T) {
 		Password: "password123",
 	}
 
-	mockService.On("Login", loginReq).Return(nil, services.ErrUserInactive)
+	mockService.On("Login", loginReq, "192.0.2.1").Return(nil, services.ErrUserInactive)
 
 	body, _ := json.Marshal(loginReq)
 	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
@@ -194,7 +238,7 @@ func TestAuthHandler_Login_InternalServerError(t *testing.T) {
 		Password: "password123",
 	}
 
-	mockService.On("Login", loginReq).Return(nil, assert.AnError)
+	mockService.On("Login", loginReq, "192.0.2.1").Return(nil, assert.AnError)
 
 	body, _ := json.Marshal(loginReq)
 	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
@@ -209,6 +253,104 @@ func TestAuthHandler_Login_InternalServerError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAuthHandler_Refresh_Success(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	mockService.On("Refresh", "refresh-token").Return(&models.LoginResponse{
+		Token:        "new.jwt.token",
+		RefreshToken: "next-refresh-token",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBufferString(`{"refresh_token":"refresh-token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Refresh(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"refresh_token":"next-refresh-token"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_Refresh_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid token", services.ErrInvalidToken, http.StatusUnauthorized},
+		{"reused token", services.ErrTokenReused, http.StatusUnauthorized},
+		{"inactive user", services.ErrUserInactive, http.StatusUnauthorized},
+		{"mfa enrollment required", services.ErrMFAEnrollmentRequired, http.StatusUnauthorized},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupAuthHandler()
+			mockService.On("Refresh", "refresh-token").Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBufferString(`{"refresh_token":"refresh-token"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.Refresh(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_Refresh_MissingToken(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Refresh(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Refresh", mock.Anything)
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+	mockService.On("Logout", "refresh-token").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", bytes.NewBufferString(`{"refresh_token":"refresh-token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_Logout_InvalidToken(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+	mockService.On("Logout", "unknown").Return(services.ErrInvalidToken)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", bytes.NewBufferString(`{"refresh_token":"unknown"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 func TestAuthHandler_CreateUser_Success(t *testing.T) {
 	handler, mockService := setupAuthHandler()
 
@@ -306,6 +448,32 @@ func TestAuthHandler_CreateUser_InvalidRequest(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAuthHandler_CreateUser_UnknownRole(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	createReq := &models.CreateUserRequest{
+		Username: "newuser",
+		Email:    "new@example.com",
+		Password: "password123",
+		Role:     "superuser",
+	}
+
+	mockService.On("CreateUser", createReq).Return(nil, fmt.Errorf("%w: unknown role %q", services.ErrInvalidRole, "superuser"))
+
+	body, _ := json.Marshal(createReq)
+	req := httptest.NewRequest(http.MethodPost, "/auth/users", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CreateUser(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown role")
+	mockService.AssertExpectations(t)
+}
+
 func TestAuthHandler_CreateUser_InternalServerError(t *testing.T) {
 	handler, mockService := setupAuthHandler()
 
@@ -504,7 +672,7 @@ func TestAuthHandler_UpdateUser_Success(t *testing.T) {
 		IsActive: true,
 	}
 
-	mockService.On("UpdateUser", uint(1), updateReq).Return(userInfo, nil)
+	mockService.On("UpdateUser", uint(1), updateReq, mock.AnythingOfType("models.AuditActor")).Return(userInfo, nil)
 
 	body, _ := json.Marshal(updateReq)
 	req := httptest.NewRequest(http.MethodPatch, "/auth/users/1", bytes.NewBuffer(body))
@@ -561,7 +729,7 @@ func TestAuthHandler_UpdateUser_NotFound(t *testing.T) {
 		Email: &email,
 	}
 
-	mockService.On("UpdateUser", uint(999), updateReq).Return(nil, services.ErrUserNotFound)
+	mockService.On("UpdateUser", uint(999), updateReq, mock.AnythingOfType("models.AuditActor")).Return(nil, services.ErrUserNotFound)
 
 	body, _ := json.Marshal(updateReq)
 	req := httptest.NewRequest(http.MethodPatch, "/auth/users/999", bytes.NewBuffer(body))
@@ -585,7 +753,7 @@ func TestAuthHandler_UpdateUser_InvalidRequest(t *testing.T) {
 		Email: &email,
 	}
 
-	mockService.On("UpdateUser", uint(1), updateReq).Return(nil, services.ErrInvalidRequest)
+	mockService.On("UpdateUser", uint(1), updateReq, mock.AnythingOfType("models.AuditActor")).Return(nil, services.ErrInvalidRequest)
 
 	body, _ := json.Marshal(updateReq)
 	req := httptest.NewRequest(http.MethodPatch, "/auth/users/1", bytes.NewBuffer(body))
@@ -609,7 +777,7 @@ func TestAuthHandler_UpdateUser_InternalServerError(t *testing.T) {
 		Email: &email,
 	}
 
-	mockService.On("UpdateUser", uint(1), updateReq).Return(nil, assert.AnError)
+	mockService.On("UpdateUser", uint(1), updateReq, mock.AnythingOfType("models.AuditActor")).Return(nil, assert.AnError)
 
 	body, _ := json.Marshal(updateReq)
 	req := httptest.NewRequest(http.MethodPatch, "/auth/users/1", bytes.NewBuffer(body))
@@ -628,7 +796,7 @@ func TestAuthHandler_UpdateUser_InternalServerError(t *testing.T) {
 func TestAuthHandler_DeleteUser_Success(t *testing.T) {
 	handler, mockService := setupAuthHandler()
 
-	mockService.On("DeleteUser", uint(1)).Return(nil)
+	mockService.On("DeleteUser", uint(1), mock.AnythingOfType("models.AuditActor")).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/auth/users/1", nil)
 	w := httptest.NewRecorder()
@@ -659,7 +827,7 @@ func TestAuthHandler_DeleteUser_InvalidID(t *testing.T) {
 func TestAuthHandler_DeleteUser_NotFound(t *testing.T) {
 	handler, mockService := setupAuthHandler()
 
-	mockService.On("DeleteUser", uint(999)).Return(services.ErrUserNotFound)
+	mockService.On("DeleteUser", uint(999), mock.AnythingOfType("models.AuditActor")).Return(services.ErrUserNotFound)
 
 	req := httptest.NewRequest(http.MethodDelete, "/auth/users/999", nil)
 	w := httptest.NewRecorder()
@@ -676,7 +844,7 @@ func TestAuthHandler_DeleteUser_NotFound(t *testing.T) {
 func TestAuthHandler_DeleteUser_InternalServerError(t *testing.T) {
 	handler, mockService := setupAuthHandler()
 
-	mockService.On("DeleteUser", uint(1)).Return(assert.AnError)
+	mockService.On("DeleteUser", uint(1), mock.AnythingOfType("models.AuditActor")).Return(assert.AnError)
 
 	req := httptest.NewRequest(http.MethodDelete, "/auth/users/1", nil)
 	w := httptest.NewRecorder()
@@ -892,3 +1060,245 @@ func TestAuthHandler_GetCurrentUser_InternalServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestAuthHandler_Login_Locked(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	loginReq := &models.LoginRequest{
+		Username: "testuser",
+		Password: "password123",
+	}
+
+	mockService.On("Login", loginReq, "192.0.2.1").Return(nil, services.ErrLoginLocked)
+
+	body, _ := json.Marshal(loginReq)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Login(c)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "Too many failed login attempts")
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_GetUserLockStatus_Success(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	until := time.Now().Add(time.Minute)
+	mockService.On("GetUserLockStatus", uint(1)).Return(&models.UserLockStatus{Locked: true, LockedUntil: &until}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/users/1/lock", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	handler.GetUserLockStatus(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"locked":true`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_GetUserLockStatus_NotFound(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	mockService.On("GetUserLockStatus", uint(99)).Return(nil, services.ErrUserNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/users/99/lock", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "99"}}
+
+	handler.GetUserLockStatus(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAuthHandler_UnlockUser_Success(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	mockService.On("UnlockUser", uint(1), mock.AnythingOfType("models.AuditActor")).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/users/1/unlock", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	handler.UnlockUser(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_UnlockUser_InvalidID(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/users/abc/unlock", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	handler.UnlockUser(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "UnlockUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_UnlockUser_NotFound(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	mockService.On("UnlockUser", uint(99), mock.AnythingOfType("models.AuditActor")).Return(services.ErrUserNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/users/99/unlock", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "99"}}
+
+	handler.UnlockUser(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAuthHandler_Login_MFAChallenge(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	loginReq := &models.LoginRequest{
+		Username: "testuser",
+		Password: "password123",
+	}
+
+	mockService.On("Login", loginReq, "192.0.2.1").Return(&models.LoginResponse{
+		MFAChallenge: &models.MFAChallengeResponse{MFARequired: true, MFAToken: "challenge-token", ExpiresAt: time.Now().Add(5 * time.Minute)},
+	}, nil)
+
+	body, _ := json.Marshal(loginReq)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Login(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mfa_required":true`)
+	assert.Contains(t, w.Body.String(), `"mfa_token":"challenge-token"`)
+	assert.NotContains(t, w.Body.String(), `"token"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_LoginMFA_Success(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	mfaReq := &models.MFALoginRequest{MFAToken: "challenge-token", Code: "123456"}
+	mockService.On("CompleteMFALogin", mfaReq, "192.0.2.1").Return(&models.LoginResponse{
+		Token:         "jwt.token.here",
+		RefreshToken:  "refresh-token",
+		RecoveryCodes: []string{"abcde-fghij"},
+	}, nil)
+
+	body, _ := json.Marshal(mfaReq)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login/mfa", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.LoginMFA(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"token":"jwt.token.here"`)
+	assert.Contains(t, w.Body.String(), `"recovery_codes":["abcde-fghij"]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_LoginMFA_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid token", services.ErrInvalidToken, http.StatusUnauthorized},
+		{"invalid code", services.ErrInvalidMFACode, http.StatusUnauthorized},
+		{"locked", services.ErrLoginLocked, http.StatusTooManyRequests},
+		{"inactive user", services.ErrUserInactive, http.StatusUnauthorized},
+		{"enrollment not started", services.ErrMFANotEnrolling, http.StatusBadRequest},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupAuthHandler()
+			mockService.On("CompleteMFALogin", mock.Anything, "192.0.2.1").Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/login/mfa", bytes.NewBufferString(`{"mfa_token":"challenge-token","code":"123456"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.LoginMFA(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_LoginMFA_MissingCode(t *testing.T) {
+	handler, mockService := setupAuthHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login/mfa", bytes.NewBufferString(`{"mfa_token":"challenge-token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.LoginMFA(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CompleteMFALogin", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_BeginLoginMFAEnrollment(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   *models.MFAEnrollmentResponse
+		err        error
+		wantStatus int
+	}{
+		{"success", &models.MFAEnrollmentResponse{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}, nil, http.StatusOK},
+		{"invalid token", nil, services.ErrInvalidToken, http.StatusUnauthorized},
+		{"already enabled", nil, services.ErrMFAAlreadyEnabled, http.StatusConflict},
+		{"internal error", nil, errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupAuthHandler()
+			if tt.response != nil {
+				mockService.On("BeginMFALoginEnrollment", "challenge-token").Return(tt.response, nil)
+			} else {
+				mockService.On("BeginMFALoginEnrollment", "challenge-token").Return(nil, tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/login/mfa/enroll", bytes.NewBufferString(`{"mfa_token":"challenge-token"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.BeginLoginMFAEnrollment(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inboundEmailFormFields are the form fields inbound-parse webhooks send the raw message in:
+// email for SendGrid with "POST the raw, full MIME message" enabled, body-mime for Mailgun's MIME routes
+var inboundEmailFormFields = []string{"email", "body-mime"}
+
+// InboundEmailHandler handles support requests received by email
+type InboundEmailHandler struct {
+	service services.InboundEmailService
+	token   string
+}
+
+// NewInboundEmailHandler creates a new inbound email handler. Requests must carry token; an empty
+// token disables the endpoint.
+func NewInboundEmailHandler(service services.InboundEmailService, token string) *InboundEmailHandler {
+	return &InboundEmailHandler{
+		service: service,
+		token:   token,
+	}
+}
+
+// ReceiveEmail handles POST /api/v1/inbound/email
+// @Summary Receive email
+// @Description Receive an email sent to the support address. The raw RFC 5322 message is sent as the body with the message/rfc822 content type, or in the "email" (SendGrid) or "body-mime" (Mailgun) field of a form. A reply from the submitter of an open support request, found by its reference (SR-42) in the subject or by In-Reply-To, is added to its conversation; any other email creates a support request with the Email platform for the app named by the plus address of the recipient (support+my-app@example.com) or the default app. Automatic replies are ignored and a repeated Message-ID is only processed once. The token is sent in the X-Inbound-Token header, or in the token query parameter as a fallback for providers that can't send custom headers. Deliveries of an email that is still being processed get 409
+// @Tags Inbound Email
+// @Accept message/rfc822,multipart/form-data,x-www-form-urlencoded
+// @Produce json
+// @Param X-Inbound-Token header string false "Inbound email token"
+// @Param token query string false "Inbound email token, only for providers that can't send custom headers"
+// @Success 200 {object} map[string]interface{} "Email received"
+// @Failure 400 {object} map[string]interface{} "No raw message in the request"
+// @Failure 401 {object} map[string]interface{} "Invalid token"
+// @Failure 404 {object} map[string]interface{} "Inbound email is not enabled"
+// @Failure 409 {object} map[string]interface{} "An email with this Message-ID is still being processed"
+// @Failure 413 {object} map[string]interface{} "Email too large"
+// @Failure 422 {object} map[string]interface{} "Invalid email, or no registered and active app to file it under"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /inbound/email [post]
+func (h *InboundEmailHandler) ReceiveEmail(c *gin.Context) {
+	if h.token == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Inbound email is not enabled"})
+		return
+	}
+	token := c.GetHeader("X-Inbound-Token")
+	if token == "" {
+		// Fallback for providers that can't send custom headers; the query of this route isn't logged
+		token = c.Query("token")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid inbound email token"})
+		return
+	}
+
+	raw, err := readInboundEmail(c)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Email too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the email"})
+		return
+	}
+	if len(raw) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No raw email in the request"})
+		return
+	}
+
+	response, err := h.service.ReceiveEmail(raw)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInboundEmailInProgress):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, mail.ErrMessageTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Email too large"})
+		case errors.Is(err, services.ErrInvalidRequest), errors.Is(err, services.ErrUnknownApp), errors.Is(err, services.ErrAppDisabled), errors.Is(err, services.ErrPlatformNotAllowed):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to receive email"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// readInboundEmail returns the raw message of a request: a form field of an inbound-parse webhook, or
+// the body itself
+func readInboundEmail(c *gin.Context) ([]byte, error) {
+	switch c.ContentType() {
+	case gin.MIMEMultipartPOSTForm:
+		if _, err := c.MultipartForm(); err != nil {
+			return nil, err
+		}
+	case gin.MIMEPOSTForm:
+		if err := c.Request.ParseForm(); err != nil {
+			return nil, err
+		}
+	default:
+		return io.ReadAll(c.Request.Body)
+	}
+
+	for _, field := range inboundEmailFormFields {
+		if value := c.PostForm(field); strings.TrimSpace(value) != "" {
+			return []byte(value), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/middleware"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInboundEmailService is a mock implementation of InboundEmailService
+type MockInboundEmailService struct {
+	mock.Mock
+}
+
+func (m *MockInboundEmailService) ReceiveEmail(raw []byte) (*models.InboundEmailResponse, error) {
+	args := m.Called(string(raw))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InboundEmailResponse), args.Error(1)
+}
+
+const testInboundEmail = "From: jane@example.com\r\nSubject: Help\r\n\r\nIt crashes."
+
+// setupInboundEmailRouter registers the inbound email handler with the given token
+func setupInboundEmailRouter(mockService *MockInboundEmailService, token string) http.Handler {
+	handler := NewInboundEmailHandler(mockService, token)
+	router := setupTestRouter()
+	router.POST("/inbound/email", middleware.BodySizeLimitMiddleware(1024), handler.ReceiveEmail)
+	return router
+}
+
+func TestInboundEmailHandler_ReceiveEmail(t *testing.T) {
+	requestID := uint(100)
+	created := &models.InboundEmailResponse{Action: models.InboundEmailCreated, SupportRequestID: &requestID, Reference: "SR-100"}
+
+	multipartBody := func(field string) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField("to", "support@example.com")
+		_ = writer.WriteField(field, testInboundEmail)
+		_ = writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+	}{
+		{"raw message with header token", func() *http.Request {
+			req, _ := http.NewRequest("POST", "/inbound/email", strings.NewReader(testInboundEmail))
+			req.Header.Set("Content-Type", "message/rfc822")
+			req.Header.Set("X-Inbound-Token", "secret")
+			return req
+		}},
+		{"SendGrid raw form", func() *http.Request {
+			body, contentType := multipartBody("email")
+			req, _ := http.NewRequest("POST", "/inbound/email?token=secret", body)
+			req.Header.Set("Content-Type", contentType)
+			return req
+		}},
+		{"Mailgun MIME form", func() *http.Request {
+			form := url.Values{"recipient": {"support@example.com"}, "body-mime": {testInboundEmail}}
+			req, _ := http.NewRequest("POST", "/inbound/email?token=secret", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return req
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockInboundEmailService)
+			router := setupInboundEmailRouter(mockService, "secret")
+			mockService.On("ReceiveEmail", testInboundEmail).Return(created, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tt.request())
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), `"reference":"SR-100"`)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInboundEmailHandler_ReceiveEmail_Rejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		requestToken   string
+		body           string
+		contentType    string
+		expectedStatus int
+	}{
+		{"disabled", "", "", testInboundEmail, "message/rfc822", http.StatusNotFound},
+		{"missing token", "secret", "", testInboundEmail, "message/rfc822", http.StatusUnauthorized},
+		{"wrong token", "secret", "guess", testInboundEmail, "message/rfc822", http.StatusUnauthorized},
+		{"empty body", "secret", "secret", "", "message/rfc822", http.StatusBadRequest},
+		{"form without message", "secret", "secret", "from=jane%40example.com", "application/x-www-form-urlencoded", http.StatusBadRequest},
+		{"too large", "secret", "secret", strings.Repeat("a", 2048), "message/rfc822", http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockInboundEmailService)
+			router := setupInboundEmailRouter(mockService, tt.token)
+			req, _ := http.NewRequest("POST", "/inbound/email", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.requestToken != "" {
+				req.Header.Set("X-Inbound-Token", tt.requestToken)
+			}
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertNotCalled(t, "ReceiveEmail", mock.Anything)
+		})
+	}
+}
+
+func TestInboundEmailHandler_ReceiveEmail_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"too large", fmt.Errorf("%w: %w", services.ErrInvalidRequest, mail.ErrMessageTooLarge), http.StatusRequestEntityTooLarge},
+		{"invalid email", fmt.Errorf("%w: missing From header", services.ErrInvalidRequest), http.StatusUnprocessableEntity},
+		{"no app", fmt.Errorf("%w: no default app", services.ErrUnknownApp), http.StatusUnprocessableEntity},
+		{"app disabled", services.ErrAppDisabled, http.StatusUnprocessableEntity},
+		{"email not allowed", services.ErrPlatformNotAllowed, http.StatusUnprocessableEntity},
+		{"delivery in progress", services.ErrInboundEmailInProgress, http.StatusConflict},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockInboundEmailService)
+			router := setupInboundEmailRouter(mockService, "secret")
+			mockService.On("ReceiveEmail", testInboundEmail).Return(nil, tt.err)
+			req, _ := http.NewRequest("POST", "/inbound/email", strings.NewReader(testInboundEmail))
+			req.Header.Set("Content-Type", "message/rfc822")
+			req.Header.Set("X-Inbound-Token", "secret")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MFAHandler handles two-factor authentication HTTP requests of the current user
+type MFAHandler struct {
+	service services.MFAService
+}
+
+// NewMFAHandler creates a new MFA handler
+func NewMFAHandler(service services.MFAService) *MFAHandler {
+	return &MFAHandler{
+		service: service,
+	}
+}
+
+// Enroll handles POST /api/v1/auth/mfa/enroll
+// @Summary Start MFA enrollment
+// @Description Generate a new TOTP secret for the current user. Show the provisioning URI as a QR code, then confirm with POST /auth/mfa/confirm
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "TOTP secret and provisioning URI"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "MFA is already enabled"
+// @Router /auth/mfa/enroll [post]
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	enrollment, err := h.service.Enroll(userID.(uint))
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case services.ErrMFAAlreadyEnabled:
+			c.JSON(http.StatusConflict, gin.H{"error": "MFA is already enabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start MFA enrollment"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": enrollment})
+}
+
+// Confirm handles POST /api/v1/auth/mfa/confirm
+// @Summary Confirm MFA enrollment
+// @Description Turn on MFA with a code from the authenticator app. The response carries the recovery codes, which are shown only once
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFACodeRequest true "TOTP code"
+// @Success 200 {object} map[string]interface{} "MFA enabled"
+// @Failure 400 {object} map[string]interface{} "Invalid request, invalid code or enrollment not started"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "MFA is already enabled"
+// @Router /auth/mfa/confirm [post]
+func (h *MFAHandler) Confirm(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.service.Confirm(userID.(uint), req.Code)
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case services.ErrInvalidMFACode:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MFA code"})
+		case services.ErrMFANotEnrolling:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MFA enrollment has not been started"})
+		case services.ErrMFAAlreadyEnabled:
+			c.JSON(http.StatusConflict, gin.H{"error": "MFA is already enabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm MFA enrollment"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": codes})
+}
+
+// Disable handles POST /api/v1/auth/mfa/disable
+// @Summary Disable MFA
+// @Description Turn off MFA for the current user. Requires the password and, once MFA is enabled, a TOTP or recovery code. Not allowed for admins while MFA is required for admins
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DisableMFARequest true "Password and code"
+// @Success 200 {object} map[string]interface{} "MFA disabled"
+// @Failure 400 {object} map[string]interface{} "Invalid request, invalid code or MFA not enabled"
+// @Failure 401 {object} map[string]interface{} "Unauthorized or incorrect password"
+// @Failure 403 {object} map[string]interface{} "MFA is required for this account"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /auth/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.DisableMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.service.Disable(userID.(uint), &req)
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case services.ErrInvalidCredentials:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Password is incorrect"})
+		case services.ErrInvalidMFACode:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MFA code"})
+		case services.ErrMFANotEnabled:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MFA is not enabled"})
+		case services.ErrMFAMandatory:
+			c.JSON(http.StatusForbidden, gin.H{"error": "MFA is required for this account"})
+		case services.ErrInvalidRequest:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable MFA"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "MFA disabled successfully"})
+}
+
+// RegenerateRecoveryCodes handles POST /api/v1/auth/mfa/recovery-codes
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes of the current user after checking a TOTP or recovery code. The old codes stop working
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFACodeRequest true "TOTP or recovery code"
+// @Success 200 {object} map[string]interface{} "New recovery codes"
+// @Failure 400 {object} map[string]interface{} "Invalid request, invalid code or MFA not enabled"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /auth/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(userID.(uint), req.Code)
+	if err != nil {
+		switch err {
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case services.ErrInvalidMFACode:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MFA code"})
+		case services.ErrMFANotEnabled:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "MFA is not enabled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to regenerate recovery codes"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": codes})
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMFAService is a mock implementation of MFAService
+type MockMFAService struct {
+	mock.Mock
+}
+
+func (m *MockMFAService) Enroll(userID uint) (*models.MFAEnrollmentResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockMFAService) Confirm(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	args := m.Called(userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockMFAService) Disable(userID uint, req *models.DisableMFARequest) error {
+	args := m.Called(userID, req)
+	return args.Error(0)
+}
+
+func (m *MockMFAService) RegenerateRecoveryCodes(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	args := m.Called(userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockMFAService) IsRequired(user *models.User) bool {
+	args := m.Called(user)
+	return args.Bool(0)
+}
+
+func (m *MockMFAService) BeginEnrollment(user *models.User) (*models.MFAEnrollmentResponse, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockMFAService) VerifyLogin(user *models.User, code string) ([]string, error) {
+	args := m.Called(user, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func setupMFAHandler() (*MFAHandler, *MockMFAService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockMFAService)
+	return NewMFAHandler(mockService), mockService
+}
+
+func performMFARequest(handle gin.HandlerFunc, body string, authenticated bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/mfa", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	if authenticated {
+		c.Set("user_id", uint(1))
+	}
+	handle(c)
+	return w
+}
+
+func TestMFAHandler_Enroll_Success(t *testing.T) {
+	handler, mockService := setupMFAHandler()
+	mockService.On("Enroll", uint(1)).Return(&models.MFAEnrollmentResponse{
+		Secret:          "JBSWY3DPEHPK3PXP",
+		ProvisioningURI: "otpauth://totp/Support%20App:alice?secret=JBSWY3DPEHPK3PXP",
+	}, nil)
+
+	w := performMFARequest(handler.Enroll, "", true)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"provisioning_uri":"otpauth://totp/Support%20App:alice?secret=JBSWY3DPEHPK3PXP"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestMFAHandler_Enroll_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"user not found", services.ErrUserNotFound, http.StatusNotFound},
+		{"already enabled", services.ErrMFAAlreadyEnabled, http.StatusConflict},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupMFAHandler()
+			mockService.On("Enroll", uint(1)).Return(nil, tt.err)
+
+			w := performMFARequest(handler.Enroll, "", true)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMFAHandler_Enroll_NotAuthenticated(t *testing.T) {
+	handler, mockService := setupMFAHandler()
+
+	w := performMFARequest(handler.Enroll, "", false)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "Enroll", mock.Anything)
+}
+
+func TestMFAHandler_Confirm_Success(t *testing.T) {
+	handler, mockService := setupMFAHandler()
+	mockService.On("Confirm", uint(1), "123456").Return(&models.MFARecoveryCodesResponse{RecoveryCodes: []string{"abcde-fghij"}}, nil)
+
+	w := performMFARequest(handler.Confirm, `{"code":"123456"}`, true)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"recovery_codes":["abcde-fghij"]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestMFAHandler_Confirm_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid code", services.ErrInvalidMFACode, http.StatusBadRequest},
+		{"not enrolling", services.ErrMFANotEnrolling, http.StatusBadRequest},
+		{"already enabled", services.ErrMFAAlreadyEnabled, http.StatusConflict},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupMFAHandler()
+			mockService.On("Confirm", uint(1), "123456").Return(nil, tt.err)
+
+			w := performMFARequest(handler.Confirm, `{"code":"123456"}`, true)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMFAHandler_Confirm_MissingCode(t *testing.T) {
+	handler, mockService := setupMFAHandler()
+
+	w := performMFARequest(handler.Confirm, `{}`, true)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Confirm", mock.Anything, mock.Anything)
+}
+
+func TestMFAHandler_Disable_Success(t *testing.T) {
+	handler, mockService := setupMFAHandler()
+	mockService.On("Disable", uint(1), &models.DisableMFARequest{Password: "password123", Code: "123456"}).Return(nil)
+
+	w := performMFARequest(handler.Disable, `{"password":"password123","code":"123456"}`, true)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "MFA disabled successfully")
+	mockService.AssertExpectations(t)
+}
+
+func TestMFAHandler_Disable_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"wrong password", services.ErrInvalidCredentials, http.StatusUnauthorized},
+		{"invalid code", services.ErrInvalidMFACode, http.StatusBadRequest},
+		{"not enabled", services.ErrMFANotEnabled, http.StatusBadRequest},
+		{"mandatory", services.ErrMFAMandatory, http.StatusForbidden},
+		{"user not found", services.ErrUserNotFound, http.StatusNotFound},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupMFAHandler()
+			mockService.On("Disable", uint(1), mock.Anything).Return(tt.err)
+
+			w := performMFARequest(handler.Disable, `{"password":"password123","code":"123456"}`, true)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMFAHandler_RegenerateRecoveryCodes_Success(t *testing.T) {
+	handler, mockService := setupMFAHandler()
+	mockService.On("RegenerateRecoveryCodes", uint(1), "123456").Return(&models.MFARecoveryCodesResponse{RecoveryCodes: []string{"k7rqm-2xw4d"}}, nil)
+
+	w := performMFARequest(handler.RegenerateRecoveryCodes, `{"code":"123456"}`, true)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"recovery_codes":["k7rqm-2xw4d"]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestMFAHandler_RegenerateRecoveryCodes_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid code", services.ErrInvalidMFACode, http.StatusBadRequest},
+		{"not enabled", services.ErrMFANotEnabled, http.StatusBadRequest},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupMFAHandler()
+			mockService.On("RegenerateRecoveryCodes", uint(1), "123456").Return(nil, tt.err)
+
+			w := performMFARequest(handler.RegenerateRecoveryCodes, `{"code":"123456"}`, true)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unsubscribePage is shown to submitters following the unsubscribe link of a notification email.
+// Opening the link only asks for confirmation, so that mail scanners fetching it don't unsubscribe.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Email notifications</title></head>
+<body>
+<p>{{.Message}}</p>
+{{if .Confirm}}<form method="post"><button type="submit">Unsubscribe</button></form>{{end}}
+</body>
+</html>
+`))
+
+// unsubscribePageData is what unsubscribePage is rendered with
+type unsubscribePageData struct {
+	Message string
+	Confirm bool
+}
+
+// NotificationHandler handles HTTP requests for submitter email notifications
+type NotificationHandler struct {
+	service services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(service services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+	}
+}
+
+// ListTemplates handles GET /api/v1/apps/:id/notification-templates
+// @Summary List notification templates
+// @Description Retrieve the email templates of an app for every notification event. Events without a custom template return the default one (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Success 200 {object} map[string]interface{} "Templates retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id}/notification-templates [get]
+func (h *NotificationHandler) ListTemplates(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	templates, err := h.service.ListTemplates(id)
+	if err != nil {
+		respondNotificationError(c, err, "Failed to retrieve notification templates")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": templates})
+}
+
+// SetTemplate handles PUT /api/v1/apps/:id/notification-templates/:event
+// @Summary Set notification template
+// @Description Replace the email template of an app for a notification event (status_changed or reply). Subject and body are Go text/template sources that can use .App, .RequestID, .Reference, .Status, .StatusText, .PreviousStatus, .Reply and .UnsubscribeURL (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Param event path string true "Notification event" Enums(status_changed, reply)
+// @Param request body models.SetNotificationTemplateRequest true "Template"
+// @Success 200 {object} map[string]interface{} "Template saved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or template"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /apps/{id}/notification-templates/{event} [put]
+func (h *NotificationHandler) SetTemplate(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SetNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.SetTemplate(id, models.NotificationEvent(c.Param("event")), &req)
+	if err != nil {
+		respondNotificationError(c, err, "Failed to save notification template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// DeleteTemplate handles DELETE /api/v1/apps/:id/notification-templates/:event
+// @Summary Reset notification template
+// @Description Remove the custom email template of an app for a notification event, so that the default one is sent again (requires the apps:manage permission)
+// @Tags Apps
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "App ID"
+// @Param event path string true "Notification event" Enums(status_changed, reply)
+// @Success 200 {object} map[string]interface{} "Template removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format or event"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App or custom template not found"
+// @Router /apps/{id}/notification-templates/{event} [delete]
+func (h *NotificationHandler) DeleteTemplate(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(id, models.NotificationEvent(c.Param("event"))); err != nil {
+		respondNotificationError(c, err, "Failed to remove notification template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification template removed successfully"})
+}
+
+// ConfirmUnsubscribe handles GET /api/v1/support-request/unsubscribe/:token
+// @Summary Confirm unsubscribe
+// @Description Show the page the unsubscribe link of a notification email opens. It asks the submitter to confirm, and changes nothing itself (public endpoint with rate limiting)
+// @Tags Support Requests
+// @Produce html
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {string} string "Confirmation page"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /support-request/unsubscribe/{token} [get]
+func (h *NotificationHandler) ConfirmUnsubscribe(c *gin.Context) {
+	c.Header("Referrer-Policy", "no-referrer")
+	renderUnsubscribePage(c, http.StatusOK, unsubscribePageData{
+		Message: "Stop receiving emails about this support request?",
+		Confirm: true,
+	})
+}
+
+// Unsubscribe handles POST /api/v1/support-request/unsubscribe/:token
+// @Summary Unsubscribe from notifications
+// @Description Stop the emails about the support request an unsubscribe token was mailed for. Also used by mail clients for one-click unsubscribe (RFC 8058). Responds with a page when HTML is accepted (public endpoint with rate limiting)
+// @Tags Support Requests
+// @Accept x-www-form-urlencoded
+// @Produce json,html
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {object} map[string]interface{} "Unsubscribed successfully"
+// @Failure 404 {object} map[string]interface{} "Invalid unsubscribe token"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /support-request/unsubscribe/{token} [post]
+func (h *NotificationHandler) Unsubscribe(c *gin.Context) {
+	c.Header("Referrer-Policy", "no-referrer")
+	wantsHTML := c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML
+
+	if err := h.service.Unsubscribe(c.Param("token")); err != nil {
+		status, message := http.StatusInternalServerError, "Failed to unsubscribe"
+		if errors.Is(err, services.ErrInvalidUnsubscribeToken) {
+			status, message = http.StatusNotFound, "Invalid unsubscribe link"
+		}
+		if wantsHTML {
+			renderUnsubscribePage(c, status, unsubscribePageData{Message: message + "."})
+			return
+		}
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	if wantsHTML {
+		renderUnsubscribePage(c, http.StatusOK, unsubscribePageData{Message: "You will no longer receive emails about this support request."})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed successfully"})
+}
+
+// renderUnsubscribePage writes unsubscribePage with data
+func renderUnsubscribePage(c *gin.Context, status int, data unsubscribePageData) {
+	var b bytes.Buffer
+	if err := unsubscribePage.Execute(&b, data); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render page"})
+		return
+	}
+	c.Data(status, "text/html; charset=utf-8", b.Bytes())
+}
+
+// respondNotificationError maps notification service errors to HTTP responses
+func respondNotificationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrAppNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "App not found"})
+	case errors.Is(err, services.ErrNotificationTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification template not found"})
+	case errors.Is(err, services.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationService is a mock implementation of NotificationService
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) ListTemplates(appID uint) ([]*models.NotificationTemplateResponse, error) {
+	args := m.Called(appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NotificationTemplateResponse), args.Error(1)
+}
+
+func (m *MockNotificationService) SetTemplate(appID uint, event models.NotificationEvent, req *models.SetNotificationTemplateRequest) (*models.NotificationTemplateResponse, error) {
+	args := m.Called(appID, event, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationTemplateResponse), args.Error(1)
+}
+
+func (m *MockNotificationService) DeleteTemplate(appID uint, event models.NotificationEvent) error {
+	args := m.Called(appID, event)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) Unsubscribe(token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func TestNotificationHandler_ListTemplates(t *testing.T) {
+	// Arrange
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/apps/:id/notification-templates", handler.ListTemplates)
+	mockService.On("ListTemplates", uint(1)).Return([]*models.NotificationTemplateResponse{
+		{Event: models.NotificationEventStatusChanged, Subject: "Status"},
+		{Event: models.NotificationEventReply, Subject: "Reply", Custom: true},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/apps/1/notification-templates", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"custom":true`)
+	mockService.AssertExpectations(t)
+}
+
+func TestNotificationHandler_SetTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"saved", `{"subject":"{{.Reference}}","body":"{{.Reply}}"}`, nil, http.StatusOK},
+		{"missing body", `{"subject":"{{.Reference}}"}`, nil, http.StatusBadRequest},
+		{"invalid template", `{"subject":"{{.Reference","body":"Hi"}`, fmt.Errorf("%w: invalid subject template", services.ErrInvalidRequest), http.StatusBadRequest},
+		{"unknown app", `{"subject":"Hi","body":"Hi"}`, services.ErrAppNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockNotificationService)
+			handler := NewNotificationHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/apps/:id/notification-templates/:event", handler.SetTemplate)
+			if tt.err != nil {
+				mockService.On("SetTemplate", uint(1), models.NotificationEventReply, mock.Anything).Return(nil, tt.err)
+			} else {
+				mockService.On("SetTemplate", uint(1), models.NotificationEventReply, mock.Anything).Return(&models.NotificationTemplateResponse{Event: models.NotificationEventReply, Custom: true}, nil).Maybe()
+			}
+
+			// Act
+			req, _ := http.NewRequest("PUT", "/apps/1/notification-templates/reply", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestNotificationHandler_DeleteTemplate_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService)
+	router := setupTestRouter()
+	router.DELETE("/apps/:id/notification-templates/:event", handler.DeleteTemplate)
+	mockService.On("DeleteTemplate", uint(1), models.NotificationEventReply).Return(services.ErrNotificationTemplateNotFound)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/apps/1/notification-templates/reply", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNotificationHandler_ConfirmUnsubscribe(t *testing.T) {
+	// Arrange
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-request/unsubscribe/:token", handler.ConfirmUnsubscribe)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/support-request/unsubscribe/7.sig", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), `<form method="post">`)
+	mockService.AssertNotCalled(t, "Unsubscribe", mock.Anything)
+}
+
+func TestNotificationHandler_Unsubscribe(t *testing.T) {
+	tests := []struct {
+		name           string
+		accept         string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"one-click", "", nil, http.StatusOK, "Unsubscribed successfully"},
+		{"confirmed in browser", "text/html,application/xhtml+xml", nil, http.StatusOK, "You will no longer receive emails"},
+		{"invalid token", "", services.ErrInvalidUnsubscribeToken, http.StatusNotFound, "Invalid unsubscribe link"},
+		{"invalid token in browser", "text/html", services.ErrInvalidUnsubscribeToken, http.StatusNotFound, "<p>Invalid unsubscribe link.</p>"},
+		{"repository error", "", errors.New("database down"), http.StatusInternalServerError, "Failed to unsubscribe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockNotificationService)
+			handler := NewNotificationHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/support-request/unsubscribe/:token", handler.Unsubscribe)
+			mockService.On("Unsubscribe", "7.sig").Return(tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", "/support-request/unsubscribe/7.sig", bytes.NewBufferString("List-Unsubscribe=One-Click"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.NotContains(t, w.Body.String(), "database down")
+			mockService.AssertExpectations(t)
+		})
+	}
+}
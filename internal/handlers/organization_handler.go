@@ -0,0 +1,433 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles HTTP requests for organizations, their members and apps
+type OrganizationHandler struct {
+	service services.OrganizationService
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(service services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+	}
+}
+
+// ListOrganizations handles GET /api/v1/organizations
+// @Summary List organizations
+// @Description Retrieve all organizations ordered by name (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Organizations retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	organizations, err := h.service.ListOrganizations()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": organizations})
+}
+
+// GetOrganization handles GET /api/v1/organizations/:id
+// @Summary Get organization
+// @Description Retrieve an organization by ID (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Success 200 {object} map[string]interface{} "Organization retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GetOrganization(id)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to retrieve organization")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// CreateOrganization handles POST /api/v1/organizations
+// @Summary Create organization
+// @Description Create an organization (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateOrganizationRequest true "Organization data"
+// @Success 201 {object} map[string]interface{} "Organization created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 409 {object} map[string]interface{} "Organization already exists"
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req models.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.CreateOrganization(&req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to create organization")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+// UpdateOrganization handles PATCH /api/v1/organizations/:id
+// @Summary Rename organization
+// @Description Change the name of an organization (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param request body models.UpdateOrganizationRequest true "Organization update data"
+// @Success 200 {object} map[string]interface{} "Organization updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 409 {object} map[string]interface{} "Organization already exists"
+// @Router /organizations/{id} [patch]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.UpdateOrganization(id, &req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to update organization")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// DeleteOrganization handles DELETE /api/v1/organizations/:id
+// @Summary Delete organization
+// @Description Delete an organization with its memberships. Its apps stay registered, and its support requests are kept and become visible to platform staff only (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Success 200 {object} map[string]interface{} "Organization deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Router /organizations/{id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOrganization(id); err != nil {
+		respondOrganizationError(c, err, "Failed to delete organization")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Organization deleted successfully"})
+}
+
+// ListMembers handles GET /api/v1/organizations/:id/members
+// @Summary List organization members
+// @Description Retrieve the members of an organization with their roles (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Success 200 {object} map[string]interface{} "Members retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Router /organizations/{id}/members [get]
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	members, err := h.service.ListMembers(id)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to retrieve members")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": members})
+}
+
+// SetMember handles PUT /api/v1/organizations/:id/members/:userId
+// @Summary Add or update organization member
+// @Description Add a user to an organization, or change their role in it. The role applies to the organization's support requests only (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param userId path int true "User ID"
+// @Param request body models.SetOrganizationMemberRequest true "Membership role"
+// @Success 200 {object} map[string]interface{} "Member saved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or role"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization or user not found"
+// @Router /organizations/{id}/members/{userId} [put]
+func (h *OrganizationHandler) SetMember(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseUintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req models.SetOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.SetMember(id, userID, &req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to save member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// RemoveMember handles DELETE /api/v1/organizations/:id/members/:userId
+// @Summary Remove organization member
+// @Description Remove a user from an organization (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} map[string]interface{} "Member removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization member not found"
+// @Router /organizations/{id}/members/{userId} [delete]
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseUintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(id, userID); err != nil {
+		respondOrganizationError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// ListApps handles GET /api/v1/organizations/:id/apps
+// @Summary List organization apps
+// @Description Retrieve the apps an organization owns (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Success 200 {object} map[string]interface{} "Apps retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Router /organizations/{id}/apps [get]
+func (h *OrganizationHandler) ListApps(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	apps, err := h.service.ListApps(id)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to retrieve apps")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": apps})
+}
+
+// AddApp handles POST /api/v1/organizations/:id/apps
+// @Summary Add app to organization
+// @Description Give an organization ownership of an app. New support requests for the app, and existing ones not yet owned by an organization, belong to it (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param request body models.AddOrganizationAppRequest true "App"
+// @Success 201 {object} map[string]interface{} "App added successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 409 {object} map[string]interface{} "App already belongs to an organization"
+// @Router /organizations/{id}/apps [post]
+func (h *OrganizationHandler) AddApp(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AddOrganizationAppRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.AddApp(id, &req)
+	if err != nil {
+		respondOrganizationError(c, err, "Failed to add app")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+// RemoveApp handles DELETE /api/v1/organizations/:id/apps/:app
+// @Summary Remove app from organization
+// @Description Give up an organization's ownership of an app. Support requests already received stay with the organization (requires the organizations:manage permission)
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param app path string true "App identifier"
+// @Success 200 {object} map[string]interface{} "App removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "App not found"
+// @Router /organizations/{id}/apps/{app} [delete]
+func (h *OrganizationHandler) RemoveApp(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveApp(id, c.Param("app")); err != nil {
+		respondOrganizationError(c, err, "Failed to remove app")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "App removed successfully"})
+}
+
+// ListMyOrganizations handles GET /api/v1/auth/me/organizations
+// @Summary List my organizations
+// @Description List the organizations the current user belongs to, with their role in each. Send one of the IDs in the X-Organization-ID header to work in that organization
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Organizations retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /auth/me/organizations [get]
+func (h *OrganizationHandler) ListMyOrganizations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	organizations, err := h.service.ListUserOrganizations(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": organizations})
+}
+
+// organizationScope returns the organization the caller works in, as resolved by
+// OrganizationScopeMiddleware. Callers outside any organization are unscoped.
+func organizationScope(c *gin.Context) models.OrganizationScope {
+	if organizationID, exists := c.Get("organization_id"); exists {
+		return models.ScopeToOrganization(organizationID.(uint))
+	}
+	return models.OrganizationScope{}
+}
+
+// parseUintParam parses a numeric path parameter, responding with 400 when it is invalid
+func parseUintParam(c *gin.Context, name string) (uint, bool) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return 0, false
+	}
+	return uint(value), true
+}
+
+// respondOrganizationError maps organization service errors to HTTP responses
+func respondOrganizationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+	case errors.Is(err, services.ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization member not found"})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case errors.Is(err, services.ErrAppNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "App not found"})
+	case errors.Is(err, services.ErrOrganizationExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Organization already exists"})
+	case errors.Is(err, services.ErrAppOwned):
+		c.JSON(http.StatusConflict, gin.H{"error": "App already belongs to an organization"})
+	case errors.Is(err, services.ErrInvalidRole), errors.Is(err, services.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrganizationService is a mock implementation of OrganizationService
+type MockOrganizationService struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationService) ListOrganizations() ([]*models.OrganizationResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) GetOrganization(id uint) (*models.OrganizationResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) CreateOrganization(req *models.CreateOrganizationRequest) (*models.OrganizationResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) UpdateOrganization(id uint, req *models.UpdateOrganizationRequest) (*models.OrganizationResponse, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) DeleteOrganization(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationService) ListMembers(organizationID uint) ([]*models.OrganizationMemberResponse, error) {
+	args := m.Called(organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrganizationMemberResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) SetMember(organizationID, userID uint, req *models.SetOrganizationMemberRequest) (*models.OrganizationMemberResponse, error) {
+	args := m.Called(organizationID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrganizationMemberResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) RemoveMember(organizationID, userID uint) error {
+	args := m.Called(organizationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationService) ListApps(organizationID uint) ([]*models.AppResponse, error) {
+	args := m.Called(organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AppResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) AddApp(organizationID uint, req *models.AddOrganizationAppRequest) (*models.AppResponse, error) {
+	args := m.Called(organizationID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) RemoveApp(organizationID uint, slug string) error {
+	args := m.Called(organizationID, slug)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationService) ListUserOrganizations(userID uint) ([]*models.UserOrganizationResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserOrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) ResolveScope(userID uint, requestedID *uint) (models.OrganizationScope, models.UserRole, error) {
+	args := m.Called(userID, requestedID)
+	return args.Get(0).(models.OrganizationScope), args.Get(1).(models.UserRole), args.Error(2)
+}
+
+func setupOrganizationHandler() (*OrganizationHandler, *MockOrganizationService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockOrganizationService)
+	return NewOrganizationHandler(mockService), mockService
+}
+
+func performOrganizationRequest(handle gin.HandlerFunc, method, body string, params gin.Params) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/organizations", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	handle(c)
+	return w
+}
+
+func TestOrganizationHandler_CreateOrganization_Success(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	mockService.On("CreateOrganization", &models.CreateOrganizationRequest{Name: "Acme"}).Return(&models.OrganizationResponse{ID: 1, Name: "Acme"}, nil)
+
+	w := performOrganizationRequest(handler.CreateOrganization, http.MethodPost, `{"name":"Acme"}`, nil)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Acme"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrganizationHandler_CreateOrganization_MissingName(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+
+	w := performOrganizationRequest(handler.CreateOrganization, http.MethodPost, `{}`, nil)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateOrganization", mock.Anything)
+}
+
+func TestOrganizationHandler_GetOrganization_InvalidID(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+
+	w := performOrganizationRequest(handler.GetOrganization, http.MethodGet, "", gin.Params{{Key: "id", Value: "acme"}})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetOrganization", mock.Anything)
+}
+
+func TestOrganizationHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"organization not found", services.ErrOrganizationNotFound, http.StatusNotFound},
+		{"user not found", services.ErrUserNotFound, http.StatusNotFound},
+		{"organization exists", services.ErrOrganizationExists, http.StatusConflict},
+		{"invalid role", services.ErrInvalidRole, http.StatusBadRequest},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupOrganizationHandler()
+			mockService.On("SetMember", uint(1), uint(2), &models.SetOrganizationMemberRequest{Role: "agent"}).Return(nil, tt.err)
+
+			w := performOrganizationRequest(handler.SetMember, http.MethodPut, `{"role":"agent"}`, gin.Params{{Key: "id", Value: "1"}, {Key: "userId", Value: "2"}})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestOrganizationHandler_SetMember_Success(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	mockService.On("SetMember", uint(1), uint(2), &models.SetOrganizationMemberRequest{Role: "agent"}).
+		Return(&models.OrganizationMemberResponse{OrganizationID: 1, UserID: 2, Username: "alice", Role: models.UserRoleAgent}, nil)
+
+	w := performOrganizationRequest(handler.SetMember, http.MethodPut, `{"role":"agent"}`, gin.Params{{Key: "id", Value: "1"}, {Key: "userId", Value: "2"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"role":"agent"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrganizationHandler_RemoveMember_NotFound(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	mockService.On("RemoveMember", uint(1), uint(2)).Return(services.ErrMemberNotFound)
+
+	w := performOrganizationRequest(handler.RemoveMember, http.MethodDelete, "", gin.Params{{Key: "id", Value: "1"}, {Key: "userId", Value: "2"}})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOrganizationHandler_AddApp_Success(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	organizationID := uint(1)
+	mockService.On("AddApp", uint(1), &models.AddOrganizationAppRequest{App: "acme-ios"}).Return(&models.AppResponse{ID: 1, OrganizationID: &organizationID, Slug: "acme-ios"}, nil)
+
+	w := performOrganizationRequest(handler.AddApp, http.MethodPost, `{"app":"acme-ios"}`, gin.Params{{Key: "id", Value: "1"}})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"slug":"acme-ios"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrganizationHandler_AddApp_OwnedElsewhere(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	mockService.On("AddApp", uint(1), mock.Anything).Return(nil, services.ErrAppOwned)
+
+	w := performOrganizationRequest(handler.AddApp, http.MethodPost, `{"app":"acme-ios"}`, gin.Params{{Key: "id", Value: "1"}})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestOrganizationHandler_RemoveApp_NotFound(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	mockService.On("RemoveApp", uint(1), "acme-ios").Return(services.ErrAppNotFound)
+
+	w := performOrganizationRequest(handler.RemoveApp, http.MethodDelete, "", gin.Params{{Key: "id", Value: "1"}, {Key: "app", Value: "acme-ios"}})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOrganizationHandler_ListMyOrganizations(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+	mockService.On("ListUserOrganizations", uint(7)).Return([]*models.UserOrganizationResponse{{ID: 1, Name: "Acme", Role: models.UserRoleAgent}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/auth/me/organizations", nil)
+	c.Set("user_id", uint(7))
+	handler.ListMyOrganizations(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Acme"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrganizationHandler_ListMyOrganizations_NotAuthenticated(t *testing.T) {
+	handler, mockService := setupOrganizationHandler()
+
+	w := performOrganizationRequest(handler.ListMyOrganizations, http.MethodGet, "", nil)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "ListUserOrganizations", mock.Anything)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordResetHandler handles self-service password reset HTTP requests
+type PasswordResetHandler struct {
+	service services.PasswordResetService
+}
+
+// NewPasswordResetHandler creates a new password reset handler
+func NewPasswordResetHandler(service services.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		service: service,
+	}
+}
+
+// RequestReset handles POST /api/v1/auth/password-reset/request
+// @Summary Request a password reset
+// @Description Mail a single-use, time-limited reset token to the account with the given email. The response is the same whether or not such an account exists
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.PasswordResetRequest true "Account email"
+// @Success 202 {object} map[string]interface{} "Reset requested"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /auth/password-reset/request [post]
+func (h *PasswordResetHandler) RequestReset(c *gin.Context) {
+	var req models.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.RequestReset(&req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Password reset request failed"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If an account with that email exists, a password reset email has been sent"})
+}
+
+// ConfirmReset handles POST /api/v1/auth/password-reset/confirm
+// @Summary Confirm a password reset
+// @Description Set a new password with a mailed reset token. The token works once, and all existing sessions of the account are logged out
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body models.PasswordResetConfirmRequest true "Reset token and new password"
+// @Success 200 {object} map[string]interface{} "Password reset"
+// @Failure 400 {object} map[string]interface{} "Invalid request or invalid, expired or used token"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /auth/password-reset/confirm [post]
+func (h *PasswordResetHandler) ConfirmReset(c *gin.Context) {
+	var req models.PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.ConfirmReset(&req); err != nil {
+		if err == services.ErrInvalidResetToken {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired reset token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Password reset failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
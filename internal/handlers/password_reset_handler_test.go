@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPasswordResetService is a mock implementation of PasswordResetService
+type MockPasswordResetService struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetService) RequestReset(req *models.PasswordResetRequest) error {
+	args := m.Called(req)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetService) ConfirmReset(req *models.PasswordResetConfirmRequest) error {
+	args := m.Called(req)
+	return args.Error(0)
+}
+
+func setupPasswordResetHandler() (*PasswordResetHandler, *MockPasswordResetService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockPasswordResetService)
+	return NewPasswordResetHandler(mockService), mockService
+}
+
+func performPasswordResetRequest(handle gin.HandlerFunc, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/password-reset", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	handle(c)
+	return w
+}
+
+func TestPasswordResetHandler_RequestReset_Accepted(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+	mockService.On("RequestReset", &models.PasswordResetRequest{Email: "alice@example.com"}).Return(nil)
+
+	w := performPasswordResetRequest(handler.RequestReset, `{"email":"alice@example.com"}`)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), "If an account with that email exists")
+	mockService.AssertExpectations(t)
+}
+
+func TestPasswordResetHandler_RequestReset_InvalidEmail(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+
+	w := performPasswordResetRequest(handler.RequestReset, `{"email":"not-an-email"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "RequestReset", mock.Anything)
+}
+
+func TestPasswordResetHandler_RequestReset_ServiceError(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+	mockService.On("RequestReset", mock.Anything).Return(errors.New("database error"))
+
+	w := performPasswordResetRequest(handler.RequestReset, `{"email":"alice@example.com"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestPasswordResetHandler_ConfirmReset_Success(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+	mockService.On("ConfirmReset", &models.PasswordResetConfirmRequest{Token: "reset-token", NewPassword: "newpassword123"}).Return(nil)
+
+	w := performPasswordResetRequest(handler.ConfirmReset, `{"token":"reset-token","new_password":"newpassword123"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestPasswordResetHandler_ConfirmReset_InvalidToken(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+	mockService.On("ConfirmReset", mock.Anything).Return(services.ErrInvalidResetToken)
+
+	w := performPasswordResetRequest(handler.ConfirmReset, `{"token":"reset-token","new_password":"newpassword123"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid or expired reset token")
+}
+
+func TestPasswordResetHandler_ConfirmReset_ShortPassword(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+
+	w := performPasswordResetRequest(handler.ConfirmReset, `{"token":"reset-token","new_password":"short"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ConfirmReset", mock.Anything)
+}
+
+func TestPasswordResetHandler_ConfirmReset_ServiceError(t *testing.T) {
+	handler, mockService := setupPasswordResetHandler()
+	mockService.On("ConfirmReset", mock.Anything).Return(errors.New("database error"))
+
+	w := performPasswordResetRequest(handler.ConfirmReset, `{"token":"reset-token","new_password":"newpassword123"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
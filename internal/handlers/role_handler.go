@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler handles HTTP requests for roles and their permissions
+type RoleHandler struct {
+	service services.RoleService
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(service services.RoleService) *RoleHandler {
+	return &RoleHandler{
+		service: service,
+	}
+}
+
+// ListRoles handles GET /api/v1/roles
+// @Summary List roles
+// @Description Retrieve the built-in roles followed by the custom roles, with the permissions each grants (requires the users:manage permission)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Roles retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Router /roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.service.ListRoles()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve roles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": roles, "permissions": models.Permissions})
+}
+
+// GetRole handles GET /api/v1/roles/:name
+// @Summary Get role
+// @Description Retrieve a built-in or custom role by name (requires the users:manage permission)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Success 200 {object} map[string]interface{} "Role retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Role not found"
+// @Router /roles/{name} [get]
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	response, err := h.service.GetRole(models.UserRole(c.Param("name")))
+	if err != nil {
+		respondRoleError(c, err, "Failed to retrieve role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// CreateRole handles POST /api/v1/roles
+// @Summary Create custom role
+// @Description Create a role granting a set of permissions (requires the users:manage permission)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateRoleRequest true "Role data"
+// @Success 201 {object} map[string]interface{} "Role created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request, name or permission"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 409 {object} map[string]interface{} "Role already exists"
+// @Router /roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req models.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.CreateRole(&req)
+	if err != nil {
+		respondRoleError(c, err, "Failed to create role")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+// UpdateRole handles PATCH /api/v1/roles/:name
+// @Summary Update custom role
+// @Description Change the description or permissions of a custom role. Built-in roles cannot be changed (requires the users:manage permission)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Param request body models.UpdateRoleRequest true "Role update data"
+// @Success 200 {object} map[string]interface{} "Role updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or permission"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied or built-in role"
+// @Failure 404 {object} map[string]interface{} "Role not found"
+// @Router /roles/{name} [patch]
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	var req models.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.UpdateRole(models.UserRole(c.Param("name")), &req)
+	if err != nil {
+		respondRoleError(c, err, "Failed to update role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// DeleteRole handles DELETE /api/v1/roles/:name
+// @Summary Delete custom role
+// @Description Delete a custom role that no user has. Built-in roles cannot be deleted (requires the users:manage permission)
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Success 200 {object} map[string]interface{} "Role deleted successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied or built-in role"
+// @Failure 404 {object} map[string]interface{} "Role not found"
+// @Failure 409 {object} map[string]interface{} "Role is assigned to users"
+// @Router /roles/{name} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	if err := h.service.DeleteRole(models.UserRole(c.Param("name"))); err != nil {
+		respondRoleError(c, err, "Failed to delete role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully"})
+}
+
+// respondRoleError maps role service errors to HTTP responses
+func respondRoleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrRoleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+	case errors.Is(err, services.ErrRoleExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Role already exists"})
+	case errors.Is(err, services.ErrRoleInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": "Role is assigned to users"})
+	case errors.Is(err, services.ErrBuiltinRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Built-in roles cannot be changed"})
+	case errors.Is(err, services.ErrInvalidRole), errors.Is(err, services.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
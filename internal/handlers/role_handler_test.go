@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoleService is a mock implementation of RoleService
+type MockRoleService struct {
+	mock.Mock
+}
+
+func (m *MockRoleService) ListRoles() ([]*models.RoleResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) GetRole(name models.UserRole) (*models.RoleResponse, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) CreateRole(req *models.CreateRoleRequest) (*models.RoleResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) UpdateRole(name models.UserRole, req *models.UpdateRoleRequest) (*models.RoleResponse, error) {
+	args := m.Called(name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) DeleteRole(name models.UserRole) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockRoleService) HasPermission(role models.UserRole, permission models.Permission) (bool, error) {
+	args := m.Called(role, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupRoleHandler() (*RoleHandler, *MockRoleService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockRoleService)
+	return NewRoleHandler(mockService), mockService
+}
+
+func performRoleRequest(handle gin.HandlerFunc, method, name, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/roles/"+name, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	if name != "" {
+		c.Params = gin.Params{{Key: "name", Value: name}}
+	}
+	handle(c)
+	return w
+}
+
+func TestRoleHandler_ListRoles(t *testing.T) {
+	handler, mockService := setupRoleHandler()
+	mockService.On("ListRoles").Return(models.BuiltinRoleResponses(), nil)
+
+	w := performRoleRequest(handler.ListRoles, http.MethodGet, "", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"agent"`)
+	assert.Contains(t, w.Body.String(), `"permissions":["tickets:read","tickets:update","tickets:delete"`)
+}
+
+func TestRoleHandler_GetRole_NotFound(t *testing.T) {
+	handler, mockService := setupRoleHandler()
+	mockService.On("GetRole", models.UserRole("missing")).Return(nil, services.ErrRoleNotFound)
+
+	w := performRoleRequest(handler.GetRole, http.MethodGet, "missing", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRoleHandler_CreateRole_Success(t *testing.T) {
+	handler, mockService := setupRoleHandler()
+	req := &models.CreateRoleRequest{Name: "triage", Permissions: []models.Permission{models.PermissionTicketsRead}}
+	mockService.On("CreateRole", req).Return(&models.RoleResponse{Name: "triage", Permissions: req.Permissions}, nil)
+
+	w := performRoleRequest(handler.CreateRole, http.MethodPost, "", `{"name":"triage","permissions":["tickets:read"]}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"triage"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestRoleHandler_CreateRole_InvalidBody(t *testing.T) {
+	handler, mockService := setupRoleHandler()
+
+	w := performRoleRequest(handler.CreateRole, http.MethodPost, "", `{"permissions":["tickets:read"]}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateRole", mock.Anything)
+}
+
+func TestRoleHandler_CreateRole_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"exists", services.ErrRoleExists, http.StatusConflict},
+		{"invalid permission", fmt.Errorf("%w: unknown permission %q", services.ErrInvalidRole, "tickets:archive"), http.StatusBadRequest},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupRoleHandler()
+			mockService.On("CreateRole", mock.Anything).Return(nil, tt.err)
+
+			w := performRoleRequest(handler.CreateRole, http.MethodPost, "", `{"name":"triage","permissions":["tickets:archive"]}`)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRoleHandler_UpdateRole_Builtin(t *testing.T) {
+	handler, mockService := setupRoleHandler()
+	mockService.On("UpdateRole", models.UserRoleAgent, mock.Anything).Return(nil, services.ErrBuiltinRole)
+
+	w := performRoleRequest(handler.UpdateRole, http.MethodPatch, "agent", `{"permissions":["tickets:delete"]}`)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRoleHandler_DeleteRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"in use", services.ErrRoleInUse, http.StatusConflict},
+		{"built-in", services.ErrBuiltinRole, http.StatusForbidden},
+		{"not found", services.ErrRoleNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupRoleHandler()
+			mockService.On("DeleteRole", models.UserRole("triage")).Return(tt.err)
+
+			w := performRoleRequest(handler.DeleteRole, http.MethodDelete, "triage", "")
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupportRequestBulkHandler handles HTTP requests that change many support requests at once
+type SupportRequestBulkHandler struct {
+	service services.SupportRequestBulkService
+}
+
+// NewSupportRequestBulkHandler creates a new bulk handler
+func NewSupportRequestBulkHandler(service services.SupportRequestBulkService) *SupportRequestBulkHandler {
+	return &SupportRequestBulkHandler{
+		service: service,
+	}
+}
+
+// BulkUpdateSupportRequests handles POST /api/v1/support-requests/bulk/update
+// @Summary Bulk update support requests
+// @Description Change the status, priority, assignee or tags of up to 1000 support requests selected by ID or by a filter in list endpoint query syntax. Changes are written in a single transaction; requests a change is not allowed for are reported as failed and left unchanged. With dry_run nothing is written (requires the tickets:update permission)
+// @Tags Support Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.BulkUpdateSupportRequestsRequest true "Selection and changes"
+// @Success 200 {object} map[string]interface{} "Outcome per support request"
+// @Failure 400 {object} map[string]interface{} "Invalid selection or changes"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 422 {object} map[string]interface{} "Unknown tag, or assignee is not an active user"
+// @Router /support-requests/bulk/update [post]
+func (h *SupportRequestBulkHandler) BulkUpdateSupportRequests(c *gin.Context) {
+	var req models.BulkUpdateSupportRequestsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	selection, ok := parseBulkSelection(c, req.IDs, req.Filter)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.BulkUpdate(selection, &req, auditActor(c))
+	if err != nil {
+		respondBulkError(c, err, "Failed to update support requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// BulkDeleteSupportRequests handles POST /api/v1/support-requests/bulk/delete
+// @Summary Bulk delete support requests
+// @Description Delete up to 1000 support requests selected by ID or by a filter in list endpoint query syntax, in a single transaction. With dry_run nothing is deleted (requires the tickets:delete permission)
+// @Tags Support Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.BulkDeleteSupportRequestsRequest true "Selection"
+// @Success 200 {object} map[string]interface{} "Outcome per support request"
+// @Failure 400 {object} map[string]interface{} "Invalid selection"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Router /support-requests/bulk/delete [post]
+func (h *SupportRequestBulkHandler) BulkDeleteSupportRequests(c *gin.Context) {
+	var req models.BulkDeleteSupportRequestsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	selection, ok := parseBulkSelection(c, req.IDs, req.Filter)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.BulkDelete(selection, req.DryRun, auditActor(c))
+	if err != nil {
+		respondBulkError(c, err, "Failed to delete support requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// parseBulkSelection builds the selection of a bulk request from its IDs or its filter, which uses
+// the list endpoint query syntax. It responds with 400 and returns false when the selection is invalid.
+func parseBulkSelection(c *gin.Context, ids []uint, filter string) (services.SupportRequestSelection, bool) {
+	selection := services.SupportRequestSelection{IDs: ids}
+	selection.Filter.Scope = organizationScope(c)
+
+	filter = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(filter), "?"))
+	if (len(ids) == 0) == (filter == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Select support requests with either ids or filter"})
+		return selection, false
+	}
+	if filter == "" {
+		return selection, true
+	}
+
+	values, err := url.ParseQuery(filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter: expected list endpoint query parameters such as status=new&app=my-app"})
+		return selection, false
+	}
+	if selection.Filter, err = parseSupportRequestFilterValues(c, values); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return selection, false
+	}
+	return selection, true
+}
+
+// respondBulkError maps bulk service errors to HTTP responses
+func respondBulkError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRequest), errors.Is(err, services.ErrInvalidFilter):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTagNotFound), errors.Is(err, services.ErrAssigneeNotFound), errors.Is(err, services.ErrAssigneeInactive):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
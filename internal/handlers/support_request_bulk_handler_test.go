@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSupportRequestBulkService is a mock implementation of SupportRequestBulkService
+type MockSupportRequestBulkService struct {
+	mock.Mock
+}
+
+func (m *MockSupportRequestBulkService) BulkUpdate(selection services.SupportRequestSelection, req *models.BulkUpdateSupportRequestsRequest, actor models.AuditActor) (*models.BulkSupportRequestsResponse, error) {
+	args := m.Called(selection, req, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkSupportRequestsResponse), args.Error(1)
+}
+
+func (m *MockSupportRequestBulkService) BulkDelete(selection services.SupportRequestSelection, dryRun bool, actor models.AuditActor) (*models.BulkSupportRequestsResponse, error) {
+	args := m.Called(selection, dryRun, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkSupportRequestsResponse), args.Error(1)
+}
+
+// performBulkRequest posts body to a bulk route backed by mockService, as an authenticated member of organization 3
+func performBulkRequest(mockService *MockSupportRequestBulkService, path, body string) *httptest.ResponseRecorder {
+	handler := NewSupportRequestBulkHandler(mockService)
+	router := setupTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(9))
+		c.Set("username", "agent")
+		c.Set("organization_id", uint(3))
+		c.Next()
+	})
+	router.POST("/support-requests/bulk/update", handler.BulkUpdateSupportRequests)
+	router.POST("/support-requests/bulk/delete", handler.BulkDeleteSupportRequests)
+
+	req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSupportRequestBulkHandler_BulkUpdateSupportRequests_ByIDs(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestBulkService)
+	selection := services.SupportRequestSelection{
+		IDs:    []uint{1, 2},
+		Filter: repositories.SupportRequestFilter{Scope: models.ScopeToOrganization(3)},
+	}
+	response := &models.BulkSupportRequestsResponse{
+		Matched: 2,
+		Changed: 2,
+		Results: []*models.BulkSupportRequestResult{
+			{ID: 1, Outcome: models.BulkOutcomeUpdated},
+			{ID: 2, Outcome: models.BulkOutcomeUpdated},
+		},
+	}
+	mockService.On("BulkUpdate", selection, mock.MatchedBy(func(req *models.BulkUpdateSupportRequestsRequest) bool {
+		return *req.Status == models.StatusSpam && req.AddTags[0] == "spam"
+	}), mock.MatchedBy(func(actor models.AuditActor) bool {
+		return actor.UserID == 9
+	})).Return(response, nil)
+
+	// Act
+	w := performBulkRequest(mockService, "/support-requests/bulk/update", `{"ids":[1,2],"status":"spam","add_tags":["spam"]}`)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"changed":2`)
+	assert.Contains(t, w.Body.String(), `"outcome":"updated"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestBulkHandler_BulkUpdateSupportRequests_ByFilter(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestBulkService)
+	mockService.On("BulkUpdate", mock.MatchedBy(func(selection services.SupportRequestSelection) bool {
+		filter := selection.Filter
+		return len(selection.IDs) == 0 &&
+			filter.App == "spam-app" &&
+			len(filter.Statuses) == 2 && filter.Statuses[1] == models.StatusInProgress &&
+			filter.AssigneeID != nil && *filter.AssigneeID == 9 &&
+			filter.CreatedAfter != nil &&
+			*filter.Scope.OrganizationID == 3
+	}), mock.Anything, mock.Anything).Return(&models.BulkSupportRequestsResponse{DryRun: true}, nil)
+
+	// Act
+	w := performBulkRequest(mockService, "/support-requests/bulk/update",
+		`{"filter":"status=new,in_progress&app=spam-app&assignee=me&created_after=2025-06-01","unassign":true,"dry_run":true}`)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"dry_run":true`)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestBulkHandler_InvalidSelection(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"neither ids nor filter", "/support-requests/bulk/update", `{"status":"spam"}`},
+		{"both ids and filter", "/support-requests/bulk/delete", `{"ids":[1],"filter":"app=x"}`},
+		{"malformed filter", "/support-requests/bulk/delete", `{"filter":"app=%zz"}`},
+		{"invalid filter value", "/support-requests/bulk/delete", `{"filter":"created_after=yesterday"}`},
+		{"invalid body", "/support-requests/bulk/update", `{"ids":"all"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestBulkService)
+
+			// Act
+			w := performBulkRequest(mockService, tt.path, tt.body)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "BulkUpdate", mock.Anything, mock.Anything, mock.Anything)
+			mockService.AssertNotCalled(t, "BulkDelete", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestBulkHandler_BulkUpdateSupportRequests_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"invalid request", fmt.Errorf("%w: no changes requested", services.ErrInvalidRequest), http.StatusBadRequest},
+		{"too many matches", fmt.Errorf("%w: the filter matches 1500 support requests", services.ErrInvalidFilter), http.StatusBadRequest},
+		{"unknown tag", fmt.Errorf("%w: spam", services.ErrTagNotFound), http.StatusUnprocessableEntity},
+		{"inactive assignee", services.ErrAssigneeInactive, http.StatusUnprocessableEntity},
+		{"database error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestBulkService)
+			mockService.On("BulkUpdate", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			// Act
+			w := performBulkRequest(mockService, "/support-requests/bulk/update", `{"ids":[1],"status":"spam"}`)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestSupportRequestBulkHandler_BulkDeleteSupportRequests(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestBulkService)
+	response := &models.BulkSupportRequestsResponse{
+		DryRun:  true,
+		Matched: 1,
+		Changed: 1,
+		Results: []*models.BulkSupportRequestResult{{ID: 4, Outcome: models.BulkOutcomeDeleted}},
+	}
+	mockService.On("BulkDelete", mock.MatchedBy(func(selection services.SupportRequestSelection) bool {
+		return len(selection.Filter.Statuses) == 1 && selection.Filter.Statuses[0] == models.StatusSpam
+	}), true, mock.Anything).Return(response, nil)
+
+	// Act
+	w := performBulkRequest(mockService, "/support-requests/bulk/delete", `{"filter":"?status=spam","dry_run":true}`)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"outcome":"deleted"`)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Export formats accepted by the format query parameter
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportFlushInterval is the number of rows written between flushes to the client
+const exportFlushInterval = 100
+
+// exportContentTypes maps the export formats to the content types they are served as
+var exportContentTypes = map[string]string{
+	exportFormatCSV:    "text/csv; charset=utf-8",
+	exportFormatNDJSON: "application/x-ndjson",
+}
+
+// exportColumn is a support request field that can be exported. value returns nil, a string,
+// a uint, a time.Time or a []string.
+type exportColumn struct {
+	name  string
+	value func(*models.SupportRequestResponse) interface{}
+}
+
+// exportColumns lists the exportable columns in their default order
+var exportColumns = []exportColumn{
+	{"id", func(r *models.SupportRequestResponse) interface{} { return r.ID }},
+	{"type", func(r *models.SupportRequestResponse) interface{} { return string(r.Type) }},
+	{"status", func(r *models.SupportRequestResponse) interface{} { return string(r.Status) }},
+	{"priority", func(r *models.SupportRequestResponse) interface{} { return string(r.Priority) }},
+	{"platform", func(r *models.SupportRequestResponse) interface{} { return string(r.Platform) }},
+	{"app", func(r *models.SupportRequestResponse) interface{} { return r.App }},
+	{"app_version", func(r *models.SupportRequestResponse) interface{} { return r.AppVersion }},
+	{"device_model", func(r *models.SupportRequestResponse) interface{} { return r.DeviceModel }},
+	{"user_email", func(r *models.SupportRequestResponse) interface{} { return optionalString(r.UserEmail) }},
+	{"message", func(r *models.SupportRequestResponse) interface{} { return r.Message }},
+	{"admin_notes", func(r *models.SupportRequestResponse) interface{} { return optionalString(r.AdminNotes) }},
+	{"assignee_id", func(r *models.SupportRequestResponse) interface{} { return optionalUint(r.AssigneeID) }},
+	{"organization_id", func(r *models.SupportRequestResponse) interface{} { return optionalUint(r.OrganizationID) }},
+	{"tags", func(r *models.SupportRequestResponse) interface{} {
+		if r.Tags == nil {
+			return []string{}
+		}
+		return r.Tags
+	}},
+	{"first_response_due_at", func(r *models.SupportRequestResponse) interface{} { return optionalTime(r.FirstResponseDueAt) }},
+	{"resolution_due_at", func(r *models.SupportRequestResponse) interface{} { return optionalTime(r.ResolutionDueAt) }},
+	{"first_responded_at", func(r *models.SupportRequestResponse) interface{} { return optionalTime(r.FirstRespondedAt) }},
+	{"resolved_at", func(r *models.SupportRequestResponse) interface{} { return optionalTime(r.ResolvedAt) }},
+	{"closed_at", func(r *models.SupportRequestResponse) interface{} { return optionalTime(r.ClosedAt) }},
+	{"created_at", func(r *models.SupportRequestResponse) interface{} { return r.CreatedAt }},
+	{"updated_at", func(r *models.SupportRequestResponse) interface{} { return r.UpdatedAt }},
+}
+
+// ExportSupportRequests handles GET /api/v1/support-requests/export
+// @Summary Export support requests
+// @Description Stream every support request matching the list endpoint filters as CSV or newline-delimited JSON. The format is taken from the format parameter, or else from the Accept header (text/csv or application/x-ndjson), and defaults to CSV (requires the tickets:read permission)
+// @Tags Support Requests
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param format query string false "Export format (csv, ndjson)"
+// @Param columns query string false "Comma-separated or repeated columns to export, in order (default: all)"
+// @Param status query string false "Comma-separated statuses (new, in_progress, waiting_on_customer, resolved, closed, reopened, spam)"
+// @Param type query string false "Comma-separated types (support, feedback, bug_report, feature_request)"
+// @Param platform query string false "Comma-separated platforms (iOS, Android, Web, Email)"
+// @Param priority query string false "Comma-separated priorities (low, normal, high, urgent)"
+// @Param sla query string false "SLA state: breached (a deadline has passed) or due_soon (a deadline is near)"
+// @Param app query string false "Application name"
+// @Param app_version query string false "Application version"
+// @Param user_email query string false "Submitter email (case-insensitive)"
+// @Param assignee query string false "Assignee user ID, me (the authenticated user) or none (unassigned)"
+// @Param tag query string false "Comma-separated or repeated tag names"
+// @Param tag_match query string false "How multiple tags combine: any (OR) or all (AND)" default(any)
+// @Param suspected_duplicate query bool false "Only requests flagged as a suspected duplicate and not merged yet"
+// @Param created_after query string false "Only requests created at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param created_before query string false "Only requests created before this time (RFC3339 or YYYY-MM-DD)"
+// @Param updated_after query string false "Only requests updated at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param updated_before query string false "Only requests updated before this time (RFC3339 or YYYY-MM-DD)"
+// @Param sort_by query string false "Sort field (id, created_at, updated_at, status, type, platform, app, app_version)" default(created_at)
+// @Param sort_order query string false "Sort order (asc, desc)" default(desc)
+// @Success 200 {string} string "Exported support requests"
+// @Failure 400 {object} map[string]interface{} "Invalid filter, format or column"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 406 {object} map[string]interface{} "Accept header matches no export format"
+// @Router /support-requests/export [get]
+func (h *SupportRequestHandler) ExportSupportRequests(c *gin.Context) {
+	format, err := parseExportFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format == "" {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": "Export is available as text/csv or application/x-ndjson"})
+		return
+	}
+
+	columns, err := parseExportColumns(queryList(c.Request.URL.Query(), "columns"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseSupportRequestFilter(c)
+	if err != nil {
+		if errors.Is(err, errAssigneeMeUnauthenticated) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writer := newExportWriter(format, columns, c.Writer)
+
+	// The response starts with the first row, so errors raised before it can still be reported
+	started := false
+	start := func() error {
+		started = true
+		c.Header("Content-Type", exportContentTypes[format])
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="support-requests-%s.%s"`, time.Now().UTC().Format("20060102-150405"), format))
+		c.Header("Cache-Control", "no-store")
+		c.Status(http.StatusOK)
+		return writer.writeHeader()
+	}
+
+	rows := 0
+	err = h.service.ExportSupportRequests(filter, func(response *models.SupportRequestResponse) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.writeRow(response); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushInterval == 0 {
+			if err := writer.flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if started {
+			// Too late to change the status; the client sees a truncated export
+			_ = c.Error(err)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export support requests"})
+		return
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+	if err := writer.flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// parseExportFormat returns the export format named by the format parameter, or else the one
+// negotiated from the Accept header. It returns an empty format when Accept matches none.
+func parseExportFormat(c *gin.Context) (string, error) {
+	if format := strings.ToLower(strings.TrimSpace(c.Query("format"))); format != "" {
+		if _, ok := exportContentTypes[format]; !ok {
+			return "", fmt.Errorf("format must be %s or %s", exportFormatCSV, exportFormatNDJSON)
+		}
+		return format, nil
+	}
+
+	switch c.NegotiateFormat("text/csv", "application/x-ndjson", "application/ndjson") {
+	case "text/csv":
+		return exportFormatCSV, nil
+	case "application/x-ndjson", "application/ndjson":
+		return exportFormatNDJSON, nil
+	}
+	return "", nil
+}
+
+// parseExportColumns resolves column names, defaulting to every column
+func parseExportColumns(names []string) ([]exportColumn, error) {
+	if len(names) == 0 {
+		return exportColumns, nil
+	}
+
+	byName := make(map[string]exportColumn, len(exportColumns))
+	valid := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		byName[column.name] = column
+		valid[i] = column.name
+	}
+
+	seen := make(map[string]bool, len(names))
+	columns := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		column, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid column %q, must be one of %s", name, strings.Join(valid, ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// exportWriter encodes exported support requests. Rows may be buffered until flush.
+type exportWriter interface {
+	writeHeader() error
+	writeRow(response *models.SupportRequestResponse) error
+	flush() error
+}
+
+// newExportWriter creates a writer of columns in format to w
+func newExportWriter(format string, columns []exportColumn, w http.ResponseWriter) exportWriter {
+	if format == exportFormatNDJSON {
+		return &ndjsonExportWriter{columns: columns, w: w}
+	}
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true // RFC 4180 line endings
+	return &csvExportWriter{columns: columns, w: writer}
+}
+
+// csvExportWriter writes a header line with the column names followed by one line per request.
+// Fields containing commas, quotes or line breaks are quoted by encoding/csv.
+type csvExportWriter struct {
+	columns []exportColumn
+	w       *csv.Writer
+}
+
+func (e *csvExportWriter) writeHeader() error {
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = column.name
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExportWriter) writeRow(response *models.SupportRequestResponse) error {
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = csvField(column.value(response))
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExportWriter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvField formats an exported value as a CSV field. Text submitted by users that a spreadsheet
+// would evaluate as a formula is prefixed with a single quote.
+func csvField(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// ndjsonExportWriter writes one JSON object per line with the columns as keys, in column order
+type ndjsonExportWriter struct {
+	columns []exportColumn
+	w       http.ResponseWriter
+}
+
+func (e *ndjsonExportWriter) writeHeader() error {
+	return nil
+}
+
+func (e *ndjsonExportWriter) writeRow(response *models.SupportRequestResponse) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, err := json.Marshal(column.name)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(column.value(response))
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+
+	_, err := e.w.Write(line.Bytes())
+	return err
+}
+
+func (e *ndjsonExportWriter) flush() error {
+	return nil
+}
+
+// optionalString returns the value s points to, or nil
+func optionalString(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// optionalUint returns the value u points to, or nil
+func optionalUint(u *uint) interface{} {
+	if u == nil {
+		return nil
+	}
+	return *u
+}
+
+// optionalTime returns the value t points to, or nil
+func optionalTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"support-app-backend/internal/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// exportResponses returns two support requests, one with text that needs CSV quoting
+func exportResponses() []*models.SupportRequestResponse {
+	email := "user@example.com"
+	createdAt := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	return []*models.SupportRequestResponse{
+		{
+			ID:        1,
+			Type:      models.SupportRequestTypeBugReport,
+			UserEmail: &email,
+			Message:   "It crashes, then says \"oops\"\nevery time",
+			Platform:  models.PlatformIOS,
+			App:       "app-x",
+			Status:    models.StatusNew,
+			Tags:      []string{"login", "payments"},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		{
+			ID:        2,
+			Type:      models.SupportRequestTypeFeedback,
+			Message:   "=HYPERLINK(\"http://example.com\")",
+			Platform:  models.PlatformWeb,
+			App:       "app-x",
+			Status:    models.StatusResolved,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+	}
+}
+
+// performExport requests target with the given Accept header from an export route backed by mockService
+func performExport(mockService *MockSupportRequestService, target, accept string) *httptest.ResponseRecorder {
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests/export", handler.ExportSupportRequests)
+
+	req, _ := http.NewRequest("GET", target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSupportRequestHandler_ExportSupportRequests_CSV(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	filter := repositories.SupportRequestFilter{App: "app-x"}
+	mockService.On("ExportSupportRequests", filter).Return(exportResponses(), nil)
+
+	// Act
+	w := performExport(mockService, "/support-requests/export?app=app-x&columns=id,message,user_email,tags,created_at", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="support-requests-`)
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "message", "user_email", "tags", "created_at"},
+		{"1", "It crashes, then says \"oops\"\nevery time", "user@example.com", "login,payments", "2025-06-09T10:00:00Z"},
+		{"2", "'=HYPERLINK(\"http://example.com\")", "", "", "2025-06-09T10:00:00Z"},
+	}, records)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_ExportSupportRequests_NDJSON(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	mockService.On("ExportSupportRequests", repositories.SupportRequestFilter{}).Return(exportResponses(), nil)
+
+	// Act
+	w := performExport(mockService, "/support-requests/export?columns=id&columns=user_email,tags", "application/x-ndjson")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"id":1,"user_email":"user@example.com","tags":["login","payments"]}`+"\n"+
+		`{"id":2,"user_email":null,"tags":[]}`+"\n", w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_ExportSupportRequests_FormatOverridesAccept(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	mockService.On("ExportSupportRequests", repositories.SupportRequestFilter{}).Return(exportResponses()[:1], nil)
+
+	// Act
+	w := performExport(mockService, "/support-requests/export?format=ndjson", "text/csv")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	var row map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &row))
+	assert.Len(t, row, len(exportColumns))
+	assert.Equal(t, "bug_report", row["type"])
+}
+
+func TestSupportRequestHandler_ExportSupportRequests_EmptyCSVHasHeader(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	mockService.On("ExportSupportRequests", repositories.SupportRequestFilter{}).Return([]*models.SupportRequestResponse{}, nil)
+
+	// Act
+	w := performExport(mockService, "/support-requests/export?columns=id,status", "text/csv")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "id,status\r\n", w.Body.String())
+}
+
+func TestSupportRequestHandler_ExportSupportRequests_BadRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		accept string
+		status int
+	}{
+		{"unknown format", "/support-requests/export?format=xml", "", http.StatusBadRequest},
+		{"unknown column", "/support-requests/export?columns=id,password", "", http.StatusBadRequest},
+		{"invalid filter", "/support-requests/export?created_after=yesterday", "", http.StatusBadRequest},
+		{"unacceptable", "/support-requests/export", "application/json", http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestService)
+
+			// Act
+			w := performExport(mockService, tt.target, tt.accept)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			mockService.AssertNotCalled(t, "ExportSupportRequests", mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestHandler_ExportSupportRequests_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"invalid filter", fmt.Errorf("%w: invalid status", services.ErrInvalidFilter), http.StatusBadRequest},
+		{"database error", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestService)
+			mockService.On("ExportSupportRequests", repositories.SupportRequestFilter{}).Return([]*models.SupportRequestResponse{}, tt.err)
+
+			// Act
+			w := performExport(mockService, "/support-requests/export", "")
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+		})
+	}
+}
@@ -1,52 +1,115 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
 	"support-app-backend/internal/services"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+// errAssigneeMeUnauthenticated is returned by parseSupportRequestFilter when assignee=me is used without a token
+var errAssigneeMeUnauthenticated = errors.New("assignee=me requires authentication")
+
 // SupportRequestHandler handles HTTP requests for support requests
 type SupportRequestHandler struct {
-	service services.SupportRequestService
+	service           services.SupportRequestService
+	attachmentService services.AttachmentService
 }
 
 // NewSupportRequestHandler creates a new support request handler
-func NewSupportRequestHandler(service services.SupportRequestService) *SupportRequestHandler {
+func NewSupportRequestHandler(service services.SupportRequestService, attachmentService services.AttachmentService) *SupportRequestHandler {
 	return &SupportRequestHandler{
-		service: service,
+		service:           service,
+		attachmentService: attachmentService,
 	}
 }
 
 // CreateSupportRequest handles POST /api/v1/support-request
 // @Summary Create support request
-// @Description Create a new support request (public endpoint with rate limiting)
+// @Description Create a new support request (public endpoint with rate limiting). Send JSON, or multipart/form-data with the same fields plus up to the configured number of "attachments" files (screenshots, logs). The app must be registered and active. The response includes a tracking_token for the tracking endpoint, which is only returned here, and the submitter email redacted. Retries with the same Idempotency-Key and body get the original response again instead of creating another request.
 // @Tags Support Requests
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
+// @Param X-App-Key header string false "Intake key of the app"
+// @Param Idempotency-Key header string false "Unique key of the submission, for safe retries"
 // @Param request body models.CreateSupportRequestRequest true "Support request data"
 // @Success 201 {object} map[string]interface{} "Support request created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid app key"
+// @Failure 403 {object} map[string]interface{} "App is disabled"
+// @Failure 409 {object} map[string]interface{} "A request with the Idempotency-Key is still being processed"
+// @Failure 413 {object} map[string]interface{} "Attachment or request body too large"
+// @Failure 415 {object} map[string]interface{} "Attachment type not allowed"
+// @Failure 422 {object} map[string]interface{} "Unknown app, platform not allowed for the app, or Idempotency-Key used with a different request"
 // @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
 // @Router /support-request [post]
 func (h *SupportRequestHandler) CreateSupportRequest(c *gin.Context) {
 	var req models.CreateSupportRequestRequest
+	var files []*multipart.FileHeader
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if c.ContentType() == binding.MIMEMultipartPOSTForm {
+		if err := c.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+			if isBodyTooLarge(err) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large"})
+				return
+			}
+			c.Header("X-Validation-Error", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		files = c.Request.MultipartForm.File["attachments"]
+	} else if err := c.ShouldBindJSON(&req); err != nil {
 		// Log the validation error for debugging
 		c.Header("X-Validation-Error", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	response, err := h.service.CreateSupportRequest(&req)
+	var keyApp *models.App
+	if app, exists := c.Get("intake_app"); exists {
+		keyApp = app.(*models.App)
+	}
+
+	// Files are checked and stored first so the request is only created, and announced, with all of them
+	var attachments []*models.Attachment
+	if len(files) > 0 {
+		var err error
+		attachments, err = h.attachmentService.StoreUploads(files)
+		if err != nil {
+			respondAttachmentError(c, err)
+			return
+		}
+	}
+
+	response, err := h.service.CreateSupportRequest(&req, keyApp, attachments...)
 	if err != nil {
-		if err == services.ErrInvalidRequest {
+		if len(attachments) > 0 {
+			h.attachmentService.DiscardUploads(attachments)
+		}
+		switch {
+		case errors.Is(err, services.ErrInvalidRequest):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
+		case errors.Is(err, services.ErrInvalidAppKey):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, services.ErrAppDisabled):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, services.ErrUnknownApp), errors.Is(err, services.ErrPlatformNotAllowed):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
 		}
 		// Log internal server errors for debugging
 		c.Header("X-Internal-Error", err.Error())
@@ -54,18 +117,25 @@ func (h *SupportRequestHandler) CreateSupportRequest(c *gin.Context) {
 		return
 	}
 
+	// Submitters are not signed in, so they only see their email redacted
+	response.UserEmail = models.RedactEmail(response.UserEmail)
+
 	c.JSON(http.StatusCreated, gin.H{"data": response})
 }
 
 // GetSupportRequest handles GET /api/v1/support-requests/:id
 // @Summary Get support request by ID
-// @Description Get support request details by ID (public endpoint)
+// @Description Get support request details by ID (requires the tickets:read permission)
 // @Tags Support Requests
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Support Request ID"
 // @Success 200 {object} map[string]interface{} "Support request details"
+// @Success 301 {object} map[string]interface{} "Support request was merged, Location points at the request it was merged into"
 // @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
 // @Failure 404 {object} map[string]interface{} "Support request not found"
 // @Router /support-requests/{id} [get]
 func (h *SupportRequestHandler) GetSupportRequest(c *gin.Context) {
@@ -76,7 +146,7 @@ func (h *SupportRequestHandler) GetSupportRequest(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.GetSupportRequest(uint(id))
+	response, err := h.service.GetSupportRequest(uint(id), organizationScope(c))
 	if err != nil {
 		if err == services.ErrSupportRequestNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
@@ -86,26 +156,67 @@ func (h *SupportRequestHandler) GetSupportRequest(c *gin.Context) {
 		return
 	}
 
+	// A merged request is a stub pointing at the request that has its conversation now
+	if response.MergedIntoID != nil {
+		c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, idParam)+strconv.FormatUint(uint64(*response.MergedIntoID), 10))
+		c.JSON(http.StatusMovedPermanently, gin.H{"data": response})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"data": response})
 }
 
 // GetAllSupportRequests handles GET /api/v1/support-requests
 // @Summary Get all support requests
-// @Description Get paginated list of all support requests (public endpoint)
+// @Description Get paginated list of support requests, optionally filtered and sorted (requires the tickets:read permission)
 // @Tags Support Requests
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
+// @Param status query string false "Comma-separated statuses (new, in_progress, waiting_on_customer, resolved, closed, reopened, spam)"
+// @Param type query string false "Comma-separated types (support, feedback, bug_report, feature_request)"
+// @Param platform query string false "Comma-separated platforms (iOS, Android, Web, Email)"
+// @Param priority query string false "Comma-separated priorities (low, normal, high, urgent)"
+// @Param sla query string false "SLA state: breached (a deadline has passed) or due_soon (a deadline is near)"
+// @Param app query string false "Application name"
+// @Param app_version query string false "Application version"
+// @Param user_email query string false "Submitter email (case-insensitive)"
+// @Param assignee query string false "Assignee user ID, me (the authenticated user) or none (unassigned)"
+// @Param tag query string false "Comma-separated or repeated tag names"
+// @Param tag_match query string false "How multiple tags combine: any (OR) or all (AND)" default(any)
+// @Param suspected_duplicate query bool false "Only requests flagged as a suspected duplicate and not merged yet"
+// @Param created_after query string false "Only requests created at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param created_before query string false "Only requests created before this time (RFC3339 or YYYY-MM-DD)"
+// @Param updated_after query string false "Only requests updated at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param updated_before query string false "Only requests updated before this time (RFC3339 or YYYY-MM-DD)"
+// @Param sort_by query string false "Sort field (id, created_at, updated_at, status, type, platform, app, app_version)" default(created_at)
+// @Param sort_order query string false "Sort order (asc, desc)" default(desc)
 // @Success 200 {object} map[string]interface{} "Support requests list"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
 // @Router /support-requests [get]
 func (h *SupportRequestHandler) GetAllSupportRequests(c *gin.Context) {
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	page, pageSize := parsePagination(c)
 
-	responses, total, err := h.service.GetAllSupportRequests(page, pageSize)
+	filter, err := parseSupportRequestFilter(c)
 	if err != nil {
+		if errors.Is(err, errAssigneeMeUnauthenticated) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	responses, total, err := h.service.GetAllSupportRequests(filter, page, pageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get support requests"})
 		return
 	}
@@ -124,6 +235,107 @@ func (h *SupportRequestHandler) GetAllSupportRequests(c *gin.Context) {
 	})
 }
 
+// SearchSupportRequests handles GET /api/v1/support-requests/search
+// @Summary Search support requests
+// @Description Full-text search over support request messages and admin notes, ranked by relevance. Accepts the same filters as the list endpoint (requires the tickets:read permission)
+// @Tags Support Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search query, e.g. crash on launch"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param status query string false "Comma-separated statuses (new, in_progress, waiting_on_customer, resolved, closed, reopened, spam)"
+// @Param type query string false "Comma-separated types (support, feedback, bug_report, feature_request)"
+// @Param platform query string false "Comma-separated platforms (iOS, Android, Web, Email)"
+// @Param app query string false "Application name"
+// @Success 200 {object} map[string]interface{} "Ranked search results with highlighted snippets"
+// @Failure 400 {object} map[string]interface{} "Missing query or invalid filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Router /support-requests/search [get]
+func (h *SupportRequestHandler) SearchSupportRequests(c *gin.Context) {
+	page, pageSize := parsePagination(c)
+
+	filter, err := parseSupportRequestFilter(c)
+	if err != nil {
+		if errors.Is(err, errAssigneeMeUnauthenticated) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, total, err := h.service.SearchSupportRequests(c.Query("q"), filter, page, pageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search support requests"})
+		return
+	}
+
+	// Calculate pagination metadata
+	totalPages := (int(total) + pageSize - 1) / pageSize
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": results,
+		"pagination": gin.H{
+			"page":        page,
+			"page_size":   pageSize,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetSupportRequestStats handles GET /api/v1/stats
+// @Summary Support request statistics
+// @Description Count support requests by status, type, platform, app and app version, as a time series and with median first response and resolution times. Accepts the same filters as the list endpoint; created_after and created_before set the date range, which defaults to the last 30 days (requires the stats:read permission)
+// @Tags Statistics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param interval query string false "Series bucket size (day, week, month)" default(day)
+// @Param created_after query string false "Start of the range, inclusive (RFC3339 or YYYY-MM-DD)"
+// @Param created_before query string false "End of the range, exclusive (RFC3339 or YYYY-MM-DD)"
+// @Param status query string false "Comma-separated statuses (new, in_progress, waiting_on_customer, resolved, closed, reopened, spam)"
+// @Param type query string false "Comma-separated types (support, feedback, bug_report, feature_request)"
+// @Param platform query string false "Comma-separated platforms (iOS, Android, Web, Email)"
+// @Param app query string false "Application name"
+// @Param app_version query string false "Application version"
+// @Param tag query string false "Comma-separated or repeated tag names"
+// @Success 200 {object} map[string]interface{} "Statistics"
+// @Failure 400 {object} map[string]interface{} "Invalid filter, interval or date range"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Router /stats [get]
+func (h *SupportRequestHandler) GetSupportRequestStats(c *gin.Context) {
+	filter, err := parseSupportRequestFilter(c)
+	if err != nil {
+		if errors.Is(err, errAssigneeMeUnauthenticated) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := h.service.GetSupportRequestStats(filter, strings.ToLower(strings.TrimSpace(c.Query("interval"))))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute statistics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": stats})
+}
+
 // UpdateSupportRequest handles PATCH /api/v1/support-requests/:id
 // @Summary Update support request (Admin only)
 // @Description Update support request details (requires admin authentication)
@@ -138,6 +350,7 @@ func (h *SupportRequestHandler) GetAllSupportRequests(c *gin.Context) {
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
 // @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Failure 409 {object} map[string]interface{} "Status transition not allowed, with the allowed statuses, or support request was merged"
 // @Router /support-requests/{id} [patch]
 func (h *SupportRequestHandler) UpdateSupportRequest(c *gin.Context) {
 	idParam := c.Param("id")
@@ -153,7 +366,7 @@ func (h *SupportRequestHandler) UpdateSupportRequest(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.UpdateSupportRequest(uint(id), &req)
+	response, err := h.service.UpdateSupportRequest(uint(id), organizationScope(c), &req, auditActor(c))
 	if err != nil {
 		if err == services.ErrSupportRequestNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
@@ -163,6 +376,18 @@ func (h *SupportRequestHandler) UpdateSupportRequest(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err == services.ErrSupportRequestMerged {
+			c.JSON(http.StatusConflict, gin.H{"error": "Support request was merged into another, update that one instead"})
+			return
+		}
+		var transitionErr *services.StatusTransitionError
+		if errors.As(err, &transitionErr) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":            err.Error(),
+				"allowed_statuses": services.AllowedStatusTransitions(transitionErr.From),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update support request"})
 		return
 	}
@@ -192,7 +417,7 @@ func (h *SupportRequestHandler) DeleteSupportRequest(c *gin.Context) {
 		return
 	}
 
-	err = h.service.DeleteSupportRequest(uint(id))
+	err = h.service.DeleteSupportRequest(uint(id), organizationScope(c), auditActor(c))
 	if err != nil {
 		if err == services.ErrSupportRequestNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
@@ -205,6 +430,56 @@ func (h *SupportRequestHandler) DeleteSupportRequest(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// MergeSupportRequest handles POST /api/v1/support-requests/:id/merge
+// @Summary Merge support request (Admin only)
+// @Description Merge a support request, typically a duplicate, into another one. Its original message, replies, attachments and tags move to the target; replies become internal notes when the requests have different submitters. The merged request is closed and left as a stub that redirects to the target (requires the tickets:update permission)
+// @Tags Support Requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID of the support request to merge"
+// @Param request body models.MergeSupportRequestRequest true "Target support request"
+// @Success 200 {object} map[string]interface{} "Target support request after the merge"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Failure 409 {object} map[string]interface{} "One of the support requests was already merged, or the request can't be closed"
+// @Router /support-requests/{id}/merge [post]
+func (h *SupportRequestHandler) MergeSupportRequest(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req models.MergeSupportRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.MergeSupportRequest(uint(id), req.TargetID, organizationScope(c), auditActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSupportRequestNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+		case errors.Is(err, services.ErrInvalidRequest):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrSupportRequestMerged):
+			c.JSON(http.StatusConflict, gin.H{"error": "Support request was already merged"})
+		case errors.Is(err, services.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge support request"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
 // HealthCheck handles GET /health
 func (h *SupportRequestHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -212,3 +487,128 @@ func (h *SupportRequestHandler) HealthCheck(c *gin.Context) {
 		"service": "support-app-backend",
 	})
 }
+
+// parsePagination reads page and page_size from the query string, applying the same
+// defaults and bounds as the services so the pagination metadata matches the results
+func parsePagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	return page, pageSize
+}
+
+// parseSupportRequestFilter builds a repository filter from the list endpoint query parameters
+func parseSupportRequestFilter(c *gin.Context) (repositories.SupportRequestFilter, error) {
+	return parseSupportRequestFilterValues(c, c.Request.URL.Query())
+}
+
+// parseSupportRequestFilterValues builds a repository filter from list endpoint parameters given as
+// values. The caller's organization scope and user ID are taken from c.
+func parseSupportRequestFilterValues(c *gin.Context, values url.Values) (repositories.SupportRequestFilter, error) {
+	filter := repositories.SupportRequestFilter{
+		Scope:      organizationScope(c),
+		App:        strings.TrimSpace(values.Get("app")),
+		AppVersion: strings.TrimSpace(values.Get("app_version")),
+		UserEmail:  strings.TrimSpace(values.Get("user_email")),
+		SLA:        strings.ToLower(strings.TrimSpace(values.Get("sla"))),
+		TagMatch:   strings.ToLower(strings.TrimSpace(values.Get("tag_match"))),
+		SortBy:     values.Get("sort_by"),
+		SortOrder:  strings.ToLower(values.Get("sort_order")),
+	}
+
+	switch assignee := strings.TrimSpace(values.Get("assignee")); assignee {
+	case "":
+	case "me":
+		// Set by AuthMiddleware; only missing when a route is registered without it
+		userID, exists := c.Get("user_id")
+		if !exists {
+			return filter, errAssigneeMeUnauthenticated
+		}
+		id := userID.(uint)
+		filter.AssigneeID = &id
+	case "none":
+		filter.Unassigned = true
+	default:
+		id, err := strconv.ParseUint(assignee, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("invalid assignee: expected a user ID, me or none")
+		}
+		assigneeID := uint(id)
+		filter.AssigneeID = &assigneeID
+	}
+
+	if value := strings.TrimSpace(values.Get("suspected_duplicate")); value != "" {
+		suspected, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid suspected_duplicate: expected true or false")
+		}
+		filter.SuspectedDuplicates = suspected
+	}
+
+	for _, value := range queryList(values, "status") {
+		filter.Statuses = append(filter.Statuses, models.Status(value))
+	}
+	for _, value := range queryList(values, "type") {
+		filter.Types = append(filter.Types, models.SupportRequestType(value))
+	}
+	for _, value := range queryList(values, "platform") {
+		filter.Platforms = append(filter.Platforms, models.Platform(value))
+	}
+	for _, value := range queryList(values, "priority") {
+		filter.Priorities = append(filter.Priorities, models.Priority(value))
+	}
+	for _, value := range queryList(values, "tag") {
+		filter.Tags = append(filter.Tags, models.NormalizeTagName(value))
+	}
+
+	timeParams := []struct {
+		key    string
+		target **time.Time
+	}{
+		{"created_after", &filter.CreatedAfter},
+		{"created_before", &filter.CreatedBefore},
+		{"updated_after", &filter.UpdatedAfter},
+		{"updated_before", &filter.UpdatedBefore},
+	}
+	for _, param := range timeParams {
+		value := values.Get(param.key)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseQueryTime(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: expected RFC3339 timestamp or YYYY-MM-DD date", param.key)
+		}
+		*param.target = &parsed
+	}
+
+	return filter, nil
+}
+
+// queryList returns the values of a query parameter that may be repeated or comma-separated
+func queryList(values url.Values, key string) []string {
+	var list []string
+	for _, raw := range values[key] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				list = append(list, value)
+			}
+		}
+	}
+	return list
+}
+
+// parseQueryTime parses an RFC3339 timestamp or a plain YYYY-MM-DD date (midnight UTC)
+func parseQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
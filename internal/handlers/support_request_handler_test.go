@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
 	"support-app-backend/internal/services"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -20,40 +23,72 @@ type MockSupportRequestService struct {
 	mock.Mock
 }
 
-func (m *MockSupportRequestService) CreateSupportRequest(req *models.CreateSupportRequestRequest) (*models.SupportRequestResponse, error) {
-	args := m.Called(req)
+func (m *MockSupportRequestService) CreateSupportRequest(req *models.CreateSupportRequestRequest, keyApp *models.App, attachments ...*models.Attachment) (*models.SupportRequestResponse, error) {
+	args := m.Called(req, keyApp, attachments)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
 }
 
-func (m *MockSupportRequestService) GetSupportRequest(id uint) (*models.SupportRequestResponse, error) {
-	args := m.Called(id)
+func (m *MockSupportRequestService) GetSupportRequest(id uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error) {
+	args := m.Called(id, scope)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
 }
 
-func (m *MockSupportRequestService) GetAllSupportRequests(page, pageSize int) ([]*models.SupportRequestResponse, int64, error) {
-	args := m.Called(page, pageSize)
+func (m *MockSupportRequestService) GetAllSupportRequests(filter repositories.SupportRequestFilter, page, pageSize int) ([]*models.SupportRequestResponse, int64, error) {
+	args := m.Called(filter, page, pageSize)
 	return args.Get(0).([]*models.SupportRequestResponse), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockSupportRequestService) UpdateSupportRequest(id uint, req *models.UpdateSupportRequestRequest) (*models.SupportRequestResponse, error) {
-	args := m.Called(id, req)
+func (m *MockSupportRequestService) SearchSupportRequests(query string, filter repositories.SupportRequestFilter, page, pageSize int) ([]*models.SupportRequestSearchResult, int64, error) {
+	args := m.Called(query, filter, page, pageSize)
+	return args.Get(0).([]*models.SupportRequestSearchResult), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSupportRequestService) GetSupportRequestStats(filter repositories.SupportRequestFilter, interval string) (*models.SupportRequestStatsResponse, error) {
+	args := m.Called(filter, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestStatsResponse), args.Error(1)
+}
+
+// ExportSupportRequests passes each response given to Return to fn, stopping at the first error
+func (m *MockSupportRequestService) ExportSupportRequests(filter repositories.SupportRequestFilter, fn func(*models.SupportRequestResponse) error) error {
+	args := m.Called(filter)
+	for _, response := range args.Get(0).([]*models.SupportRequestResponse) {
+		if err := fn(response); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockSupportRequestService) UpdateSupportRequest(id uint, scope models.OrganizationScope, req *models.UpdateSupportRequestRequest, actor models.AuditActor) (*models.SupportRequestResponse, error) {
+	args := m.Called(id, scope, req, actor)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
 }
 
-func (m *MockSupportRequestService) DeleteSupportRequest(id uint) error {
-	args := m.Called(id)
+func (m *MockSupportRequestService) DeleteSupportRequest(id uint, scope models.OrganizationScope, actor models.AuditActor) error {
+	args := m.Called(id, scope, actor)
 	return args.Error(0)
 }
 
+func (m *MockSupportRequestService) MergeSupportRequest(id, targetID uint, scope models.OrganizationScope, actor models.AuditActor) (*models.SupportRequestResponse, error) {
+	args := m.Called(id, targetID, scope, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -62,7 +97,7 @@ func setupTestRouter() *gin.Engine {
 func TestSupportRequestHandler_CreateSupportRequest(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.POST("/support-request", handler.CreateSupportRequest)
 
@@ -78,18 +113,19 @@ func TestSupportRequestHandler_CreateSupportRequest(t *testing.T) {
 	}
 
 	response := &models.SupportRequestResponse{
-		ID:          1,
-		Type:        models.SupportRequestTypeSupport,
-		UserEmail:   &userEmail,
-		Message:     "Test message",
-		Platform:    models.PlatformIOS,
-		AppVersion:  "1.0.0",
-		DeviceModel: "iPhone 13",
-		App:         "test-app",
-		Status:      models.StatusNew,
+		ID:            1,
+		Type:          models.SupportRequestTypeSupport,
+		UserEmail:     &userEmail,
+		Message:       "Test message",
+		Platform:      models.PlatformIOS,
+		AppVersion:    "1.0.0",
+		DeviceModel:   "iPhone 13",
+		App:           "test-app",
+		Status:        models.StatusNew,
+		TrackingToken: "trk_abc",
 	}
 
-	mockService.On("CreateSupportRequest", mock.AnythingOfType("*models.CreateSupportRequestRequest")).Return(response, nil)
+	mockService.On("CreateSupportRequest", mock.AnythingOfType("*models.CreateSupportRequestRequest"), (*models.App)(nil), []*models.Attachment(nil)).Return(response, nil)
 
 	requestBody, _ := json.Marshal(request)
 	req, _ := http.NewRequest("POST", "/support-request", bytes.NewBuffer(requestBody))
@@ -101,13 +137,16 @@ func TestSupportRequestHandler_CreateSupportRequest(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"tracking_token":"trk_abc"`)
+	assert.Contains(t, w.Body.String(), `"user_email":"t***@example.com"`)
+	assert.NotContains(t, w.Body.String(), "test@example.com")
 	mockService.AssertExpectations(t)
 }
 
 func TestSupportRequestHandler_CreateSupportRequest_InvalidJSON(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.POST("/support-request", handler.CreateSupportRequest)
 
@@ -122,10 +161,73 @@ func TestSupportRequestHandler_CreateSupportRequest_InvalidJSON(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestSupportRequestHandler_CreateSupportRequest_PassesKeyApp(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	keyApp := &models.App{ID: 3, Slug: "test-app", IsActive: true}
+	router := setupTestRouter()
+	router.POST("/support-request", func(c *gin.Context) {
+		c.Set("intake_app", keyApp)
+		c.Next()
+	}, handler.CreateSupportRequest)
+
+	mockService.On("CreateSupportRequest", mock.AnythingOfType("*models.CreateSupportRequestRequest"), keyApp, []*models.Attachment(nil)).
+		Return(&models.SupportRequestResponse{ID: 1, App: "test-app"}, nil)
+
+	body := `{"type":"support","message":"Test message","platform":"iOS","app_version":"1.0.0","device_model":"iPhone 13","app":"test-app"}`
+	req, _ := http.NewRequest("POST", "/support-request", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_CreateSupportRequest_AppErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown app", services.ErrUnknownApp, http.StatusUnprocessableEntity},
+		{"platform not allowed", services.ErrPlatformNotAllowed, http.StatusUnprocessableEntity},
+		{"disabled app", services.ErrAppDisabled, http.StatusForbidden},
+		{"key for another app", fmt.Errorf("%w: the key belongs to app %q", services.ErrInvalidAppKey, "other-app"), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestService)
+			handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+			router := setupTestRouter()
+			router.POST("/support-request", handler.CreateSupportRequest)
+
+			mockService.On("CreateSupportRequest", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			body := `{"type":"support","message":"Test message","platform":"iOS","app_version":"1.0.0","device_model":"iPhone 13","app":"test-app"}`
+			req, _ := http.NewRequest("POST", "/support-request", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
 func TestSupportRequestHandler_GetSupportRequest(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.GET("/support-requests/:id", handler.GetSupportRequest)
 
@@ -141,7 +243,7 @@ func TestSupportRequestHandler_GetSupportRequest(t *testing.T) {
 		Status:      models.StatusNew,
 	}
 
-	mockService.On("GetSupportRequest", uint(1)).Return(response, nil)
+	mockService.On("GetSupportRequest", uint(1), models.OrganizationScope{}).Return(response, nil)
 
 	req, _ := http.NewRequest("GET", "/support-requests/1", nil)
 
@@ -154,10 +256,55 @@ func TestSupportRequestHandler_GetSupportRequest(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSupportRequestHandler_GetSupportRequest_Merged(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/api/v1/support-requests/:id", handler.GetSupportRequest)
+
+	mergedInto := uint(41)
+	mockService.On("GetSupportRequest", uint(9), models.OrganizationScope{}).Return(&models.SupportRequestResponse{ID: 9, Status: models.StatusClosed, MergedIntoID: &mergedInto}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/support-requests/9", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/api/v1/support-requests/41", w.Header().Get("Location"))
+	assert.Contains(t, w.Body.String(), `"merged_into_id":41`)
+}
+
+func TestSupportRequestHandler_GetSupportRequest_OrganizationScope(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests/:id", func(c *gin.Context) {
+		c.Set("organization_id", uint(3))
+		handler.GetSupportRequest(c)
+	})
+
+	mockService.On("GetSupportRequest", uint(1), models.ScopeToOrganization(3)).Return(nil, services.ErrSupportRequestNotFound)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestSupportRequestHandler_GetSupportRequest_InvalidID(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.GET("/support-requests/:id", handler.GetSupportRequest)
 
@@ -174,11 +321,11 @@ func TestSupportRequestHandler_GetSupportRequest_InvalidID(t *testing.T) {
 func TestSupportRequestHandler_GetSupportRequest_NotFound(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.GET("/support-requests/:id", handler.GetSupportRequest)
 
-	mockService.On("GetSupportRequest", uint(999)).Return(nil, services.ErrSupportRequestNotFound)
+	mockService.On("GetSupportRequest", uint(999), models.OrganizationScope{}).Return(nil, services.ErrSupportRequestNotFound)
 
 	req, _ := http.NewRequest("GET", "/support-requests/999", nil)
 
@@ -194,7 +341,7 @@ func TestSupportRequestHandler_GetSupportRequest_NotFound(t *testing.T) {
 func TestSupportRequestHandler_GetAllSupportRequests(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.GET("/support-requests", handler.GetAllSupportRequests)
 
@@ -210,7 +357,7 @@ func TestSupportRequestHandler_GetAllSupportRequests(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetAllSupportRequests", 1, 20).Return(responses, int64(1), nil)
+	mockService.On("GetAllSupportRequests", repositories.SupportRequestFilter{}, 1, 20).Return(responses, int64(1), nil)
 
 	req, _ := http.NewRequest("GET", "/support-requests", nil)
 
@@ -223,10 +370,384 @@ func TestSupportRequestHandler_GetAllSupportRequests(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSupportRequestHandler_GetAllSupportRequests_WithFilters(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	createdAfter := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	expectedFilter := repositories.SupportRequestFilter{
+		Statuses:     []models.Status{models.StatusNew, models.StatusInProgress},
+		Types:        []models.SupportRequestType{models.SupportRequestTypeBugReport},
+		Platforms:    []models.Platform{models.PlatformIOS},
+		App:          "app-x",
+		AppVersion:   "1.4.0",
+		CreatedAfter: &createdAfter,
+		SortBy:       "updated_at",
+		SortOrder:    "asc",
+	}
+
+	mockService.On("GetAllSupportRequests", expectedFilter, 2, 10).Return([]*models.SupportRequestResponse{}, int64(15), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests?status=new,in_progress&type=bug_report&platform=iOS&app=app-x&app_version=1.4.0&created_after=2024-01-15&sort_by=updated_at&sort_order=ASC&page=2&page_size=10", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	pagination := body["pagination"].(map[string]interface{})
+	assert.Equal(t, float64(15), pagination["total"])
+	assert.Equal(t, float64(2), pagination["total_pages"])
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_InvalidDate(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	req, _ := http.NewRequest("GET", "/support-requests?created_after=last-monday", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetAllSupportRequests")
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_AssigneeMe(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", withUserID(7), handler.GetAllSupportRequests)
+
+	assigneeID := uint(7)
+	mockService.On("GetAllSupportRequests", repositories.SupportRequestFilter{AssigneeID: &assigneeID}, 1, 20).Return([]*models.SupportRequestResponse{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests?assignee=me", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_PriorityAndSLA(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	expectedFilter := repositories.SupportRequestFilter{
+		Priorities: []models.Priority{models.PriorityHigh, models.PriorityUrgent},
+		SLA:        repositories.SLAStateBreached,
+	}
+	mockService.On("GetAllSupportRequests", expectedFilter, 1, 20).Return([]*models.SupportRequestResponse{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests?priority=high,urgent&sla=breached", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_SuspectedDuplicates(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	expectedFilter := repositories.SupportRequestFilter{SuspectedDuplicates: true}
+	mockService.On("GetAllSupportRequests", expectedFilter, 1, 20).Return([]*models.SupportRequestResponse{}, int64(0), nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/support-requests?suspected_duplicate=true", nil)
+	router.ServeHTTP(w, req)
+	invalid := httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/support-requests?suspected_duplicate=maybe", nil)
+	router.ServeHTTP(invalid, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_Tags(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	expectedFilter := repositories.SupportRequestFilter{
+		Tags:     []string{"payments", "login", "ios"},
+		TagMatch: repositories.TagMatchAll,
+	}
+	mockService.On("GetAllSupportRequests", expectedFilter, 1, 20).Return([]*models.SupportRequestResponse{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests?tag=Payments,login&tag=ios&tag_match=ALL", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_AssigneeMeUnauthenticated(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	req, _ := http.NewRequest("GET", "/support-requests?assignee=me", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "GetAllSupportRequests")
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_AssigneeValues(t *testing.T) {
+	tests := []struct {
+		query          string
+		expectedFilter repositories.SupportRequestFilter
+		expectedStatus int
+	}{
+		{"assignee=none", repositories.SupportRequestFilter{Unassigned: true}, http.StatusOK},
+		{"assignee=12", repositories.SupportRequestFilter{AssigneeID: func() *uint { id := uint(12); return &id }()}, http.StatusOK},
+		{"assignee=bob", repositories.SupportRequestFilter{}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestService)
+			handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+			router := setupTestRouter()
+			router.GET("/support-requests", handler.GetAllSupportRequests)
+
+			mockService.On("GetAllSupportRequests", tt.expectedFilter, 1, 20).Return([]*models.SupportRequestResponse{}, int64(0), nil)
+
+			req, _ := http.NewRequest("GET", "/support-requests?"+tt.query, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_InvalidFilter(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	mockService.On("GetAllSupportRequests", mock.Anything, 1, 20).Return([]*models.SupportRequestResponse(nil), int64(0), fmt.Errorf("%w: unknown status", services.ErrInvalidFilter))
+
+	req, _ := http.NewRequest("GET", "/support-requests?status=open", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetAllSupportRequests_InvalidPageSize(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests", handler.GetAllSupportRequests)
+
+	mockService.On("GetAllSupportRequests", repositories.SupportRequestFilter{}, 1, 20).Return([]*models.SupportRequestResponse{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests?page_size=0", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_SearchSupportRequests(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests/search", handler.SearchSupportRequests)
+
+	results := []*models.SupportRequestSearchResult{
+		{
+			SupportRequestResponse: models.SupportRequestResponse{ID: 3, Message: "App crashes on launch"},
+			Rank:                   0.25,
+			Snippet:                "App <mark>crashes</mark> on <mark>launch</mark>",
+		},
+	}
+	filter := repositories.SupportRequestFilter{App: "app-x"}
+	mockService.On("SearchSupportRequests", "crash on launch", filter, 1, 20).Return(results, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests/search?q=crash+on+launch&app=app-x", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	data := body["data"].([]interface{})
+	assert.Len(t, data, 1)
+	hit := data[0].(map[string]interface{})
+	assert.Equal(t, float64(3), hit["id"])
+	assert.Equal(t, 0.25, hit["rank"])
+	assert.Equal(t, "App <mark>crashes</mark> on <mark>launch</mark>", hit["snippet"])
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_SearchSupportRequests_MissingQuery(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/support-requests/search", handler.SearchSupportRequests)
+
+	mockService.On("SearchSupportRequests", "", repositories.SupportRequestFilter{}, 1, 20).Return([]*models.SupportRequestSearchResult(nil), int64(0), fmt.Errorf("%w: search query is required", services.ErrInvalidFilter))
+
+	req, _ := http.NewRequest("GET", "/support-requests/search", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetSupportRequestStats(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/stats", handler.GetSupportRequestStats)
+
+	median := 3600.0
+	stats := &models.SupportRequestStatsResponse{
+		Interval:                   models.StatsIntervalWeek,
+		Total:                      2,
+		ByStatus:                   []models.SupportRequestCount{{Value: "new", Count: 2}},
+		Series:                     []models.SupportRequestBucket{{Start: "2025-06-09", Count: 2}},
+		MedianFirstResponseSeconds: &median,
+	}
+	filter := repositories.SupportRequestFilter{App: "app-x"}
+	mockService.On("GetSupportRequestStats", filter, models.StatsIntervalWeek).Return(stats, nil)
+
+	req, _ := http.NewRequest("GET", "/stats?app=app-x&interval=Week", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, float64(2), data["total"])
+	assert.Equal(t, "week", data["interval"])
+	assert.Equal(t, median, data["median_first_response_seconds"])
+	assert.Nil(t, data["median_resolution_seconds"])
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetSupportRequestStats_InvalidInterval(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/stats", handler.GetSupportRequestStats)
+
+	mockService.On("GetSupportRequestStats", repositories.SupportRequestFilter{}, "hour").Return(nil, fmt.Errorf("%w: invalid interval", services.ErrInvalidFilter))
+
+	req, _ := http.NewRequest("GET", "/stats?interval=hour", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_GetSupportRequestStats_Error(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.GET("/stats", handler.GetSupportRequestStats)
+
+	mockService.On("GetSupportRequestStats", repositories.SupportRequestFilter{}, "").Return(nil, errors.New("database error"))
+
+	req, _ := http.NewRequest("GET", "/stats", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestSupportRequestHandler_UpdateSupportRequest(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
 
@@ -240,7 +761,7 @@ func TestSupportRequestHandler_UpdateSupportRequest(t *testing.T) {
 		Status: models.StatusInProgress,
 	}
 
-	mockService.On("UpdateSupportRequest", uint(1), mock.AnythingOfType("*models.UpdateSupportRequestRequest")).Return(response, nil)
+	mockService.On("UpdateSupportRequest", uint(1), models.OrganizationScope{}, mock.AnythingOfType("*models.UpdateSupportRequestRequest"), mock.AnythingOfType("models.AuditActor")).Return(response, nil)
 
 	requestBody, _ := json.Marshal(updateRequest)
 	req, _ := http.NewRequest("PATCH", "/support-requests/1", bytes.NewBuffer(requestBody))
@@ -258,7 +779,7 @@ func TestSupportRequestHandler_UpdateSupportRequest(t *testing.T) {
 func TestSupportRequestHandler_UpdateSupportRequest_InvalidID(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
 
@@ -281,7 +802,7 @@ func TestSupportRequestHandler_UpdateSupportRequest_InvalidID(t *testing.T) {
 func TestSupportRequestHandler_UpdateSupportRequest_InvalidJSON(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
 
@@ -296,14 +817,55 @@ func TestSupportRequestHandler_UpdateSupportRequest_InvalidJSON(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestSupportRequestHandler_UpdateSupportRequest_InvalidPriority(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
+
+	req, _ := http.NewRequest("PATCH", "/support-requests/1", bytes.NewBufferString(`{"priority":"critical"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "UpdateSupportRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSupportRequestHandler_UpdateSupportRequest_IllegalTransition(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
+
+	mockService.On("UpdateSupportRequest", uint(1), models.OrganizationScope{}, mock.AnythingOfType("*models.UpdateSupportRequestRequest"), mock.AnythingOfType("models.AuditActor")).
+		Return(nil, &services.StatusTransitionError{From: models.StatusResolved, To: models.StatusNew})
+
+	req, _ := http.NewRequest("PATCH", "/support-requests/1", bytes.NewBufferString(`{"status":"new"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"error":"cannot change status from resolved to new","allowed_statuses":["reopened","closed"]}`, w.Body.String())
+}
+
 func TestSupportRequestHandler_UpdateSupportRequest_NotFound(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
 
-	mockService.On("UpdateSupportRequest", uint(999), mock.AnythingOfType("*models.UpdateSupportRequestRequest")).
+	mockService.On("UpdateSupportRequest", uint(999), models.OrganizationScope{}, mock.AnythingOfType("*models.UpdateSupportRequestRequest"), mock.AnythingOfType("models.AuditActor")).
 		Return(nil, services.ErrSupportRequestNotFound)
 
 	req, _ := http.NewRequest("PATCH", "/support-requests/999", bytes.NewBuffer([]byte("{}")))
@@ -322,14 +884,35 @@ func TestSupportRequestHandler_UpdateSupportRequest_NotFound(t *testing.T) {
 	assert.Equal(t, "Support request not found", response["error"])
 }
 
+func TestSupportRequestHandler_UpdateSupportRequest_Merged(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
+
+	mockService.On("UpdateSupportRequest", uint(9), models.OrganizationScope{}, mock.AnythingOfType("*models.UpdateSupportRequestRequest"), mock.AnythingOfType("models.AuditActor")).
+		Return(nil, services.ErrSupportRequestMerged)
+
+	req, _ := http.NewRequest("PATCH", "/support-requests/9", bytes.NewBuffer([]byte(`{"status":"reopened"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
 func TestSupportRequestHandler_UpdateSupportRequest_InvalidRequest(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
 
-	mockService.On("UpdateSupportRequest", uint(1), mock.AnythingOfType("*models.UpdateSupportRequestRequest")).
+	mockService.On("UpdateSupportRequest", uint(1), models.OrganizationScope{}, mock.AnythingOfType("*models.UpdateSupportRequestRequest"), mock.AnythingOfType("models.AuditActor")).
 		Return(nil, services.ErrInvalidRequest)
 
 	req, _ := http.NewRequest("PATCH", "/support-requests/1", bytes.NewBuffer([]byte("{}")))
@@ -346,11 +929,11 @@ func TestSupportRequestHandler_UpdateSupportRequest_InvalidRequest(t *testing.T)
 func TestSupportRequestHandler_UpdateSupportRequest_ServiceError(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.PATCH("/support-requests/:id", handler.UpdateSupportRequest)
 
-	mockService.On("UpdateSupportRequest", uint(1), mock.AnythingOfType("*models.UpdateSupportRequestRequest")).
+	mockService.On("UpdateSupportRequest", uint(1), models.OrganizationScope{}, mock.AnythingOfType("*models.UpdateSupportRequestRequest"), mock.AnythingOfType("models.AuditActor")).
 		Return(nil, errors.New("service error"))
 
 	req, _ := http.NewRequest("PATCH", "/support-requests/1", bytes.NewBuffer([]byte("{}")))
@@ -372,11 +955,11 @@ func TestSupportRequestHandler_UpdateSupportRequest_ServiceError(t *testing.T) {
 func TestSupportRequestHandler_DeleteSupportRequest(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.DELETE("/support-requests/:id", handler.DeleteSupportRequest)
 
-	mockService.On("DeleteSupportRequest", uint(1)).Return(nil)
+	mockService.On("DeleteSupportRequest", uint(1), models.OrganizationScope{}, mock.AnythingOfType("models.AuditActor")).Return(nil)
 
 	req, _ := http.NewRequest("DELETE", "/support-requests/1", nil)
 
@@ -392,7 +975,7 @@ func TestSupportRequestHandler_DeleteSupportRequest(t *testing.T) {
 func TestSupportRequestHandler_DeleteSupportRequest_InvalidID(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.DELETE("/support-requests/:id", handler.DeleteSupportRequest)
 
@@ -414,11 +997,11 @@ func TestSupportRequestHandler_DeleteSupportRequest_InvalidID(t *testing.T) {
 func TestSupportRequestHandler_DeleteSupportRequest_NotFound(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.DELETE("/support-requests/:id", handler.DeleteSupportRequest)
 
-	mockService.On("DeleteSupportRequest", uint(999)).Return(services.ErrSupportRequestNotFound)
+	mockService.On("DeleteSupportRequest", uint(999), models.OrganizationScope{}, mock.AnythingOfType("models.AuditActor")).Return(services.ErrSupportRequestNotFound)
 
 	req, _ := http.NewRequest("DELETE", "/support-requests/999", nil)
 
@@ -438,11 +1021,11 @@ func TestSupportRequestHandler_DeleteSupportRequest_NotFound(t *testing.T) {
 func TestSupportRequestHandler_DeleteSupportRequest_ServiceError(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.DELETE("/support-requests/:id", handler.DeleteSupportRequest)
 
-	mockService.On("DeleteSupportRequest", uint(1)).Return(errors.New("service error"))
+	mockService.On("DeleteSupportRequest", uint(1), models.OrganizationScope{}, mock.AnythingOfType("models.AuditActor")).Return(errors.New("service error"))
 
 	req, _ := http.NewRequest("DELETE", "/support-requests/1", nil)
 
@@ -459,10 +1042,71 @@ func TestSupportRequestHandler_DeleteSupportRequest_ServiceError(t *testing.T) {
 	assert.Equal(t, "Failed to delete support request", response["error"])
 }
 
+func TestSupportRequestHandler_MergeSupportRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/merge", handler.MergeSupportRequest)
+
+	mockService.On("MergeSupportRequest", uint(9), uint(41), models.OrganizationScope{}, mock.AnythingOfType("models.AuditActor")).Return(&models.SupportRequestResponse{ID: 41, Status: models.StatusInProgress}, nil)
+
+	req, _ := http.NewRequest("POST", "/support-requests/9/merge", bytes.NewBufferString(`{"target_id":41}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":41`)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestHandler_MergeSupportRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"invalid ID", "/support-requests/abc/merge", `{"target_id":41}`, nil, http.StatusBadRequest},
+		{"missing target", "/support-requests/9/merge", `{}`, nil, http.StatusBadRequest},
+		{"not found", "/support-requests/9/merge", `{"target_id":41}`, services.ErrSupportRequestNotFound, http.StatusNotFound},
+		{"invalid", "/support-requests/9/merge", `{"target_id":41}`, fmt.Errorf("%w: the support requests belong to different organizations", services.ErrInvalidRequest), http.StatusBadRequest},
+		{"already merged", "/support-requests/9/merge", `{"target_id":41}`, services.ErrSupportRequestMerged, http.StatusConflict},
+		{"spam", "/support-requests/9/merge", `{"target_id":41}`, &services.StatusTransitionError{From: models.StatusSpam, To: models.StatusClosed}, http.StatusConflict},
+		{"service error", "/support-requests/9/merge", `{"target_id":41}`, errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestService)
+			handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
+			router := setupTestRouter()
+			router.POST("/support-requests/:id/merge", handler.MergeSupportRequest)
+			mockService.On("MergeSupportRequest", uint(9), uint(41), models.OrganizationScope{}, mock.AnythingOfType("models.AuditActor")).Return(nil, tt.err).Maybe()
+
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestSupportRequestHandler_HealthCheck(t *testing.T) {
 	// Arrange
 	mockService := new(MockSupportRequestService)
-	handler := NewSupportRequestHandler(mockService)
+	handler := NewSupportRequestHandler(mockService, new(MockAttachmentService))
 	router := setupTestRouter()
 	router.GET("/health", handler.HealthCheck)
 
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupportRequestMessageHandler handles HTTP requests for support request conversations
+type SupportRequestMessageHandler struct {
+	service services.SupportRequestMessageService
+}
+
+// NewSupportRequestMessageHandler creates a new support request message handler
+func NewSupportRequestMessageHandler(service services.SupportRequestMessageService) *SupportRequestMessageHandler {
+	return &SupportRequestMessageHandler{
+		service: service,
+	}
+}
+
+// ListMessages handles GET /api/v1/support-requests/:id/messages
+// @Summary List support request messages
+// @Description Get the conversation of a support request, oldest first, including internal notes (requires the tickets:read permission)
+// @Tags Support Request Messages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Success 200 {object} map[string]interface{} "Conversation messages"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Router /support-requests/{id}/messages [get]
+func (h *SupportRequestMessageHandler) ListMessages(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	responses, err := h.service.ListMessages(uint(id), organizationScope(c), true)
+	if err != nil {
+		if err == services.ErrSupportRequestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get messages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// CreateMessage handles POST /api/v1/support-requests/:id/messages
+// @Summary Reply to support request
+// @Description Post a public reply or an internal note on a support request as the authenticated agent (requires the tickets:update permission)
+// @Tags Support Request Messages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Param request body models.CreateSupportRequestMessageRequest true "Message data"
+// @Success 201 {object} map[string]interface{} "Message created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Permission denied"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Failure 409 {object} map[string]interface{} "Support request was merged into another"
+// @Router /support-requests/{id}/messages [post]
+func (h *SupportRequestMessageHandler) CreateMessage(c *gin.Context) {
+	// Replies are attributed to the user in the JWT claims
+	if _, exists := c.Get("user_id"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req models.CreateSupportRequestMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.AddAgentReply(uint(id), organizationScope(c), auditActor(c), &req)
+	if err != nil {
+		switch err {
+		case services.ErrSupportRequestNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+		case services.ErrInvalidRequest:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case services.ErrSupportRequestMerged:
+			c.JSON(http.StatusConflict, gin.H{"error": "Support request was merged into another, reply there"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create message"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
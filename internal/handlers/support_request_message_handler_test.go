@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSupportRequestMessageService is a mock implementation of SupportRequestMessageService
+type MockSupportRequestMessageService struct {
+	mock.Mock
+}
+
+func (m *MockSupportRequestMessageService) ListMessages(supportRequestID uint, scope models.OrganizationScope, includeInternal bool) ([]*models.SupportRequestMessageResponse, error) {
+	args := m.Called(supportRequestID, scope, includeInternal)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SupportRequestMessageResponse), args.Error(1)
+}
+
+func (m *MockSupportRequestMessageService) AddAgentReply(supportRequestID uint, scope models.OrganizationScope, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error) {
+	args := m.Called(supportRequestID, scope, actor, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestMessageResponse), args.Error(1)
+}
+
+func (m *MockSupportRequestMessageService) AddSubmitterReply(supportRequestID uint, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error) {
+	args := m.Called(supportRequestID, actor, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestMessageResponse), args.Error(1)
+}
+
+// withUserID simulates AuthMiddleware by setting the authenticated user ID
+func withUserID(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	}
+}
+
+// actorWithUserID matches the audit actor of the given authenticated user
+func actorWithUserID(userID uint) interface{} {
+	return mock.MatchedBy(func(actor models.AuditActor) bool { return actor.UserID == userID })
+}
+
+func TestSupportRequestMessageHandler_ListMessages(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/messages", handler.ListMessages)
+
+	mockService.On("ListMessages", uint(1), models.OrganizationScope{}, true).Return([]*models.SupportRequestMessageResponse{{ID: 1, Body: "Hello"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/messages", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestMessageHandler_ListMessages_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/messages", handler.ListMessages)
+
+	mockService.On("ListMessages", uint(1), models.OrganizationScope{}, true).Return(nil, services.ErrSupportRequestNotFound)
+
+	req, _ := http.NewRequest("GET", "/support-requests/1/messages", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSupportRequestMessageHandler_ListMessages_InvalidID(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-requests/:id/messages", handler.ListMessages)
+
+	req, _ := http.NewRequest("GET", "/support-requests/abc/messages", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSupportRequestMessageHandler_CreateMessage(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/messages", withUserID(7), handler.CreateMessage)
+
+	authorID := uint(7)
+	mockService.On("AddAgentReply", uint(1), models.OrganizationScope{}, actorWithUserID(7), mock.AnythingOfType("*models.CreateSupportRequestMessageRequest")).
+		Return(&models.SupportRequestMessageResponse{ID: 1, AuthorType: models.MessageAuthorAgent, AuthorUserID: &authorID}, nil)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/messages", bytes.NewBufferString(`{"body":"Looking into it","visibility":"internal"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestMessageHandler_CreateMessage_InvalidVisibility(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/messages", withUserID(7), handler.CreateMessage)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/messages", bytes.NewBufferString(`{"body":"Hi","visibility":"secret"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "AddAgentReply")
+}
+
+func TestSupportRequestMessageHandler_CreateMessage_Unauthenticated(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/messages", handler.CreateMessage)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/messages", bytes.NewBufferString(`{"body":"Hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSupportRequestMessageHandler_CreateMessage_ServiceError(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/messages", withUserID(7), handler.CreateMessage)
+
+	mockService.On("AddAgentReply", uint(1), models.OrganizationScope{}, actorWithUserID(7), mock.Anything).Return(nil, errors.New("database error"))
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/messages", bytes.NewBufferString(`{"body":"Hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestSupportRequestMessageHandler_CreateMessage_MergedRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestMessageService)
+	handler := NewSupportRequestMessageHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/messages", withUserID(7), handler.CreateMessage)
+
+	mockService.On("AddAgentReply", uint(1), models.OrganizationScope{}, actorWithUserID(7), mock.Anything).Return(nil, services.ErrSupportRequestMerged)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/messages", bytes.NewBufferString(`{"body":"Hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupportRequestTrackingHandler handles HTTP requests of submitters following their support requests
+type SupportRequestTrackingHandler struct {
+	service services.SupportRequestTrackingService
+}
+
+// NewSupportRequestTrackingHandler creates a new tracking handler
+func NewSupportRequestTrackingHandler(service services.SupportRequestTrackingService) *SupportRequestTrackingHandler {
+	return &SupportRequestTrackingHandler{
+		service: service,
+	}
+}
+
+// TrackSupportRequest handles GET /api/v1/support-request/track/:token
+// @Summary Track support request
+// @Description Get the status and public replies of the support request a tracking token was issued for. The token is returned when the request is created; the submitter email is redacted and internal notes are never shown (public endpoint with rate limiting)
+// @Tags Support Requests
+// @Produce json
+// @Param token path string true "Tracking token"
+// @Success 200 {object} map[string]interface{} "Support request status and public replies"
+// @Failure 404 {object} map[string]interface{} "Unknown tracking token"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /support-request/track/{token} [get]
+func (h *SupportRequestTrackingHandler) TrackSupportRequest(c *gin.Context) {
+	// The token is a credential, so the page must not end up in shared caches
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+
+	response, err := h.service.TrackSupportRequest(c.Param("token"))
+	if err != nil {
+		if errors.Is(err, services.ErrSupportRequestNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get support request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// ReplyToSupportRequest handles POST /api/v1/support-request/track/:token/messages
+// @Summary Reply to tracked support request
+// @Description Post a public reply on the support request a tracking token was issued for. A reply reopens a resolved request and resumes work on one waiting on the submitter (public endpoint with rate limiting)
+// @Tags Support Requests
+// @Accept json
+// @Produce json
+// @Param token path string true "Tracking token"
+// @Param request body models.CreateSupportRequestMessageRequest true "Message data"
+// @Success 201 {object} map[string]interface{} "Message created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Unknown tracking token"
+// @Failure 409 {object} map[string]interface{} "Support request was merged into another"
+// @Failure 429 {object} map[string]interface{} "Rate limit exceeded"
+// @Router /support-request/track/{token}/messages [post]
+func (h *SupportRequestTrackingHandler) ReplyToSupportRequest(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+
+	var req models.CreateSupportRequestMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.ReplyToSupportRequest(c.Param("token"), auditActor(c), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSupportRequestNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+		case errors.Is(err, services.ErrInvalidRequest):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrSupportRequestMerged):
+			c.JSON(http.StatusConflict, gin.H{"error": "Support request was merged into another"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create message"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSupportRequestTrackingService is a mock implementation of SupportRequestTrackingService
+type MockSupportRequestTrackingService struct {
+	mock.Mock
+}
+
+func (m *MockSupportRequestTrackingService) TrackSupportRequest(token string) (*models.SupportRequestTrackingResponse, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestTrackingResponse), args.Error(1)
+}
+
+func (m *MockSupportRequestTrackingService) ReplyToSupportRequest(token string, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error) {
+	args := m.Called(token, actor, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestMessageResponse), args.Error(1)
+}
+
+// performTrackRequest gets the tracking page of token from a route backed by mockService
+func performTrackRequest(mockService *MockSupportRequestTrackingService, token string) *httptest.ResponseRecorder {
+	handler := NewSupportRequestTrackingHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/support-request/track/:token", handler.TrackSupportRequest)
+
+	req, _ := http.NewRequest("GET", "/support-request/track/"+token, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSupportRequestTrackingHandler_TrackSupportRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestTrackingService)
+	email := "j***@example.com"
+	mockService.On("TrackSupportRequest", "trk_abc").Return(&models.SupportRequestTrackingResponse{
+		ID:        7,
+		UserEmail: &email,
+		Status:    models.StatusWaitingOnCustomer,
+		Replies:   []*models.SupportRequestTrackingReply{{AuthorType: models.MessageAuthorAgent, Body: "Could you send a screenshot?"}},
+	}, nil)
+
+	// Act
+	w := performTrackRequest(mockService, "trk_abc")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "waiting_on_customer", body.Data["status"])
+	assert.Equal(t, "j***@example.com", body.Data["user_email"])
+	assert.NotContains(t, body.Data, "admin_notes")
+	assert.Len(t, body.Data["replies"], 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestTrackingHandler_TrackSupportRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"unknown token", services.ErrSupportRequestNotFound, http.StatusNotFound},
+		{"repository error", errors.New("database down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestTrackingService)
+			mockService.On("TrackSupportRequest", "trk_unknown").Return(nil, tt.err)
+
+			// Act
+			w := performTrackRequest(mockService, "trk_unknown")
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.NotContains(t, w.Body.String(), "database down")
+		})
+	}
+}
+
+// performReplyRequest posts a reply with the tracking token to a route backed by mockService
+func performReplyRequest(mockService *MockSupportRequestTrackingService, token, body string) *httptest.ResponseRecorder {
+	handler := NewSupportRequestTrackingHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-request/track/:token/messages", handler.ReplyToSupportRequest)
+
+	req, _ := http.NewRequest("POST", "/support-request/track/"+token+"/messages", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSupportRequestTrackingHandler_ReplyToSupportRequest(t *testing.T) {
+	// Arrange
+	mockService := new(MockSupportRequestTrackingService)
+	mockService.On("ReplyToSupportRequest", "trk_abc", mock.AnythingOfType("models.AuditActor"), mock.MatchedBy(func(req *models.CreateSupportRequestMessageRequest) bool {
+		return req.Body == "Here is the screenshot"
+	})).Return(&models.SupportRequestMessageResponse{ID: 3, AuthorType: models.MessageAuthorSubmitter, Body: "Here is the screenshot"}, nil)
+
+	// Act
+	w := performReplyRequest(mockService, "trk_abc", `{"body":"Here is the screenshot"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	mockService.AssertExpectations(t)
+}
+
+func TestSupportRequestTrackingHandler_ReplyToSupportRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"missing body", `{}`, nil, http.StatusBadRequest},
+		{"unknown token", `{"body":"Hello"}`, services.ErrSupportRequestNotFound, http.StatusNotFound},
+		{"internal visibility", `{"body":"Hello","visibility":"internal"}`, services.ErrInvalidRequest, http.StatusBadRequest},
+		{"merged request", `{"body":"Hello"}`, services.ErrSupportRequestMerged, http.StatusConflict},
+		{"repository error", `{"body":"Hello"}`, errors.New("database down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSupportRequestTrackingService)
+			mockService.On("ReplyToSupportRequest", "trk_abc", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			// Act
+			w := performReplyRequest(mockService, "trk_abc", tt.body)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.NotContains(t, w.Body.String(), "database down")
+		})
+	}
+}
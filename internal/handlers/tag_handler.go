@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles HTTP requests for tags and tagging support requests
+type TagHandler struct {
+	service services.TagService
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(service services.TagService) *TagHandler {
+	return &TagHandler{
+		service: service,
+	}
+}
+
+// ListTags handles GET /api/v1/tags
+// @Summary List tags (Admin only)
+// @Description Retrieve every tag ordered by name (requires admin authentication)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Tags retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Router /tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.service.ListTags()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tags})
+}
+
+// CreateTag handles POST /api/v1/tags
+// @Summary Create tag (Admin only)
+// @Description Create a new tag. Names are stored lowercase and must be unique (requires admin authentication)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateTagRequest true "Tag data"
+// @Success 201 {object} map[string]interface{} "Tag created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or tag name"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 409 {object} map[string]interface{} "Tag already exists"
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req models.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.CreateTag(&req)
+	if err != nil {
+		respondTagError(c, err, "Failed to create tag")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": response})
+}
+
+// UpdateTag handles PATCH /api/v1/tags/:id
+// @Summary Update tag (Admin only)
+// @Description Rename or recolor a tag. Tagged support requests keep the tag (requires admin authentication)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Param request body models.UpdateTagRequest true "Tag update data"
+// @Success 200 {object} map[string]interface{} "Tag updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or tag name"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Tag not found"
+// @Failure 409 {object} map[string]interface{} "Tag already exists"
+// @Router /tags/{id} [patch]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req models.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.UpdateTag(uint(id), &req)
+	if err != nil {
+		respondTagError(c, err, "Failed to update tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// DeleteTag handles DELETE /api/v1/tags/:id
+// @Summary Delete tag (Admin only)
+// @Description Delete a tag and remove it from every support request (requires admin authentication)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 200 {object} map[string]interface{} "Tag deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Tag not found"
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.service.DeleteTag(uint(id)); err != nil {
+		respondTagError(c, err, "Failed to delete tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
+
+// AddSupportRequestTags handles POST /api/v1/support-requests/:id/tags
+// @Summary Tag support request (Admin only)
+// @Description Add existing tags to a support request. Tags it already carries are ignored (requires admin authentication)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Param request body models.SupportRequestTagsRequest true "Tag names"
+// @Success 200 {object} map[string]interface{} "Support request tagged successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request not found"
+// @Failure 422 {object} map[string]interface{} "Unknown tag"
+// @Router /support-requests/{id}/tags [post]
+func (h *TagHandler) AddSupportRequestTags(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req models.SupportRequestTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.AddTagsToSupportRequest(uint(id), organizationScope(c), req.Tags)
+	if err != nil {
+		if errors.Is(err, services.ErrTagNotFound) {
+			// The support request exists, the tag names in the body don't
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		respondTagError(c, err, "Failed to tag support request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// RemoveSupportRequestTag handles DELETE /api/v1/support-requests/:id/tags/:name
+// @Summary Untag support request (Admin only)
+// @Description Remove a tag from a support request (requires admin authentication)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Support Request ID"
+// @Param name path string true "Tag name"
+// @Success 200 {object} map[string]interface{} "Tag removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]interface{} "Support request or tag not found"
+// @Router /support-requests/{id}/tags/{name} [delete]
+func (h *TagHandler) RemoveSupportRequestTag(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	response, err := h.service.RemoveTagFromSupportRequest(uint(id), organizationScope(c), c.Param("name"))
+	if err != nil {
+		respondTagError(c, err, "Failed to untag support request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// respondTagError maps tag service errors to HTTP responses
+func respondTagError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrSupportRequestNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Support request not found"})
+	case errors.Is(err, services.ErrTagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+	case errors.Is(err, services.ErrTagAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidTagName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
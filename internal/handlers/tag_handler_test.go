@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagService is a mock implementation of TagService
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) ListTags() ([]*models.TagResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.TagResponse), args.Error(1)
+}
+
+func (m *MockTagService) CreateTag(req *models.CreateTagRequest) (*models.TagResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TagResponse), args.Error(1)
+}
+
+func (m *MockTagService) UpdateTag(id uint, req *models.UpdateTagRequest) (*models.TagResponse, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TagResponse), args.Error(1)
+}
+
+func (m *MockTagService) DeleteTag(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTagService) AddTagsToSupportRequest(supportRequestID uint, scope models.OrganizationScope, names []string) (*models.SupportRequestResponse, error) {
+	args := m.Called(supportRequestID, scope, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
+}
+
+func (m *MockTagService) RemoveTagFromSupportRequest(supportRequestID uint, scope models.OrganizationScope, name string) (*models.SupportRequestResponse, error) {
+	args := m.Called(supportRequestID, scope, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequestResponse), args.Error(1)
+}
+
+func TestTagHandler_ListTags(t *testing.T) {
+	// Arrange
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/tags", handler.ListTags)
+
+	mockService.On("ListTags").Return([]*models.TagResponse{{ID: 1, Name: "payments"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/tags", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"payments"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestTagHandler_CreateTag(t *testing.T) {
+	// Arrange
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/tags", handler.CreateTag)
+
+	mockService.On("CreateTag", mock.MatchedBy(func(req *models.CreateTagRequest) bool {
+		return req.Name == "payments" && *req.Color == "#ff8800"
+	})).Return(&models.TagResponse{ID: 1, Name: "payments"}, nil)
+
+	req, _ := http.NewRequest("POST", "/tags", bytes.NewBufferString(`{"name":"payments","color":"#ff8800"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTagHandler_CreateTag_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"missing name", `{}`, nil, http.StatusBadRequest},
+		{"invalid color", `{"name":"payments","color":"orange"}`, nil, http.StatusBadRequest},
+		{"invalid name", `{"name":"has space"}`, services.ErrInvalidTagName, http.StatusBadRequest},
+		{"duplicate name", `{"name":"payments"}`, services.ErrTagAlreadyExists, http.StatusConflict},
+		{"internal error", `{"name":"payments"}`, assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockTagService)
+			handler := NewTagHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/tags", handler.CreateTag)
+
+			if tt.err != nil {
+				mockService.On("CreateTag", mock.Anything).Return(nil, tt.err)
+			}
+
+			req, _ := http.NewRequest("POST", "/tags", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTagHandler_UpdateTag(t *testing.T) {
+	// Arrange
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+	router := setupTestRouter()
+	router.PATCH("/tags/:id", handler.UpdateTag)
+
+	mockService.On("UpdateTag", uint(1), mock.MatchedBy(func(req *models.UpdateTagRequest) bool {
+		return req.Name != nil && *req.Name == "billing"
+	})).Return(&models.TagResponse{ID: 1, Name: "billing"}, nil)
+
+	req, _ := http.NewRequest("PATCH", "/tags/1", bytes.NewBufferString(`{"name":"billing"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTagHandler_DeleteTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{"deleted", "/tags/1", nil, http.StatusOK},
+		{"not found", "/tags/1", services.ErrTagNotFound, http.StatusNotFound},
+		{"invalid id", "/tags/abc", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockTagService)
+			handler := NewTagHandler(mockService)
+			router := setupTestRouter()
+			router.DELETE("/tags/:id", handler.DeleteTag)
+
+			mockService.On("DeleteTag", uint(1)).Return(tt.err)
+
+			req, _ := http.NewRequest("DELETE", tt.path, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTagHandler_AddSupportRequestTags(t *testing.T) {
+	// Arrange
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/support-requests/:id/tags", handler.AddSupportRequestTags)
+
+	mockService.On("AddTagsToSupportRequest", uint(1), models.OrganizationScope{}, []string{"payments", "login"}).
+		Return(&models.SupportRequestResponse{ID: 1, Tags: []string{"login", "payments"}}, nil)
+
+	req, _ := http.NewRequest("POST", "/support-requests/1/tags", bytes.NewBufferString(`{"tags":["payments","login"]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tags":["login","payments"]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestTagHandler_AddSupportRequestTags_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"empty tag list", `{"tags":[]}`, nil, http.StatusBadRequest},
+		{"unknown tag", `{"tags":["nope"]}`, fmt.Errorf("%w: nope", services.ErrTagNotFound), http.StatusUnprocessableEntity},
+		{"support request not found", `{"tags":["payments"]}`, services.ErrSupportRequestNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockTagService)
+			handler := NewTagHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/support-requests/:id/tags", handler.AddSupportRequestTags)
+
+			if tt.err != nil {
+				mockService.On("AddTagsToSupportRequest", uint(1), models.OrganizationScope{}, mock.Anything).Return(nil, tt.err)
+			}
+
+			req, _ := http.NewRequest("POST", "/support-requests/1/tags", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTagHandler_RemoveSupportRequestTag(t *testing.T) {
+	// Arrange
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+	router := setupTestRouter()
+	router.DELETE("/support-requests/:id/tags/:name", handler.RemoveSupportRequestTag)
+
+	mockService.On("RemoveTagFromSupportRequest", uint(1), models.OrganizationScope{}, "payments").Return(&models.SupportRequestResponse{ID: 1}, nil)
+
+	req, _ := http.NewRequest("DELETE", "/support-requests/1/tags/payments", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTagHandler_RemoveSupportRequestTag_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+	router := setupTestRouter()
+	router.DELETE("/support-requests/:id/tags/:name", handler.RemoveSupportRequestTag)
+
+	mockService.On("RemoveTagFromSupportRequest", uint(1), models.OrganizationScope{}, "nope").Return(nil, services.ErrTagNotFound)
+
+	req, _ := http.NewRequest("DELETE", "/support-requests/1/tags/nope", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "Tag not found")
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService is a mock implementation of WebhookService
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) ListWebhooks() ([]*models.WebhookResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhook(id uint) (*models.WebhookResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) CreateWebhook(req *models.CreateWebhookRequest) (*models.WebhookResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.WebhookResponse, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhook(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(webhookID uint, page, pageSize int) ([]*models.WebhookDeliveryResponse, int64, error) {
+	args := m.Called(webhookID, page, pageSize)
+	return args.Get(0).([]*models.WebhookDeliveryResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookService) Redeliver(webhookID, deliveryID uint) (*models.WebhookDeliveryResponse, error) {
+	args := m.Called(webhookID, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDeliveryResponse), args.Error(1)
+}
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/webhooks", handler.CreateWebhook)
+
+	mockService.On("CreateWebhook", mock.AnythingOfType("*models.CreateWebhookRequest")).
+		Return(&models.WebhookResponse{ID: 1, URL: "https://hooks.example.com/support", Secret: "generated-secret"}, nil)
+
+	body := `{"url":"https://hooks.example.com/support","events":["support_request.created"]}`
+	req, _ := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"secret":"generated-secret"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestWebhookHandler_CreateWebhook_ValidationError(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/webhooks", handler.CreateWebhook)
+
+	req, _ := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(`{"url":"not a url","events":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+}
+
+func TestWebhookHandler_CreateWebhook_InvalidEvent(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/webhooks", handler.CreateWebhook)
+
+	mockService.On("CreateWebhook", mock.AnythingOfType("*models.CreateWebhookRequest")).
+		Return(nil, services.ErrInvalidWebhook)
+
+	body := `{"url":"https://hooks.example.com/support","events":["support_request.viewed"]}`
+	req, _ := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhookHandler_UpdateWebhook_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.PATCH("/webhooks/:id", handler.UpdateWebhook)
+
+	mockService.On("UpdateWebhook", uint(9), mock.AnythingOfType("*models.UpdateWebhookRequest")).
+		Return(nil, services.ErrWebhookNotFound)
+
+	req, _ := http.NewRequest("PATCH", "/webhooks/9", bytes.NewBufferString(`{"is_active":false}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.DELETE("/webhooks/:id", handler.DeleteWebhook)
+
+	mockService.On("DeleteWebhook", uint(1)).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/webhooks/1", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestWebhookHandler_ListWebhookDeliveries(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/webhooks/:id/deliveries", handler.ListWebhookDeliveries)
+
+	mockService.On("ListDeliveries", uint(1), 1, 20).
+		Return([]*models.WebhookDeliveryResponse{{ID: 5, Status: models.WebhookDeliveryFailed}}, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/webhooks/1/deliveries", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"failed"`)
+	assert.Contains(t, w.Body.String(), `"total_pages":1`)
+}
+
+func TestWebhookHandler_RedeliverWebhookDelivery(t *testing.T) {
+	// Arrange
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+	router := setupTestRouter()
+	router.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", handler.RedeliverWebhookDelivery)
+
+	mockService.On("Redeliver", uint(1), uint(5)).
+		Return(&models.WebhookDeliveryResponse{ID: 6, Status: models.WebhookDeliveryPending}, nil)
+
+	req, _ := http.NewRequest("POST", "/webhooks/1/deliveries/5/redeliver", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":6`)
+}
+
+func TestWebhookHandler_RedeliverWebhookDelivery_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid delivery ID", "/webhooks/1/deliveries/abc/redeliver", nil, http.StatusBadRequest},
+		{"delivery not found", "/webhooks/1/deliveries/5/redeliver", services.ErrWebhookDeliveryNotFound, http.StatusNotFound},
+		{"inactive webhook", "/webhooks/1/deliveries/5/redeliver", services.ErrInvalidWebhook, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockWebhookService)
+			handler := NewWebhookHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", handler.RedeliverWebhookDelivery)
+			mockService.On("Redeliver", uint(1), uint(5)).Return(nil, tt.err)
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileMailer implements Mailer by writing every message to a .eml file instead of sending it.
+// It is meant for local development and tests.
+type fileMailer struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+// NewFileMailer creates a mailer that writes messages from the given address into dir.
+// The directory is created when the first message is written.
+func NewFileMailer(dir, from string) Mailer {
+	return &fileMailer{
+		dir:  dir,
+		from: from,
+		now:  time.Now,
+	}
+}
+
+// Send writes msg to a new file in the mail directory and logs where it went
+func (m *fileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return err
+	}
+
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	now := m.now()
+	path := filepath.Join(m.dir, fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), hex.EncodeToString(b)))
+
+	if err := os.WriteFile(path, []byte(formatMessage(m.from, msg, now)), 0o640); err != nil {
+		return err
+	}
+
+	log.Printf("📧 Mail %q to %s written to %s", msg.Subject, msg.To, path)
+	return nil
+}
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer := NewFileMailer(dir, "no-reply@supportapp.local")
+
+	// Act
+	err := mailer.Send(Message{To: "user@example.com", Subject: "Hello", Body: "Line one\nLine two"})
+
+	// Assert
+	require.NoError(t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	message := string(content)
+	assert.Contains(t, message, "From: no-reply@supportapp.local\r\n")
+	assert.Contains(t, message, "To: user@example.com\r\n")
+	assert.Contains(t, message, "Subject: Hello\r\n")
+	assert.True(t, strings.HasSuffix(message, "\r\n\r\nLine one\r\nLine two"))
+}
+
+func TestFileMailer_SendWritesOneFilePerMessage(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(dir, "no-reply@supportapp.local")
+
+	require.NoError(t, mailer.Send(Message{To: "a@example.com", Subject: "One", Body: "1"}))
+	require.NoError(t, mailer.Send(Message{To: "b@example.com", Subject: "Two", Body: "2"}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestFormatMessage_Headers(t *testing.T) {
+	message := formatMessage("no-reply@supportapp.local", Message{
+		To:      "user@example.com",
+		Subject: "Réponse\r\nBcc: victim@example.com",
+		Body:    "Hello",
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/unsubscribe>", "Message-ID": "<1@supportapp.local>"},
+	}, time.Date(2025, 6, 12, 10, 30, 0, 0, time.UTC))
+
+	assert.Contains(t, message, "Subject: =?utf-8?q?R=C3=A9ponse_Bcc:_victim@example.com?=\r\n")
+	assert.NotContains(t, message, "\r\nBcc:")
+	assert.Contains(t, message, "List-Unsubscribe: <https://example.com/unsubscribe>\r\nMessage-ID: <1@supportapp.local>\r\n")
+}
@@ -0,0 +1,326 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMIMEDepth bounds how deeply multipart bodies are searched for text
+const maxMIMEDepth = 10
+
+// InboundMessage is the part of a received email that support requests are made of
+type InboundMessage struct {
+	From          string   // Sender address
+	Subject       string   // Decoded subject
+	MessageID     string   // Message-ID without angle brackets, empty when missing
+	InReplyTo     []string // Message IDs of the In-Reply-To header without angle brackets
+	References    []string // Message IDs of the References header without angle brackets, oldest first
+	Recipients    []string // Addresses of the To, Cc, Delivered-To and X-Original-To headers
+	Body          string   // Plain text body, converted from HTML when the email has no plain text part
+	AutoGenerated bool     // Whether the email was sent automatically, such as an out of office reply or a bounce
+}
+
+// ParseInbound parses a raw RFC 5322 email. Attachments are ignored.
+func ParseInbound(raw []byte) (*InboundMessage, error) {
+	msg, err := netmail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("malformed message: %w", err)
+	}
+
+	addressParser := &netmail.AddressParser{WordDecoder: wordDecoder}
+	from, err := addressParser.Parse(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("malformed From header: %w", err)
+	}
+
+	inbound := &InboundMessage{
+		From:          from.Address,
+		Subject:       strings.TrimSpace(decodeHeader(msg.Header.Get("Subject"))),
+		MessageID:     firstMessageID(msg.Header.Get("Message-ID")),
+		InReplyTo:     messageIDs(msg.Header.Get("In-Reply-To")),
+		References:    messageIDs(msg.Header.Get("References")),
+		AutoGenerated: isAutoGenerated(msg.Header),
+	}
+	for _, name := range []string{"To", "Cc", "Delivered-To", "X-Original-To"} {
+		for _, value := range msg.Header[name] {
+			addresses, err := addressParser.ParseList(value)
+			if err != nil {
+				continue // A malformed recipient doesn't make the message unreadable
+			}
+			for _, address := range addresses {
+				inbound.Recipients = append(inbound.Recipients, address.Address)
+			}
+		}
+	}
+
+	plain, htmlBody, err := textParts(messagePartHeader(msg.Header), msg.Body, 0)
+	if err != nil {
+		return nil, fmt.Errorf("malformed body: %w", err)
+	}
+	if strings.TrimSpace(plain) == "" && htmlBody != "" {
+		plain = htmlToText(htmlBody)
+	}
+	inbound.Body = strings.TrimSpace(normalizeNewlines(plain))
+
+	return inbound, nil
+}
+
+// partHeader is the subset of MIME part headers textParts needs
+type partHeader struct {
+	contentType        string
+	transferEncoding   string
+	contentDisposition string
+}
+
+// messagePartHeader returns the MIME headers of the top level message
+func messagePartHeader(header netmail.Header) partHeader {
+	return partHeader{
+		contentType:        header.Get("Content-Type"),
+		transferEncoding:   header.Get("Content-Transfer-Encoding"),
+		contentDisposition: header.Get("Content-Disposition"),
+	}
+}
+
+// textParts returns the first plain text and the first HTML part of a body, searching multipart
+// bodies depth first and skipping attachments
+func textParts(header partHeader, body io.Reader, depth int) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(header.contentType)
+	if err != nil {
+		// RFC 2045 defaults to US-ASCII plain text
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if disposition, _, err := mime.ParseMediaType(header.contentDisposition); err == nil && disposition == "attachment" {
+		return "", "", nil
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		if depth >= maxMIMEDepth {
+			return "", "", errors.New("too deeply nested")
+		}
+		boundary := params["boundary"]
+		if boundary == "" {
+			return "", "", errors.New("multipart body without boundary")
+		}
+		reader := multipart.NewReader(body, boundary)
+		var plain, htmlBody string
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", err
+			}
+			partPlain, partHTML, err := textParts(partHeader{
+				contentType:        part.Header.Get("Content-Type"),
+				transferEncoding:   part.Header.Get("Content-Transfer-Encoding"),
+				contentDisposition: part.Header.Get("Content-Disposition"),
+			}, part, depth+1)
+			if err != nil {
+				return "", "", err
+			}
+			if plain == "" {
+				plain = partPlain
+			}
+			if htmlBody == "" {
+				htmlBody = partHTML
+			}
+		}
+		return plain, htmlBody, nil
+	case mediaType == "text/plain", mediaType == "text/html":
+		text, err := decodeText(body, header.transferEncoding, params["charset"])
+		if err != nil {
+			return "", "", err
+		}
+		if mediaType == "text/html" {
+			return "", text, nil
+		}
+		return text, "", nil
+	default:
+		return "", "", nil
+	}
+}
+
+// decodeText undoes the transfer encoding of a text part and converts it to UTF-8
+func decodeText(body io.Reader, transferEncoding, charset string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(transferEncoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	return toUTF8(data, charset), nil
+}
+
+// toUTF8 converts text in charset to UTF-8. Latin-1 is converted; UTF-8, ASCII and unknown charsets
+// are kept, with invalid bytes replaced.
+func toUTF8(data []byte, charset string) string {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	return strings.ToValidUTF8(string(data), "�")
+}
+
+// wordDecoder decodes RFC 2047 encoded words in headers
+var wordDecoder = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(toUTF8(data, charset)), nil
+	},
+}
+
+// decodeHeader decodes the encoded words of a header value, keeping it as is when they are malformed
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// messageIDPattern matches a message ID in angle brackets
+var messageIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// messageIDs returns the message IDs of a header without angle brackets
+func messageIDs(value string) []string {
+	var ids []string
+	for _, match := range messageIDPattern.FindAllStringSubmatch(value, -1) {
+		ids = append(ids, match[1])
+	}
+	return ids
+}
+
+// firstMessageID returns the first message ID of a header, or the trimmed value when it has no angle
+// brackets
+func firstMessageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	return strings.TrimSpace(value)
+}
+
+// isAutoGenerated reports whether the headers mark an email as sent automatically (RFC 3834), as
+// mailing list or bulk mail, or as a delivery status report
+func isAutoGenerated(header netmail.Header) bool {
+	if value := strings.ToLower(strings.TrimSpace(header.Get("Auto-Submitted"))); value != "" && value != "no" {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(header.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	if header.Get("X-Autoreply") != "" || header.Get("X-Autorespond") != "" {
+		return true
+	}
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/report" && params["report-type"] == "delivery-status"
+}
+
+var (
+	htmlInvisiblePattern = regexp.MustCompile(`(?is)<(head|style|script)\b.*?</(head|style|script)\s*>`)
+	htmlBreakPattern     = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])\s*>`)
+	htmlTagPattern       = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinesPattern    = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText reduces an HTML body to its text, keeping paragraph breaks
+func htmlToText(body string) string {
+	text := htmlInvisiblePattern.ReplaceAllString(body, "")
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(normalizeNewlines(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+// normalizeNewlines converts CRLF and CR line endings to LF
+func normalizeNewlines(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+}
+
+// quoteHeaderPatterns match the line mail clients put above the quoted message in a reply
+var quoteHeaderPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^On .+ wrote:$`),
+	regexp.MustCompile(`^-+ ?Original Message ?-+$`),
+	regexp.MustCompile(`^_{10,}$`), // Outlook separator above the quoted headers
+}
+
+// StripQuotedReply removes the quoted earlier message from a reply, keeping what the sender wrote
+// above it. Text is kept as is when nothing would be left.
+func StripQuotedReply(body string) string {
+	lines := strings.Split(body, "\n")
+	end := len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, ">") {
+			// Quoted lines count only when nothing but quotes and blank lines follow
+			if onlyQuotesFollow(lines[i:]) {
+				end = i
+				break
+			}
+			continue
+		}
+		// Some clients wrap the line before "wrote:"
+		if matchesQuoteHeader(trimmed) || (strings.HasPrefix(trimmed, "On ") && i+1 < len(lines) && strings.TrimSpace(lines[i+1]) == "wrote:") {
+			end = i
+			break
+		}
+	}
+
+	stripped := strings.TrimSpace(strings.Join(lines[:end], "\n"))
+	if stripped == "" {
+		return strings.TrimSpace(body)
+	}
+	return stripped
+}
+
+// onlyQuotesFollow reports whether lines only hold quoted or blank lines
+func onlyQuotesFollow(lines []string) bool {
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, ">") {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesQuoteHeader reports whether a line introduces a quoted message
+func matchesQuoteHeader(line string) bool {
+	for _, pattern := range quoteHeaderPatterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
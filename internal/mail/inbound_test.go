@@ -0,0 +1,158 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rawMessage joins header and body lines with CRLF like a mail server would
+func rawMessage(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\r\n"))
+}
+
+func TestParseInbound_PlainText(t *testing.T) {
+	raw := rawMessage(
+		"From: =?utf-8?q?Jos=C3=A9?= <jose@example.com>",
+		"To: Support <support+acme-ios@example.com>",
+		"Cc: billing@example.com, \"Sales\" <sales@example.com>",
+		"Subject: =?utf-8?q?Re:_[SR-42]_L=C3=A4uft_nicht?=",
+		"Message-ID: <CAF=abc@mail.example.com>",
+		"In-Reply-To: <sr-42.0a1b@support.example.com>",
+		"References: <first@mail.example.com> <sr-42.0a1b@support.example.com>",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"The app still crashes on l=C3=A4unch.",
+		"",
+	)
+
+	msg, err := ParseInbound(raw)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jose@example.com", msg.From)
+	assert.Equal(t, "Re: [SR-42] Läuft nicht", msg.Subject)
+	assert.Equal(t, "CAF=abc@mail.example.com", msg.MessageID)
+	assert.Equal(t, []string{"sr-42.0a1b@support.example.com"}, msg.InReplyTo)
+	assert.Equal(t, []string{"first@mail.example.com", "sr-42.0a1b@support.example.com"}, msg.References)
+	assert.Equal(t, []string{"support+acme-ios@example.com", "billing@example.com", "sales@example.com"}, msg.Recipients)
+	assert.Equal(t, "The app still crashes on läunch.", msg.Body)
+	assert.False(t, msg.AutoGenerated)
+}
+
+func TestParseInbound_Multipart(t *testing.T) {
+	raw := rawMessage(
+		"From: jane@example.com",
+		"Subject: Crash",
+		"Content-Type: multipart/mixed; boundary=outer",
+		"",
+		"--outer",
+		"Content-Type: multipart/alternative; boundary=inner",
+		"",
+		"--inner",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<p>HTML version</p>",
+		"--inner",
+		"Content-Type: text/plain; charset=iso-8859-1",
+		"Content-Transfer-Encoding: base64",
+		"",
+		"UGxhaW4gdmVyc2lvbiDp", // "Plain version " followed by é in Latin-1
+		"--inner--",
+		"--outer",
+		"Content-Type: text/plain",
+		"Content-Disposition: attachment; filename=log.txt",
+		"",
+		"attached log",
+		"--outer--",
+		"",
+	)
+
+	msg, err := ParseInbound(raw)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Plain version é", msg.Body)
+}
+
+func TestParseInbound_HTMLOnly(t *testing.T) {
+	raw := rawMessage(
+		"From: jane@example.com",
+		"Subject: Crash",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<html><head><style>p { color: red; }</style></head><body><p>It crashes &amp; burns.</p><p>Line<br>break</p></body></html>",
+	)
+
+	msg, err := ParseInbound(raw)
+
+	require.NoError(t, err)
+	assert.Equal(t, "It crashes & burns.\nLine\nbreak", msg.Body)
+}
+
+func TestParseInbound_AutoGenerated(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"auto reply", "Auto-Submitted: auto-replied"},
+		{"bulk", "Precedence: bulk"},
+		{"autoreply header", "X-Autoreply: yes"},
+		{"bounce", "Content-Type: multipart/report; report-type=delivery-status; boundary=b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := ParseInbound(rawMessage("From: mailer-daemon@example.com", tt.header, "", "--b--", ""))
+
+			require.NoError(t, err)
+			assert.True(t, msg.AutoGenerated)
+		})
+	}
+
+	msg, err := ParseInbound(rawMessage("From: jane@example.com", "Auto-Submitted: no", "", "Hello"))
+	require.NoError(t, err)
+	assert.False(t, msg.AutoGenerated)
+}
+
+func TestParseInbound_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"no headers", []byte("just some text")},
+		{"no sender", rawMessage("Subject: Hi", "", "Hello")},
+		{"multipart without boundary", rawMessage("From: jane@example.com", "Content-Type: multipart/mixed", "", "Hello")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInbound(tt.raw)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStripQuotedReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"quoted lines", "Still broken.\n\n> Could you update?\n> Thanks", "Still broken."},
+		{"gmail header", "Still broken.\n\nOn Mon, 3 Jun 2024 at 10:00, Support <support@example.com> wrote:\n> Could you update?", "Still broken."},
+		{"wrapped gmail header", "Still broken.\n\nOn Mon, 3 Jun 2024 at 10:00, Support <support@example.com>\nwrote:\n> Could you update?", "Still broken."},
+		{"outlook header", "Still broken.\n\n-----Original Message-----\nFrom: Support", "Still broken."},
+		{"inline quote is kept", "> Could you update?\nI did, still broken.", "> Could you update?\nI did, still broken."},
+		{"only a quote", "> Could you update?", "> Could you update?"},
+		{"no quote", "Still broken.", "Still broken."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripQuotedReply(tt.body))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrMessageTooLarge is returned for messages larger than the size limit
+var ErrMessageTooLarge = errors.New("message too large")
+
+// Maildir reads the messages delivered to a Maildir. Delivered messages wait in new/ and are moved
+// to cur/ with info flags once processed.
+type Maildir struct {
+	dir string
+}
+
+// NewMaildir creates a Maildir reader for dir
+func NewMaildir(dir string) *Maildir {
+	return &Maildir{dir: dir}
+}
+
+// New returns the file names of the messages waiting in new/, in delivery order
+func (m *Maildir) New() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, "new"))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	// Delivery agents start names with the delivery time
+	sort.Strings(names)
+	return names, nil
+}
+
+// Read returns the raw message name from new/, failing with ErrMessageTooLarge beyond maxSize bytes
+func (m *Maildir) Read(name string, maxSize int64) ([]byte, error) {
+	file, err := os.Open(filepath.Join(m.dir, "new", filepath.Base(name)))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrMessageTooLarge, maxSize)
+	}
+	return raw, nil
+}
+
+// MarkSeen moves a message from new/ to cur/ flagged as seen
+func (m *Maildir) MarkSeen(name string) error {
+	return m.moveToCur(name, "S")
+}
+
+// MarkTrashed moves a message that can't be processed from new/ to cur/ flagged as seen and trashed,
+// leaving it for the mail server to clean up
+func (m *Maildir) MarkTrashed(name string) error {
+	return m.moveToCur(name, "ST")
+}
+
+// moveToCur moves a message into cur/ with the info flags, which must be in ASCII order
+func (m *Maildir) moveToCur(name, flags string) error {
+	name = filepath.Base(name)
+	return os.Rename(filepath.Join(m.dir, "new", name), filepath.Join(m.dir, "cur", name+":2,"+flags))
+}
@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMaildir creates a Maildir with the messages in new/
+func newTestMaildir(t *testing.T, messages map[string]string) (*Maildir, string) {
+	dir := t.TempDir()
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, sub), 0o755))
+	}
+	for name, content := range messages {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "new", name), []byte(content), 0o644))
+	}
+	return NewMaildir(dir), dir
+}
+
+func TestMaildir_New(t *testing.T) {
+	maildir, _ := newTestMaildir(t, map[string]string{
+		"1700000002.M2.host": "second",
+		"1700000001.M1.host": "first",
+		".hidden":            "skipped",
+	})
+
+	names, err := maildir.New()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1700000001.M1.host", "1700000002.M2.host"}, names)
+}
+
+func TestMaildir_Read(t *testing.T) {
+	maildir, _ := newTestMaildir(t, map[string]string{"1.M1.host": "0123456789"})
+
+	raw, err := maildir.Read("1.M1.host", 10)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(raw))
+
+	_, err = maildir.Read("1.M1.host", 9)
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestMaildir_Mark(t *testing.T) {
+	maildir, dir := newTestMaildir(t, map[string]string{"1.M1.host": "one", "2.M2.host": "two"})
+
+	require.NoError(t, maildir.MarkSeen("1.M1.host"))
+	require.NoError(t, maildir.MarkTrashed("2.M2.host"))
+
+	assert.FileExists(t, filepath.Join(dir, "cur", "1.M1.host:2,S"))
+	assert.FileExists(t, filepath.Join(dir, "cur", "2.M2.host:2,ST"))
+	names, err := maildir.New()
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
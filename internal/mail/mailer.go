@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	Headers map[string]string // Additional headers such as Message-ID or List-Unsubscribe
+}
+
+// Mailer defines the interface for sending email
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// formatMessage renders msg as an RFC 5322 message. Line breaks are removed from header values so
+// that user supplied text cannot add headers, and non-ASCII subjects are MIME encoded.
+func formatMessage(from string, msg Message, date time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(msg.Subject)))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+
+	names := make([]string, 0, len(msg.Headers))
+	for name := range msg.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: %s\r\n", headerValue(name), headerValue(msg.Headers[name]))
+	}
+
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.String()
+}
+
+// headerValue replaces line breaks in a header value with spaces
+func headerValue(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
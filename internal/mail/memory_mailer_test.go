@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryMailer_Send(t *testing.T) {
+	mailer := NewMemoryMailer()
+
+	require.NoError(t, mailer.Send(Message{To: "a@example.com", Subject: "One", Body: "1"}))
+	require.NoError(t, mailer.Send(Message{To: "b@example.com", Subject: "Two", Body: "2"}))
+
+	messages := mailer.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, "One", messages[0].Subject)
+	assert.Equal(t, "b@example.com", messages[1].To)
+
+	mailer.Reset()
+	assert.Empty(t, mailer.Messages())
+}
@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig holds the connection settings of an SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // Empty to send without authentication
+	Password string
+}
+
+// smtpMailer implements Mailer by handing messages to an SMTP server. The connection is upgraded
+// with STARTTLS when the server offers it, and credentials are only sent over TLS or to localhost.
+type smtpMailer struct {
+	addr         string
+	auth         smtp.Auth
+	from         string
+	envelopeFrom string
+	now          func() time.Time
+	sendMail     func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPMailer creates a mailer that sends messages from the given address through an SMTP server
+func NewSMTPMailer(config SMTPConfig, from string) (Mailer, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	sender, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", from, err)
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+
+	return &smtpMailer{
+		addr:         net.JoinHostPort(config.Host, strconv.Itoa(config.Port)),
+		auth:         auth,
+		from:         from,
+		envelopeFrom: sender.Address,
+		now:          time.Now,
+		sendMail:     smtp.SendMail,
+	}, nil
+}
+
+// Send delivers msg to the SMTP server
+func (m *smtpMailer) Send(msg Message) error {
+	recipient, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %q: %w", msg.To, err)
+	}
+
+	body := formatMessage(m.from, msg, m.now())
+	if err := m.sendMail(m.addr, m.auth, m.envelopeFrom, []string{recipient.Address}, []byte(body)); err != nil {
+		return fmt.Errorf("failed to send mail through %s: %w", m.addr, err)
+	}
+	return nil
+}
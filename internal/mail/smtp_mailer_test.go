@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPMailer_Send(t *testing.T) {
+	// Arrange
+	mailer, err := NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", Port: 587, Username: "mailer", Password: "secret"}, "Support App <no-reply@supportapp.local>")
+	require.NoError(t, err)
+
+	var addr, from string
+	var to []string
+	var body []byte
+	mailer.(*smtpMailer).sendMail = func(a string, auth smtp.Auth, f string, t []string, msg []byte) error {
+		addr, from, to, body = a, f, t, msg
+		return nil
+	}
+
+	// Act
+	err = mailer.Send(Message{To: "Jane <jane@example.com>", Subject: "Hello", Body: "Hi", Headers: map[string]string{"Message-ID": "<1@supportapp.local>"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.Equal(t, "no-reply@supportapp.local", from)
+	assert.Equal(t, []string{"jane@example.com"}, to)
+	assert.Contains(t, string(body), "From: Support App <no-reply@supportapp.local>\r\n")
+	assert.Contains(t, string(body), "Message-ID: <1@supportapp.local>\r\n")
+}
+
+func TestSMTPMailer_SendErrors(t *testing.T) {
+	mailer, err := NewSMTPMailer(SMTPConfig{Host: "localhost", Port: 25}, "no-reply@supportapp.local")
+	require.NoError(t, err)
+	mailer.(*smtpMailer).sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+
+	assert.Error(t, mailer.Send(Message{To: "not an address", Subject: "Hello", Body: "Hi"}))
+	assert.ErrorContains(t, mailer.Send(Message{To: "jane@example.com", Subject: "Hello", Body: "Hi"}), "connection refused")
+}
+
+func TestNewSMTPMailer_InvalidConfig(t *testing.T) {
+	_, err := NewSMTPMailer(SMTPConfig{Port: 587}, "no-reply@supportapp.local")
+	assert.Error(t, err)
+
+	_, err = NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", Port: 587}, "Support App")
+	assert.Error(t, err)
+}
@@ -1,8 +1,12 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"support-app-backend/internal/models"
 	"support-app-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -43,15 +47,116 @@ func AuthMiddleware(authService services.AuthService) gin.HandlerFunc {
 	}
 }
 
-// AdminOnlyMiddleware ensures only admin users can access the endpoint
-func AdminOnlyMiddleware() gin.HandlerFunc {
+// RequirePermission ensures the authenticated user's role grants every given permission. It must run
+// after AuthMiddleware, which puts the role in the context.
+func RequirePermission(roleService services.RoleService, permissions ...models.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
-		if !exists || role != "admin" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		if !exists {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Permission denied"})
 			c.Abort()
 			return
 		}
+
+		for _, permission := range permissions {
+			allowed, err := roleService.HasPermission(models.UserRole(role.(string)), permission)
+			if err != nil {
+				log.Printf("Warning: Failed to check permission %s: %v", permission, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+				c.Abort()
+				return
+			}
+			if !allowed {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Permission denied", "required_permission": permission})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// OrganizationScopeMiddleware resolves the organization the authenticated caller works in, from the
+// X-Organization-ID header or their only membership, and puts it in the context as "organization_id".
+// Inside an organization the caller acts with their membership role, so it must run after
+// AuthMiddleware and before RequirePermission. Anonymous requests pass through unscoped.
+func OrganizationScopeMiddleware(organizationService services.OrganizationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.Next()
+			return
+		}
+
+		var requestedID *uint
+		if header := c.GetHeader("X-Organization-ID"); header != "" {
+			id, err := strconv.ParseUint(header, 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid X-Organization-ID header"})
+				c.Abort()
+				return
+			}
+			organizationID := uint(id)
+			requestedID = &organizationID
+		}
+
+		scope, role, err := organizationService.ResolveScope(userID.(uint), requestedID)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrOrganizationRequired):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrNotOrganizationMember):
+				c.JSON(http.StatusForbidden, gin.H{"error": "Not a member of this organization"})
+			case errors.Is(err, services.ErrOrganizationNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+			default:
+				log.Printf("Warning: Failed to resolve organization for user %v: %v", userID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve organization"})
+			}
+			c.Abort()
+			return
+		}
+
+		if !scope.IsUnscoped() {
+			c.Set("organization_id", *scope.OrganizationID)
+		}
+		if role != "" {
+			c.Set("role", string(role))
+		}
+		c.Next()
+	}
+}
+
+// AppKeyMiddleware authenticates the intake key a client sends in the X-App-Key header and puts its
+// app in the context as "intake_app". An unknown or revoked key is rejected. Requests without a key
+// pass through unless required is set. It must run before the rate limiter, which counts requests
+// with a key per app.
+func AppKeyMiddleware(appService services.AppService, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-App-Key")
+		if key == "" {
+			if required {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "X-App-Key header required"})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		app, err := appService.AuthenticateAppKey(key)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidAppKey) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid app key"})
+			} else {
+				log.Printf("Warning: Failed to authenticate app key: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate app key"})
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("intake_app", app)
 		c.Next()
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -17,11 +18,31 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
-	args := m.Called(req)
+func (m *MockAuthService) Login(req *models.LoginRequest, clientIP string) (*models.LoginResponse, error) {
+	args := m.Called(req, clientIP)
+	return args.Get(0).(*models.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthService) CompleteMFALogin(req *models.MFALoginRequest, clientIP string) (*models.LoginResponse, error) {
+	args := m.Called(req, clientIP)
 	return args.Get(0).(*models.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthService) BeginMFALoginEnrollment(mfaToken string) (*models.MFAEnrollmentResponse, error) {
+	args := m.Called(mfaToken)
+	return args.Get(0).(*models.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockAuthService) Refresh(refreshToken string) (*models.LoginResponse, error) {
+	args := m.Called(refreshToken)
+	return args.Get(0).(*models.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(refreshToken string) error {
+	args := m.Called(refreshToken)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) CreateUser(req *models.CreateUserRequest) (*models.UserInfo, error) {
 	args := m.Called(req)
 	return args.Get(0).(*models.UserInfo), args.Error(1)
@@ -37,8 +58,8 @@ func (m *MockAuthService) GetAllUsers(page, pageSize int) ([]*models.UserInfo, i
 	return args.Get(0).([]*models.UserInfo), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockAuthService) UpdateUser(id uint, req *models.UpdateUserRequest) (*models.UserInfo, error) {
-	args := m.Called(id, req)
+func (m *MockAuthService) UpdateUser(id uint, req *models.UpdateUserRequest, actor models.AuditActor) (*models.UserInfo, error) {
+	args := m.Called(id, req, actor)
 	return args.Get(0).(*models.UserInfo), args.Error(1)
 }
 
@@ -47,8 +68,21 @@ func (m *MockAuthService) ChangePassword(userID uint, req *models.ChangePassword
 	return args.Error(0)
 }
 
-func (m *MockAuthService) DeleteUser(id uint) error {
+func (m *MockAuthService) DeleteUser(id uint, actor models.AuditActor) error {
+	args := m.Called(id, actor)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) GetUserLockStatus(id uint) (*models.UserLockStatus, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserLockStatus), args.Error(1)
+}
+
+func (m *MockAuthService) UnlockUser(id uint, actor models.AuditActor) error {
+	args := m.Called(id, actor)
 	return args.Error(0)
 }
 
@@ -170,58 +204,375 @@ func TestAuthMiddleware_MalformedAuthHeader(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
-func TestAdminOnlyMiddleware_AdminUser(t *testing.T) {
+// MockRoleService is a mock implementation of RoleService
+type MockRoleService struct {
+	mock.Mock
+}
+
+func (m *MockRoleService) ListRoles() ([]*models.RoleResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) GetRole(name models.UserRole) (*models.RoleResponse, error) {
+	args := m.Called(name)
+	return args.Get(0).(*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) CreateRole(req *models.CreateRoleRequest) (*models.RoleResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) UpdateRole(name models.UserRole, req *models.UpdateRoleRequest) (*models.RoleResponse, error) {
+	args := m.Called(name, req)
+	return args.Get(0).(*models.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleService) DeleteRole(name models.UserRole) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockRoleService) HasPermission(role models.UserRole, permission models.Permission) (bool, error) {
+	args := m.Called(role, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+func performPermissionRequest(roleService services.RoleService, role string, permissions ...models.Permission) *httptest.ResponseRecorder {
 	gin.SetMode(gin.TestMode)
 
 	router := gin.New()
-	router.Use(func(c *gin.Context) {
-		c.Set("role", "admin")
-		c.Next()
-	})
-	router.Use(AdminOnlyMiddleware())
-	router.GET("/admin", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "admin access granted"})
+	if role != "" {
+		router.Use(func(c *gin.Context) {
+			c.Set("role", role)
+			c.Next()
+		})
+	}
+	router.Use(RequirePermission(roleService, permissions...))
+	router.GET("/tickets", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "access granted"})
 	})
 
-	req, _ := http.NewRequest("GET", "/admin", nil)
+	req, _ := http.NewRequest("GET", "/tickets", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequirePermission_Granted(t *testing.T) {
+	mockRoleService := new(MockRoleService)
+	mockRoleService.On("HasPermission", models.UserRoleAgent, models.PermissionTicketsRead).Return(true, nil)
+	mockRoleService.On("HasPermission", models.UserRoleAgent, models.PermissionTicketsUpdate).Return(true, nil)
+
+	w := performPermissionRequest(mockRoleService, "agent", models.PermissionTicketsRead, models.PermissionTicketsUpdate)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+	mockRoleService.AssertExpectations(t)
+}
+
+func TestRequirePermission_Denied(t *testing.T) {
+	mockRoleService := new(MockRoleService)
+	mockRoleService.On("HasPermission", models.UserRoleViewer, models.PermissionTicketsRead).Return(true, nil)
+	mockRoleService.On("HasPermission", models.UserRoleViewer, models.PermissionTicketsDelete).Return(false, nil)
+
+	w := performPermissionRequest(mockRoleService, "viewer", models.PermissionTicketsRead, models.PermissionTicketsDelete)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"required_permission":"tickets:delete"`)
+}
+
+func TestRequirePermission_NoRole(t *testing.T) {
+	mockRoleService := new(MockRoleService)
+
+	w := performPermissionRequest(mockRoleService, "", models.PermissionTicketsRead)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockRoleService.AssertNotCalled(t, "HasPermission", mock.Anything, mock.Anything)
+}
+
+func TestRequirePermission_LookupError(t *testing.T) {
+	mockRoleService := new(MockRoleService)
+	mockRoleService.On("HasPermission", models.UserRole("triage"), models.PermissionTicketsRead).Return(false, errors.New("database error"))
+
+	w := performPermissionRequest(mockRoleService, "triage", models.PermissionTicketsRead)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// MockOrganizationService is a mock implementation of OrganizationService
+type MockOrganizationService struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationService) ListOrganizations() ([]*models.OrganizationResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) GetOrganization(id uint) (*models.OrganizationResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) CreateOrganization(req *models.CreateOrganizationRequest) (*models.OrganizationResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) UpdateOrganization(id uint, req *models.UpdateOrganizationRequest) (*models.OrganizationResponse, error) {
+	args := m.Called(id, req)
+	return args.Get(0).(*models.OrganizationResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) DeleteOrganization(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationService) ListMembers(organizationID uint) ([]*models.OrganizationMemberResponse, error) {
+	args := m.Called(organizationID)
+	return args.Get(0).([]*models.OrganizationMemberResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) SetMember(organizationID, userID uint, req *models.SetOrganizationMemberRequest) (*models.OrganizationMemberResponse, error) {
+	args := m.Called(organizationID, userID, req)
+	return args.Get(0).(*models.OrganizationMemberResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) RemoveMember(organizationID, userID uint) error {
+	args := m.Called(organizationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationService) ListApps(organizationID uint) ([]*models.AppResponse, error) {
+	args := m.Called(organizationID)
+	return args.Get(0).([]*models.AppResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) AddApp(organizationID uint, req *models.AddOrganizationAppRequest) (*models.AppResponse, error) {
+	args := m.Called(organizationID, req)
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockOrganizationService) RemoveApp(organizationID uint, slug string) error {
+	args := m.Called(organizationID, slug)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationService) ListUserOrganizations(userID uint) ([]*models.UserOrganizationResponse, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*models.UserOrganizationResponse), args.Error(1)
 }
 
-func TestAdminOnlyMiddleware_NonAdminUser(t *testing.T) {
+func (m *MockOrganizationService) ResolveScope(userID uint, requestedID *uint) (models.OrganizationScope, models.UserRole, error) {
+	args := m.Called(userID, requestedID)
+	return args.Get(0).(models.OrganizationScope), args.Get(1).(models.UserRole), args.Error(2)
+}
+
+func performOrganizationScopeRequest(organizationService services.OrganizationService, authenticated bool, header string) (*httptest.ResponseRecorder, gin.H) {
 	gin.SetMode(gin.TestMode)
 
+	seen := gin.H{}
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
-		c.Set("role", "user")
+		if authenticated {
+			c.Set("user_id", uint(7))
+			c.Set("role", "admin")
+		}
 		c.Next()
 	})
-	router.Use(AdminOnlyMiddleware())
-	router.GET("/admin", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "admin access granted"})
+	router.Use(OrganizationScopeMiddleware(organizationService))
+	router.GET("/tickets", func(c *gin.Context) {
+		seen["organization_id"], _ = c.Get("organization_id")
+		seen["role"], _ = c.Get("role")
+		c.JSON(200, gin.H{"message": "access granted"})
 	})
 
-	req, _ := http.NewRequest("GET", "/admin", nil)
+	req, _ := http.NewRequest("GET", "/tickets", nil)
+	if header != "" {
+		req.Header.Set("X-Organization-ID", header)
+	}
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	return w, seen
+}
 
-	assert.Equal(t, http.StatusForbidden, w.Code)
+func TestOrganizationScopeMiddleware_Member(t *testing.T) {
+	mockOrgService := new(MockOrganizationService)
+	organizationID := uint(3)
+	mockOrgService.On("ResolveScope", uint(7), &organizationID).Return(models.ScopeToOrganization(3), models.UserRoleAgent, nil)
+
+	w, seen := performOrganizationScopeRequest(mockOrgService, true, "3")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(3), seen["organization_id"])
+	assert.Equal(t, "agent", seen["role"])
+	mockOrgService.AssertExpectations(t)
+}
+
+func TestOrganizationScopeMiddleware_PlatformStaff(t *testing.T) {
+	mockOrgService := new(MockOrganizationService)
+	mockOrgService.On("ResolveScope", uint(7), (*uint)(nil)).Return(models.OrganizationScope{}, models.UserRole(""), nil)
+
+	w, seen := performOrganizationScopeRequest(mockOrgService, true, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, seen["organization_id"])
+	assert.Equal(t, "admin", seen["role"])
+}
+
+func TestOrganizationScopeMiddleware_Anonymous(t *testing.T) {
+	mockOrgService := new(MockOrganizationService)
+
+	w, seen := performOrganizationScopeRequest(mockOrgService, false, "3")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, seen["organization_id"])
+	mockOrgService.AssertNotCalled(t, "ResolveScope", mock.Anything, mock.Anything)
+}
+
+func TestOrganizationScopeMiddleware_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		err        error
+		wantStatus int
+	}{
+		{"invalid header", "acme", nil, http.StatusBadRequest},
+		{"organization required", "", services.ErrOrganizationRequired, http.StatusBadRequest},
+		{"not a member", "3", services.ErrNotOrganizationMember, http.StatusForbidden},
+		{"organization not found", "3", services.ErrOrganizationNotFound, http.StatusNotFound},
+		{"lookup error", "3", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrgService := new(MockOrganizationService)
+			mockOrgService.On("ResolveScope", uint(7), mock.Anything).Return(models.OrganizationScope{}, models.UserRole(""), tt.err)
+
+			w, _ := performOrganizationScopeRequest(mockOrgService, true, tt.header)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+// MockAppService is a mock implementation of AppService
+type MockAppService struct {
+	mock.Mock
+}
+
+func (m *MockAppService) ListApps() ([]*models.AppResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.AppResponse), args.Error(1)
 }
 
-func TestAdminOnlyMiddleware_NoRole(t *testing.T) {
+func (m *MockAppService) GetApp(id uint) (*models.AppResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) CreateApp(req *models.CreateAppRequest) (*models.AppResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) UpdateApp(id uint, req *models.UpdateAppRequest) (*models.AppResponse, error) {
+	args := m.Called(id, req)
+	return args.Get(0).(*models.AppResponse), args.Error(1)
+}
+
+func (m *MockAppService) DeleteApp(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAppService) ListAppKeys(appID uint) ([]*models.AppKeyResponse, error) {
+	args := m.Called(appID)
+	return args.Get(0).([]*models.AppKeyResponse), args.Error(1)
+}
+
+func (m *MockAppService) CreateAppKey(appID uint, req *models.CreateAppKeyRequest) (*models.AppKeyResponse, error) {
+	args := m.Called(appID, req)
+	return args.Get(0).(*models.AppKeyResponse), args.Error(1)
+}
+
+func (m *MockAppService) RevokeAppKey(appID, keyID uint) error {
+	args := m.Called(appID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAppService) AuthenticateAppKey(key string) (*models.App, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.App), args.Error(1)
+}
+
+func performAppKeyRequest(appService services.AppService, required bool, key string) (*httptest.ResponseRecorder, interface{}) {
 	gin.SetMode(gin.TestMode)
 
+	var seen interface{}
 	router := gin.New()
-	router.Use(AdminOnlyMiddleware())
-	router.GET("/admin", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "admin access granted"})
+	router.Use(AppKeyMiddleware(appService, required))
+	router.POST("/support-request", func(c *gin.Context) {
+		seen, _ = c.Get("intake_app")
+		c.JSON(http.StatusCreated, gin.H{"message": "created"})
 	})
 
-	req, _ := http.NewRequest("GET", "/admin", nil)
+	req, _ := http.NewRequest("POST", "/support-request", nil)
+	if key != "" {
+		req.Header.Set("X-App-Key", key)
+	}
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	return w, seen
+}
 
-	assert.Equal(t, http.StatusForbidden, w.Code)
+func TestAppKeyMiddleware_ValidKey(t *testing.T) {
+	mockAppService := new(MockAppService)
+	app := &models.App{ID: 1, Slug: "acme-ios", IsActive: true}
+	mockAppService.On("AuthenticateAppKey", "ak_valid").Return(app, nil)
+
+	w, seen := performAppKeyRequest(mockAppService, true, "ak_valid")
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, app, seen)
+}
+
+func TestAppKeyMiddleware_NoKey(t *testing.T) {
+	mockAppService := new(MockAppService)
+
+	w, seen := performAppKeyRequest(mockAppService, false, "")
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Nil(t, seen)
+	mockAppService.AssertNotCalled(t, "AuthenticateAppKey", mock.Anything)
+}
+
+func TestAppKeyMiddleware_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		required   bool
+		key        string
+		err        error
+		wantStatus int
+	}{
+		{"missing required key", true, "", nil, http.StatusUnauthorized},
+		{"invalid key", false, "ak_revoked", services.ErrInvalidAppKey, http.StatusUnauthorized},
+		{"lookup error", false, "ak_valid", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAppService := new(MockAppService)
+			mockAppService.On("AuthenticateAppKey", tt.key).Return(nil, tt.err).Maybe()
+
+			w, _ := performAppKeyRequest(mockAppService, tt.required, tt.key)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
 }
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyMiddleware makes retrying a request with the same Idempotency-Key header safe. The first
+// request is processed and its 201 Created response stored; a retry with the same key and body gets
+// the stored response again, marked with the Idempotent-Replayed header, and a retry with another
+// body is rejected. Failed requests aren't stored, so they can be retried with the same key. Keys are
+// scoped to the app of the X-App-Key, not the client IP, because mobile clients change networks
+// between retries; it must run after AppKeyMiddleware.
+func IdempotencyMiddleware(idempotencyService services.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		scope := ""
+		if app, exists := c.Get("intake_app"); exists {
+			scope = fmt.Sprintf("app:%d", app.(*models.App).ID)
+		}
+
+		record, err := idempotencyService.Begin(scope, key)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidRequest):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrIdempotencyKeyInUse):
+				c.JSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still being processed"})
+			default:
+				log.Printf("Warning: Failed to check idempotency key: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key"})
+			}
+			c.Abort()
+			return
+		}
+
+		if record.IsCompleted() {
+			replayIdempotentResponse(c, idempotencyService, record, key)
+			return
+		}
+
+		// Hash the body while the handler reads it, and record the response it writes
+		bodyHash := sha256.New()
+		c.Request.Body = &hashingBody{Reader: io.TeeReader(c.Request.Body, bodyHash), Closer: c.Request.Body}
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		requestHash, err := finishRequestHash(c, bodyHash)
+		if err == nil && recorder.Status() == http.StatusCreated {
+			err = idempotencyService.Complete(record, key, requestHash, recorder.Status(), recorder.body.Bytes())
+		} else {
+			err = idempotencyService.Release(record)
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to store idempotency key: %v", err)
+		}
+	}
+}
+
+// replayIdempotentResponse writes the stored response of a completed key, if the request is the one
+// the key was first used for
+func replayIdempotentResponse(c *gin.Context, idempotencyService services.IdempotencyService, record *models.IdempotencyKey, key string) {
+	defer c.Abort()
+
+	var requestHash string
+	var err error
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		if _, err = c.MultipartForm(); err == nil {
+			requestHash, err = hashMultipartForm(c.Request.MultipartForm)
+		}
+	} else {
+		bodyHash := sha256.New()
+		if _, err = io.Copy(bodyHash, c.Request.Body); err == nil {
+			requestHash = hex.EncodeToString(bodyHash.Sum(nil))
+		}
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+
+	if err := idempotencyService.Check(record, requestHash); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request"})
+		return
+	}
+
+	body, err := idempotencyService.Response(record, key)
+	if err != nil {
+		log.Printf("Warning: Failed to read idempotent response: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay Idempotency-Key response"})
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(record.ResponseStatus, gin.MIMEJSON+"; charset=utf-8", body)
+}
+
+// finishRequestHash returns the hash of a request after the handler read it. Multipart forms are
+// hashed by their content, because clients pick a new boundary when they build a request again.
+func finishRequestHash(c *gin.Context, bodyHash hash.Hash) (string, error) {
+	if c.Request.MultipartForm != nil {
+		return hashMultipartForm(c.Request.MultipartForm)
+	}
+	// Hash the rest of a body the handler didn't read to the end
+	if _, err := io.Copy(io.Discard, c.Request.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bodyHash.Sum(nil)), nil
+}
+
+// hashMultipartForm hashes the fields and files of a form independently of their order and encoding
+func hashMultipartForm(form *multipart.Form) (string, error) {
+	h := sha256.New()
+
+	names := make([]string, 0, len(form.Value))
+	for name := range form.Value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range form.Value[name] {
+			fmt.Fprintf(h, "field %q %q\n", name, value)
+		}
+	}
+
+	names = names[:0]
+	for name := range form.File {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, file := range form.File[name] {
+			fmt.Fprintf(h, "file %q %q %d\n", name, file.Filename, file.Size)
+			f, err := file.Open()
+			if err != nil {
+				return "", err
+			}
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashingBody is a request body that hashes what is read from it
+type hashingBody struct {
+	io.Reader
+	io.Closer
+}
+
+// responseRecorder keeps a copy of the response body it writes
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyService keeps idempotency keys in memory
+type memoryIdempotencyService struct {
+	keys map[string]*models.IdempotencyKey
+}
+
+func newMemoryIdempotencyService() *memoryIdempotencyService {
+	return &memoryIdempotencyService{keys: map[string]*models.IdempotencyKey{}}
+}
+
+func (s *memoryIdempotencyService) Begin(scope, key string) (*models.IdempotencyKey, error) {
+	if existing, ok := s.keys[scope+"|"+key]; ok {
+		if !existing.IsCompleted() {
+			return nil, services.ErrIdempotencyKeyInUse
+		}
+		return existing, nil
+	}
+	record := &models.IdempotencyKey{Scope: scope, Key: key}
+	s.keys[scope+"|"+key] = record
+	return record, nil
+}
+
+func (s *memoryIdempotencyService) Check(key *models.IdempotencyKey, requestHash string) error {
+	if key.RequestHash != requestHash {
+		return services.ErrIdempotencyKeyMismatch
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyService) Complete(key *models.IdempotencyKey, idempotencyKey, requestHash string, status int, body []byte) error {
+	key.RequestHash = requestHash
+	key.ResponseStatus = status
+	key.ResponseBody = string(body)
+	return nil
+}
+
+func (s *memoryIdempotencyService) Response(key *models.IdempotencyKey, idempotencyKey string) ([]byte, error) {
+	return []byte(key.ResponseBody), nil
+}
+
+func (s *memoryIdempotencyService) Release(key *models.IdempotencyKey) error {
+	delete(s.keys, key.Scope+"|"+key.Key)
+	return nil
+}
+
+// setupIdempotencyRouter registers a handler that creates a numbered resource from JSON or multipart
+// bodies, and fails with 400 when the body contains "invalid"
+func setupIdempotencyRouter(idempotencyService services.IdempotencyService) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+	created := 0
+
+	router := gin.New()
+	router.POST("/items", func(c *gin.Context) {
+		if c.GetHeader("X-Test-App") != "" {
+			c.Set("intake_app", &models.App{ID: 1})
+		}
+	}, IdempotencyMiddleware(idempotencyService), func(c *gin.Context) {
+		var body string
+		if c.ContentType() == gin.MIMEMultipartPOSTForm {
+			body = c.PostForm("message")
+		} else {
+			raw, _ := io.ReadAll(c.Request.Body)
+			body = string(raw)
+		}
+		if strings.Contains(body, "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid"})
+			return
+		}
+		created++
+		c.JSON(http.StatusCreated, gin.H{"data": gin.H{"id": created}})
+	})
+	return router, &created
+}
+
+// postItem posts a JSON body with an Idempotency-Key
+func postItem(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyMiddleware_ReplaysResponse(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	first := postItem(router, "retry-1", `{"message":"help"}`)
+	retry := postItem(router, "retry-1", `{"message":"help"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotencyMiddleware_DifferentBody(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	postItem(router, "retry-1", `{"message":"help"}`)
+	w := postItem(router, "retry-1", `{"message":"other"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotencyMiddleware_WithoutKey(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	postItem(router, "", `{"message":"help"}`)
+	postItem(router, "", `{"message":"help"}`)
+
+	assert.Equal(t, 2, *created)
+}
+
+func TestIdempotencyMiddleware_FailedRequestCanBeRetried(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	failed := postItem(router, "retry-1", `{"message":"invalid"}`)
+	retry := postItem(router, "retry-1", `{"message":"help"}`)
+
+	assert.Equal(t, http.StatusBadRequest, failed.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Empty(t, retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotencyMiddleware_InUse(t *testing.T) {
+	idempotencyService := newMemoryIdempotencyService()
+	idempotencyService.keys["|retry-1"] = &models.IdempotencyKey{Key: "retry-1"}
+	router, created := setupIdempotencyRouter(idempotencyService)
+
+	w := postItem(router, "retry-1", `{"message":"help"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 0, *created)
+}
+
+func TestIdempotencyMiddleware_KeysAreScopedToApp(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	postItem(router, "retry-1", `{"message":"help"}`)
+	req := httptest.NewRequest("POST", "/items", strings.NewReader(`{"message":"help"}`))
+	req.Header.Set("Idempotency-Key", "retry-1")
+	req.Header.Set("X-Test-App", "1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 2, *created)
+}
+
+func TestIdempotencyMiddleware_ReplaysToNewClientIP(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	// A mobile client retries after moving from wifi to cellular
+	first := postItem(router, "retry-1", `{"message":"help"}`)
+	req := httptest.NewRequest("POST", "/items", strings.NewReader(`{"message":"help"}`))
+	req.RemoteAddr = "198.51.100.7:1234"
+	req.Header.Set("Idempotency-Key", "retry-1")
+	retry := httptest.NewRecorder()
+	router.ServeHTTP(retry, req)
+
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotencyMiddleware_MultipartIgnoresBoundary(t *testing.T) {
+	router, created := setupIdempotencyRouter(newMemoryIdempotencyService())
+
+	post := func(message string) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body) // A new random boundary every time
+		_ = writer.WriteField("message", message)
+		part, _ := writer.CreateFormFile("attachments", "log.txt")
+		_, _ = part.Write([]byte("log line"))
+		_ = writer.Close()
+		req := httptest.NewRequest("POST", "/items", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Idempotency-Key", "retry-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("help")
+	retry := post("help")
+	other := post("other")
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, http.StatusUnprocessableEntity, other.Code)
+	assert.Equal(t, 1, *created)
+}
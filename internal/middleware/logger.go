@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedRouteKey holds the route template logged instead of the path of a redacted route
+const redactedRouteKey = "redacted_route"
+
+// RequestLogger logs every request like gin's default logger. Requests to the given routes are logged
+// by their route template ("/track/:token") without the query string, because their path or query
+// carries a credential.
+func RequestLogger(redactedRoutes ...string) gin.HandlerFunc {
+	logger := gin.LoggerWithConfig(gin.LoggerConfig{
+		Formatter: func(param gin.LogFormatterParams) string {
+			if route, ok := param.Keys[redactedRouteKey].(string); ok {
+				param.Path = route
+			}
+			return formatRequestLog(param)
+		},
+	})
+	return func(c *gin.Context) {
+		if route := c.FullPath(); slices.Contains(redactedRoutes, route) {
+			c.Set(redactedRouteKey, route)
+		}
+		logger(c)
+	}
+}
+
+// formatRequestLog formats a request the way gin's default logger does
+func formatRequestLog(param gin.LogFormatterParams) string {
+	var statusColor, methodColor, resetColor string
+	if param.IsOutputColor() {
+		statusColor = param.StatusCodeColor()
+		methodColor = param.MethodColor()
+		resetColor = param.ResetColor()
+	}
+
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		statusColor, param.StatusCode, resetColor,
+		param.Latency,
+		param.ClientIP,
+		methodColor, param.Method, resetColor,
+		param.Path,
+		param.ErrorMessage,
+	)
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLogger_RedactsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var out bytes.Buffer
+	defaultWriter := gin.DefaultWriter
+	gin.DefaultWriter = &out
+	defer func() { gin.DefaultWriter = defaultWriter }()
+
+	router := gin.New()
+	router.Use(RequestLogger("/inbound", "/track/:token"))
+	router.POST("/inbound", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/track/:token", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/search", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("POST", "/inbound?token=query-secret", nil),
+		httptest.NewRequest("GET", "/track/path-secret", nil),
+		httptest.NewRequest("GET", "/search?q=login", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Contains(t, out.String(), `"/inbound"`)
+	assert.Contains(t, out.String(), `"/track/:token"`)
+	assert.NotContains(t, out.String(), "secret")
+	assert.Contains(t, out.String(), `"/search?q=login"`, "other routes are logged with their path and query")
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"support-app-backend/internal/models"
 	"sync"
 	"time"
 
@@ -38,17 +40,17 @@ func NewRateLimitMiddleware(rps float64, burst int) *RateLimitMiddleware {
 }
 
 // getLimiter gets or creates a rate limiter for a client
-func (rl *RateLimitMiddleware) getLimiter(clientIP string) *rate.Limiter {
+func (rl *RateLimitMiddleware) getLimiter(client string) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	limiter, exists := rl.clients[clientIP]
+	limiter, exists := rl.clients[client]
 	if !exists {
 		limiter = &RateLimiter{
 			limiter:  rate.NewLimiter(rl.rate, rl.burst),
 			lastSeen: time.Now(),
 		}
-		rl.clients[clientIP] = limiter
+		rl.clients[client] = limiter
 	} else {
 		limiter.lastSeen = time.Now()
 	}
@@ -64,9 +66,9 @@ func (rl *RateLimitMiddleware) cleanupRoutine() {
 	for {
 		<-ticker.C
 		rl.mu.Lock()
-		for clientIP, limiter := range rl.clients {
+		for client, limiter := range rl.clients {
 			if time.Since(limiter.lastSeen) > 3*time.Minute {
-				delete(rl.clients, clientIP)
+				delete(rl.clients, client)
 			}
 		}
 		rl.mu.Unlock()
@@ -76,8 +78,7 @@ func (rl *RateLimitMiddleware) cleanupRoutine() {
 // Middleware returns the Gin middleware function
 func (rl *RateLimitMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		clientIP := c.ClientIP()
-		limiter := rl.getLimiter(clientIP)
+		limiter := rl.getLimiter(rateLimitClient(c))
 
 		if !limiter.Allow() {
 			c.JSON(http.StatusTooManyRequests, gin.H{
@@ -90,3 +91,13 @@ func (rl *RateLimitMiddleware) Middleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// rateLimitClient identifies the client a request is counted against: its IP address, or, when
+// AppKeyMiddleware authenticated an intake key, the key's app and the IP address. Clients of
+// different apps behind one address then don't share a limit.
+func rateLimitClient(c *gin.Context) string {
+	if app, exists := c.Get("intake_app"); exists {
+		return fmt.Sprintf("app:%d|%s", app.(*models.App).ID, c.ClientIP())
+	}
+	return c.ClientIP()
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"support-app-backend/internal/models"
 	"testing"
 	"time"
 
@@ -80,6 +81,44 @@ func TestRateLimitMiddleware_DifferentIPs(t *testing.T) {
 	assert.Equal(t, http.StatusTooManyRequests, w3.Code)
 }
 
+func TestRateLimitMiddleware_PerApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Create rate limiter that allows 1 request per second with burst of 1
+	rl := NewRateLimitMiddleware(1.0, 1)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if slug := c.GetHeader("X-Test-App"); slug != "" {
+			c.Set("intake_app", &models.App{ID: uint(len(slug)), Slug: slug})
+		}
+		c.Next()
+	})
+	router.Use(rl.Middleware())
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	send := func(app string) int {
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "127.0.0.1:12345"
+		if app != "" {
+			req.Header.Set("X-Test-App", app)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Requests from the same IP are counted separately per app and without an app
+	assert.Equal(t, http.StatusOK, send("ios"))
+	assert.Equal(t, http.StatusOK, send("android"))
+	assert.Equal(t, http.StatusOK, send(""))
+
+	// A second request for the same app from the same IP is rate limited
+	assert.Equal(t, http.StatusTooManyRequests, send("ios"))
+}
+
 func TestRateLimitMiddleware_CleanupRoutine(t *testing.T) {
 	// This test verifies that the cleanup routine doesn't panic
 	// We can't easily test the actual cleanup without waiting
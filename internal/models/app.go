@@ -0,0 +1,158 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// App is a registered application allowed to submit support requests. Slug matches the app field
+// clients send with support requests. An app may belong to an organization, which then owns its
+// support requests.
+type App struct {
+	ID             uint          `json:"id" gorm:"primaryKey"`
+	OrganizationID *uint         `json:"organization_id,omitempty" gorm:"index"`
+	Organization   *Organization `json:"-" gorm:"foreignKey:OrganizationID;constraint:OnDelete:SET NULL"`
+	Slug           string        `json:"slug" gorm:"not null;size:100;unique"`
+	DisplayName    string        `json:"display_name" gorm:"not null;size:100"`
+	Platforms      string        `json:"-" gorm:"not null;size:100"` // Comma-separated Platform values the app may submit from, empty for any
+	IsActive       bool          `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// PlatformList returns the platforms the app may submit from. An empty list allows any platform.
+func (a *App) PlatformList() []Platform {
+	platforms := []Platform{}
+	for _, platform := range strings.Split(a.Platforms, ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			platforms = append(platforms, Platform(platform))
+		}
+	}
+	return platforms
+}
+
+// SetPlatforms replaces the platforms the app may submit from
+func (a *App) SetPlatforms(platforms []Platform) {
+	names := make([]string, len(platforms))
+	for i, platform := range platforms {
+		names[i] = string(platform)
+	}
+	a.Platforms = strings.Join(names, ",")
+}
+
+// AllowsPlatform reports whether the app may submit support requests from platform
+func (a *App) AllowsPlatform(platform Platform) bool {
+	platforms := a.PlatformList()
+	if len(platforms) == 0 {
+		return true
+	}
+	for _, allowed := range platforms {
+		if allowed == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// appSlugPattern restricts app slugs to lowercase labels such as "my-awesome-app"
+var appSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,99}$`)
+
+// IsValidAppSlug reports whether slug is an acceptable app slug
+func IsValidAppSlug(slug string) bool {
+	return appSlugPattern.MatchString(slug)
+}
+
+// AppKey is a public intake key that identifies an app submitting support requests. Keys ship inside
+// client apps, so they are not secret, but they tie submissions and rate limits to a registered app
+// and can be revoked when abused.
+type AppKey struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	AppID     uint      `json:"app_id" gorm:"not null;index"`
+	App       *App      `json:"-" gorm:"foreignKey:AppID;constraint:OnDelete:CASCADE"`
+	Name      string    `json:"name" gorm:"not null;size:100"`
+	Prefix    string    `json:"prefix" gorm:"not null;size:16"`        // Start of the key, to recognize it in listings
+	KeyHash   string    `json:"-" gorm:"not null;size:64;uniqueIndex"` // SHA-256 of the key, the key itself is never stored
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateAppRequest represents the payload for registering an app
+// @Description Request payload for registering an app
+type CreateAppRequest struct {
+	Slug        string     `json:"slug" binding:"required,max=100" example:"my-awesome-app"`         // App name clients send in support requests (lowercase letters, digits, '.', '_' and '-')
+	DisplayName string     `json:"display_name" binding:"required,max=100" example:"My Awesome App"` // Human-readable name
+	Platforms   []Platform `json:"platforms,omitempty" example:"iOS,Android"`                        // Platforms the app may submit from, any when empty
+}
+
+// UpdateAppRequest represents the payload for updating an app
+// @Description Request payload for updating an app
+type UpdateAppRequest struct {
+	DisplayName *string    `json:"display_name,omitempty" binding:"omitempty,min=1,max=100" example:"My Awesome App"` // New human-readable name
+	Platforms   []Platform `json:"platforms,omitempty" example:"iOS"`                                                 // New platforms the app may submit from
+	IsActive    *bool      `json:"is_active,omitempty" example:"false"`                                               // Disable to reject new support requests from the app
+}
+
+// CreateAppKeyRequest represents the payload for issuing an intake key
+// @Description Request payload for issuing an app intake key
+type CreateAppKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"iOS 2.x"` // Label telling keys apart, such as the client release that ships it
+}
+
+// AppResponse represents the API response for apps
+// @Description App details
+type AppResponse struct {
+	ID             uint       `json:"id" example:"1"`                            // App ID
+	OrganizationID *uint      `json:"organization_id,omitempty" example:"1"`     // ID of the owning organization, if any
+	Slug           string     `json:"slug" example:"my-awesome-app"`             // App name as sent in support requests
+	DisplayName    string     `json:"display_name" example:"My Awesome App"`     // Human-readable name
+	Platforms      []Platform `json:"platforms" example:"iOS,Android"`           // Platforms the app may submit from, any when empty
+	IsActive       bool       `json:"is_active" example:"true"`                  // Whether the app accepts support requests
+	CreatedAt      time.Time  `json:"created_at" example:"2023-12-01T10:00:00Z"` // Creation timestamp
+	UpdatedAt      time.Time  `json:"updated_at" example:"2023-12-01T10:00:00Z"` // Last update timestamp
+}
+
+// AppKeyResponse represents the API response for app intake keys
+// @Description App intake key details
+type AppKeyResponse struct {
+	ID        uint      `json:"id" example:"1"`                            // Key ID
+	AppID     uint      `json:"app_id" example:"1"`                        // App the key identifies
+	Name      string    `json:"name" example:"iOS 2.x"`                    // Key label
+	Prefix    string    `json:"prefix" example:"ak_3kq9Zx"`                // Start of the key
+	Key       string    `json:"key,omitempty" example:"ak_3kq9Zx..."`      // Full key, only returned when the key is issued
+	CreatedAt time.Time `json:"created_at" example:"2023-12-01T10:00:00Z"` // Creation timestamp
+}
+
+// ToResponse converts App to AppResponse
+func (a *App) ToResponse() *AppResponse {
+	return &AppResponse{
+		ID:             a.ID,
+		OrganizationID: a.OrganizationID,
+		Slug:           a.Slug,
+		DisplayName:    a.DisplayName,
+		Platforms:      a.PlatformList(),
+		IsActive:       a.IsActive,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+// ToResponse converts AppKey to AppKeyResponse
+func (k *AppKey) ToResponse() *AppKeyResponse {
+	return &AppKeyResponse{
+		ID:        k.ID,
+		AppID:     k.AppID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		CreatedAt: k.CreatedAt,
+	}
+}
+
+// TableName returns the table name for GORM
+func (App) TableName() string {
+	return "apps"
+}
+
+// TableName returns the table name for GORM
+func (AppKey) TableName() string {
+	return "app_keys"
+}
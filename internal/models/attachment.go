@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Attachment represents a file (screenshot, log, ...) uploaded with a support request.
+// The file contents live in blob storage under StorageKey.
+type Attachment struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	SupportRequestID uint           `json:"support_request_id" gorm:"not null;index"`
+	FileName         string         `json:"file_name" gorm:"not null;size:255"`
+	ContentType      string         `json:"content_type" gorm:"not null;size:100"`
+	Size             int64          `json:"size" gorm:"not null"`
+	StorageKey       string         `json:"-" gorm:"not null;size:255;unique"`
+	Checksum         string         `json:"checksum" gorm:"not null;size:64"`
+	CreatedAt        time.Time      `json:"created_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// AttachmentResponse represents the API response for attachments
+// @Description Attachment metadata
+type AttachmentResponse struct {
+	ID               uint      `json:"id" example:"1"`                                                                      // Attachment ID
+	SupportRequestID uint      `json:"support_request_id" example:"1"`                                                      // Support request ID
+	FileName         string    `json:"file_name" example:"screenshot.png"`                                                  // Original file name
+	ContentType      string    `json:"content_type" example:"image/png"`                                                    // Detected content type
+	Size             int64     `json:"size" example:"48213"`                                                                // Size in bytes
+	Checksum         string    `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"` // SHA-256 of the contents
+	CreatedAt        time.Time `json:"created_at" example:"2023-12-01T10:00:00Z"`                                           // Upload timestamp
+}
+
+// ToResponse converts Attachment to AttachmentResponse
+func (a *Attachment) ToResponse() *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:               a.ID,
+		SupportRequestID: a.SupportRequestID,
+		FileName:         a.FileName,
+		ContentType:      a.ContentType,
+		Size:             a.Size,
+		Checksum:         a.Checksum,
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+// TableName returns the table name for GORM
+func (Attachment) TableName() string {
+	return "attachments"
+}
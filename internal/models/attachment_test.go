@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachment_ToResponse(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	attachment := &Attachment{
+		ID:               1,
+		SupportRequestID: 2,
+		FileName:         "screenshot.png",
+		ContentType:      "image/png",
+		Size:             1024,
+		StorageKey:       "support-requests/2/abc",
+		Checksum:         "deadbeef",
+		CreatedAt:        now,
+	}
+
+	// Act
+	response := attachment.ToResponse()
+
+	// Assert
+	assert.Equal(t, attachment.ID, response.ID)
+	assert.Equal(t, attachment.SupportRequestID, response.SupportRequestID)
+	assert.Equal(t, attachment.FileName, response.FileName)
+	assert.Equal(t, attachment.ContentType, response.ContentType)
+	assert.Equal(t, attachment.Size, response.Size)
+	assert.Equal(t, attachment.Checksum, response.Checksum)
+	assert.Equal(t, attachment.CreatedAt, response.CreatedAt)
+}
+
+func TestAttachment_TableName_ReturnsCorrectName(t *testing.T) {
+	assert.Equal(t, "attachments", Attachment{}.TableName())
+}
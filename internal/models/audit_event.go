@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction represents what was done to an audited entity
+type AuditAction string
+
+const (
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+	AuditActionMerge  AuditAction = "merge"
+)
+
+// AuditEntityType represents the kind of entity an audit event refers to
+type AuditEntityType string
+
+const (
+	AuditEntitySupportRequest AuditEntityType = "support_request"
+	AuditEntityUser           AuditEntityType = "user"
+)
+
+// IsValid reports whether a is a known audit action
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionUpdate, AuditActionDelete, AuditActionMerge:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether t is a known audited entity type
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntitySupportRequest, AuditEntityUser:
+		return true
+	}
+	return false
+}
+
+// AuditActor identifies who performed an audited action and from where
+type AuditActor struct {
+	UserID    uint
+	Username  string
+	IPAddress string
+}
+
+// AuditChange holds the value of a single field before and after an audited action
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEvent is an append-only record of an admin action. The actor is stored by value so the
+// record stays meaningful after the user is deleted.
+type AuditEvent struct {
+	ID            uint            `json:"id" gorm:"primaryKey"`
+	ActorID       *uint           `json:"actor_id,omitempty" gorm:"index"`
+	ActorUsername string          `json:"actor_username" gorm:"size:50"`
+	Action        AuditAction     `json:"action" gorm:"not null;size:50;index"`
+	EntityType    AuditEntityType `json:"entity_type" gorm:"not null;size:50;index:idx_audit_events_entity"`
+	EntityID      uint            `json:"entity_id" gorm:"not null;index:idx_audit_events_entity"`
+	Changes       string          `json:"-" gorm:"type:text;not null"` // JSON encoded map[string]AuditChange
+	IPAddress     string          `json:"ip_address" gorm:"size:45"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"index"`
+}
+
+// AuditEventResponse represents the API response for audit events
+// @Description Audit log entry
+type AuditEventResponse struct {
+	ID            uint                   `json:"id" example:"1"`                            // Audit event ID
+	ActorID       *uint                  `json:"actor_id,omitempty" example:"1"`            // ID of the user who acted
+	ActorUsername string                 `json:"actor_username" example:"admin"`            // Username of the user who acted
+	Action        AuditAction            `json:"action" example:"update"`                   // Action performed (update, delete, merge)
+	EntityType    AuditEntityType        `json:"entity_type" example:"support_request"`     // Kind of entity acted on (support_request, user)
+	EntityID      uint                   `json:"entity_id" example:"42"`                    // ID of the entity acted on
+	Changes       map[string]AuditChange `json:"changes"`                                   // Changed fields with their before and after values
+	IPAddress     string                 `json:"ip_address" example:"203.0.113.7"`          // Client IP address of the request
+	CreatedAt     time.Time              `json:"created_at" example:"2023-12-01T10:00:00Z"` // When the action happened
+}
+
+// ToResponse converts AuditEvent to AuditEventResponse
+func (e *AuditEvent) ToResponse() *AuditEventResponse {
+	changes := map[string]AuditChange{}
+	// Changes is written by the services, so a decoding error only happens with hand-edited rows
+	_ = json.Unmarshal([]byte(e.Changes), &changes)
+
+	return &AuditEventResponse{
+		ID:            e.ID,
+		ActorID:       e.ActorID,
+		ActorUsername: e.ActorUsername,
+		Action:        e.Action,
+		EntityType:    e.EntityType,
+		EntityID:      e.EntityID,
+		Changes:       changes,
+		IPAddress:     e.IPAddress,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+// TableName returns the table name for GORM
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEvent_ToResponse(t *testing.T) {
+	// Arrange
+	actorID := uint(1)
+	now := time.Now()
+	event := &AuditEvent{
+		ID:            5,
+		ActorID:       &actorID,
+		ActorUsername: "admin",
+		Action:        AuditActionUpdate,
+		EntityType:    AuditEntitySupportRequest,
+		EntityID:      42,
+		Changes:       `{"status":{"before":"new","after":"resolved"}}`,
+		IPAddress:     "203.0.113.7",
+		CreatedAt:     now,
+	}
+
+	// Act
+	response := event.ToResponse()
+
+	// Assert
+	assert.Equal(t, uint(5), response.ID)
+	assert.Equal(t, &actorID, response.ActorID)
+	assert.Equal(t, AuditActionUpdate, response.Action)
+	assert.Equal(t, AuditEntitySupportRequest, response.EntityType)
+	assert.Equal(t, uint(42), response.EntityID)
+	assert.Equal(t, AuditChange{Before: "new", After: "resolved"}, response.Changes["status"])
+	assert.Equal(t, "203.0.113.7", response.IPAddress)
+	assert.Equal(t, now, response.CreatedAt)
+}
+
+func TestAuditEvent_ToResponse_InvalidChanges(t *testing.T) {
+	event := &AuditEvent{Changes: "not json"}
+
+	response := event.ToResponse()
+
+	assert.NotNil(t, response.Changes)
+	assert.Empty(t, response.Changes)
+}
+
+func TestAuditEvent_TableName(t *testing.T) {
+	assert.Equal(t, "audit_events", AuditEvent{}.TableName())
+}
+
+func TestAuditAction_IsValid(t *testing.T) {
+	assert.True(t, AuditActionUpdate.IsValid())
+	assert.True(t, AuditActionDelete.IsValid())
+	assert.False(t, AuditAction("create").IsValid())
+}
+
+func TestAuditEntityType_IsValid(t *testing.T) {
+	assert.True(t, AuditEntitySupportRequest.IsValid())
+	assert.True(t, AuditEntityUser.IsValid())
+	assert.False(t, AuditEntityType("tag").IsValid())
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// IdempotencyKey is an Idempotency-Key sent with a support request submission, stored with the
+// response so that a retried submission gets the same response instead of creating a duplicate
+type IdempotencyKey struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Scope          string    `json:"scope" gorm:"not null;size:50;uniqueIndex:idx_idempotency_keys_scope_key"` // The app of the X-App-Key used, empty without one
+	Key            string    `json:"-" gorm:"not null;size:255;uniqueIndex:idx_idempotency_keys_scope_key"`    // SHA-256 of the Idempotency-Key
+	RequestHash    string    `json:"-" gorm:"size:64"`                                                         // SHA-256 of the request body, set with the response
+	ResponseStatus int       `json:"response_status"`                                                          // Zero while the first request is still being processed
+	ResponseBody   string    `json:"-" gorm:"type:text"`                                                       // Encrypted with the Idempotency-Key, because it includes the tracking token
+	ExpiresAt      time.Time `json:"expires_at" gorm:"not null;index"`                                         // Expired keys are deleted and can be used again
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// IsCompleted reports whether the response of the first request is stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseStatus != 0
+}
+
+// TableName returns the table name for GORM
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
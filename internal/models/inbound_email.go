@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// InboundEmailAction is what was done with a received email
+type InboundEmailAction string
+
+const (
+	InboundEmailCreated    InboundEmailAction = "created"    // A new support request was created
+	InboundEmailReplied    InboundEmailAction = "replied"    // The email was added to an existing support request
+	InboundEmailIgnored    InboundEmailAction = "ignored"    // Automatic replies, such as out of office notices and bounces
+	InboundEmailProcessing InboundEmailAction = "processing" // Reserves the Message-ID while the email is processed, so concurrent deliveries wait
+)
+
+// InboundEmail records a received email, so that redelivered messages are only processed once
+type InboundEmail struct {
+	ID               uint               `json:"id" gorm:"primaryKey"`
+	MessageID        *string            `json:"message_id,omitempty" gorm:"size:998;uniqueIndex"` // Message-ID header without angle brackets, nil when the email had none
+	Sender           string             `json:"sender" gorm:"not null;size:255"`
+	Subject          string             `json:"subject" gorm:"not null;size:255"`
+	Action           InboundEmailAction `json:"action" gorm:"not null;size:20"`
+	SupportRequestID *uint              `json:"support_request_id,omitempty" gorm:"index"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+// InboundEmailResponse represents the API response for a received email
+// @Description Outcome of processing a received email
+type InboundEmailResponse struct {
+	Action           InboundEmailAction `json:"action" example:"created"`                  // What was done with the email (created, replied or ignored)
+	SupportRequestID *uint              `json:"support_request_id,omitempty" example:"42"` // Support request created or replied to
+	Reference        string             `json:"reference,omitempty" example:"SR-42"`       // Reference of that support request
+	Duplicate        bool               `json:"duplicate" example:"false"`                 // Whether the email was already processed before, and nothing was done now
+}
+
+// ToResponse converts InboundEmail to InboundEmailResponse
+func (e *InboundEmail) ToResponse() *InboundEmailResponse {
+	response := &InboundEmailResponse{
+		Action:           e.Action,
+		SupportRequestID: e.SupportRequestID,
+	}
+	if e.SupportRequestID != nil {
+		response.Reference = SupportRequestReference(*e.SupportRequestID)
+	}
+	return response
+}
+
+// TableName returns the table name for GORM
+func (InboundEmail) TableName() string {
+	return "inbound_emails"
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// LoginThrottle tracks failed logins for one username or client IP. Keys are "user:<username>" and
+// "ip:<address>"; unknown usernames are tracked like real ones, so lockouts don't reveal which exist.
+type LoginThrottle struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	Key           string     `json:"key" gorm:"not null;size:255;uniqueIndex"`
+	Failures      int        `json:"failures" gorm:"not null;default:0"` // Failed logins since the last lockout
+	Lockouts      int        `json:"lockouts" gorm:"not null;default:0"` // Lockouts in a row, each one twice as long as the previous
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`             // Logins are refused until then
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// IsLocked reports whether logins are refused at now
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
+
+// TableName returns the table name for GORM
+func (LoginThrottle) TableName() string {
+	return "login_throttles"
+}
+
+// UserLockStatus represents the login lockout state of a user account
+type UserLockStatus struct {
+	Locked         bool       `json:"locked" example:"true"`                                 // Whether logins are currently refused
+	LockedUntil    *time.Time `json:"locked_until,omitempty" example:"2025-06-12T10:15:00Z"` // When the lockout ends
+	FailedAttempts int        `json:"failed_attempts" example:"3"`                           // Recent failed logins counting towards the next lockout
+}
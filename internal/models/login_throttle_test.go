@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle_IsLocked(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	until := now.Add(time.Minute)
+	throttle := &LoginThrottle{}
+
+	// Assert
+	assert.False(t, throttle.IsLocked(now))
+
+	throttle.LockedUntil = &until
+	assert.True(t, throttle.IsLocked(now))
+	assert.False(t, throttle.IsLocked(until))
+}
+
+func TestLoginThrottle_TableName(t *testing.T) {
+	assert.Equal(t, "login_throttles", LoginThrottle{}.TableName())
+}
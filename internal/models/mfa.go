@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// MFARecoveryCode is a single-use code that stands in for a TOTP code when the authenticator is lost
+type MFARecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"not null;size:64"` // SHA-256 of the normalized code, the code itself is never stored
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
+
+// MFAEnrollmentResponse represents a new TOTP secret waiting to be confirmed
+// @Description TOTP secret and provisioning URI for an authenticator app
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`                                               // Base32 secret for manual entry
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Support%20App:admin?issuer=Support+App&secret=JBSW..."` // otpauth:// URI to render as a QR code
+}
+
+// MFARecoveryCodesResponse represents freshly generated recovery codes
+// @Description Recovery codes, shown only once
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7rqm-2xw4d,p3nfa-h6tzs"` // Single-use recovery codes
+}
+
+// MFACodeRequest represents a request carrying a TOTP code
+// @Description Request payload carrying a TOTP code
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"` // Current code from the authenticator app
+}
+
+// DisableMFARequest represents the payload for turning off MFA
+// @Description Request payload for disabling two-factor authentication
+type DisableMFARequest struct {
+	Password string `json:"password" binding:"required" example:"securePassword@123"` // Current password
+	Code     string `json:"code" example:"123456"`                                    // TOTP or recovery code, required once MFA is enabled
+}
+
+// MFALoginRequest represents the second step of a login
+// @Description Request payload completing a login with a second factor
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Challenge token from POST /auth/login
+	Code     string `json:"code" binding:"required" example:"123456"`                                       // TOTP or recovery code
+}
+
+// MFAChallengeRequest represents a request carrying an MFA challenge token
+// @Description Request payload carrying an MFA challenge token
+type MFAChallengeRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Challenge token from POST /auth/login
+}
+
+// MFAChallengeResponse represents a login waiting for its second factor
+// @Description Login response when a second factor is needed
+type MFAChallengeResponse struct {
+	MFARequired        bool      `json:"mfa_required" example:"true"`                                 // Always true
+	EnrollmentRequired bool      `json:"enrollment_required" example:"false"`                         // MFA is mandatory for the account but not yet set up
+	MFAToken           string    `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Short-lived token for POST /auth/login/mfa
+	ExpiresAt          time.Time `json:"expires_at" example:"2023-12-31T23:59:59Z"`                   // Challenge token expiration time
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_IsEnrollingMFA(t *testing.T) {
+	user := &User{}
+	assert.False(t, user.IsEnrollingMFA())
+
+	user.MFASecret = "JBSWY3DPEHPK3PXP"
+	assert.True(t, user.IsEnrollingMFA())
+
+	user.MFAEnabled = true
+	assert.False(t, user.IsEnrollingMFA())
+}
+
+func TestUser_ToUserInfoIncludesMFA(t *testing.T) {
+	user := &User{ID: 1, Username: "admin", MFAEnabled: true, MFASecret: "JBSWY3DPEHPK3PXP"}
+
+	info := user.ToUserInfo()
+
+	assert.True(t, info.MFAEnabled)
+}
+
+func TestMFARecoveryCode_TableName(t *testing.T) {
+	assert.Equal(t, "mfa_recovery_codes", MFARecoveryCode{}.TableName())
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// NotificationEvent is a support request change the submitter is emailed about
+type NotificationEvent string
+
+const (
+	NotificationEventStatusChanged NotificationEvent = "status_changed"
+	NotificationEventReply         NotificationEvent = "reply"
+)
+
+// NotificationEvents lists every event submitters are notified about
+var NotificationEvents = []NotificationEvent{NotificationEventStatusChanged, NotificationEventReply}
+
+// IsValid reports whether e is a known notification event
+func (e NotificationEvent) IsValid() bool {
+	for _, event := range NotificationEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationTemplate overrides the default email sent to submitters of an app's support requests
+// for one event. Subject and Body are Go text/template sources.
+type NotificationTemplate struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	AppID     uint              `json:"app_id" gorm:"not null;uniqueIndex:idx_notification_templates_app_event"`
+	App       *App              `json:"-" gorm:"foreignKey:AppID;constraint:OnDelete:CASCADE"`
+	Event     NotificationEvent `json:"event" gorm:"not null;size:30;uniqueIndex:idx_notification_templates_app_event"`
+	Subject   string            `json:"subject" gorm:"not null;size:255"`
+	Body      string            `json:"body" gorm:"not null;type:text"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// SetNotificationTemplateRequest represents the payload for customizing a notification email of an app
+// @Description Request payload for setting the subject and body templates of a notification email
+type SetNotificationTemplateRequest struct {
+	Subject string `json:"subject" binding:"required,max=255" example:"[{{.App}}] {{.Reference}} is now {{.StatusText}}"`                                              // Subject template
+	Body    string `json:"body" binding:"required,max=10000" example:"Hi,\n\nYour request {{.Reference}} is now {{.StatusText}}.\n\nUnsubscribe: {{.UnsubscribeURL}}"` // Plain text body template
+}
+
+// NotificationTemplateResponse represents the API response for notification templates
+// @Description Notification email template of an app, custom or default
+type NotificationTemplateResponse struct {
+	Event     NotificationEvent `json:"event" example:"status_changed"`                                     // Event the email is sent for (status_changed or reply)
+	Subject   string            `json:"subject" example:"[{{.App}}] {{.Reference}} is now {{.StatusText}}"` // Subject template
+	Body      string            `json:"body" example:"Your request {{.Reference}} is now {{.StatusText}}."` // Plain text body template
+	Custom    bool              `json:"custom" example:"true"`                                              // Whether the app overrides the default template
+	UpdatedAt *time.Time        `json:"updated_at,omitempty" example:"2023-12-01T10:00:00Z"`                // Last change of a custom template
+}
+
+// ToResponse converts NotificationTemplate to NotificationTemplateResponse
+func (t *NotificationTemplate) ToResponse() *NotificationTemplateResponse {
+	updatedAt := t.UpdatedAt
+	return &NotificationTemplateResponse{
+		Event:     t.Event,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		Custom:    true,
+		UpdatedAt: &updatedAt,
+	}
+}
+
+// TableName returns the table name for GORM
+func (NotificationTemplate) TableName() string {
+	return "notification_templates"
+}
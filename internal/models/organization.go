@@ -0,0 +1,134 @@
+package models
+
+import "time"
+
+// Organization is a client company whose apps, and the support requests submitted from them,
+// are only visible to its own members and to platform staff
+type Organization struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;size:100;unique"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizationMember gives a user a role within an organization. Users with at least one
+// membership are confined to their organizations; users without any are platform staff.
+type OrganizationMember struct {
+	ID             uint          `json:"id" gorm:"primaryKey"`
+	OrganizationID uint          `json:"organization_id" gorm:"not null;uniqueIndex:idx_organization_members_org_user"`
+	Organization   *Organization `json:"-" gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+	UserID         uint          `json:"user_id" gorm:"not null;uniqueIndex:idx_organization_members_org_user;index"`
+	User           *User         `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Role           UserRole      `json:"role" gorm:"not null;size:50"` // Built-in role or name of a custom Role, applied to the organization's support requests
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// OrganizationScope limits support request queries to a single organization. The zero value
+// is unscoped and sees the support requests of every organization, which only platform staff get.
+type OrganizationScope struct {
+	OrganizationID *uint
+}
+
+// ScopeToOrganization returns a scope limited to the organization with the given ID
+func ScopeToOrganization(organizationID uint) OrganizationScope {
+	return OrganizationScope{OrganizationID: &organizationID}
+}
+
+// IsUnscoped reports whether the scope sees every organization
+func (s OrganizationScope) IsUnscoped() bool {
+	return s.OrganizationID == nil
+}
+
+// Allows reports whether data owned by organizationID (nil for no organization) is visible in the scope
+func (s OrganizationScope) Allows(organizationID *uint) bool {
+	if s.IsUnscoped() {
+		return true
+	}
+	return organizationID != nil && *organizationID == *s.OrganizationID
+}
+
+// CreateOrganizationRequest represents the payload for creating an organization
+// @Description Request payload for creating an organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Acme Corp"` // Organization name
+}
+
+// UpdateOrganizationRequest represents the payload for renaming an organization
+// @Description Request payload for updating an organization
+type UpdateOrganizationRequest struct {
+	Name *string `json:"name,omitempty" binding:"omitempty,min=1,max=100" example:"Acme Inc"` // New organization name
+}
+
+// SetOrganizationMemberRequest represents the payload for adding a member or changing their role
+// @Description Request payload for adding or updating an organization member
+type SetOrganizationMemberRequest struct {
+	Role UserRole `json:"role" binding:"required,max=50" example:"agent"` // Built-in role or name of a custom role within the organization
+}
+
+// AddOrganizationAppRequest represents the payload for giving an organization ownership of an app
+// @Description Request payload for adding an app to an organization
+type AddOrganizationAppRequest struct {
+	App string `json:"app" binding:"required,max=100" example:"my-awesome-app"` // App name as sent in support requests
+}
+
+// OrganizationResponse represents the API response for organizations
+// @Description Organization details
+type OrganizationResponse struct {
+	ID        uint      `json:"id" example:"1"`                            // Organization ID
+	Name      string    `json:"name" example:"Acme Corp"`                  // Organization name
+	CreatedAt time.Time `json:"created_at" example:"2023-12-01T10:00:00Z"` // Creation timestamp
+	UpdatedAt time.Time `json:"updated_at" example:"2023-12-01T10:00:00Z"` // Last update timestamp
+}
+
+// OrganizationMemberResponse represents the API response for organization members
+// @Description Organization member details
+type OrganizationMemberResponse struct {
+	OrganizationID uint      `json:"organization_id" example:"1"`               // Organization ID
+	UserID         uint      `json:"user_id" example:"2"`                       // User ID
+	Username       string    `json:"username,omitempty" example:"jane"`         // Username of the member
+	Role           UserRole  `json:"role" example:"agent"`                      // Role within the organization
+	CreatedAt      time.Time `json:"created_at" example:"2023-12-01T10:00:00Z"` // Time the user joined the organization
+}
+
+// UserOrganizationResponse represents an organization the current user belongs to
+// @Description Organization membership of the current user
+type UserOrganizationResponse struct {
+	ID   uint     `json:"id" example:"1"`           // Organization ID, to send as X-Organization-ID
+	Name string   `json:"name" example:"Acme Corp"` // Organization name
+	Role UserRole `json:"role" example:"agent"`     // Role within the organization
+}
+
+// ToResponse converts Organization to OrganizationResponse
+func (o *Organization) ToResponse() *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:        o.ID,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+// ToResponse converts OrganizationMember to OrganizationMemberResponse
+func (m *OrganizationMember) ToResponse() *OrganizationMemberResponse {
+	response := &OrganizationMemberResponse{
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Role:           m.Role,
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.User != nil {
+		response.Username = m.User.Username
+	}
+	return response
+}
+
+// TableName returns the table name for GORM
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// TableName returns the table name for GORM
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single-use, time-limited token mailed to a user who forgot their password
+type PasswordResetToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;size:64;uniqueIndex"` // SHA-256 of the token, the token itself is never stored
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // Set when the token was redeemed or superseded by a newer one
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsUsed reports whether the token can no longer be redeemed
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired reports whether the token has expired at now
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// TableName returns the table name for GORM
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// PasswordResetRequest represents the request payload for starting a password reset
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email" example:"admin@example.com"`
+}
+
+// PasswordResetConfirmRequest represents the request payload for setting a new password with a reset token
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" binding:"required" example:"q3Jx0v7mJ8Qe0bq6m0mB4S2b1pWQ0C1a9i3mPp1n2xw"`
+	NewPassword string `json:"new_password" binding:"required,min=8" example:"newsecurepassword123"`
+}
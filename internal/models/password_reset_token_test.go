@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetToken_State(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	token := &PasswordResetToken{ExpiresAt: now.Add(time.Hour)}
+
+	// Assert
+	assert.False(t, token.IsUsed())
+	assert.False(t, token.IsExpired(now))
+	assert.True(t, token.IsExpired(now.Add(time.Hour)))
+
+	token.UsedAt = &now
+	assert.True(t, token.IsUsed())
+}
+
+func TestPasswordResetToken_TableName(t *testing.T) {
+	assert.Equal(t, "password_reset_tokens", PasswordResetToken{}.TableName())
+}
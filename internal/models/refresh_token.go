@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// RefreshToken is a single-use token exchanged for a new access token. Tokens issued from one login form a
+// family: each refresh marks the presented token as used and issues the next one in the same family, so
+// presenting a used token again reveals that it was stolen and the whole family is revoked.
+type RefreshToken struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	UserID        uint       `json:"user_id" gorm:"not null;index"`
+	FamilyID      string     `json:"family_id" gorm:"not null;size:64;index"`
+	TokenHash     string     `json:"-" gorm:"not null;size:64;uniqueIndex"`               // SHA-256 of the token, the token itself is never stored
+	AccessTokenID string     `json:"access_token_id" gorm:"not null;size:64;uniqueIndex"` // jti of the access token issued with this refresh token
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt        *time.Time `json:"used_at,omitempty"`    // Set when the token was exchanged
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"` // Set on logout or reuse detection
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// IsRevoked reports whether the token's family has been revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsUsed reports whether the token has already been exchanged for a new one
+func (t *RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired reports whether the token has expired at now
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// TableName returns the table name for GORM
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
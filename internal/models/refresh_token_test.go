@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshToken_State(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	token := &RefreshToken{ExpiresAt: now.Add(time.Hour)}
+
+	// Assert
+	assert.False(t, token.IsUsed())
+	assert.False(t, token.IsRevoked())
+	assert.False(t, token.IsExpired(now))
+	assert.True(t, token.IsExpired(now.Add(time.Hour)))
+
+	token.UsedAt = &now
+	token.RevokedAt = &now
+	assert.True(t, token.IsUsed())
+	assert.True(t, token.IsRevoked())
+}
+
+func TestRefreshToken_TableName(t *testing.T) {
+	assert.Equal(t, "refresh_tokens", RefreshToken{}.TableName())
+}
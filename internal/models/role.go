@@ -0,0 +1,167 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Permission names an action a role allows, as "<resource>:<action>"
+type Permission string
+
+const (
+	PermissionTicketsRead         Permission = "tickets:read"         // View support requests, their conversation and attachments
+	PermissionTicketsUpdate       Permission = "tickets:update"       // Change, reply to, assign and tag support requests
+	PermissionTicketsDelete       Permission = "tickets:delete"       // Delete support requests
+	PermissionTagsManage          Permission = "tags:manage"          // Create, rename and delete tags
+	PermissionUsersManage         Permission = "users:manage"         // Manage user accounts and roles
+	PermissionWebhooksManage      Permission = "webhooks:manage"      // Manage webhooks and their delivery log
+	PermissionAuditRead           Permission = "audit:read"           // Read the audit log
+	PermissionOrganizationsManage Permission = "organizations:manage" // Manage organizations, their members and apps
+	PermissionAppsManage          Permission = "apps:manage"          // Register apps and issue their intake keys
+	PermissionStatsRead           Permission = "stats:read"           // View support volume and response time statistics
+)
+
+// Permissions lists every permission a role can grant
+var Permissions = []Permission{
+	PermissionTicketsRead,
+	PermissionTicketsUpdate,
+	PermissionTicketsDelete,
+	PermissionTagsManage,
+	PermissionUsersManage,
+	PermissionWebhooksManage,
+	PermissionAuditRead,
+	PermissionOrganizationsManage,
+	PermissionAppsManage,
+	PermissionStatsRead,
+}
+
+// IsValid reports whether p is a known permission
+func (p Permission) IsValid() bool {
+	for _, permission := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltinRolePermissions holds the permissions of the built-in roles, which cannot be changed
+var BuiltinRolePermissions = map[UserRole][]Permission{
+	UserRoleAdmin:  Permissions,
+	UserRoleAgent:  {PermissionTicketsRead, PermissionTicketsUpdate},
+	UserRoleViewer: {PermissionTicketsRead},
+	UserRoleUser:   {},
+}
+
+// builtinRoleDescriptions describes the built-in roles in role listings
+var builtinRoleDescriptions = map[UserRole]string{
+	UserRoleAdmin:  "Full access",
+	UserRoleAgent:  "Works support requests",
+	UserRoleViewer: "Read-only access to support requests",
+	UserRoleUser:   "No admin access",
+}
+
+// builtinRoleOrder is the order built-in roles are listed in
+var builtinRoleOrder = []UserRole{UserRoleAdmin, UserRoleAgent, UserRoleViewer, UserRoleUser}
+
+// roleNamePattern restricts custom role names to lowercase labels such as "billing-agent"
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,49}$`)
+
+// IsBuiltin reports whether r is one of the built-in roles
+func (r UserRole) IsBuiltin() bool {
+	_, ok := BuiltinRolePermissions[r]
+	return ok
+}
+
+// IsValidRoleName reports whether name is an acceptable custom role name
+func IsValidRoleName(name string) bool {
+	return roleNamePattern.MatchString(name)
+}
+
+// Role is a custom role defined by an admin in addition to the built-in ones
+type Role struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        UserRole  `json:"name" gorm:"not null;size:50;unique"`
+	Description string    `json:"description" gorm:"size:255"`
+	Permissions string    `json:"-" gorm:"type:text;not null"` // Comma-separated Permission values
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PermissionList returns the permissions the role grants
+func (r *Role) PermissionList() []Permission {
+	permissions := []Permission{}
+	for _, permission := range strings.Split(r.Permissions, ",") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			permissions = append(permissions, Permission(permission))
+		}
+	}
+	return permissions
+}
+
+// SetPermissions replaces the permissions the role grants
+func (r *Role) SetPermissions(permissions []Permission) {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = string(permission)
+	}
+	r.Permissions = strings.Join(names, ",")
+}
+
+// CreateRoleRequest represents the payload for creating a custom role
+// @Description Request payload for creating a custom role
+type CreateRoleRequest struct {
+	Name        string       `json:"name" binding:"required,max=50" example:"billing-agent"`                    // Role name (lowercase letters, digits, '_' and '-')
+	Description string       `json:"description,omitempty" binding:"max=255" example:"Handles billing tickets"` // Optional description
+	Permissions []Permission `json:"permissions" binding:"required" example:"tickets:read,tickets:update"`      // Granted permissions
+}
+
+// UpdateRoleRequest represents the payload for updating a custom role
+// @Description Request payload for updating a custom role
+type UpdateRoleRequest struct {
+	Description *string      `json:"description,omitempty" binding:"omitempty,max=255" example:"Handles billing and refunds"` // New description
+	Permissions []Permission `json:"permissions,omitempty" example:"tickets:read"`                                            // New granted permissions
+}
+
+// RoleResponse represents the API response for roles
+// @Description Role details
+type RoleResponse struct {
+	Name        UserRole     `json:"name" example:"agent"`                                // Role name
+	Description string       `json:"description" example:"Works support requests"`        // Role description
+	Permissions []Permission `json:"permissions" example:"tickets:read,tickets:update"`   // Granted permissions
+	BuiltIn     bool         `json:"built_in" example:"true"`                             // Built-in roles cannot be changed or deleted
+	CreatedAt   *time.Time   `json:"created_at,omitempty" example:"2023-12-01T10:00:00Z"` // Creation timestamp (custom roles only)
+	UpdatedAt   *time.Time   `json:"updated_at,omitempty" example:"2023-12-01T10:00:00Z"` // Last update timestamp (custom roles only)
+}
+
+// ToResponse converts Role to RoleResponse
+func (r *Role) ToResponse() *RoleResponse {
+	createdAt, updatedAt := r.CreatedAt, r.UpdatedAt
+	return &RoleResponse{
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.PermissionList(),
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &updatedAt,
+	}
+}
+
+// BuiltinRoleResponses returns the built-in roles in listing order
+func BuiltinRoleResponses() []*RoleResponse {
+	responses := make([]*RoleResponse, len(builtinRoleOrder))
+	for i, role := range builtinRoleOrder {
+		responses[i] = &RoleResponse{
+			Name:        role,
+			Description: builtinRoleDescriptions[role],
+			Permissions: append([]Permission{}, BuiltinRolePermissions[role]...),
+			BuiltIn:     true,
+		}
+	}
+	return responses
+}
+
+// TableName returns the table name for GORM
+func (Role) TableName() string {
+	return "roles"
+}
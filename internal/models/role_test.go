@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermission_IsValid(t *testing.T) {
+	for _, permission := range Permissions {
+		assert.True(t, permission.IsValid(), permission)
+	}
+	for _, permission := range []Permission{"", "tickets", "tickets:archive", "TICKETS:READ"} {
+		assert.False(t, permission.IsValid(), permission)
+	}
+}
+
+func TestUserRole_IsBuiltin(t *testing.T) {
+	for _, role := range []UserRole{UserRoleAdmin, UserRoleAgent, UserRoleViewer, UserRoleUser} {
+		assert.True(t, role.IsBuiltin(), role)
+	}
+	assert.False(t, UserRole("billing-agent").IsBuiltin())
+}
+
+func TestIsValidRoleName(t *testing.T) {
+	for _, name := range []string{"triage", "billing-agent", "tier_2"} {
+		assert.True(t, IsValidRoleName(name), name)
+	}
+	for _, name := range []string{"", "Triage", "2nd-line", "has space", "a:b"} {
+		assert.False(t, IsValidRoleName(name), name)
+	}
+}
+
+func TestRole_SetPermissions(t *testing.T) {
+	// Arrange
+	role := &Role{Name: "triage"}
+
+	// Act
+	role.SetPermissions([]Permission{PermissionTicketsRead, PermissionTagsManage})
+
+	// Assert
+	assert.Equal(t, "tickets:read,tags:manage", role.Permissions)
+	assert.Equal(t, []Permission{PermissionTicketsRead, PermissionTagsManage}, role.PermissionList())
+}
+
+func TestRole_PermissionListEmpty(t *testing.T) {
+	role := &Role{Name: "triage"}
+
+	assert.NotNil(t, role.PermissionList())
+	assert.Empty(t, role.PermissionList())
+}
+
+func TestRole_ToResponse(t *testing.T) {
+	role := &Role{ID: 1, Name: "triage", Description: "Triages tickets", Permissions: "tickets:read"}
+
+	response := role.ToResponse()
+
+	assert.Equal(t, UserRole("triage"), response.Name)
+	assert.Equal(t, []Permission{PermissionTicketsRead}, response.Permissions)
+	assert.False(t, response.BuiltIn)
+	assert.NotNil(t, response.CreatedAt)
+}
+
+func TestBuiltinRoleResponses(t *testing.T) {
+	responses := BuiltinRoleResponses()
+
+	assert.Len(t, responses, 4)
+	assert.Equal(t, UserRoleAdmin, responses[0].Name)
+	assert.True(t, responses[0].BuiltIn)
+	assert.Nil(t, responses[0].CreatedAt)
+
+	// Changing a response must not change the built-in permissions
+	responses[1].Permissions[0] = PermissionUsersManage
+	assert.Equal(t, PermissionTicketsRead, BuiltinRolePermissions[UserRoleAgent][0])
+}
+
+func TestRole_TableName(t *testing.T) {
+	assert.Equal(t, "roles", Role{}.TableName())
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -23,86 +24,225 @@ const (
 	PlatformIOS     Platform = "iOS"
 	PlatformAndroid Platform = "Android"
 	PlatformWeb     Platform = "Web"
+	PlatformEmail   Platform = "Email" // Received by email rather than submitted by a client app
 )
 
 // Status represents the request status
 type Status string
 
 const (
-	StatusNew        Status = "new"
-	StatusInProgress Status = "in_progress"
-	StatusResolved   Status = "resolved"
+	StatusNew               Status = "new"
+	StatusInProgress        Status = "in_progress"
+	StatusWaitingOnCustomer Status = "waiting_on_customer"
+	StatusResolved          Status = "resolved"
+	StatusClosed            Status = "closed"
+	StatusReopened          Status = "reopened"
+	StatusSpam              Status = "spam"
 )
 
+// Priority represents how urgently a request must be handled
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+// SLAStoppedStatuses lists the statuses in which SLA deadlines no longer apply
+var SLAStoppedStatuses = []Status{StatusResolved, StatusClosed, StatusSpam}
+
+// IsValid reports whether t is a known support request type
+func (t SupportRequestType) IsValid() bool {
+	switch t {
+	case SupportRequestTypeSupport, SupportRequestTypeFeedback, SupportRequestTypeBugReport, SupportRequestTypeFeatureRequest:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether p is a known platform
+func (p Platform) IsValid() bool {
+	switch p {
+	case PlatformIOS, PlatformAndroid, PlatformWeb, PlatformEmail:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusNew, StatusInProgress, StatusWaitingOnCustomer, StatusResolved, StatusClosed, StatusReopened, StatusSpam:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether p is a known priority
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
 // SupportRequest represents a support ticket or feedback request
 type SupportRequest struct {
-	ID          uint               `json:"id" gorm:"primaryKey"`
-	Type        SupportRequestType `json:"type" gorm:"not null" binding:"required,oneof=support feedback bug_report feature_request"`
-	UserEmail   *string            `json:"user_email,omitempty" gorm:"type:varchar(255)"`
-	Message     string             `json:"message" gorm:"not null;type:text" binding:"required"`
-	Platform    Platform           `json:"platform" gorm:"not null" binding:"required,oneof=iOS Android Web"`
-	AppVersion  string             `json:"app_version" gorm:"not null" binding:"required"`
-	DeviceModel string             `json:"device_model" gorm:"not null" binding:"required"`
-	App         string             `json:"app" gorm:"not null" binding:"required"`
-	Status      Status             `json:"status" gorm:"not null;default:new"`
-	AdminNotes  *string            `json:"admin_notes,omitempty" gorm:"type:text"`
-	CreatedAt   time.Time          `json:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt     `json:"-" gorm:"index"`
+	ID                 uint               `json:"id" gorm:"primaryKey"`
+	Type               SupportRequestType `json:"type" gorm:"not null" binding:"required,oneof=support feedback bug_report feature_request"`
+	UserEmail          *string            `json:"user_email,omitempty" gorm:"type:varchar(255)"`
+	Message            string             `json:"message" gorm:"not null;type:text" binding:"required"`
+	Platform           Platform           `json:"platform" gorm:"not null" binding:"required,oneof=iOS Android Web Email"`
+	AppVersion         string             `json:"app_version" gorm:"not null" binding:"required"`
+	DeviceModel        string             `json:"device_model" gorm:"not null" binding:"required"`
+	App                string             `json:"app" gorm:"not null" binding:"required"`
+	OrganizationID     *uint              `json:"organization_id,omitempty" gorm:"index"` // Organization owning App when the request was submitted
+	Organization       *Organization      `json:"-" gorm:"foreignKey:OrganizationID;constraint:OnDelete:SET NULL"`
+	Status             Status             `json:"status" gorm:"not null;default:new"`
+	Priority           Priority           `json:"priority" gorm:"not null;default:normal;index"`
+	AdminNotes         *string            `json:"admin_notes,omitempty" gorm:"type:text"`
+	AssigneeID         *uint              `json:"assignee_id,omitempty" gorm:"index"`
+	Assignee           *User              `json:"-" gorm:"foreignKey:AssigneeID;constraint:OnDelete:SET NULL"`
+	Tags               []*Tag             `json:"-" gorm:"many2many:support_request_tags;constraint:OnDelete:CASCADE"`
+	Attachments        []*Attachment      `json:"-"` // Only set while creating the request, to save its attachments with it
+	FirstResponseDueAt *time.Time         `json:"first_response_due_at,omitempty" gorm:"index"`
+	ResolutionDueAt    *time.Time         `json:"resolution_due_at,omitempty" gorm:"index"`
+	FirstRespondedAt   *time.Time         `json:"first_responded_at,omitempty"`
+	ResolvedAt         *time.Time         `json:"resolved_at,omitempty"`
+	ClosedAt           *time.Time         `json:"closed_at,omitempty"`
+	TrackingTokenHash  *string            `json:"-" gorm:"type:varchar(64);uniqueIndex"`  // SHA-256 of the submitter's tracking token
+	UnsubscribedAt     *time.Time         `json:"unsubscribed_at,omitempty"`              // Time the submitter opted out of email notifications
+	DuplicateOfID      *uint              `json:"duplicate_of_id,omitempty" gorm:"index"` // Earlier request this one is suspected to duplicate
+	MergedIntoID       *uint              `json:"merged_into_id,omitempty" gorm:"index"`  // Request this one was merged into, leaving it as a stub
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt     `json:"-" gorm:"index"`
 }
 
 // CreateSupportRequestRequest represents the payload for creating a support request
 // @Description Request payload for creating a new support request
 type CreateSupportRequestRequest struct {
-	Type        SupportRequestType `json:"type" binding:"required,oneof=support feedback bug_report feature_request" example:"support"`               // Type of request (support, feedback, bug_report, or feature_request)
-	UserEmail   *string            `json:"user_email,omitempty" example:"user@example.com"`                                // Optional user email
-	Message     string             `json:"message" binding:"required" example:"I'm having trouble with the login feature"` // Support request message
-	Platform    Platform           `json:"platform" binding:"required,oneof=iOS Android Web" example:"iOS"`                    // Platform (iOS, Android, or Web)
-	AppVersion  string             `json:"app_version" binding:"required" example:"1.2.3"`                                 // Application version
-	DeviceModel string             `json:"device_model" binding:"required" example:"iPhone 14 Pro"`                        // Device model
-	App         string             `json:"app" binding:"required" example:"my-awesome-app"`                                // Application name
+	Type        SupportRequestType `json:"type" form:"type" binding:"required,oneof=support feedback bug_report feature_request" example:"support"` // Type of request (support, feedback, bug_report, or feature_request)
+	UserEmail   *string            `json:"user_email,omitempty" form:"user_email" example:"user@example.com"`                                       // Optional user email
+	Message     string             `json:"message" form:"message" binding:"required" example:"I'm having trouble with the login feature"`           // Support request message
+	Platform    Platform           `json:"platform" form:"platform" binding:"required,oneof=iOS Android Web" example:"iOS"`                         // Platform (iOS, Android, or Web)
+	AppVersion  string             `json:"app_version" form:"app_version" binding:"required" example:"1.2.3"`                                       // Application version
+	DeviceModel string             `json:"device_model" form:"device_model" binding:"required" example:"iPhone 14 Pro"`                             // Device model
+	App         string             `json:"app" form:"app" binding:"required" example:"my-awesome-app"`                                              // Application name
 }
 
 // UpdateSupportRequestRequest represents the payload for updating a support request
 // @Description Request payload for updating support request status and admin notes
 type UpdateSupportRequestRequest struct {
-	Status     *Status `json:"status,omitempty" binding:"omitempty,oneof=new in_progress resolved" example:"in_progress"` // New status
-	AdminNotes *string `json:"admin_notes,omitempty" example:"Contacted user for more details"`                           // Admin notes
+	Status     *Status   `json:"status,omitempty" binding:"omitempty,oneof=new in_progress waiting_on_customer resolved closed reopened spam" example:"in_progress"` // New status, must be an allowed transition from the current one
+	Priority   *Priority `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent" example:"high"`                                                 // New priority, recalculates the SLA deadlines
+	AdminNotes *string   `json:"admin_notes,omitempty" example:"Contacted user for more details"`                                                                    // Admin notes
+}
+
+// AssignSupportRequestRequest represents the payload for assigning a support request to an agent
+// @Description Request payload for assigning a support request
+type AssignSupportRequestRequest struct {
+	AssigneeID uint `json:"assignee_id" binding:"required" example:"2"` // ID of the active user to assign
+}
+
+// MergeSupportRequestRequest represents the payload for merging a support request into another
+// @Description Request payload for merging a support request into another
+type MergeSupportRequestRequest struct {
+	TargetID uint `json:"target_id" binding:"required" example:"41"` // ID of the request that receives the messages, tags and attachments
 }
 
 // SupportRequestResponse represents the API response for support requests
 // @Description Support request response with all details
 type SupportRequestResponse struct {
-	ID          uint               `json:"id" example:"1"`                                                  // Support request ID
-	Type        SupportRequestType `json:"type" example:"support"`                                          // Type of request
-	UserEmail   *string            `json:"user_email,omitempty" example:"user@example.com"`                 // User email (optional)
-	Message     string             `json:"message" example:"I'm having trouble with the login feature"`     // Support request message
-	Platform    Platform           `json:"platform" example:"iOS"`                                          // Platform (iOS, Android, or Web)
-	AppVersion  string             `json:"app_version" example:"1.2.3"`                                     // Application version
-	DeviceModel string             `json:"device_model" example:"iPhone 14 Pro"`                            // Device model
-	App         string             `json:"app" example:"my-awesome-app"`                                    // Application name
-	Status      Status             `json:"status" example:"new"`                                            // Current status
-	AdminNotes  *string            `json:"admin_notes,omitempty" example:"Contacted user for more details"` // Admin notes (optional)
-	CreatedAt   time.Time          `json:"created_at" example:"2023-12-01T10:00:00Z"`                       // Creation timestamp
-	UpdatedAt   time.Time          `json:"updated_at" example:"2023-12-01T10:00:00Z"`                       // Last update timestamp
+	ID                 uint                  `json:"id" example:"1"`                                                          // Support request ID
+	Type               SupportRequestType    `json:"type" example:"support"`                                                  // Type of request
+	UserEmail          *string               `json:"user_email,omitempty" example:"user@example.com"`                         // User email (optional)
+	Message            string                `json:"message" example:"I'm having trouble with the login feature"`             // Support request message
+	Platform           Platform              `json:"platform" example:"iOS"`                                                  // Platform (iOS, Android, Web, or Email)
+	AppVersion         string                `json:"app_version" example:"1.2.3"`                                             // Application version
+	DeviceModel        string                `json:"device_model" example:"iPhone 14 Pro"`                                    // Device model
+	App                string                `json:"app" example:"my-awesome-app"`                                            // Application name
+	OrganizationID     *uint                 `json:"organization_id,omitempty" example:"1"`                                   // ID of the organization owning the app (optional)
+	Status             Status                `json:"status" example:"new"`                                                    // Current status
+	Priority           Priority              `json:"priority" example:"normal"`                                               // Priority (low, normal, high, urgent)
+	AdminNotes         *string               `json:"admin_notes,omitempty" example:"Contacted user for more details"`         // Admin notes (optional)
+	AssigneeID         *uint                 `json:"assignee_id,omitempty" example:"2"`                                       // ID of the agent working the request (optional)
+	Tags               []string              `json:"tags,omitempty" example:"payments,login"`                                 // Names of the tags on the request
+	FirstResponseDueAt *time.Time            `json:"first_response_due_at,omitempty" example:"2023-12-02T10:00:00Z"`          // Deadline for the first public agent reply
+	ResolutionDueAt    *time.Time            `json:"resolution_due_at,omitempty" example:"2023-12-04T10:00:00Z"`              // Deadline for resolving the request
+	FirstRespondedAt   *time.Time            `json:"first_responded_at,omitempty" example:"2023-12-01T12:00:00Z"`             // Time of the first public agent reply
+	ResolvedAt         *time.Time            `json:"resolved_at,omitempty" example:"2023-12-02T09:00:00Z"`                    // Time the request was last resolved
+	ClosedAt           *time.Time            `json:"closed_at,omitempty" example:"2023-12-09T09:00:00Z"`                      // Time the request was closed
+	UnsubscribedAt     *time.Time            `json:"unsubscribed_at,omitempty" example:"2023-12-03T08:00:00Z"`                // Time the submitter opted out of email notifications
+	DuplicateOfID      *uint                 `json:"duplicate_of_id,omitempty" example:"41"`                                  // Earlier request this one is suspected to duplicate (optional)
+	MergedIntoID       *uint                 `json:"merged_into_id,omitempty" example:"41"`                                   // Request this one was merged into (optional)
+	CreatedAt          time.Time             `json:"created_at" example:"2023-12-01T10:00:00Z"`                               // Creation timestamp
+	UpdatedAt          time.Time             `json:"updated_at" example:"2023-12-01T10:00:00Z"`                               // Last update timestamp
+	Attachments        []*AttachmentResponse `json:"attachments,omitempty"`                                                   // Uploaded attachments (only returned on creation)
+	TrackingToken      string                `json:"tracking_token,omitempty" example:"trk_8fJq2mVxR0c4T1yNa7LpWsKe3Ud9HbZo"` // Token for following the request on the tracking page (only returned on creation)
+}
+
+// SupportRequestSearchResult represents a support request matched by a full-text search
+// @Description Support request search hit with relevance rank and highlighted snippet
+type SupportRequestSearchResult struct {
+	SupportRequestResponse
+	Rank    float64 `json:"rank" example:"0.0607927"`                                              // Relevance score, higher is better
+	Snippet string  `json:"snippet" example:"The app <mark>crashes</mark> on <mark>launch</mark>"` // Matching excerpt with highlighted terms
 }
 
 // ToResponse converts SupportRequest to SupportRequestResponse
 func (sr *SupportRequest) ToResponse() *SupportRequestResponse {
 	return &SupportRequestResponse{
-		ID:          sr.ID,
-		Type:        sr.Type,
-		UserEmail:   sr.UserEmail,
-		Message:     sr.Message,
-		Platform:    sr.Platform,
-		AppVersion:  sr.AppVersion,
-		DeviceModel: sr.DeviceModel,
-		App:         sr.App,
-		Status:      sr.Status,
-		AdminNotes:  sr.AdminNotes,
-		CreatedAt:   sr.CreatedAt,
-		UpdatedAt:   sr.UpdatedAt,
+		ID:                 sr.ID,
+		Type:               sr.Type,
+		UserEmail:          sr.UserEmail,
+		Message:            sr.Message,
+		Platform:           sr.Platform,
+		AppVersion:         sr.AppVersion,
+		DeviceModel:        sr.DeviceModel,
+		App:                sr.App,
+		OrganizationID:     sr.OrganizationID,
+		Status:             sr.Status,
+		Priority:           sr.Priority,
+		AdminNotes:         sr.AdminNotes,
+		AssigneeID:         sr.AssigneeID,
+		Tags:               tagNames(sr.Tags),
+		FirstResponseDueAt: sr.FirstResponseDueAt,
+		ResolutionDueAt:    sr.ResolutionDueAt,
+		FirstRespondedAt:   sr.FirstRespondedAt,
+		ResolvedAt:         sr.ResolvedAt,
+		ClosedAt:           sr.ClosedAt,
+		UnsubscribedAt:     sr.UnsubscribedAt,
+		DuplicateOfID:      sr.DuplicateOfID,
+		MergedIntoID:       sr.MergedIntoID,
+		CreatedAt:          sr.CreatedAt,
+		UpdatedAt:          sr.UpdatedAt,
+	}
+}
+
+// IsMerged reports whether the request was merged into another and is only kept as a stub
+func (sr *SupportRequest) IsMerged() bool {
+	return sr.MergedIntoID != nil
+}
+
+// SupportRequestReference returns the reference submitters see for a support request, such as SR-42
+func SupportRequestReference(id uint) string {
+	return fmt.Sprintf("SR-%d", id)
+}
+
+// tagNames returns the names of tags, or nil when there are none
+func tagNames(tags []*Tag) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
 	}
+	return names
 }
 
 // TableName returns the table name for GORM
@@ -0,0 +1,53 @@
+package models
+
+// BulkOutcome is what a bulk action did, or in a dry run would do, to a single support request
+type BulkOutcome string
+
+const (
+	BulkOutcomeUpdated   BulkOutcome = "updated"
+	BulkOutcomeUnchanged BulkOutcome = "unchanged" // The request already had every requested value
+	BulkOutcomeDeleted   BulkOutcome = "deleted"
+	BulkOutcomeFailed    BulkOutcome = "failed" // The change is not allowed for this request; others are still applied
+)
+
+// BulkUpdateSupportRequestsRequest represents the payload for changing many support requests at once
+// @Description Request payload for bulk updating support requests selected by ID or by filter
+type BulkUpdateSupportRequestsRequest struct {
+	IDs        []uint    `json:"ids,omitempty" example:"12,15,18"`                                                                                            // Support requests to change, or use filter
+	Filter     string    `json:"filter,omitempty" example:"status=new&app=my-awesome-app&created_after=2025-06-01"`                                           // List endpoint query parameters selecting the support requests, or use ids
+	Status     *Status   `json:"status,omitempty" binding:"omitempty,oneof=new in_progress waiting_on_customer resolved closed reopened spam" example:"spam"` // New status, must be an allowed transition from each request's current one
+	Priority   *Priority `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent" example:"low"`                                           // New priority, recalculates the SLA deadlines
+	AssigneeID *uint     `json:"assignee_id,omitempty" example:"2"`                                                                                           // ID of the active user to assign
+	Unassign   bool      `json:"unassign,omitempty" example:"false"`                                                                                          // Remove the assignee, cannot be combined with assignee_id
+	AddTags    []string  `json:"add_tags,omitempty" example:"spam-wave"`                                                                                      // Names of existing tags to add
+	RemoveTags []string  `json:"remove_tags,omitempty" example:"needs-triage"`                                                                                // Names of existing tags to remove
+	DryRun     bool      `json:"dry_run,omitempty" example:"true"`                                                                                            // Report what would change without changing anything
+}
+
+// BulkDeleteSupportRequestsRequest represents the payload for deleting many support requests at once
+// @Description Request payload for bulk deleting support requests selected by ID or by filter
+type BulkDeleteSupportRequestsRequest struct {
+	IDs    []uint `json:"ids,omitempty" example:"12,15,18"`                                 // Support requests to delete, or use filter
+	Filter string `json:"filter,omitempty" example:"status=spam&created_before=2025-06-01"` // List endpoint query parameters selecting the support requests, or use ids
+	DryRun bool   `json:"dry_run,omitempty" example:"true"`                                 // Report what would be deleted without deleting anything
+}
+
+// BulkSupportRequestResult reports the outcome of a bulk action for one support request
+// @Description Outcome of a bulk action for a single support request
+type BulkSupportRequestResult struct {
+	ID      uint                   `json:"id" example:"12"`                                                    // Support request ID
+	Outcome BulkOutcome            `json:"outcome" example:"updated"`                                          // updated, unchanged, deleted or failed
+	Changes map[string]AuditChange `json:"changes,omitempty"`                                                  // Changed fields with their before and after values
+	Error   string                 `json:"error,omitempty" example:"cannot change status from closed to spam"` // Why the request was left unchanged, when failed
+}
+
+// BulkSupportRequestsResponse represents the API response of a bulk action
+// @Description Per support request outcomes of a bulk action
+type BulkSupportRequestsResponse struct {
+	DryRun    bool                        `json:"dry_run" example:"false"` // Whether nothing was actually changed
+	Matched   int                         `json:"matched" example:"3"`     // Support requests selected
+	Changed   int                         `json:"changed" example:"2"`     // Support requests updated or deleted
+	Unchanged int                         `json:"unchanged" example:"0"`   // Support requests that already had the requested values
+	Failed    int                         `json:"failed" example:"1"`      // Support requests the change could not be applied to
+	Results   []*BulkSupportRequestResult `json:"results"`                 // Outcome per support request, in selection order
+}
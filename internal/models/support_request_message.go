@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MessageAuthorType represents who wrote a support request message
+type MessageAuthorType string
+
+const (
+	MessageAuthorAgent     MessageAuthorType = "agent"
+	MessageAuthorSubmitter MessageAuthorType = "submitter"
+)
+
+// MessageVisibility controls whether a message is shown to the submitter
+type MessageVisibility string
+
+const (
+	MessageVisibilityPublic   MessageVisibility = "public"
+	MessageVisibilityInternal MessageVisibility = "internal"
+)
+
+// SupportRequestMessage represents a reply in a support request conversation
+type SupportRequestMessage struct {
+	ID               uint              `json:"id" gorm:"primaryKey"`
+	SupportRequestID uint              `json:"support_request_id" gorm:"not null;index"`
+	AuthorType       MessageAuthorType `json:"author_type" gorm:"not null;size:20"`
+	AuthorUserID     *uint             `json:"author_user_id,omitempty" gorm:"index"`
+	Body             string            `json:"body" gorm:"not null;type:text"`
+	Visibility       MessageVisibility `json:"visibility" gorm:"not null;size:20;default:public"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt    `json:"-" gorm:"index"`
+}
+
+// CreateSupportRequestMessageRequest represents the payload for replying to a support request
+// @Description Request payload for posting a reply to a support request
+type CreateSupportRequestMessageRequest struct {
+	Body       string             `json:"body" binding:"required,max=10000" example:"Could you send us a screenshot of the error?"` // Message body
+	Visibility *MessageVisibility `json:"visibility,omitempty" binding:"omitempty,oneof=public internal" example:"public"`          // Visibility (public or internal, defaults to public)
+}
+
+// SupportRequestMessageResponse represents the API response for support request messages
+// @Description Support request conversation message
+type SupportRequestMessageResponse struct {
+	ID               uint              `json:"id" example:"1"`                                              // Message ID
+	SupportRequestID uint              `json:"support_request_id" example:"1"`                              // Support request ID
+	AuthorType       MessageAuthorType `json:"author_type" example:"agent"`                                 // Author type (agent or submitter)
+	AuthorUserID     *uint             `json:"author_user_id,omitempty" example:"1"`                        // Author user ID (agents only)
+	Body             string            `json:"body" example:"Could you send us a screenshot of the error?"` // Message body
+	Visibility       MessageVisibility `json:"visibility" example:"public"`                                 // Visibility (public or internal)
+	CreatedAt        time.Time         `json:"created_at" example:"2023-12-01T10:00:00Z"`                   // Creation timestamp
+	UpdatedAt        time.Time         `json:"updated_at" example:"2023-12-01T10:00:00Z"`                   // Last update timestamp
+}
+
+// ToResponse converts SupportRequestMessage to SupportRequestMessageResponse
+func (m *SupportRequestMessage) ToResponse() *SupportRequestMessageResponse {
+	return &SupportRequestMessageResponse{
+		ID:               m.ID,
+		SupportRequestID: m.SupportRequestID,
+		AuthorType:       m.AuthorType,
+		AuthorUserID:     m.AuthorUserID,
+		Body:             m.Body,
+		Visibility:       m.Visibility,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// IsInternal reports whether the message is hidden from the submitter
+func (m *SupportRequestMessage) IsInternal() bool {
+	return m.Visibility == MessageVisibilityInternal
+}
+
+// TableName returns the table name for GORM
+func (SupportRequestMessage) TableName() string {
+	return "support_request_messages"
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportRequestMessage_ToResponse(t *testing.T) {
+	// Arrange
+	authorID := uint(3)
+	now := time.Now()
+	message := &SupportRequestMessage{
+		ID:               1,
+		SupportRequestID: 2,
+		AuthorType:       MessageAuthorAgent,
+		AuthorUserID:     &authorID,
+		Body:             "Could you send a screenshot?",
+		Visibility:       MessageVisibilityPublic,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	// Act
+	response := message.ToResponse()
+
+	// Assert
+	assert.Equal(t, message.ID, response.ID)
+	assert.Equal(t, message.SupportRequestID, response.SupportRequestID)
+	assert.Equal(t, message.AuthorType, response.AuthorType)
+	assert.Equal(t, message.AuthorUserID, response.AuthorUserID)
+	assert.Equal(t, message.Body, response.Body)
+	assert.Equal(t, message.Visibility, response.Visibility)
+	assert.Equal(t, message.CreatedAt, response.CreatedAt)
+	assert.Equal(t, message.UpdatedAt, response.UpdatedAt)
+}
+
+func TestSupportRequestMessage_IsInternal(t *testing.T) {
+	assert.True(t, (&SupportRequestMessage{Visibility: MessageVisibilityInternal}).IsInternal())
+	assert.False(t, (&SupportRequestMessage{Visibility: MessageVisibilityPublic}).IsInternal())
+}
+
+func TestSupportRequestMessage_TableName_ReturnsCorrectName(t *testing.T) {
+	assert.Equal(t, "support_request_messages", SupportRequestMessage{}.TableName())
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Time bucket sizes of support request statistics
+const (
+	StatsIntervalDay   = "day"
+	StatsIntervalWeek  = "week" // Weeks start on Monday
+	StatsIntervalMonth = "month"
+)
+
+// IsValidStatsInterval reports whether interval is a known time bucket size
+func IsValidStatsInterval(interval string) bool {
+	switch interval {
+	case StatsIntervalDay, StatsIntervalWeek, StatsIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// SupportRequestCount is the number of support requests sharing a value
+// @Description Number of support requests with a value
+type SupportRequestCount struct {
+	Value string `json:"value" example:"new"` // Grouped value, such as a status
+	Count int64  `json:"count" example:"42"`  // Number of support requests
+}
+
+// AppVersionCount is the number of support requests for one version of an app
+// @Description Number of support requests for an app version
+type AppVersionCount struct {
+	App        string `json:"app" example:"my-awesome-app"` // App name
+	AppVersion string `json:"app_version" example:"2.1.0"`  // App version
+	Count      int64  `json:"count" example:"12"`           // Number of support requests
+}
+
+// SupportRequestBucket is the number of support requests created in one time bucket
+// @Description Number of support requests created in a time bucket
+type SupportRequestBucket struct {
+	Start string `json:"start" example:"2025-06-09"` // First day of the bucket (UTC, YYYY-MM-DD)
+	Count int64  `json:"count" example:"7"`          // Number of support requests created in the bucket
+}
+
+// SupportRequestStatsResponse represents the API response for support request statistics
+// @Description Support request volume and response times over a date range
+type SupportRequestStatsResponse struct {
+	From                       time.Time              `json:"from" example:"2025-06-01T00:00:00Z"` // Start of the range (inclusive)
+	To                         time.Time              `json:"to" example:"2025-07-01T00:00:00Z"`   // End of the range (exclusive)
+	Interval                   string                 `json:"interval" example:"week"`             // Size of the series buckets
+	Total                      int64                  `json:"total" example:"120"`                 // Support requests created in the range
+	ByStatus                   []SupportRequestCount  `json:"by_status"`                           // Counts per status, largest first
+	ByType                     []SupportRequestCount  `json:"by_type"`                             // Counts per type, largest first
+	ByPlatform                 []SupportRequestCount  `json:"by_platform"`                         // Counts per platform, largest first
+	ByApp                      []SupportRequestCount  `json:"by_app"`                              // Counts per app, largest first
+	ByAppVersion               []AppVersionCount      `json:"by_app_version"`                      // Counts per app and version, largest first
+	Series                     []SupportRequestBucket `json:"series"`                              // Counts per bucket, oldest first, including empty buckets
+	MedianFirstResponseSeconds *float64               `json:"median_first_response_seconds"`       // Median time from creation to the first agent reply, null without replies
+	MedianResolutionSeconds    *float64               `json:"median_resolution_seconds"`           // Median time from creation to resolution, null without resolved requests
+}
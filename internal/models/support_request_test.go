@@ -11,6 +11,7 @@ func TestSupportRequest_ToResponse_CompleteRequest(t *testing.T) {
 	// Arrange
 	userEmail := "test@example.com"
 	adminNotes := "Test admin notes"
+	assigneeID := uint(2)
 	now := time.Now()
 
 	supportRequest := &SupportRequest{
@@ -24,6 +25,7 @@ func TestSupportRequest_ToResponse_CompleteRequest(t *testing.T) {
 		Status:      StatusNew,
 		AdminNotes:  &adminNotes,
 		App:         "my-awesome-app", // Add app field
+		AssigneeID:  &assigneeID,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -42,6 +44,7 @@ func TestSupportRequest_ToResponse_CompleteRequest(t *testing.T) {
 	assert.Equal(t, supportRequest.Status, response.Status)
 	assert.Equal(t, supportRequest.AdminNotes, response.AdminNotes)
 	assert.Equal(t, supportRequest.App, response.App) // Test app field
+	assert.Equal(t, supportRequest.AssigneeID, response.AssigneeID)
 	assert.Equal(t, supportRequest.CreatedAt, response.CreatedAt)
 	assert.Equal(t, supportRequest.UpdatedAt, response.UpdatedAt)
 }
@@ -105,12 +108,14 @@ func TestPlatform_Constants_ValidValues(t *testing.T) {
 	assert.Equal(t, Platform("iOS"), PlatformIOS)
 	assert.Equal(t, Platform("Android"), PlatformAndroid)
 	assert.Equal(t, Platform("Web"), PlatformWeb)
+	assert.Equal(t, Platform("Email"), PlatformEmail)
 }
 
 func TestPlatform_Constants_StringValues(t *testing.T) {
 	assert.Equal(t, "iOS", string(PlatformIOS))
 	assert.Equal(t, "Android", string(PlatformAndroid))
 	assert.Equal(t, "Web", string(PlatformWeb))
+	assert.Equal(t, "Email", string(PlatformEmail))
 }
 
 func TestStatus_Constants_ValidValues(t *testing.T) {
@@ -123,6 +128,17 @@ func TestStatus_Constants_StringValues(t *testing.T) {
 	assert.Equal(t, "new", string(StatusNew))
 	assert.Equal(t, "in_progress", string(StatusInProgress))
 	assert.Equal(t, "resolved", string(StatusResolved))
+	assert.Equal(t, "waiting_on_customer", string(StatusWaitingOnCustomer))
+	assert.Equal(t, "closed", string(StatusClosed))
+	assert.Equal(t, "reopened", string(StatusReopened))
+	assert.Equal(t, "spam", string(StatusSpam))
+}
+
+func TestStatus_IsValid(t *testing.T) {
+	for _, status := range []Status{StatusNew, StatusInProgress, StatusWaitingOnCustomer, StatusResolved, StatusClosed, StatusReopened, StatusSpam} {
+		assert.True(t, status.IsValid(), string(status))
+	}
+	assert.False(t, Status("cancelled").IsValid())
 }
 
 // Additional validation and edge case tests
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SupportRequestTrackingResponse represents what the submitter of a support request sees on the tracking page
+// @Description Status and public replies of a support request, looked up by its tracking token
+type SupportRequestTrackingResponse struct {
+	ID         uint                           `json:"id" example:"1"`                                       // Support request ID
+	Type       SupportRequestType             `json:"type" example:"support"`                               // Type of request
+	App        string                         `json:"app" example:"my-awesome-app"`                         // Application name
+	UserEmail  *string                        `json:"user_email,omitempty" example:"u***@example.com"`      // Redacted submitter email (optional)
+	Status     Status                         `json:"status" example:"waiting_on_customer"`                 // Current status
+	ResolvedAt *time.Time                     `json:"resolved_at,omitempty" example:"2023-12-02T09:00:00Z"` // Time the request was last resolved
+	ClosedAt   *time.Time                     `json:"closed_at,omitempty" example:"2023-12-09T09:00:00Z"`   // Time the request was closed
+	CreatedAt  time.Time                      `json:"created_at" example:"2023-12-01T10:00:00Z"`            // Creation timestamp
+	UpdatedAt  time.Time                      `json:"updated_at" example:"2023-12-01T12:00:00Z"`            // Last update timestamp
+	Replies    []*SupportRequestTrackingReply `json:"replies"`                                              // Public conversation, oldest first
+}
+
+// SupportRequestTrackingReply represents a public message on the tracking page. Agents are not identified.
+// @Description Public reply shown to the submitter of a support request
+type SupportRequestTrackingReply struct {
+	AuthorType MessageAuthorType `json:"author_type" example:"agent"`                                 // Author type (agent or submitter)
+	Body       string            `json:"body" example:"Could you send us a screenshot of the error?"` // Message body
+	CreatedAt  time.Time         `json:"created_at" example:"2023-12-01T12:00:00Z"`                   // Creation timestamp
+}
+
+// ToTrackingResponse converts SupportRequest to SupportRequestTrackingResponse with the given public messages
+func (sr *SupportRequest) ToTrackingResponse(messages []*SupportRequestMessage) *SupportRequestTrackingResponse {
+	replies := make([]*SupportRequestTrackingReply, 0, len(messages))
+	for _, message := range messages {
+		if message.IsInternal() {
+			continue
+		}
+		replies = append(replies, &SupportRequestTrackingReply{
+			AuthorType: message.AuthorType,
+			Body:       message.Body,
+			CreatedAt:  message.CreatedAt,
+		})
+	}
+
+	return &SupportRequestTrackingResponse{
+		ID:         sr.ID,
+		Type:       sr.Type,
+		App:        sr.App,
+		UserEmail:  RedactEmail(sr.UserEmail),
+		Status:     sr.Status,
+		ResolvedAt: sr.ResolvedAt,
+		ClosedAt:   sr.ClosedAt,
+		CreatedAt:  sr.CreatedAt,
+		UpdatedAt:  sr.UpdatedAt,
+		Replies:    replies,
+	}
+}
+
+// RedactEmail masks a submitter email for callers who are not signed in, keeping the first character
+// of the local part and the domain: "jane.doe@example.com" becomes "j***@example.com".
+func RedactEmail(email *string) *string {
+	if email == nil {
+		return nil
+	}
+
+	redacted := "***"
+	if at := strings.LastIndex(*email, "@"); at > 0 {
+		redacted = (*email)[:1] + "***" + (*email)[at:]
+	}
+	return &redacted
+}
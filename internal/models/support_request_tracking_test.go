@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportRequest_ToTrackingResponse(t *testing.T) {
+	// Arrange
+	email := "jane.doe@example.com"
+	notes := "Refund approved"
+	agentID := uint(3)
+	request := &SupportRequest{ID: 7, Type: SupportRequestTypeSupport, App: "test-app", UserEmail: &email, AdminNotes: &notes, Status: StatusInProgress}
+	messages := []*SupportRequestMessage{
+		{ID: 1, AuthorType: MessageAuthorAgent, AuthorUserID: &agentID, Body: "Looking into it", Visibility: MessageVisibilityPublic},
+		{ID: 2, AuthorType: MessageAuthorAgent, AuthorUserID: &agentID, Body: "Likely a duplicate", Visibility: MessageVisibilityInternal},
+	}
+
+	// Act
+	response := request.ToTrackingResponse(messages)
+
+	// Assert
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, StatusInProgress, response.Status)
+	assert.Equal(t, "j***@example.com", *response.UserEmail)
+	assert.Len(t, response.Replies, 1)
+	assert.Equal(t, "Looking into it", response.Replies[0].Body)
+	assert.Equal(t, "jane.doe@example.com", email, "the request is not modified")
+}
+
+func TestRedactEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    *string
+		expected *string
+	}{
+		{"no email", nil, nil},
+		{"email", stringPtr("jane.doe@example.com"), stringPtr("j***@example.com")},
+		{"single character local part", stringPtr("j@example.com"), stringPtr("j***@example.com")},
+		{"not an email", stringPtr("jane.doe"), stringPtr("***")},
+		{"empty local part", stringPtr("@example.com"), stringPtr("***")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RedactEmail(tt.email))
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// tagNamePattern restricts tag names to lowercase labels such as "payments" or "regression-1.4"
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,49}$`)
+
+// Tag represents a label that can be attached to support requests
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;size:50;unique"`
+	Color     *string   `json:"color,omitempty" gorm:"size:7"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateTagRequest represents the payload for creating a tag
+// @Description Request payload for creating a new tag
+type CreateTagRequest struct {
+	Name  string  `json:"name" binding:"required,max=50" example:"payments"`                    // Tag name (lowercase letters, digits, '.', '_' and '-')
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor,len=7" example:"#ff8800"` // Optional display color
+}
+
+// UpdateTagRequest represents the payload for updating a tag
+// @Description Request payload for renaming or recoloring a tag
+type UpdateTagRequest struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,max=50" example:"billing"`          // New tag name
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor,len=7" example:"#0088ff"` // New display color
+}
+
+// SupportRequestTagsRequest represents the payload for adding tags to a support request
+// @Description Request payload for tagging a support request
+type SupportRequestTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1,dive,required" example:"payments,login"` // Names of existing tags
+}
+
+// TagResponse represents the API response for tags
+// @Description Tag details
+type TagResponse struct {
+	ID        uint      `json:"id" example:"1"`                            // Tag ID
+	Name      string    `json:"name" example:"payments"`                   // Tag name
+	Color     *string   `json:"color,omitempty" example:"#ff8800"`         // Display color (optional)
+	CreatedAt time.Time `json:"created_at" example:"2023-12-01T10:00:00Z"` // Creation timestamp
+	UpdatedAt time.Time `json:"updated_at" example:"2023-12-01T10:00:00Z"` // Last update timestamp
+}
+
+// NormalizeTagName trims and lowercases a tag name so lookups are case-insensitive
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// IsValidTagName reports whether name (already normalized) is an acceptable tag name
+func IsValidTagName(name string) bool {
+	return tagNamePattern.MatchString(name)
+}
+
+// ToResponse converts Tag to TagResponse
+func (t *Tag) ToResponse() *TagResponse {
+	return &TagResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Color:     t.Color,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// TableName returns the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
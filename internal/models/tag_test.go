@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTag_ToResponse(t *testing.T) {
+	// Arrange
+	color := "#ff8800"
+	now := time.Now()
+	tag := &Tag{ID: 3, Name: "payments", Color: &color, CreatedAt: now, UpdatedAt: now}
+
+	// Act
+	response := tag.ToResponse()
+
+	// Assert
+	assert.Equal(t, uint(3), response.ID)
+	assert.Equal(t, "payments", response.Name)
+	assert.Equal(t, &color, response.Color)
+	assert.Equal(t, now, response.CreatedAt)
+}
+
+func TestTag_TableName(t *testing.T) {
+	assert.Equal(t, "tags", Tag{}.TableName())
+}
+
+func TestNormalizeTagName(t *testing.T) {
+	assert.Equal(t, "payments", NormalizeTagName("  Payments "))
+}
+
+func TestIsValidTagName(t *testing.T) {
+	for _, name := range []string{"payments", "login", "regression-1.4", "ios_17"} {
+		assert.True(t, IsValidTagName(name), name)
+	}
+	for _, name := range []string{"", "Payments", "has space", "-leading", "a/b", "averyveryveryveryveryveryveryveryveryveryverylongname"} {
+		assert.False(t, IsValidTagName(name), name)
+	}
+}
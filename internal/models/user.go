@@ -11,8 +11,10 @@ import (
 type UserRole string
 
 const (
-	UserRoleAdmin UserRole = "admin"
-	UserRoleUser  UserRole = "user"
+	UserRoleAdmin  UserRole = "admin"
+	UserRoleAgent  UserRole = "agent"
+	UserRoleViewer UserRole = "viewer"
+	UserRoleUser   UserRole = "user"
 )
 
 // User represents a system user
@@ -21,8 +23,11 @@ type User struct {
 	Username     string         `json:"username" gorm:"not null;size:50;unique"`
 	Email        string         `json:"email" gorm:"not null;size:255;unique"`
 	PasswordHash string         `json:"-" gorm:"not null"`
-	Role         UserRole       `json:"role" gorm:"not null;default:user"`
+	Role         UserRole       `json:"role" gorm:"not null;size:50;default:user"` // Built-in role or name of a custom Role
 	IsActive     bool           `json:"is_active" gorm:"not null;default:true"`
+	MFAEnabled   bool           `json:"mfa_enabled" gorm:"not null;default:false"`
+	MFASecret    string         `json:"-" gorm:"size:64"`            // Base32 TOTP secret, set while enrolling and once enabled
+	MFALastStep  int64          `json:"-" gorm:"not null;default:0"` // Time step of the last accepted code, so a code works once
 	LastLoginAt  *time.Time     `json:"last_login_at,omitempty"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
@@ -37,21 +42,34 @@ type LoginRequest struct {
 }
 
 // LoginResponse represents the login response
-// @Description User login response with JWT token
+// @Description User login response with a short-lived JWT access token and a refresh token
 type LoginResponse struct {
-	Token     string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT access token
-	ExpiresAt time.Time `json:"expires_at" example:"2023-12-31T23:59:59Z"`               // Token expiration time
-	User      UserInfo  `json:"user"`                                                    // User information
+	Token                 string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`                // JWT access token
+	ExpiresAt             time.Time `json:"expires_at" example:"2023-12-31T23:59:59Z"`                              // Access token expiration time
+	RefreshToken          string    `json:"refresh_token" example:"3q2-7wAAAAB1c2VyLXJlZnJlc2gtdG9rZW4tZXhhbXBsZQ"` // Single-use token for POST /auth/refresh
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at" example:"2024-01-30T23:59:59Z"`                // Refresh token expiration time
+	User                  UserInfo  `json:"user"`                                                                   // User information
+	RecoveryCodes         []string  `json:"recovery_codes,omitempty"`                                               // MFA recovery codes, only when MFA was enabled during this login
+
+	// MFAChallenge is set instead of the tokens when the password was right but a second factor is needed
+	MFAChallenge *MFAChallengeResponse `json:"-"`
+}
+
+// RefreshTokenRequest represents the payload for refreshing tokens or logging out
+// @Description Request payload carrying a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"3q2-7wAAAAB1c2VyLXJlZnJlc2gtdG9rZW4tZXhhbXBsZQ"` // Refresh token from login or the previous refresh
 }
 
 // UserInfo represents user information for responses
 // @Description User information response
 type UserInfo struct {
-	ID       uint     `json:"id" example:"1"`                    // User ID
-	Username string   `json:"username" example:"admin"`          // Username
-	Email    string   `json:"email" example:"admin@example.com"` // User email
-	Role     UserRole `json:"role" example:"admin"`              // User role (admin/user)
-	IsActive bool     `json:"is_active" example:"true"`          // Whether user is active
+	ID         uint     `json:"id" example:"1"`                    // User ID
+	Username   string   `json:"username" example:"admin"`          // Username
+	Email      string   `json:"email" example:"admin@example.com"` // User email
+	Role       UserRole `json:"role" example:"admin"`              // User role (built-in or custom)
+	IsActive   bool     `json:"is_active" example:"true"`          // Whether user is active
+	MFAEnabled bool     `json:"mfa_enabled" example:"true"`        // Whether two-factor authentication is enabled
 }
 
 // CreateUserRequest represents the payload for creating a user
@@ -60,14 +78,14 @@ type CreateUserRequest struct {
 	Username string   `json:"username" binding:"required,min=3,max=50" example:"newuser"`     // Username (3-50 characters)
 	Email    string   `json:"email" binding:"required,email" example:"newuser@example.com"`   // Valid email address
 	Password string   `json:"password" binding:"required,min=8" example:"securePassword@123"` // Password (min 8 characters)
-	Role     UserRole `json:"role" binding:"required,oneof=admin user" example:"user"`        // User role (admin or user)
+	Role     UserRole `json:"role" binding:"required,max=50" example:"agent"`                 // Built-in role (admin, agent, viewer, user) or custom role
 }
 
 // UpdateUserRequest represents the payload for updating a user
 // @Description Request payload for updating user information
 type UpdateUserRequest struct {
 	Email    *string   `json:"email,omitempty" binding:"omitempty,email" example:"updated@example.com"` // New email address
-	Role     *UserRole `json:"role,omitempty" binding:"omitempty,oneof=admin user" example:"admin"`     // New user role
+	Role     *UserRole `json:"role,omitempty" binding:"omitempty,max=50" example:"agent"`               // New user role
 	IsActive *bool     `json:"is_active,omitempty" example:"false"`                                     // Active status
 }
 
@@ -78,6 +96,11 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required,min=8" example:"newPassword123"` // New password (min 8 characters)
 }
 
+// IsEnrollingMFA reports whether the user started but has not yet confirmed MFA enrollment
+func (u *User) IsEnrollingMFA() bool {
+	return !u.MFAEnabled && u.MFASecret != ""
+}
+
 // TableName returns the table name for GORM
 func (User) TableName() string {
 	return "users"
@@ -102,10 +125,11 @@ func (u *User) CheckPassword(password string) bool {
 // ToUserInfo converts User to UserInfo
 func (u *User) ToUserInfo() UserInfo {
 	return UserInfo{
-		ID:       u.ID,
-		Username: u.Username,
-		Email:    u.Email,
-		Role:     u.Role,
-		IsActive: u.IsActive,
+		ID:         u.ID,
+		Username:   u.Username,
+		Email:      u.Email,
+		Role:       u.Role,
+		IsActive:   u.IsActive,
+		MFAEnabled: u.MFAEnabled,
 	}
 }
@@ -0,0 +1,208 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookEventType represents a support request lifecycle event webhooks can subscribe to
+type WebhookEventType string
+
+const (
+	WebhookEventSupportRequestCreated       WebhookEventType = "support_request.created"
+	WebhookEventSupportRequestUpdated       WebhookEventType = "support_request.updated"
+	WebhookEventSupportRequestStatusChanged WebhookEventType = "support_request.status_changed"
+	WebhookEventSupportRequestDeleted       WebhookEventType = "support_request.deleted"
+)
+
+// WebhookEventTypes lists every event webhooks can subscribe to
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventSupportRequestCreated,
+	WebhookEventSupportRequestUpdated,
+	WebhookEventSupportRequestStatusChanged,
+	WebhookEventSupportRequestDeleted,
+}
+
+// IsValid reports whether e is a known webhook event type
+func (e WebhookEventType) IsValid() bool {
+	for _, eventType := range WebhookEventTypes {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its first or next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // The receiver answered with a 2xx status
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // Every attempt failed
+)
+
+// Webhook is an admin-managed subscription that receives signed support request events
+type Webhook struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	URL       string         `json:"url" gorm:"not null;size:2048"`
+	Secret    string         `json:"-" gorm:"not null;size:255"`
+	Events    string         `json:"-" gorm:"not null;size:500"` // Comma-separated WebhookEventType values
+	IsActive  bool           `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// EventTypes returns the events the webhook is subscribed to
+func (w *Webhook) EventTypes() []WebhookEventType {
+	var events []WebhookEventType
+	for _, event := range strings.Split(w.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, WebhookEventType(event))
+		}
+	}
+	return events
+}
+
+// SetEventTypes replaces the events the webhook is subscribed to
+func (w *Webhook) SetEventTypes(events []WebhookEventType) {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	w.Events = strings.Join(names, ",")
+}
+
+// Subscribes reports whether the webhook is subscribed to event
+func (w *Webhook) Subscribes(event WebhookEventType) bool {
+	for _, subscribed := range w.EventTypes() {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one event sent (or to be sent) to a webhook, including its retries
+type WebhookDelivery struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	WebhookID      uint                  `json:"webhook_id" gorm:"not null;index"`
+	EventID        string                `json:"event_id" gorm:"not null;size:64;index"`
+	Event          WebhookEventType      `json:"event" gorm:"not null;size:50"`
+	Payload        string                `json:"-" gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"not null;size:20;default:pending;index:idx_webhook_deliveries_due"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty" gorm:"index:idx_webhook_deliveries_due"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	LastError      *string               `json:"last_error,omitempty" gorm:"type:text"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// WebhookPayload is the JSON body sent to webhooks
+type WebhookPayload struct {
+	ID        string           `json:"id"`         // Event ID, shared by redeliveries of the same event
+	Event     WebhookEventType `json:"event"`      // Event type
+	CreatedAt time.Time        `json:"created_at"` // When the event happened
+	Data      interface{}      `json:"data"`       // Event specific data
+}
+
+// SupportRequestStatusChange is the data of support_request.status_changed events
+type SupportRequestStatusChange struct {
+	SupportRequest *SupportRequestResponse `json:"support_request"`
+	PreviousStatus Status                  `json:"previous_status"`
+}
+
+// CreateWebhookRequest represents the payload for creating a webhook
+// @Description Request payload for creating a webhook subscription
+type CreateWebhookRequest struct {
+	URL    string             `json:"url" binding:"required,url,max=2048" example:"https://hooks.example.com/support"`                  // Receiver URL (http or https)
+	Secret string             `json:"secret,omitempty" binding:"omitempty,min=16,max=255" example:"s3cr3t-signing-key-123"`             // Signing secret, generated when omitted
+	Events []WebhookEventType `json:"events" binding:"required,min=1" example:"support_request.created,support_request.status_changed"` // Subscribed event types
+}
+
+// UpdateWebhookRequest represents the payload for updating a webhook
+// @Description Request payload for updating a webhook subscription
+type UpdateWebhookRequest struct {
+	URL      *string            `json:"url,omitempty" binding:"omitempty,url,max=2048" example:"https://hooks.example.com/support"` // New receiver URL
+	Secret   *string            `json:"secret,omitempty" binding:"omitempty,min=16,max=255" example:"n3w-s3cr3t-signing-key"`       // New signing secret
+	Events   []WebhookEventType `json:"events,omitempty" binding:"omitempty,min=1" example:"support_request.created"`               // New subscribed event types
+	IsActive *bool              `json:"is_active,omitempty" example:"false"`                                                        // Whether deliveries are sent
+}
+
+// WebhookResponse represents the API response for webhooks
+// @Description Webhook subscription details
+type WebhookResponse struct {
+	ID        uint               `json:"id" example:"1"`                                                   // Webhook ID
+	URL       string             `json:"url" example:"https://hooks.example.com/support"`                  // Receiver URL
+	Secret    string             `json:"secret,omitempty" example:"s3cr3t-signing-key-123"`                // Signing secret, only returned when the webhook is created
+	Events    []WebhookEventType `json:"events" example:"support_request.created,support_request.deleted"` // Subscribed event types
+	IsActive  bool               `json:"is_active" example:"true"`                                         // Whether deliveries are sent
+	CreatedAt time.Time          `json:"created_at" example:"2023-12-01T10:00:00Z"`                        // Creation timestamp
+	UpdatedAt time.Time          `json:"updated_at" example:"2023-12-01T10:00:00Z"`                        // Last update timestamp
+}
+
+// WebhookDeliveryResponse represents the API response for webhook deliveries
+// @Description Webhook delivery log entry
+type WebhookDeliveryResponse struct {
+	ID             uint                  `json:"id" example:"1"`                                                               // Delivery ID
+	WebhookID      uint                  `json:"webhook_id" example:"1"`                                                       // Webhook ID
+	EventID        string                `json:"event_id" example:"9b2f0c4e8d7a41f6a3c5e1d2b4a69780"`                          // Event ID
+	Event          WebhookEventType      `json:"event" example:"support_request.created"`                                      // Event type
+	Status         WebhookDeliveryStatus `json:"status" example:"succeeded"`                                                   // Delivery status (pending, succeeded, failed)
+	Attempts       int                   `json:"attempts" example:"1"`                                                         // Number of attempts so far
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty" example:"2023-12-01T10:01:00Z"`                     // When the next retry is due (pending only)
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty" example:"2023-12-01T10:00:00Z"`                     // When the last attempt was made
+	ResponseStatus *int                  `json:"response_status,omitempty" example:"200"`                                      // HTTP status of the last attempt
+	LastError      *string               `json:"last_error,omitempty" example:"unexpected status 500"`                         // Error of the last failed attempt
+	Payload        string                `json:"payload" example:"{\"id\":\"9b2f...\",\"event\":\"support_request.created\"}"` // JSON body sent to the webhook
+	CreatedAt      time.Time             `json:"created_at" example:"2023-12-01T10:00:00Z"`                                    // When the delivery was queued
+}
+
+// ToResponse converts Webhook to WebhookResponse. The secret is never included.
+func (w *Webhook) ToResponse() *WebhookResponse {
+	events := w.EventTypes()
+	if events == nil {
+		events = []WebhookEventType{}
+	}
+	return &WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    events,
+		IsActive:  w.IsActive,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+// ToResponse converts WebhookDelivery to WebhookDeliveryResponse
+func (d *WebhookDelivery) ToResponse() *WebhookDeliveryResponse {
+	return &WebhookDeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		Payload:        d.Payload,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// TableName returns the table name for GORM
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// TableName returns the table name for GORM
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookEventType_IsValid(t *testing.T) {
+	for _, event := range WebhookEventTypes {
+		assert.True(t, event.IsValid(), event)
+	}
+	assert.False(t, WebhookEventType("support_request.viewed").IsValid())
+	assert.False(t, WebhookEventType("").IsValid())
+}
+
+func TestWebhook_EventTypes(t *testing.T) {
+	// Arrange
+	webhook := &Webhook{}
+
+	// Act
+	webhook.SetEventTypes([]WebhookEventType{WebhookEventSupportRequestCreated, WebhookEventSupportRequestDeleted})
+
+	// Assert
+	assert.Equal(t, "support_request.created,support_request.deleted", webhook.Events)
+	assert.Equal(t, []WebhookEventType{WebhookEventSupportRequestCreated, WebhookEventSupportRequestDeleted}, webhook.EventTypes())
+	assert.True(t, webhook.Subscribes(WebhookEventSupportRequestDeleted))
+	assert.False(t, webhook.Subscribes(WebhookEventSupportRequestUpdated))
+}
+
+func TestWebhook_ToResponse(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	webhook := &Webhook{
+		ID:        4,
+		URL:       "https://hooks.example.com/support",
+		Secret:    "s3cr3t-signing-key-123",
+		Events:    "support_request.created",
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Act
+	response := webhook.ToResponse()
+
+	// Assert
+	assert.Equal(t, uint(4), response.ID)
+	assert.Equal(t, "https://hooks.example.com/support", response.URL)
+	assert.Empty(t, response.Secret)
+	assert.Equal(t, []WebhookEventType{WebhookEventSupportRequestCreated}, response.Events)
+	assert.True(t, response.IsActive)
+}
+
+func TestWebhookDelivery_ToResponse(t *testing.T) {
+	// Arrange
+	status := 500
+	lastError := "unexpected status 500"
+	delivery := &WebhookDelivery{
+		ID:             7,
+		WebhookID:      4,
+		EventID:        "evt",
+		Event:          WebhookEventSupportRequestDeleted,
+		Payload:        `{"id":"evt"}`,
+		Status:         WebhookDeliveryPending,
+		Attempts:       2,
+		ResponseStatus: &status,
+		LastError:      &lastError,
+	}
+
+	// Act
+	response := delivery.ToResponse()
+
+	// Assert
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, uint(4), response.WebhookID)
+	assert.Equal(t, WebhookDeliveryPending, response.Status)
+	assert.Equal(t, 2, response.Attempts)
+	assert.Equal(t, &status, response.ResponseStatus)
+	assert.Equal(t, &lastError, response.LastError)
+	assert.Equal(t, `{"id":"evt"}`, response.Payload)
+}
+
+func TestWebhook_TableName(t *testing.T) {
+	assert.Equal(t, "webhooks", Webhook{}.TableName())
+	assert.Equal(t, "webhook_deliveries", WebhookDelivery{}.TableName())
+}
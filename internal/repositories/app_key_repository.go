@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AppKeyRepository defines the interface for app intake key data operations
+type AppKeyRepository interface {
+	Create(key *models.AppKey) error
+	GetByKeyHash(keyHash string) (*models.AppKey, error)
+	GetByAppID(appID uint) ([]*models.AppKey, error)
+	Delete(appID, id uint) error
+}
+
+// appKeyRepository implements AppKeyRepository
+type appKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAppKeyRepository creates a new app key repository
+func NewAppKeyRepository(db *gorm.DB) AppKeyRepository {
+	return &appKeyRepository{
+		db: db,
+	}
+}
+
+// Create creates a new app key
+func (r *appKeyRepository) Create(key *models.AppKey) error {
+	return r.db.Create(key).Error
+}
+
+// GetByKeyHash retrieves an app key and its app by the SHA-256 of the key
+func (r *appKeyRepository) GetByKeyHash(keyHash string) (*models.AppKey, error) {
+	var key models.AppKey
+	err := r.db.Joins("App").Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByAppID retrieves the keys of an app, newest first
+func (r *appKeyRepository) GetByAppID(appID uint) ([]*models.AppKey, error) {
+	var keys []*models.AppKey
+	err := r.db.Where("app_id = ?", appID).Order("created_at DESC, id DESC").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Delete revokes a key of an app, returning gorm.ErrRecordNotFound if the app has no such key
+func (r *appKeyRepository) Delete(appID, id uint) error {
+	result := r.db.Where("app_id = ?", appID).Delete(&models.AppKey{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type AppKeyRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo AppKeyRepository
+	app  *models.App
+}
+
+func (suite *AppKeyRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewAppKeyRepository(db)
+
+	err = db.AutoMigrate(&models.Organization{}, &models.App{}, &models.AppKey{})
+	suite.Require().NoError(err)
+}
+
+func (suite *AppKeyRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM app_keys")
+	suite.db.Exec("DELETE FROM apps")
+
+	suite.app = &models.App{Slug: "acme-app", DisplayName: "Acme App", IsActive: true}
+	suite.Require().NoError(suite.db.Create(suite.app).Error)
+}
+
+func (suite *AppKeyRepositoryTestSuite) TestGetByKeyHash_LoadsApp() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(&models.AppKey{AppID: suite.app.ID, Name: "iOS", Prefix: "ak_abc", KeyHash: "hash-1"}))
+
+	// Act
+	key, err := suite.repo.GetByKeyHash("hash-1")
+	_, missingErr := suite.repo.GetByKeyHash("hash-2")
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().NotNil(key.App)
+	assert.Equal(suite.T(), "acme-app", key.App.Slug)
+	assert.ErrorIs(suite.T(), missingErr, gorm.ErrRecordNotFound)
+}
+
+func (suite *AppKeyRepositoryTestSuite) TestCreate_DuplicateHash() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(&models.AppKey{AppID: suite.app.ID, Name: "iOS", Prefix: "ak_abc", KeyHash: "hash-1"}))
+
+	// Act
+	err := suite.repo.Create(&models.AppKey{AppID: suite.app.ID, Name: "Android", Prefix: "ak_abc", KeyHash: "hash-1"})
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AppKeyRepositoryTestSuite) TestGetByAppID() {
+	// Arrange
+	other := &models.App{Slug: "other-app", DisplayName: "Other App", IsActive: true}
+	suite.Require().NoError(suite.db.Create(other).Error)
+	suite.Require().NoError(suite.repo.Create(&models.AppKey{AppID: suite.app.ID, Name: "iOS", Prefix: "ak_abc", KeyHash: "hash-1"}))
+	suite.Require().NoError(suite.repo.Create(&models.AppKey{AppID: suite.app.ID, Name: "Android", Prefix: "ak_def", KeyHash: "hash-2"}))
+	suite.Require().NoError(suite.repo.Create(&models.AppKey{AppID: other.ID, Name: "Web", Prefix: "ak_ghi", KeyHash: "hash-3"}))
+
+	// Act
+	keys, err := suite.repo.GetByAppID(suite.app.ID)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(keys, 2)
+	assert.Equal(suite.T(), "Android", keys[0].Name)
+}
+
+func (suite *AppKeyRepositoryTestSuite) TestDelete() {
+	// Arrange
+	key := &models.AppKey{AppID: suite.app.ID, Name: "iOS", Prefix: "ak_abc", KeyHash: "hash-1"}
+	suite.Require().NoError(suite.repo.Create(key))
+
+	// Act
+	wrongAppErr := suite.repo.Delete(suite.app.ID+1, key.ID)
+	err := suite.repo.Delete(suite.app.ID, key.ID)
+	againErr := suite.repo.Delete(suite.app.ID, key.ID)
+
+	// Assert
+	assert.ErrorIs(suite.T(), wrongAppErr, gorm.ErrRecordNotFound)
+	suite.Require().NoError(err)
+	assert.ErrorIs(suite.T(), againErr, gorm.ErrRecordNotFound)
+}
+
+func TestAppKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AppKeyRepositoryTestSuite))
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AppRepository defines the interface for app data operations
+type AppRepository interface {
+	Create(app *models.App) error
+	GetByID(id uint) (*models.App, error)
+	GetBySlug(slug string) (*models.App, error)
+	GetAll() ([]*models.App, error)
+	GetByOrganizationID(organizationID uint) ([]*models.App, error)
+	Update(app *models.App) error
+	Delete(id uint) error
+}
+
+// appRepository implements AppRepository
+type appRepository struct {
+	db *gorm.DB
+}
+
+// NewAppRepository creates a new app repository
+func NewAppRepository(db *gorm.DB) AppRepository {
+	return &appRepository{
+		db: db,
+	}
+}
+
+// Create registers a new app. If the app belongs to an organization, the existing support requests
+// of the app that don't belong to an organization yet are handed over to it.
+func (r *appRepository) Create(app *models.App) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(app).Error; err != nil {
+			return err
+		}
+		return claimSupportRequests(tx, app)
+	})
+}
+
+// GetByID retrieves an app by ID
+func (r *appRepository) GetByID(id uint) (*models.App, error) {
+	var app models.App
+	err := r.db.First(&app, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// GetBySlug retrieves an app by slug
+func (r *appRepository) GetBySlug(slug string) (*models.App, error) {
+	var app models.App
+	err := r.db.Where("slug = ?", slug).First(&app).Error
+	if err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// GetAll retrieves all registered apps ordered by slug
+func (r *appRepository) GetAll() ([]*models.App, error) {
+	var apps []*models.App
+	err := r.db.Order("slug ASC").Find(&apps).Error
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// GetByOrganizationID retrieves the apps of an organization ordered by slug
+func (r *appRepository) GetByOrganizationID(organizationID uint) ([]*models.App, error) {
+	var apps []*models.App
+	err := r.db.Where("organization_id = ?", organizationID).Order("slug ASC").Find(&apps).Error
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// Update saves an app. Like Create, an app given to an organization brings along its support
+// requests that don't belong to an organization yet.
+func (r *appRepository) Update(app *models.App) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(app).Error; err != nil {
+			return err
+		}
+		return claimSupportRequests(tx, app)
+	})
+}
+
+// Delete unregisters an app and its intake keys. Support requests already submitted from it are kept.
+func (r *appRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("app_id = ?", id).Delete(&models.AppKey{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.App{}, id).Error
+	})
+}
+
+// claimSupportRequests hands the support requests of app that don't belong to an organization over
+// to the app's organization
+func claimSupportRequests(tx *gorm.DB, app *models.App) error {
+	if app.OrganizationID == nil {
+		return nil
+	}
+	return tx.Model(&models.SupportRequest{}).Unscoped().
+		Where("app = ? AND organization_id IS NULL", app.Slug).
+		Update("organization_id", *app.OrganizationID).Error
+}
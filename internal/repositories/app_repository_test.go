@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type AppRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo AppRepository
+}
+
+func (suite *AppRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewAppRepository(db)
+
+	err = db.AutoMigrate(&models.Organization{}, &models.App{}, &models.AppKey{}, &models.SupportRequest{})
+	suite.Require().NoError(err)
+}
+
+func (suite *AppRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_requests")
+	suite.db.Exec("DELETE FROM app_keys")
+	suite.db.Exec("DELETE FROM apps")
+	suite.db.Exec("DELETE FROM organizations")
+}
+
+// newApp returns an active app with the given slug, owned by organizationID unless it is zero
+func newApp(slug string, organizationID uint) *models.App {
+	app := &models.App{Slug: slug, DisplayName: slug, IsActive: true}
+	if organizationID != 0 {
+		app.OrganizationID = &organizationID
+	}
+	return app
+}
+
+func (suite *AppRepositoryTestSuite) TestCreate_ClaimsUnownedSupportRequests() {
+	// Arrange
+	acme := &models.Organization{Name: "Acme Corp"}
+	globex := &models.Organization{Name: "Globex"}
+	suite.Require().NoError(suite.db.Create(acme).Error)
+	suite.Require().NoError(suite.db.Create(globex).Error)
+	unowned := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "acme-app", Status: models.StatusNew}
+	owned := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "acme-app", OrganizationID: &globex.ID, Status: models.StatusNew}
+	other := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "other-app", Status: models.StatusNew}
+	for _, request := range []*models.SupportRequest{unowned, owned, other} {
+		suite.Require().NoError(suite.db.Create(request).Error)
+	}
+
+	// Act
+	err := suite.repo.Create(newApp("acme-app", acme.ID))
+
+	// Assert
+	suite.Require().NoError(err)
+	var claimed, kept, untouched models.SupportRequest
+	suite.Require().NoError(suite.db.First(&claimed, unowned.ID).Error)
+	suite.Require().NoError(suite.db.First(&kept, owned.ID).Error)
+	suite.Require().NoError(suite.db.First(&untouched, other.ID).Error)
+	assert.Equal(suite.T(), acme.ID, *claimed.OrganizationID)
+	assert.Equal(suite.T(), globex.ID, *kept.OrganizationID, "requests of another organization are left alone")
+	assert.Nil(suite.T(), untouched.OrganizationID)
+}
+
+func (suite *AppRepositoryTestSuite) TestCreate_DuplicateSlug() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(newApp("acme-app", 1)))
+
+	// Act
+	err := suite.repo.Create(newApp("acme-app", 2))
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AppRepositoryTestSuite) TestGetBySlugAndOrganization() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(newApp("zeta", 1)))
+	suite.Require().NoError(suite.repo.Create(newApp("alpha", 1)))
+	suite.Require().NoError(suite.repo.Create(newApp("beta", 2)))
+
+	// Act
+	app, err := suite.repo.GetBySlug("beta")
+	apps, listErr := suite.repo.GetByOrganizationID(1)
+	_, missingErr := suite.repo.GetBySlug("gamma")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint(2), *app.OrganizationID)
+	suite.Require().NoError(listErr)
+	suite.Require().Len(apps, 2)
+	assert.Equal(suite.T(), "alpha", apps[0].Slug)
+	assert.ErrorIs(suite.T(), missingErr, gorm.ErrRecordNotFound)
+}
+
+func (suite *AppRepositoryTestSuite) TestCreate_Unowned() {
+	// Arrange
+	request := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "acme-app", Status: models.StatusNew}
+	suite.Require().NoError(suite.db.Create(request).Error)
+
+	// Act
+	err := suite.repo.Create(newApp("acme-app", 0))
+
+	// Assert
+	suite.Require().NoError(err)
+	var reloaded models.SupportRequest
+	suite.Require().NoError(suite.db.First(&reloaded, request.ID).Error)
+	assert.Nil(suite.T(), reloaded.OrganizationID)
+}
+
+func (suite *AppRepositoryTestSuite) TestUpdate_ClaimsUnownedSupportRequests() {
+	// Arrange
+	acme := &models.Organization{Name: "Acme Corp"}
+	suite.Require().NoError(suite.db.Create(acme).Error)
+	app := newApp("acme-app", 0)
+	suite.Require().NoError(suite.repo.Create(app))
+	request := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "acme-app", Status: models.StatusNew}
+	suite.Require().NoError(suite.db.Create(request).Error)
+
+	// Act
+	app.OrganizationID = &acme.ID
+	app.IsActive = false
+	err := suite.repo.Update(app)
+
+	// Assert
+	suite.Require().NoError(err)
+	updated, err := suite.repo.GetByID(app.ID)
+	suite.Require().NoError(err)
+	assert.False(suite.T(), updated.IsActive)
+	var reloaded models.SupportRequest
+	suite.Require().NoError(suite.db.First(&reloaded, request.ID).Error)
+	assert.Equal(suite.T(), acme.ID, *reloaded.OrganizationID)
+}
+
+func (suite *AppRepositoryTestSuite) TestGetAll() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(newApp("zeta", 1)))
+	suite.Require().NoError(suite.repo.Create(newApp("alpha", 0)))
+
+	// Act
+	apps, err := suite.repo.GetAll()
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(apps, 2)
+	assert.Equal(suite.T(), "alpha", apps[0].Slug)
+}
+
+func (suite *AppRepositoryTestSuite) TestDelete() {
+	// Arrange
+	app := newApp("acme-app", 1)
+	suite.Require().NoError(suite.repo.Create(app))
+	suite.Require().NoError(suite.db.Create(&models.AppKey{AppID: app.ID, Name: "iOS", Prefix: "ak_abc", KeyHash: "hash"}).Error)
+
+	// Act
+	err := suite.repo.Delete(app.ID)
+
+	// Assert
+	suite.Require().NoError(err)
+	_, err = suite.repo.GetBySlug("acme-app")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	var keys int64
+	suite.db.Model(&models.AppKey{}).Count(&keys)
+	assert.Zero(suite.T(), keys)
+}
+
+func TestAppRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AppRepositoryTestSuite))
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository defines the interface for attachment data operations
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	GetByID(id uint) (*models.Attachment, error)
+	GetBySupportRequestID(supportRequestID uint) ([]*models.Attachment, error)
+	Delete(id uint) error
+}
+
+// attachmentRepository implements AttachmentRepository
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{
+		db: db,
+	}
+}
+
+// Create creates a new attachment
+func (r *attachmentRepository) Create(attachment *models.Attachment) error {
+	return r.db.Create(attachment).Error
+}
+
+// GetByID retrieves an attachment by ID
+func (r *attachmentRepository) GetByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.First(&attachment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetBySupportRequestID retrieves the attachments of a support request in upload order
+func (r *attachmentRepository) GetBySupportRequestID(supportRequestID uint) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := r.db.Where("support_request_id = ?", supportRequestID).Order("id ASC").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// Delete soft deletes an attachment
+func (r *attachmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Attachment{}, id).Error
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type AttachmentRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo AttachmentRepository
+}
+
+func (suite *AttachmentRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewAttachmentRepository(db)
+
+	err = db.AutoMigrate(&models.Attachment{})
+	suite.Require().NoError(err)
+}
+
+func (suite *AttachmentRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM attachments")
+}
+
+func (suite *AttachmentRepositoryTestSuite) newAttachment(supportRequestID uint, key string) *models.Attachment {
+	return &models.Attachment{
+		SupportRequestID: supportRequestID,
+		FileName:         "log.txt",
+		ContentType:      "text/plain",
+		Size:             12,
+		StorageKey:       key,
+		Checksum:         "abc",
+	}
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestCreateAndGetByID() {
+	// Arrange
+	attachment := suite.newAttachment(1, "support-requests/1/a")
+
+	// Act
+	err := suite.repo.Create(attachment)
+	suite.Require().NoError(err)
+	found, err := suite.repo.GetByID(attachment.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "support-requests/1/a", found.StorageKey)
+	assert.Equal(suite.T(), int64(12), found.Size)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestCreate_DuplicateStorageKey() {
+	suite.Require().NoError(suite.repo.Create(suite.newAttachment(1, "dup")))
+
+	err := suite.repo.Create(suite.newAttachment(2, "dup"))
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestGetBySupportRequestID() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(suite.newAttachment(1, "k1")))
+	suite.Require().NoError(suite.repo.Create(suite.newAttachment(1, "k2")))
+	suite.Require().NoError(suite.repo.Create(suite.newAttachment(2, "k3")))
+
+	// Act
+	attachments, err := suite.repo.GetBySupportRequestID(1)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.Require().Len(attachments, 2)
+	assert.Equal(suite.T(), "k1", attachments[0].StorageKey)
+	assert.Equal(suite.T(), "k2", attachments[1].StorageKey)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestDelete() {
+	// Arrange
+	attachment := suite.newAttachment(1, "k1")
+	suite.Require().NoError(suite.repo.Create(attachment))
+
+	// Act
+	err := suite.repo.Delete(attachment.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.GetByID(attachment.ID)
+	assert.Error(suite.T(), err)
+}
+
+func TestAttachmentRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentRepositoryTestSuite))
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditEventFilter holds the optional criteria used to narrow down audit event listings.
+// Zero values are ignored, so an empty filter matches every audit event.
+type AuditEventFilter struct {
+	ActorID       *uint
+	Action        models.AuditAction
+	EntityType    models.AuditEntityType
+	EntityID      *uint
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// AuditEventRepository defines the interface for audit event data operations.
+// Audit events are append-only, so there is no way to update or delete them.
+type AuditEventRepository interface {
+	Create(event *models.AuditEvent) error
+	GetAll(filter AuditEventFilter, offset, limit int) ([]*models.AuditEvent, int64, error)
+}
+
+// auditEventRepository implements AuditEventRepository
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository creates a new audit event repository
+func NewAuditEventRepository(db *gorm.DB) AuditEventRepository {
+	return &auditEventRepository{
+		db: db,
+	}
+}
+
+// Create records a new audit event
+func (r *auditEventRepository) Create(event *models.AuditEvent) error {
+	return r.db.Create(event).Error
+}
+
+// GetAll retrieves audit events matching the filter with pagination, newest first
+func (r *auditEventRepository) GetAll(filter AuditEventFilter, offset, limit int) ([]*models.AuditEvent, int64, error) {
+	var events []*models.AuditEvent
+	var total int64
+
+	query := r.db.Model(&models.AuditEvent{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+
+	// Count total matching records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := query.Offset(offset).Limit(limit).Order("created_at DESC, id DESC").Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// createAuditEvents records events with tx, so they are only kept if the audited change is
+func createAuditEvents(tx *gorm.DB, events []*models.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(events).Error
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type AuditEventRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo AuditEventRepository
+}
+
+func (suite *AuditEventRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewAuditEventRepository(db)
+
+	err = db.AutoMigrate(&models.AuditEvent{})
+	suite.Require().NoError(err)
+}
+
+func (suite *AuditEventRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM audit_events")
+}
+
+func (suite *AuditEventRepositoryTestSuite) newEvent(actorID uint, action models.AuditAction, entityType models.AuditEntityType, entityID uint, createdAt time.Time) *models.AuditEvent {
+	return &models.AuditEvent{
+		ActorID:       &actorID,
+		ActorUsername: "admin",
+		Action:        action,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Changes:       "{}",
+		IPAddress:     "203.0.113.7",
+		CreatedAt:     createdAt,
+	}
+}
+
+func (suite *AuditEventRepositoryTestSuite) TestGetAll_NewestFirst() {
+	// Arrange
+	now := time.Now()
+	suite.Require().NoError(suite.repo.Create(suite.newEvent(1, models.AuditActionUpdate, models.AuditEntityUser, 2, now.Add(-time.Hour))))
+	suite.Require().NoError(suite.repo.Create(suite.newEvent(1, models.AuditActionDelete, models.AuditEntityUser, 2, now)))
+
+	// Act
+	events, total, err := suite.repo.GetAll(AuditEventFilter{}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+	assert.Equal(suite.T(), models.AuditActionDelete, events[0].Action)
+}
+
+func (suite *AuditEventRepositoryTestSuite) TestGetAll_Filters() {
+	// Arrange
+	now := time.Now()
+	events := []*models.AuditEvent{
+		suite.newEvent(1, models.AuditActionUpdate, models.AuditEntitySupportRequest, 10, now.Add(-48*time.Hour)),
+		suite.newEvent(1, models.AuditActionUpdate, models.AuditEntitySupportRequest, 11, now),
+		suite.newEvent(2, models.AuditActionDelete, models.AuditEntitySupportRequest, 10, now),
+		suite.newEvent(2, models.AuditActionUpdate, models.AuditEntityUser, 10, now),
+	}
+	for _, event := range events {
+		suite.Require().NoError(suite.repo.Create(event))
+	}
+	actorID := uint(2)
+	entityID := uint(10)
+	since := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		filter   AuditEventFilter
+		expected int64
+	}{
+		{"actor", AuditEventFilter{ActorID: &actorID}, 2},
+		{"action", AuditEventFilter{Action: models.AuditActionDelete}, 1},
+		{"entity", AuditEventFilter{EntityType: models.AuditEntitySupportRequest, EntityID: &entityID}, 2},
+		{"created after", AuditEventFilter{CreatedAfter: &since}, 3},
+		{"created before", AuditEventFilter{CreatedBefore: &since}, 1},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Act
+			_, total, err := suite.repo.GetAll(tt.filter, 0, 10)
+
+			// Assert
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.expected, total)
+		})
+	}
+}
+
+func TestAuditEventRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditEventRepositoryTestSuite))
+}
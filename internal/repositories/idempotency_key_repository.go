@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key data operations
+type IdempotencyKeyRepository interface {
+	Reserve(key *models.IdempotencyKey) (bool, error)
+	Get(scope, key string) (*models.IdempotencyKey, error)
+	Update(key *models.IdempotencyKey) error
+	Delete(id uint) error
+	DeleteExpired(now time.Time) error
+}
+
+// idempotencyKeyRepository implements IdempotencyKeyRepository
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new idempotency key repository
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db: db,
+	}
+}
+
+// Reserve inserts a key unless its scope already has it, and reports whether it was inserted
+func (r *idempotencyKeyRepository) Reserve(key *models.IdempotencyKey) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Get retrieves a key of a scope
+func (r *idempotencyKeyRepository) Get(scope, key string) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+	err := r.db.Where("scope = ? AND key = ?", scope, key).First(&idempotencyKey).Error
+	if err != nil {
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+// Update saves a key
+func (r *idempotencyKeyRepository) Update(key *models.IdempotencyKey) error {
+	return r.db.Save(key).Error
+}
+
+// Delete removes a key
+func (r *idempotencyKeyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpired removes the keys that expired before now
+func (r *idempotencyKeyRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&models.IdempotencyKey{}).Error
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InboundEmailRepository defines the interface for inbound email data operations
+type InboundEmailRepository interface {
+	Create(email *models.InboundEmail) error
+	Reserve(email *models.InboundEmail) (bool, error)
+	GetByMessageID(messageID string) (*models.InboundEmail, error)
+	Update(email *models.InboundEmail) error
+	Delete(id uint) error
+}
+
+// inboundEmailRepository implements InboundEmailRepository
+type inboundEmailRepository struct {
+	db *gorm.DB
+}
+
+// NewInboundEmailRepository creates a new inbound email repository
+func NewInboundEmailRepository(db *gorm.DB) InboundEmailRepository {
+	return &inboundEmailRepository{
+		db: db,
+	}
+}
+
+// Create records a received email
+func (r *inboundEmailRepository) Create(email *models.InboundEmail) error {
+	return r.db.Create(email).Error
+}
+
+// Reserve records an email unless its Message-ID was already recorded, and reports whether it was
+func (r *inboundEmailRepository) Reserve(email *models.InboundEmail) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(email)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetByMessageID retrieves the received email with a Message-ID
+func (r *inboundEmailRepository) GetByMessageID(messageID string) (*models.InboundEmail, error) {
+	var email models.InboundEmail
+	err := r.db.Where("message_id = ?", messageID).First(&email).Error
+	if err != nil {
+		return nil, err
+	}
+	return &email, nil
+}
+
+// Update saves a received email
+func (r *inboundEmailRepository) Update(email *models.InboundEmail) error {
+	return r.db.Save(email).Error
+}
+
+// Delete removes a received email
+func (r *inboundEmailRepository) Delete(id uint) error {
+	return r.db.Delete(&models.InboundEmail{}, id).Error
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type InboundEmailRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo InboundEmailRepository
+}
+
+func (suite *InboundEmailRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewInboundEmailRepository(db)
+
+	err = db.AutoMigrate(&models.InboundEmail{})
+	suite.Require().NoError(err)
+}
+
+func (suite *InboundEmailRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM inbound_emails")
+}
+
+func (suite *InboundEmailRepositoryTestSuite) TestGetByMessageID() {
+	// Arrange
+	messageID := "CAF=abc@mail.example.com"
+	requestID := uint(42)
+	suite.Require().NoError(suite.repo.Create(&models.InboundEmail{MessageID: &messageID, Sender: "jane@example.com", Subject: "Help", Action: models.InboundEmailCreated, SupportRequestID: &requestID}))
+
+	// Act
+	email, err := suite.repo.GetByMessageID(messageID)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal(models.InboundEmailCreated, email.Action)
+	suite.Equal(requestID, *email.SupportRequestID)
+
+	_, err = suite.repo.GetByMessageID("other@mail.example.com")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (suite *InboundEmailRepositoryTestSuite) TestCreate_MessageIDIsUnique() {
+	// Arrange
+	messageID := "CAF=abc@mail.example.com"
+	suite.Require().NoError(suite.repo.Create(&models.InboundEmail{MessageID: &messageID, Sender: "jane@example.com", Action: models.InboundEmailIgnored}))
+
+	// Act
+	err := suite.repo.Create(&models.InboundEmail{MessageID: &messageID, Sender: "jane@example.com", Action: models.InboundEmailIgnored})
+
+	// Assert
+	suite.Error(err)
+
+	// Emails without a Message-ID are recorded every time
+	suite.NoError(suite.repo.Create(&models.InboundEmail{Sender: "jane@example.com", Action: models.InboundEmailIgnored}))
+	suite.NoError(suite.repo.Create(&models.InboundEmail{Sender: "jane@example.com", Action: models.InboundEmailIgnored}))
+}
+
+func (suite *InboundEmailRepositoryTestSuite) TestReserve() {
+	// Arrange
+	messageID := "CAF=abc@mail.example.com"
+	first := &models.InboundEmail{MessageID: &messageID, Sender: "jane@example.com", Action: models.InboundEmailProcessing}
+
+	// Act
+	reserved, err := suite.repo.Reserve(first)
+	suite.Require().NoError(err)
+	again, err := suite.repo.Reserve(&models.InboundEmail{MessageID: &messageID, Sender: "jane@example.com", Action: models.InboundEmailProcessing})
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.True(reserved)
+	suite.False(again, "a Message-ID is only reserved once")
+
+	requestID := uint(42)
+	first.Action = models.InboundEmailCreated
+	first.SupportRequestID = &requestID
+	suite.Require().NoError(suite.repo.Update(first))
+	email, err := suite.repo.GetByMessageID(messageID)
+	suite.Require().NoError(err)
+	suite.Equal(models.InboundEmailCreated, email.Action)
+
+	suite.Require().NoError(suite.repo.Delete(first.ID))
+	reserved, err = suite.repo.Reserve(&models.InboundEmail{MessageID: &messageID, Sender: "jane@example.com", Action: models.InboundEmailProcessing})
+	suite.Require().NoError(err)
+	suite.True(reserved, "a deleted reservation can be made again")
+}
+
+func TestInboundEmailRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(InboundEmailRepositoryTestSuite))
+}
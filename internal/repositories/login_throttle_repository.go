@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginThrottleRepository defines the interface for failed login tracking data operations
+type LoginThrottleRepository interface {
+	GetByKeys(keys ...string) ([]*models.LoginThrottle, error)
+	Update(key string, update func(throttle *models.LoginThrottle)) error
+	Delete(key string, events ...*models.AuditEvent) error
+}
+
+// loginThrottleRepository implements LoginThrottleRepository
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginThrottleRepository creates a new login throttle repository
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &loginThrottleRepository{
+		db: db,
+	}
+}
+
+// GetByKeys retrieves the throttles with the given keys. Keys without a throttle are left out.
+func (r *loginThrottleRepository) GetByKeys(keys ...string) ([]*models.LoginThrottle, error) {
+	var throttles []*models.LoginThrottle
+	if len(keys) == 0 {
+		return throttles, nil
+	}
+	err := r.db.Where("key IN ?", keys).Find(&throttles).Error
+	return throttles, err
+}
+
+// Update applies update to the throttle with the given key, creating it first if needed. The row is
+// locked for the duration, so concurrent failed logins against the same key are all counted.
+func (r *loginThrottleRepository) Update(key string, update func(throttle *models.LoginThrottle)) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(&models.LoginThrottle{Key: key}).Error
+		if err != nil {
+			return err
+		}
+
+		var throttle models.LoginThrottle
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&throttle).Error; err != nil {
+			return err
+		}
+		update(&throttle)
+		return tx.Save(&throttle).Error
+	})
+}
+
+// Delete removes the throttle with the given key, clearing its failures and lockout, and records the
+// given audit events in the same transaction
+func (r *loginThrottleRepository) Delete(key string, events ...*models.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("key = ?", key).Delete(&models.LoginThrottle{}).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, events)
+	})
+}
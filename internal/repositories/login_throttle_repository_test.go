@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type LoginThrottleRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo LoginThrottleRepository
+}
+
+func (suite *LoginThrottleRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewLoginThrottleRepository(db)
+
+	err = db.AutoMigrate(&models.LoginThrottle{}, &models.AuditEvent{})
+	suite.Require().NoError(err)
+}
+
+func (suite *LoginThrottleRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM login_throttles")
+	suite.db.Exec("DELETE FROM audit_events")
+}
+
+// fail counts one failure against key
+func (suite *LoginThrottleRepositoryTestSuite) fail(key string) {
+	now := time.Now()
+	suite.Require().NoError(suite.repo.Update(key, func(throttle *models.LoginThrottle) {
+		throttle.Failures++
+		throttle.LastFailureAt = &now
+	}))
+}
+
+func (suite *LoginThrottleRepositoryTestSuite) TestUpdateAndGetByKeys() {
+	// Arrange
+	suite.fail("user:alice")
+	suite.fail("ip:203.0.113.7")
+	suite.fail("ip:203.0.113.7")
+
+	// Act
+	throttles, err := suite.repo.GetByKeys("user:alice", "ip:203.0.113.7", "user:bob")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.Require().Len(throttles, 2)
+	for _, throttle := range throttles {
+		if throttle.Key == "ip:203.0.113.7" {
+			assert.Equal(suite.T(), 2, throttle.Failures)
+		}
+	}
+}
+
+func (suite *LoginThrottleRepositoryTestSuite) TestUpdateChangesExistingThrottle() {
+	// Arrange
+	suite.fail("user:alice")
+	until := time.Now().Add(time.Minute)
+
+	// Act
+	err := suite.repo.Update("user:alice", func(throttle *models.LoginThrottle) {
+		throttle.Failures = 0
+		throttle.Lockouts++
+		throttle.LockedUntil = &until
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	throttles, err := suite.repo.GetByKeys("user:alice")
+	suite.Require().NoError(err)
+	suite.Require().Len(throttles, 1)
+	assert.Equal(suite.T(), 0, throttles[0].Failures)
+	assert.Equal(suite.T(), 1, throttles[0].Lockouts)
+	assert.True(suite.T(), throttles[0].IsLocked(time.Now()))
+}
+
+func (suite *LoginThrottleRepositoryTestSuite) TestDeleteRecordsAuditEvents() {
+	// Arrange
+	suite.fail("user:alice")
+	suite.fail("user:bob")
+	event := &models.AuditEvent{ActorUsername: "admin", Action: models.AuditActionUpdate, EntityType: models.AuditEntityUser, EntityID: 2, Changes: "{}"}
+
+	// Act
+	err := suite.repo.Delete("user:alice", event)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	throttles, err := suite.repo.GetByKeys("user:alice", "user:bob")
+	suite.Require().NoError(err)
+	suite.Require().Len(throttles, 1)
+	assert.Equal(suite.T(), "user:bob", throttles[0].Key)
+
+	var count int64
+	suite.db.Model(&models.AuditEvent{}).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func TestLoginThrottleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(LoginThrottleRepositoryTestSuite))
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MFARecoveryCodeRepository defines the interface for MFA recovery code data operations
+type MFARecoveryCodeRepository interface {
+	Replace(userID uint, codes []*models.MFARecoveryCode) error
+	Use(userID uint, codeHash string, usedAt time.Time) (bool, error)
+	DeleteByUser(userID uint) error
+}
+
+// mfaRecoveryCodeRepository implements MFARecoveryCodeRepository
+type mfaRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARecoveryCodeRepository creates a new MFA recovery code repository
+func NewMFARecoveryCodeRepository(db *gorm.DB) MFARecoveryCodeRepository {
+	return &mfaRecoveryCodeRepository{
+		db: db,
+	}
+}
+
+// Replace deletes a user's recovery codes and stores the given ones in one transaction
+func (r *mfaRecoveryCodeRepository) Replace(userID uint, codes []*models.MFARecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(codes).Error
+	})
+}
+
+// Use marks the user's unused recovery code with the given hash as used. It reports false when there is
+// no such code or it was already used.
+func (r *mfaRecoveryCodeRepository) Use(userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteByUser deletes all recovery codes of a user
+func (r *mfaRecoveryCodeRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type MFARecoveryCodeRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo MFARecoveryCodeRepository
+}
+
+func (suite *MFARecoveryCodeRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewMFARecoveryCodeRepository(db)
+
+	err = db.AutoMigrate(&models.MFARecoveryCode{})
+	suite.Require().NoError(err)
+}
+
+func (suite *MFARecoveryCodeRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM mfa_recovery_codes")
+}
+
+func (suite *MFARecoveryCodeRepositoryTestSuite) countCodes(userID uint) int64 {
+	var count int64
+	suite.db.Model(&models.MFARecoveryCode{}).Where("user_id = ?", userID).Count(&count)
+	return count
+}
+
+func (suite *MFARecoveryCodeRepositoryTestSuite) TestReplace() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Replace(1, []*models.MFARecoveryCode{{UserID: 1, CodeHash: "old"}}))
+	suite.Require().NoError(suite.repo.Replace(2, []*models.MFARecoveryCode{{UserID: 2, CodeHash: "other"}}))
+
+	// Act
+	err := suite.repo.Replace(1, []*models.MFARecoveryCode{{UserID: 1, CodeHash: "new-1"}, {UserID: 1, CodeHash: "new-2"}})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), suite.countCodes(1))
+	assert.Equal(suite.T(), int64(1), suite.countCodes(2))
+	used, err := suite.repo.Use(1, "old", time.Now())
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), used)
+}
+
+func (suite *MFARecoveryCodeRepositoryTestSuite) TestUse() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Replace(1, []*models.MFARecoveryCode{{UserID: 1, CodeHash: "code"}}))
+
+	// Act
+	first, firstErr := suite.repo.Use(1, "code", time.Now())
+	second, secondErr := suite.repo.Use(1, "code", time.Now())
+	otherUser, otherErr := suite.repo.Use(2, "code", time.Now())
+
+	// Assert
+	assert.NoError(suite.T(), firstErr)
+	assert.True(suite.T(), first)
+	assert.NoError(suite.T(), secondErr)
+	assert.False(suite.T(), second)
+	assert.NoError(suite.T(), otherErr)
+	assert.False(suite.T(), otherUser)
+}
+
+func (suite *MFARecoveryCodeRepositoryTestSuite) TestDeleteByUser() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Replace(1, []*models.MFARecoveryCode{{UserID: 1, CodeHash: "a"}, {UserID: 1, CodeHash: "b"}}))
+	suite.Require().NoError(suite.repo.Replace(2, []*models.MFARecoveryCode{{UserID: 2, CodeHash: "c"}}))
+
+	// Act
+	err := suite.repo.DeleteByUser(1)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), suite.countCodes(1))
+	assert.Equal(suite.T(), int64(1), suite.countCodes(2))
+}
+
+func TestMFARecoveryCodeRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MFARecoveryCodeRepositoryTestSuite))
+}
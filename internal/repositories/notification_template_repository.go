@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationTemplateRepository defines the interface for notification template data operations
+type NotificationTemplateRepository interface {
+	GetByAppID(appID uint) ([]*models.NotificationTemplate, error)
+	GetByAppAndEvent(appID uint, event models.NotificationEvent) (*models.NotificationTemplate, error)
+	Upsert(template *models.NotificationTemplate) error
+	Delete(appID uint, event models.NotificationEvent) error
+}
+
+// notificationTemplateRepository implements NotificationTemplateRepository
+type notificationTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationTemplateRepository creates a new notification template repository
+func NewNotificationTemplateRepository(db *gorm.DB) NotificationTemplateRepository {
+	return &notificationTemplateRepository{
+		db: db,
+	}
+}
+
+// GetByAppID retrieves the custom templates of an app ordered by event
+func (r *notificationTemplateRepository) GetByAppID(appID uint) ([]*models.NotificationTemplate, error) {
+	var templates []*models.NotificationTemplate
+	err := r.db.Where("app_id = ?", appID).Order("event ASC").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetByAppAndEvent retrieves the custom template of an app for an event
+func (r *notificationTemplateRepository) GetByAppAndEvent(appID uint, event models.NotificationEvent) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	err := r.db.Where("app_id = ? AND event = ?", appID, event).First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Upsert creates the template of an app for an event, or replaces the subject and body of the existing one
+func (r *notificationTemplateRepository) Upsert(template *models.NotificationTemplate) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "app_id"}, {Name: "event"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subject", "body", "updated_at"}),
+	}).Create(template).Error
+}
+
+// Delete removes the custom template of an app for an event, returning gorm.ErrRecordNotFound if it has none
+func (r *notificationTemplateRepository) Delete(appID uint, event models.NotificationEvent) error {
+	result := r.db.Where("app_id = ? AND event = ?", appID, event).Delete(&models.NotificationTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
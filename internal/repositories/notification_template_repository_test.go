@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type NotificationTemplateRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo NotificationTemplateRepository
+	app  *models.App
+}
+
+func (suite *NotificationTemplateRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewNotificationTemplateRepository(db)
+
+	err = db.AutoMigrate(&models.Organization{}, &models.App{}, &models.NotificationTemplate{})
+	suite.Require().NoError(err)
+}
+
+func (suite *NotificationTemplateRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM notification_templates")
+	suite.db.Exec("DELETE FROM apps")
+
+	suite.app = &models.App{Slug: "acme-app", DisplayName: "Acme App", IsActive: true}
+	suite.Require().NoError(suite.db.Create(suite.app).Error)
+}
+
+func (suite *NotificationTemplateRepositoryTestSuite) TestUpsert_ReplacesExistingTemplate() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Upsert(&models.NotificationTemplate{AppID: suite.app.ID, Event: models.NotificationEventReply, Subject: "Old", Body: "Old body"}))
+
+	// Act
+	err := suite.repo.Upsert(&models.NotificationTemplate{AppID: suite.app.ID, Event: models.NotificationEventReply, Subject: "New", Body: "New body"})
+
+	// Assert
+	suite.Require().NoError(err)
+	templates, err := suite.repo.GetByAppID(suite.app.ID)
+	suite.Require().NoError(err)
+	suite.Require().Len(templates, 1)
+	assert.Equal(suite.T(), "New", templates[0].Subject)
+	assert.Equal(suite.T(), "New body", templates[0].Body)
+}
+
+func (suite *NotificationTemplateRepositoryTestSuite) TestGetByAppAndEvent() {
+	// Arrange
+	other := &models.App{Slug: "other-app", DisplayName: "Other App", IsActive: true}
+	suite.Require().NoError(suite.db.Create(other).Error)
+	suite.Require().NoError(suite.repo.Upsert(&models.NotificationTemplate{AppID: suite.app.ID, Event: models.NotificationEventStatusChanged, Subject: "Acme", Body: "Body"}))
+
+	// Act
+	template, err := suite.repo.GetByAppAndEvent(suite.app.ID, models.NotificationEventStatusChanged)
+	_, otherEventErr := suite.repo.GetByAppAndEvent(suite.app.ID, models.NotificationEventReply)
+	_, otherAppErr := suite.repo.GetByAppAndEvent(other.ID, models.NotificationEventStatusChanged)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "Acme", template.Subject)
+	assert.ErrorIs(suite.T(), otherEventErr, gorm.ErrRecordNotFound)
+	assert.ErrorIs(suite.T(), otherAppErr, gorm.ErrRecordNotFound)
+}
+
+func (suite *NotificationTemplateRepositoryTestSuite) TestDelete() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Upsert(&models.NotificationTemplate{AppID: suite.app.ID, Event: models.NotificationEventReply, Subject: "Reply", Body: "Body"}))
+
+	// Act
+	err := suite.repo.Delete(suite.app.ID, models.NotificationEventReply)
+	missingErr := suite.repo.Delete(suite.app.ID, models.NotificationEventReply)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.ErrorIs(suite.T(), missingErr, gorm.ErrRecordNotFound)
+}
+
+func TestNotificationTemplateRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationTemplateRepositoryTestSuite))
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationRepository defines the interface for organization and membership data operations
+type OrganizationRepository interface {
+	Create(organization *models.Organization) error
+	GetByID(id uint) (*models.Organization, error)
+	GetByName(name string) (*models.Organization, error)
+	GetAll() ([]*models.Organization, error)
+	Update(organization *models.Organization) error
+	Delete(id uint) error
+	SaveMember(member *models.OrganizationMember) error
+	GetMember(organizationID, userID uint) (*models.OrganizationMember, error)
+	GetMembers(organizationID uint) ([]*models.OrganizationMember, error)
+	GetMembershipsByUserID(userID uint) ([]*models.OrganizationMember, error)
+	DeleteMember(organizationID, userID uint) error
+}
+
+// organizationRepository implements OrganizationRepository
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+// Create creates a new organization
+func (r *organizationRepository) Create(organization *models.Organization) error {
+	return r.db.Create(organization).Error
+}
+
+// GetByID retrieves an organization by ID
+func (r *organizationRepository) GetByID(id uint) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.db.First(&organization, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetByName retrieves an organization by its exact name
+func (r *organizationRepository) GetByName(name string) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.db.Where("name = ?", name).First(&organization).Error
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetAll retrieves every organization ordered by name
+func (r *organizationRepository) GetAll() ([]*models.Organization, error) {
+	var organizations []*models.Organization
+	err := r.db.Order("name ASC").Find(&organizations).Error
+	if err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// Update updates an organization
+func (r *organizationRepository) Update(organization *models.Organization) error {
+	return r.db.Save(organization).Error
+}
+
+// Delete deletes an organization together with its memberships. Its apps and support requests are
+// kept and no longer belong to any organization.
+func (r *organizationRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", id).Delete(&models.OrganizationMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.App{}).Where("organization_id = ?", id).Update("organization_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SupportRequest{}).Unscoped().
+			Where("organization_id = ?", id).
+			Update("organization_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Organization{}, id).Error
+	})
+}
+
+// SaveMember adds a user to an organization, or changes their role if they already belong to it
+func (r *organizationRepository) SaveMember(member *models.OrganizationMember) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(member).Error
+}
+
+// GetMember retrieves the membership of a user in an organization
+func (r *organizationRepository) GetMember(organizationID, userID uint) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMembers retrieves the members of an organization with their users, ordered by username
+func (r *organizationRepository) GetMembers(organizationID uint) ([]*models.OrganizationMember, error) {
+	var members []*models.OrganizationMember
+	err := r.db.Joins("User").
+		Where("organization_members.organization_id = ?", organizationID).
+		Order("User__username ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetMembershipsByUserID retrieves the memberships of a user with their organizations, ordered by organization name
+func (r *organizationRepository) GetMembershipsByUserID(userID uint) ([]*models.OrganizationMember, error) {
+	var members []*models.OrganizationMember
+	err := r.db.Joins("Organization").
+		Where("organization_members.user_id = ?", userID).
+		Order("Organization__name ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// DeleteMember removes a user from an organization
+func (r *organizationRepository) DeleteMember(organizationID, userID uint) error {
+	result := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&models.OrganizationMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type OrganizationRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo OrganizationRepository
+}
+
+func (suite *OrganizationRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewOrganizationRepository(db)
+
+	err = db.AutoMigrate(&models.User{}, &models.Organization{}, &models.OrganizationMember{}, &models.App{}, &models.SupportRequest{})
+	suite.Require().NoError(err)
+}
+
+func (suite *OrganizationRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_requests")
+	suite.db.Exec("DELETE FROM apps")
+	suite.db.Exec("DELETE FROM organization_members")
+	suite.db.Exec("DELETE FROM organizations")
+	suite.db.Exec("DELETE FROM users")
+}
+
+func (suite *OrganizationRepositoryTestSuite) createOrganization(name string) *models.Organization {
+	organization := &models.Organization{Name: name}
+	suite.Require().NoError(suite.repo.Create(organization))
+	return organization
+}
+
+func (suite *OrganizationRepositoryTestSuite) createUser(username string) *models.User {
+	user := &models.User{Username: username, Email: username + "@example.com", PasswordHash: "hash", Role: models.UserRoleUser}
+	suite.Require().NoError(suite.db.Create(user).Error)
+	return user
+}
+
+func (suite *OrganizationRepositoryTestSuite) TestCreateAndGet() {
+	// Arrange
+	organization := suite.createOrganization("Acme Corp")
+
+	// Act
+	byID, errByID := suite.repo.GetByID(organization.ID)
+	byName, errByName := suite.repo.GetByName("Acme Corp")
+	_, errMissing := suite.repo.GetByName("Globex")
+
+	// Assert
+	suite.Require().NoError(errByID)
+	suite.Require().NoError(errByName)
+	assert.Equal(suite.T(), "Acme Corp", byID.Name)
+	assert.Equal(suite.T(), organization.ID, byName.ID)
+	assert.ErrorIs(suite.T(), errMissing, gorm.ErrRecordNotFound)
+}
+
+func (suite *OrganizationRepositoryTestSuite) TestGetAll_OrderedByName() {
+	// Arrange
+	suite.createOrganization("Globex")
+	suite.createOrganization("Acme Corp")
+
+	// Act
+	organizations, err := suite.repo.GetAll()
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(organizations, 2)
+	assert.Equal(suite.T(), "Acme Corp", organizations[0].Name)
+}
+
+func (suite *OrganizationRepositoryTestSuite) TestSaveMember_Upserts() {
+	// Arrange
+	organization := suite.createOrganization("Acme Corp")
+	user := suite.createUser("jane")
+	suite.Require().NoError(suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: organization.ID, UserID: user.ID, Role: models.UserRoleViewer}))
+
+	// Act
+	err := suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: organization.ID, UserID: user.ID, Role: models.UserRoleAgent})
+
+	// Assert
+	suite.Require().NoError(err)
+	members, err := suite.repo.GetMembers(organization.ID)
+	suite.Require().NoError(err)
+	suite.Require().Len(members, 1)
+	assert.Equal(suite.T(), models.UserRoleAgent, members[0].Role)
+	assert.Equal(suite.T(), "jane", members[0].User.Username)
+}
+
+func (suite *OrganizationRepositoryTestSuite) TestGetMembershipsByUserID() {
+	// Arrange
+	globex := suite.createOrganization("Globex")
+	acme := suite.createOrganization("Acme Corp")
+	user := suite.createUser("jane")
+	other := suite.createUser("john")
+	suite.Require().NoError(suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: globex.ID, UserID: user.ID, Role: models.UserRoleViewer}))
+	suite.Require().NoError(suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: acme.ID, UserID: user.ID, Role: models.UserRoleAgent}))
+	suite.Require().NoError(suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: acme.ID, UserID: other.ID, Role: models.UserRoleAgent}))
+
+	// Act
+	memberships, err := suite.repo.GetMembershipsByUserID(user.ID)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(memberships, 2)
+	assert.Equal(suite.T(), "Acme Corp", memberships[0].Organization.Name)
+	assert.Equal(suite.T(), models.UserRoleAgent, memberships[0].Role)
+	assert.Equal(suite.T(), "Globex", memberships[1].Organization.Name)
+}
+
+func (suite *OrganizationRepositoryTestSuite) TestDeleteMember() {
+	// Arrange
+	organization := suite.createOrganization("Acme Corp")
+	user := suite.createUser("jane")
+	suite.Require().NoError(suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: organization.ID, UserID: user.ID, Role: models.UserRoleAgent}))
+
+	// Act
+	err := suite.repo.DeleteMember(organization.ID, user.ID)
+	errAgain := suite.repo.DeleteMember(organization.ID, user.ID)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.ErrorIs(suite.T(), errAgain, gorm.ErrRecordNotFound)
+	_, err = suite.repo.GetMember(organization.ID, user.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *OrganizationRepositoryTestSuite) TestDelete_ReleasesSupportRequests() {
+	// Arrange
+	organization := suite.createOrganization("Acme Corp")
+	user := suite.createUser("jane")
+	suite.Require().NoError(suite.repo.SaveMember(&models.OrganizationMember{OrganizationID: organization.ID, UserID: user.ID, Role: models.UserRoleAgent}))
+	app := &models.App{OrganizationID: &organization.ID, Slug: "acme-app", DisplayName: "Acme App", IsActive: true}
+	suite.Require().NoError(suite.db.Create(app).Error)
+	request := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "acme-app", OrganizationID: &organization.ID, Status: models.StatusNew}
+	suite.Require().NoError(suite.db.Create(request).Error)
+
+	// Act
+	err := suite.repo.Delete(organization.ID)
+
+	// Assert
+	suite.Require().NoError(err)
+	var members int64
+	suite.db.Model(&models.OrganizationMember{}).Count(&members)
+	assert.Zero(suite.T(), members)
+	var released models.App
+	suite.Require().NoError(suite.db.First(&released, app.ID).Error, "apps stay registered")
+	assert.Nil(suite.T(), released.OrganizationID)
+	var reloaded models.SupportRequest
+	suite.Require().NoError(suite.db.First(&reloaded, request.ID).Error)
+	assert.Nil(suite.T(), reloaded.OrganizationID)
+}
+
+func TestOrganizationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationRepositoryTestSuite))
+}
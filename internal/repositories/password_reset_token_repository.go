@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetTokenRepository defines the interface for password reset token data operations
+type PasswordResetTokenRepository interface {
+	Create(token *models.PasswordResetToken) error
+	GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error)
+	Redeem(token *models.PasswordResetToken, user *models.User, usedAt time.Time) (bool, error)
+}
+
+// passwordResetTokenRepository implements PasswordResetTokenRepository
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetTokenRepository creates a new password reset token repository
+func NewPasswordResetTokenRepository(db *gorm.DB) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores a new reset token and invalidates the user's earlier unused ones, so only the most recently
+// mailed link works
+func (r *passwordResetTokenRepository) Create(token *models.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// GetByTokenHash retrieves a reset token by the hash of its value
+func (r *passwordResetTokenRepository) GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Redeem marks token as used and saves user's new password hash in one transaction. It reports false, saving
+// nothing, when the token was already used, e.g. because a concurrent request redeemed it first.
+func (r *passwordResetTokenRepository) Redeem(token *models.PasswordResetToken, user *models.User, usedAt time.Time) (bool, error) {
+	redeemed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", user.ID).
+			Update("password_hash", user.PasswordHash).Error; err != nil {
+			return err
+		}
+		redeemed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if redeemed {
+		token.UsedAt = &usedAt
+	}
+	return redeemed, nil
+}
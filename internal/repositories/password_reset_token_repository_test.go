@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type PasswordResetTokenRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo PasswordResetTokenRepository
+	user *models.User
+}
+
+func (suite *PasswordResetTokenRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewPasswordResetTokenRepository(db)
+
+	err = db.AutoMigrate(&models.User{}, &models.PasswordResetToken{})
+	suite.Require().NoError(err)
+}
+
+func (suite *PasswordResetTokenRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM password_reset_tokens")
+	suite.db.Exec("DELETE FROM users")
+
+	suite.user = &models.User{Username: "admin", Email: "admin@example.com", PasswordHash: "old-hash", Role: models.UserRoleAdmin, IsActive: true}
+	suite.Require().NoError(suite.db.Create(suite.user).Error)
+}
+
+func (suite *PasswordResetTokenRepositoryTestSuite) newToken(suffix string) *models.PasswordResetToken {
+	return &models.PasswordResetToken{
+		UserID:    suite.user.ID,
+		TokenHash: "hash-" + suffix,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func (suite *PasswordResetTokenRepositoryTestSuite) TestCreateAndGetByTokenHash() {
+	// Arrange
+	token := suite.newToken("1")
+	suite.Require().NoError(suite.repo.Create(token))
+
+	// Act
+	found, err := suite.repo.GetByTokenHash("hash-1")
+	_, missingErr := suite.repo.GetByTokenHash("hash-2")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), token.ID, found.ID)
+	assert.False(suite.T(), found.IsUsed())
+	assert.ErrorIs(suite.T(), missingErr, gorm.ErrRecordNotFound)
+}
+
+func (suite *PasswordResetTokenRepositoryTestSuite) TestCreateSupersedesEarlierTokens() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(suite.newToken("1")))
+
+	// Act
+	err := suite.repo.Create(suite.newToken("2"))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	earlier, err := suite.repo.GetByTokenHash("hash-1")
+	suite.Require().NoError(err)
+	assert.True(suite.T(), earlier.IsUsed())
+	latest, err := suite.repo.GetByTokenHash("hash-2")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), latest.IsUsed())
+}
+
+func (suite *PasswordResetTokenRepositoryTestSuite) TestRedeem() {
+	// Arrange
+	token := suite.newToken("1")
+	suite.Require().NoError(suite.repo.Create(token))
+	stale := *token
+	suite.user.PasswordHash = "new-hash"
+
+	// Act
+	redeemed, err := suite.repo.Redeem(token, suite.user, time.Now())
+	suite.user.PasswordHash = "other-hash"
+	redeemedAgain, errAgain := suite.repo.Redeem(&stale, suite.user, time.Now())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), redeemed)
+	assert.NotNil(suite.T(), token.UsedAt)
+	assert.NoError(suite.T(), errAgain)
+	assert.False(suite.T(), redeemedAgain)
+
+	var user models.User
+	suite.Require().NoError(suite.db.First(&user, suite.user.ID).Error)
+	assert.Equal(suite.T(), "new-hash", user.PasswordHash)
+}
+
+func TestPasswordResetTokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetTokenRepositoryTestSuite))
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository defines the interface for refresh token data operations
+type RefreshTokenRepository interface {
+	Create(token *models.RefreshToken) error
+	GetByTokenHash(tokenHash string) (*models.RefreshToken, error)
+	GetByAccessTokenID(accessTokenID string) (*models.RefreshToken, error)
+	Rotate(used, next *models.RefreshToken, usedAt time.Time) (bool, error)
+	RevokeFamily(familyID string, revokedAt time.Time) error
+	RevokeUser(userID uint, revokedAt time.Time) error
+}
+
+// refreshTokenRepository implements RefreshTokenRepository
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores a new refresh token
+func (r *refreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByTokenHash retrieves a refresh token by the hash of its value
+func (r *refreshTokenRepository) GetByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByAccessTokenID retrieves the refresh token issued together with the access token with the given jti
+func (r *refreshTokenRepository) GetByAccessTokenID(accessTokenID string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("access_token_id = ?", accessTokenID).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate marks used as used and stores next in one transaction. It reports false, storing nothing, when
+// used was already used or revoked, e.g. because a concurrent refresh got there first.
+func (r *refreshTokenRepository) Rotate(used, next *models.RefreshToken, usedAt time.Time) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", used.ID).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if rotated {
+		used.UsedAt = &usedAt
+	}
+	return rotated, nil
+}
+
+// RevokeFamily revokes every token of a family, ending the session it belongs to
+func (r *refreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+// RevokeUser revokes every token of a user, ending all of their sessions
+func (r *refreshTokenRepository) RevokeUser(userID uint, revokedAt time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
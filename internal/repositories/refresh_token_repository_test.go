@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type RefreshTokenRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo RefreshTokenRepository
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewRefreshTokenRepository(db)
+
+	err = db.AutoMigrate(&models.RefreshToken{})
+	suite.Require().NoError(err)
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM refresh_tokens")
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) newToken(familyID, suffix string) *models.RefreshToken {
+	return &models.RefreshToken{
+		UserID:        1,
+		FamilyID:      familyID,
+		TokenHash:     "hash-" + suffix,
+		AccessTokenID: "jti-" + suffix,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	}
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestCreateAndLookup() {
+	// Arrange
+	token := suite.newToken("family", "1")
+	suite.Require().NoError(suite.repo.Create(token))
+
+	// Act
+	byHash, hashErr := suite.repo.GetByTokenHash("hash-1")
+	byJTI, jtiErr := suite.repo.GetByAccessTokenID("jti-1")
+	_, missingErr := suite.repo.GetByTokenHash("hash-2")
+
+	// Assert
+	assert.NoError(suite.T(), hashErr)
+	assert.Equal(suite.T(), token.ID, byHash.ID)
+	assert.NoError(suite.T(), jtiErr)
+	assert.Equal(suite.T(), token.ID, byJTI.ID)
+	assert.ErrorIs(suite.T(), missingErr, gorm.ErrRecordNotFound)
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestRotate() {
+	// Arrange
+	used := suite.newToken("family", "1")
+	suite.Require().NoError(suite.repo.Create(used))
+	stale := *used
+	next := suite.newToken("family", "2")
+
+	// Act
+	rotated, err := suite.repo.Rotate(used, next, time.Now())
+	rotatedAgain, errAgain := suite.repo.Rotate(&stale, suite.newToken("family", "3"), time.Now())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), rotated)
+	assert.NotNil(suite.T(), used.UsedAt)
+	assert.NotZero(suite.T(), next.ID)
+	assert.NoError(suite.T(), errAgain)
+	assert.False(suite.T(), rotatedAgain)
+	_, err = suite.repo.GetByTokenHash("hash-3")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestRevokeFamily() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(suite.newToken("family", "1")))
+	suite.Require().NoError(suite.repo.Create(suite.newToken("family", "2")))
+	suite.Require().NoError(suite.repo.Create(suite.newToken("other", "3")))
+
+	// Act
+	err := suite.repo.RevokeFamily("family", time.Now())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	for _, suffix := range []string{"1", "2"} {
+		token, err := suite.repo.GetByTokenHash("hash-" + suffix)
+		suite.Require().NoError(err)
+		assert.True(suite.T(), token.IsRevoked(), suffix)
+	}
+	other, err := suite.repo.GetByTokenHash("hash-3")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), other.IsRevoked())
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestRevokeUser() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(suite.newToken("family", "1")))
+	suite.Require().NoError(suite.repo.Create(suite.newToken("other", "2")))
+	otherUser := suite.newToken("third", "3")
+	otherUser.UserID = 2
+	suite.Require().NoError(suite.repo.Create(otherUser))
+
+	// Act
+	err := suite.repo.RevokeUser(1, time.Now())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	for _, suffix := range []string{"1", "2"} {
+		token, err := suite.repo.GetByTokenHash("hash-" + suffix)
+		suite.Require().NoError(err)
+		assert.True(suite.T(), token.IsRevoked(), suffix)
+	}
+	third, err := suite.repo.GetByTokenHash("hash-3")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), third.IsRevoked())
+}
+
+func TestRefreshTokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenRepositoryTestSuite))
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// RoleRepository defines the interface for custom role data operations
+type RoleRepository interface {
+	Create(role *models.Role) error
+	GetByName(name models.UserRole) (*models.Role, error)
+	GetAll() ([]*models.Role, error)
+	Update(role *models.Role) error
+	Delete(id uint) error
+	CountUsers(name models.UserRole) (int64, error)
+}
+
+// roleRepository implements RoleRepository
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+// Create creates a new custom role
+func (r *roleRepository) Create(role *models.Role) error {
+	return r.db.Create(role).Error
+}
+
+// GetByName retrieves a custom role by name
+func (r *roleRepository) GetByName(name models.UserRole) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Where("name = ?", name).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetAll retrieves every custom role ordered by name
+func (r *roleRepository) GetAll() ([]*models.Role, error) {
+	var roles []*models.Role
+	err := r.db.Order("name ASC").Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// Update updates a custom role
+func (r *roleRepository) Update(role *models.Role) error {
+	return r.db.Save(role).Error
+}
+
+// Delete deletes a custom role
+func (r *roleRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Role{}, id).Error
+}
+
+// CountUsers counts the users, including deactivated ones, that have the role, either as their
+// own role or within an organization
+func (r *roleRepository) CountUsers(name models.UserRole) (int64, error) {
+	var users, members int64
+	if err := r.db.Model(&models.User{}).Where("role = ?", name).Count(&users).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&models.OrganizationMember{}).Where("role = ?", name).Count(&members).Error; err != nil {
+		return 0, err
+	}
+	return users + members, nil
+}
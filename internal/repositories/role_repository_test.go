@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type RoleRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo RoleRepository
+}
+
+func (suite *RoleRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewRoleRepository(db)
+
+	err = db.AutoMigrate(&models.Role{}, &models.User{}, &models.OrganizationMember{})
+	suite.Require().NoError(err)
+}
+
+func (suite *RoleRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM roles")
+	suite.db.Exec("DELETE FROM users")
+	suite.db.Exec("DELETE FROM organization_members")
+}
+
+func (suite *RoleRepositoryTestSuite) TestCreateAndGetByName() {
+	// Arrange
+	role := &models.Role{Name: "billing-agent", Description: "Handles billing tickets"}
+	role.SetPermissions([]models.Permission{models.PermissionTicketsRead, models.PermissionTicketsUpdate})
+
+	// Act
+	err := suite.repo.Create(role)
+	suite.Require().NoError(err)
+	found, err := suite.repo.GetByName("billing-agent")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), role.ID, found.ID)
+	assert.Equal(suite.T(), []models.Permission{models.PermissionTicketsRead, models.PermissionTicketsUpdate}, found.PermissionList())
+}
+
+func (suite *RoleRepositoryTestSuite) TestGetByName_NotFound() {
+	// Act
+	_, err := suite.repo.GetByName("missing")
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *RoleRepositoryTestSuite) TestCreate_DuplicateName() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(&models.Role{Name: "triage"}))
+
+	// Act
+	err := suite.repo.Create(&models.Role{Name: "triage"})
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RoleRepositoryTestSuite) TestGetAll_OrderedByName() {
+	// Arrange
+	suite.Require().NoError(suite.repo.Create(&models.Role{Name: "triage"}))
+	suite.Require().NoError(suite.repo.Create(&models.Role{Name: "billing-agent"}))
+
+	// Act
+	roles, err := suite.repo.GetAll()
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(roles, 2)
+	assert.Equal(suite.T(), models.UserRole("billing-agent"), roles[0].Name)
+	assert.Equal(suite.T(), models.UserRole("triage"), roles[1].Name)
+}
+
+func (suite *RoleRepositoryTestSuite) TestUpdateAndDelete() {
+	// Arrange
+	role := &models.Role{Name: "triage"}
+	suite.Require().NoError(suite.repo.Create(role))
+
+	// Act
+	role.SetPermissions([]models.Permission{models.PermissionTicketsRead})
+	suite.Require().NoError(suite.repo.Update(role))
+	updated, err := suite.repo.GetByName("triage")
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.repo.Delete(role.ID))
+	_, errAfterDelete := suite.repo.GetByName("triage")
+
+	// Assert
+	assert.Equal(suite.T(), []models.Permission{models.PermissionTicketsRead}, updated.PermissionList())
+	assert.ErrorIs(suite.T(), errAfterDelete, gorm.ErrRecordNotFound)
+}
+
+func (suite *RoleRepositoryTestSuite) TestCountUsers() {
+	// Arrange
+	for i, username := range []string{"alice", "bob", "carol"} {
+		role := models.UserRole("triage")
+		if i == 2 {
+			role = models.UserRoleAdmin
+		}
+		user := &models.User{Username: username, Email: username + "@example.com", PasswordHash: "hash", Role: role}
+		suite.Require().NoError(suite.db.Create(user).Error)
+	}
+
+	// Act
+	count, err := suite.repo.CountUsers("triage")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(2), count)
+}
+
+func (suite *RoleRepositoryTestSuite) TestCountUsers_IncludesOrganizationMembers() {
+	// Arrange
+	user := &models.User{Username: "alice", Email: "alice@example.com", PasswordHash: "hash", Role: models.UserRoleUser}
+	suite.Require().NoError(suite.db.Create(user).Error)
+	suite.Require().NoError(suite.db.Create(&models.OrganizationMember{OrganizationID: 1, UserID: user.ID, Role: "triage"}).Error)
+
+	// Act
+	count, err := suite.repo.CountUsers("triage")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func TestRoleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleRepositoryTestSuite))
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SupportRequestChange is a write to a single support request made by ApplyChanges
+type SupportRequestChange struct {
+	Request    *models.SupportRequest // Saved without its associations, or deleted when Delete is set
+	Delete     bool                   // Soft delete the request instead of saving it
+	AddTags    []*models.Tag          // Tags to attach
+	RemoveTags []*models.Tag          // Tags to detach
+	Event      *models.AuditEvent     // Audit event recorded with the change
+}
+
+// GetByIDs retrieves the support requests with the given IDs within scope, with their tags, in ID
+// order. IDs that don't exist or are outside scope are left out.
+func (r *supportRequestRepository) GetByIDs(ids []uint, scope models.OrganizationScope) ([]*models.SupportRequest, error) {
+	var requests []*models.SupportRequest
+	if len(ids) == 0 {
+		return requests, nil
+	}
+	err := applyOrganizationScope(r.db, scope).
+		Preload("Tags", orderTagsByName).
+		Where("support_requests.id IN ?", ids).
+		Order("id ASC").
+		Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ApplyChanges writes every change and its audit event in a single transaction, so either all of
+// them are applied or none is
+func (r *supportRequestRepository) ApplyChanges(changes []*SupportRequestChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			if err := applyChange(tx, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// applyChange writes a single change within tx
+func applyChange(tx *gorm.DB, change *SupportRequestChange) error {
+	if change.Delete {
+		if err := tx.Delete(&models.SupportRequest{}, change.Request.ID).Error; err != nil {
+			return err
+		}
+	} else {
+		if err := tx.Omit(clause.Associations).Save(change.Request).Error; err != nil {
+			return err
+		}
+
+		// A bare model keeps GORM from appending to the tags of the saved request
+		owner := &models.SupportRequest{ID: change.Request.ID}
+		if len(change.AddTags) > 0 {
+			if err := tx.Model(owner).Omit("Tags.*").Association("Tags").Append(change.AddTags); err != nil {
+				return err
+			}
+		}
+		if len(change.RemoveTags) > 0 {
+			if err := tx.Model(owner).Association("Tags").Delete(change.RemoveTags); err != nil {
+				return err
+			}
+		}
+	}
+
+	if change.Event == nil {
+		return nil
+	}
+	return createAuditEvents(tx, []*models.AuditEvent{change.Event})
+}
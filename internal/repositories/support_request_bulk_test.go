@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SupportRequestBulkTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    SupportRequestRepository
+	tagRepo TagRepository
+}
+
+func (suite *SupportRequestBulkTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewSupportRequestRepository(db)
+	suite.tagRepo = NewTagRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{}, &models.Tag{}, &models.AuditEvent{})
+	suite.Require().NoError(err)
+}
+
+func (suite *SupportRequestBulkTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_request_tags")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM support_requests")
+	suite.db.Exec("DELETE FROM audit_events")
+}
+
+func (suite *SupportRequestBulkTestSuite) createRequest(organizationID *uint) *models.SupportRequest {
+	request := &models.SupportRequest{
+		Type:           models.SupportRequestTypeSupport,
+		Message:        "Buy cheap watches",
+		Platform:       models.PlatformWeb,
+		AppVersion:     "1.0.0",
+		DeviceModel:    "Chrome",
+		App:            "test-app",
+		OrganizationID: organizationID,
+		Status:         models.StatusNew,
+	}
+	suite.Require().NoError(suite.repo.Create(request))
+	return request
+}
+
+func (suite *SupportRequestBulkTestSuite) createTag(name string) *models.Tag {
+	tag := &models.Tag{Name: name}
+	suite.Require().NoError(suite.tagRepo.Create(tag))
+	return tag
+}
+
+func (suite *SupportRequestBulkTestSuite) TestGetByIDs_ScopeAndTags() {
+	// Arrange
+	organizationID := uint(1)
+	otherOrganizationID := uint(2)
+	first := suite.createRequest(&organizationID)
+	second := suite.createRequest(&organizationID)
+	other := suite.createRequest(&otherOrganizationID)
+	suite.Require().NoError(suite.tagRepo.AddToSupportRequest(second, []*models.Tag{suite.createTag("spam")}))
+
+	// Act
+	requests, err := suite.repo.GetByIDs([]uint{second.ID, other.ID, first.ID, 999}, models.ScopeToOrganization(organizationID))
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(requests, 2)
+	assert.Equal(suite.T(), first.ID, requests[0].ID)
+	assert.Equal(suite.T(), second.ID, requests[1].ID)
+	assert.Equal(suite.T(), []string{"spam"}, requests[1].ToResponse().Tags)
+}
+
+func (suite *SupportRequestBulkTestSuite) TestApplyChanges() {
+	// Arrange
+	updated := suite.createRequest(nil)
+	deleted := suite.createRequest(nil)
+	spam := suite.createTag("spam")
+	triage := suite.createTag("needs-triage")
+	suite.Require().NoError(suite.tagRepo.AddToSupportRequest(updated, []*models.Tag{triage}))
+
+	updated.Status = models.StatusSpam
+	changes := []*SupportRequestChange{
+		{
+			Request:    updated,
+			AddTags:    []*models.Tag{spam},
+			RemoveTags: []*models.Tag{triage},
+			Event:      &models.AuditEvent{Action: models.AuditActionUpdate, EntityType: models.AuditEntitySupportRequest, EntityID: updated.ID, Changes: "{}"},
+		},
+		{
+			Request: deleted,
+			Delete:  true,
+			Event:   &models.AuditEvent{Action: models.AuditActionDelete, EntityType: models.AuditEntitySupportRequest, EntityID: deleted.ID, Changes: "{}"},
+		},
+	}
+
+	// Act
+	err := suite.repo.ApplyChanges(changes)
+
+	// Assert
+	suite.Require().NoError(err)
+	reloaded, err := suite.repo.GetByID(updated.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.StatusSpam, reloaded.Status)
+	assert.Equal(suite.T(), []string{"spam"}, reloaded.ToResponse().Tags)
+
+	_, err = suite.repo.GetByID(deleted.ID, models.OrganizationScope{})
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	var events int64
+	suite.db.Model(&models.AuditEvent{}).Count(&events)
+	assert.Equal(suite.T(), int64(2), events)
+}
+
+func (suite *SupportRequestBulkTestSuite) TestApplyChanges_RollsBackOnError() {
+	// Arrange
+	request := suite.createRequest(nil)
+	request.Status = models.StatusSpam
+	missingTag := &models.Tag{ID: 999, Name: "missing"}
+	suite.Require().NoError(suite.db.Exec("PRAGMA foreign_keys = ON").Error)
+	defer suite.db.Exec("PRAGMA foreign_keys = OFF")
+
+	changes := []*SupportRequestChange{
+		{
+			Request: request,
+			Event:   &models.AuditEvent{Action: models.AuditActionUpdate, EntityType: models.AuditEntitySupportRequest, EntityID: request.ID, Changes: "{}"},
+		},
+		{Request: request, AddTags: []*models.Tag{missingTag}},
+	}
+
+	// Act
+	err := suite.repo.ApplyChanges(changes)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	reloaded, err := suite.repo.GetByID(request.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.StatusNew, reloaded.Status)
+
+	var events int64
+	suite.db.Model(&models.AuditEvent{}).Count(&events)
+	assert.Equal(suite.T(), int64(0), events)
+}
+
+func TestSupportRequestBulkTestSuite(t *testing.T) {
+	suite.Run(t, new(SupportRequestBulkTestSuite))
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"strings"
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxDuplicateCandidates bounds how many recent requests a new one is compared with
+const maxDuplicateCandidates = 20
+
+// SupportRequestMerge folds the conversation, attachments and tags of Source into Target, written by Merge
+type SupportRequestMerge struct {
+	Source           *models.SupportRequest        // Saved without its associations, left as a stub pointing at Target
+	Target           *models.SupportRequest        // Saved without its associations
+	Message          *models.SupportRequestMessage // Original submission of Source, added to the conversation of Target
+	InternalMessages bool                          // Make the moved messages internal, because Target has another submitter
+	Events           []*models.AuditEvent          // Audit events recorded with the merge
+}
+
+// FindDuplicateCandidates retrieves the requests created since the given time by the same
+// submitter, for the same app and from the same device as request, newest first. Requests that
+// were merged or marked as spam are left out, and requests without an email have no candidates.
+func (r *supportRequestRepository) FindDuplicateCandidates(request *models.SupportRequest, since time.Time) ([]*models.SupportRequest, error) {
+	var requests []*models.SupportRequest
+	if request.UserEmail == nil || *request.UserEmail == "" {
+		return requests, nil
+	}
+	query := r.db.
+		Where("app = ?", request.App).
+		Where("LOWER(user_email) = ?", strings.ToLower(*request.UserEmail)).
+		Where("created_at >= ?", since).
+		Where("merged_into_id IS NULL AND status <> ?", models.StatusSpam)
+	if request.DeviceModel != "" {
+		query = query.Where("device_model = ?", request.DeviceModel)
+	}
+	if request.ID != 0 {
+		query = query.Where("id <> ?", request.ID)
+	}
+	err := query.Order("created_at DESC, id DESC").Limit(maxDuplicateCandidates).Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Merge moves the messages, attachments and tags of the source request to the target, repoints
+// the requests merged into or flagged as duplicates of the source, and saves both requests, in a
+// single transaction
+func (r *supportRequestRepository) Merge(merge *SupportRequestMerge) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if merge.Message != nil {
+			if err := tx.Create(merge.Message).Error; err != nil {
+				return err
+			}
+		}
+
+		messages := map[string]interface{}{"support_request_id": merge.Target.ID}
+		if merge.InternalMessages {
+			messages["visibility"] = models.MessageVisibilityInternal
+		}
+		err := tx.Model(&models.SupportRequestMessage{}).
+			Where("support_request_id = ?", merge.Source.ID).
+			Updates(messages).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Attachment{}).
+			Where("support_request_id = ?", merge.Source.ID).
+			Update("support_request_id", merge.Target.ID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := mergeTags(tx, merge.Source.ID, merge.Target.ID); err != nil {
+			return err
+		}
+
+		// Requests pointing at the source now point at the target, so stubs are never chained
+		for _, column := range []string{"merged_into_id", "duplicate_of_id"} {
+			err := tx.Model(&models.SupportRequest{}).
+				Where(column+" = ?", merge.Source.ID).
+				Update(column, merge.Target.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(merge.Source).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(merge.Target).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, merge.Events)
+	})
+}
+
+// mergeTags moves the tags of the source request to the target within tx
+func mergeTags(tx *gorm.DB, sourceID, targetID uint) error {
+	var tags []*models.Tag
+	err := tx.Model(&models.SupportRequest{ID: sourceID}).Association("Tags").Find(&tags)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+
+	// Bare models keep GORM from saving the requests along with their tags
+	if err := tx.Model(&models.SupportRequest{ID: targetID}).Omit("Tags.*").Association("Tags").Append(tags); err != nil {
+		return err
+	}
+	return tx.Model(&models.SupportRequest{ID: sourceID}).Association("Tags").Clear()
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SupportRequestDuplicatesTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    SupportRequestRepository
+	tagRepo TagRepository
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewSupportRequestRepository(db)
+	suite.tagRepo = NewTagRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{}, &models.SupportRequestMessage{}, &models.Attachment{}, &models.Tag{}, &models.AuditEvent{})
+	suite.Require().NoError(err)
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_request_tags")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM support_request_messages")
+	suite.db.Exec("DELETE FROM attachments")
+	suite.db.Exec("DELETE FROM support_requests")
+	suite.db.Exec("DELETE FROM audit_events")
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) createRequest(email, app, device string, createdAt time.Time) *models.SupportRequest {
+	request := &models.SupportRequest{
+		Type:        models.SupportRequestTypeSupport,
+		UserEmail:   &email,
+		Message:     "The app crashes on launch",
+		Platform:    models.PlatformIOS,
+		AppVersion:  "1.0.0",
+		DeviceModel: device,
+		App:         app,
+		Status:      models.StatusNew,
+		CreatedAt:   createdAt,
+	}
+	suite.Require().NoError(suite.repo.Create(request))
+	return request
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) TestFindDuplicateCandidates() {
+	// Arrange
+	now := time.Now()
+	older := suite.createRequest("jane@example.com", "test-app", "iPhone 14", now.Add(-2*time.Hour))
+	newer := suite.createRequest("Jane@Example.com", "test-app", "iPhone 14", now.Add(-time.Hour))
+	suite.createRequest("jane@example.com", "test-app", "iPhone 14", now.Add(-48*time.Hour))
+	suite.createRequest("jane@example.com", "other-app", "iPhone 14", now.Add(-time.Hour))
+	suite.createRequest("jane@example.com", "test-app", "Pixel 8", now.Add(-time.Hour))
+	suite.createRequest("john@example.com", "test-app", "iPhone 14", now.Add(-time.Hour))
+	spam := suite.createRequest("jane@example.com", "test-app", "iPhone 14", now.Add(-time.Hour))
+	spam.Status = models.StatusSpam
+	suite.Require().NoError(suite.repo.Update(spam))
+	merged := suite.createRequest("jane@example.com", "test-app", "iPhone 14", now.Add(-time.Hour))
+	merged.MergedIntoID = &older.ID
+	suite.Require().NoError(suite.repo.Update(merged))
+	request := suite.createRequest("jane@example.com", "test-app", "iPhone 14", now)
+
+	// Act
+	candidates, err := suite.repo.FindDuplicateCandidates(request, now.Add(-24*time.Hour))
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(candidates, 2)
+	assert.Equal(suite.T(), newer.ID, candidates[0].ID)
+	assert.Equal(suite.T(), older.ID, candidates[1].ID)
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) TestFindDuplicateCandidates_WithoutEmail() {
+	// Arrange
+	suite.createRequest("", "test-app", "iPhone 14", time.Now())
+	request := &models.SupportRequest{App: "test-app", DeviceModel: "iPhone 14"}
+
+	// Act
+	candidates, err := suite.repo.FindDuplicateCandidates(request, time.Now().Add(-time.Hour))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), candidates)
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) TestGetAll_SuspectedDuplicates() {
+	// Arrange
+	original := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	duplicate := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	duplicate.DuplicateOfID = &original.ID
+	suite.Require().NoError(suite.repo.Update(duplicate))
+	merged := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	merged.DuplicateOfID = &original.ID
+	merged.MergedIntoID = &original.ID
+	suite.Require().NoError(suite.repo.Update(merged))
+
+	// Act
+	requests, total, err := suite.repo.GetAll(SupportRequestFilter{SuspectedDuplicates: true}, 0, 10)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), total)
+	suite.Require().Len(requests, 1)
+	assert.Equal(suite.T(), duplicate.ID, requests[0].ID)
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) TestMerge() {
+	// Arrange
+	target := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	source := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	shared := &models.Tag{Name: "crash"}
+	suite.Require().NoError(suite.tagRepo.Create(shared))
+	login := &models.Tag{Name: "login"}
+	suite.Require().NoError(suite.tagRepo.Create(login))
+	suite.Require().NoError(suite.tagRepo.AddToSupportRequest(target, []*models.Tag{shared}))
+	suite.Require().NoError(suite.tagRepo.AddToSupportRequest(source, []*models.Tag{shared, login}))
+
+	reply := &models.SupportRequestMessage{SupportRequestID: source.ID, AuthorType: models.MessageAuthorAgent, Body: "Which version?", Visibility: models.MessageVisibilityPublic}
+	suite.Require().NoError(suite.db.Create(reply).Error)
+	attachment := &models.Attachment{SupportRequestID: source.ID, FileName: "crash.log", ContentType: "text/plain", Size: 3, StorageKey: "key", Checksum: "sum"}
+	suite.Require().NoError(suite.db.Create(attachment).Error)
+
+	earlier := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	earlier.MergedIntoID = &source.ID
+	suite.Require().NoError(suite.repo.Update(earlier))
+	flagged := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	flagged.DuplicateOfID = &source.ID
+	suite.Require().NoError(suite.repo.Update(flagged))
+
+	source.Status = models.StatusClosed
+	source.MergedIntoID = &target.ID
+	merge := &SupportRequestMerge{
+		Source:  source,
+		Target:  target,
+		Message: &models.SupportRequestMessage{SupportRequestID: target.ID, AuthorType: models.MessageAuthorSubmitter, Body: source.Message, Visibility: models.MessageVisibilityPublic},
+		Events: []*models.AuditEvent{
+			{Action: models.AuditActionMerge, EntityType: models.AuditEntitySupportRequest, EntityID: source.ID, Changes: "{}"},
+		},
+	}
+
+	// Act
+	err := suite.repo.Merge(merge)
+
+	// Assert
+	suite.Require().NoError(err)
+
+	var messages []*models.SupportRequestMessage
+	suite.db.Where("support_request_id = ?", target.ID).Order("id ASC").Find(&messages)
+	suite.Require().Len(messages, 2)
+	assert.Equal(suite.T(), "Which version?", messages[0].Body)
+	assert.Equal(suite.T(), models.MessageVisibilityPublic, messages[0].Visibility)
+	assert.Equal(suite.T(), source.Message, messages[1].Body)
+
+	var reloadedAttachment models.Attachment
+	suite.db.First(&reloadedAttachment, attachment.ID)
+	assert.Equal(suite.T(), target.ID, reloadedAttachment.SupportRequestID)
+
+	reloadedTarget, err := suite.repo.GetByID(target.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []string{"crash", "login"}, reloadedTarget.ToResponse().Tags)
+
+	reloadedSource, err := suite.repo.GetByID(source.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), reloadedSource.Tags)
+	assert.Equal(suite.T(), models.StatusClosed, reloadedSource.Status)
+	suite.Require().NotNil(reloadedSource.MergedIntoID)
+	assert.Equal(suite.T(), target.ID, *reloadedSource.MergedIntoID)
+
+	reloadedEarlier, err := suite.repo.GetByID(earlier.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), target.ID, *reloadedEarlier.MergedIntoID)
+	reloadedFlagged, err := suite.repo.GetByID(flagged.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), target.ID, *reloadedFlagged.DuplicateOfID)
+
+	var events int64
+	suite.db.Model(&models.AuditEvent{}).Count(&events)
+	assert.Equal(suite.T(), int64(1), events)
+}
+
+func (suite *SupportRequestDuplicatesTestSuite) TestMerge_InternalMessages() {
+	// Arrange
+	target := suite.createRequest("jane@example.com", "test-app", "iPhone 14", time.Now())
+	source := suite.createRequest("john@example.com", "test-app", "iPhone 14", time.Now())
+	reply := &models.SupportRequestMessage{SupportRequestID: source.ID, AuthorType: models.MessageAuthorAgent, Body: "Which version?", Visibility: models.MessageVisibilityPublic}
+	suite.Require().NoError(suite.db.Create(reply).Error)
+	source.MergedIntoID = &target.ID
+
+	// Act
+	err := suite.repo.Merge(&SupportRequestMerge{Source: source, Target: target, InternalMessages: true})
+
+	// Assert
+	suite.Require().NoError(err)
+	var moved models.SupportRequestMessage
+	suite.Require().NoError(suite.db.First(&moved, reply.ID).Error)
+	assert.Equal(suite.T(), target.ID, moved.SupportRequestID)
+	assert.Equal(suite.T(), models.MessageVisibilityInternal, moved.Visibility)
+}
+
+func TestSupportRequestDuplicatesTestSuite(t *testing.T) {
+	suite.Run(t, new(SupportRequestDuplicatesTestSuite))
+}
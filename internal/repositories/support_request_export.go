@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"strings"
+	"support-app-backend/internal/models"
+)
+
+// exportTagExpressions select the comma-separated, alphabetically ordered tag names of each support
+// request per database, so that tags are read in the same query as the requests
+var exportTagExpressions = map[string]string{
+	"postgres": "(SELECT string_agg(tags.name, ',' ORDER BY tags.name) FROM tags " +
+		"JOIN support_request_tags ON support_request_tags.tag_id = tags.id " +
+		"WHERE support_request_tags.support_request_id = support_requests.id)",
+	"sqlite": "(SELECT group_concat(name, ',') FROM (SELECT tags.name FROM tags " +
+		"JOIN support_request_tags ON support_request_tags.tag_id = tags.id " +
+		"WHERE support_request_tags.support_request_id = support_requests.id ORDER BY tags.name))",
+}
+
+// Export calls fn with each support request matching the filter, in the filter's sort order, with
+// its tags. Rows are read one at a time from a database cursor rather than loaded up front, so the
+// connection stays busy until fn has seen every request or returns an error, which stops the export.
+func (r *supportRequestRepository) Export(filter SupportRequestFilter, fn func(*models.SupportRequest) error) error {
+	dialect := r.db.Dialector.Name()
+	if dialect != "postgres" {
+		dialect = "sqlite"
+	}
+
+	rows, err := r.filtered(filter).
+		Select("support_requests.*, " + exportTagExpressions[dialect] + " AS tag_names").
+		Order(supportRequestOrderClause(filter)).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row struct {
+			models.SupportRequest
+			TagNames *string
+		}
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+
+		request := row.SupportRequest
+		if row.TagNames != nil {
+			for _, name := range strings.Split(*row.TagNames, ",") {
+				request.Tags = append(request.Tags, &models.Tag{Name: name})
+			}
+		}
+		if err := fn(&request); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
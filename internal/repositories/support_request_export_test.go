@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SupportRequestExportTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    SupportRequestRepository
+	tagRepo TagRepository
+}
+
+func (suite *SupportRequestExportTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewSupportRequestRepository(db)
+	suite.tagRepo = NewTagRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{}, &models.Tag{})
+	suite.Require().NoError(err)
+}
+
+func (suite *SupportRequestExportTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_request_tags")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM support_requests")
+}
+
+func (suite *SupportRequestExportTestSuite) createRequest(app, message string, createdAt time.Time) *models.SupportRequest {
+	request := &models.SupportRequest{
+		Type:        models.SupportRequestTypeBugReport,
+		Message:     message,
+		Platform:    models.PlatformIOS,
+		AppVersion:  "1.0.0",
+		DeviceModel: "iPhone 13",
+		App:         app,
+		Status:      models.StatusNew,
+		CreatedAt:   createdAt,
+	}
+	suite.Require().NoError(suite.repo.Create(request))
+	return request
+}
+
+// export collects the exported support requests
+func (suite *SupportRequestExportTestSuite) export(filter SupportRequestFilter) []*models.SupportRequest {
+	var requests []*models.SupportRequest
+	err := suite.repo.Export(filter, func(request *models.SupportRequest) error {
+		requests = append(requests, request)
+		return nil
+	})
+	suite.Require().NoError(err)
+	return requests
+}
+
+func (suite *SupportRequestExportTestSuite) TestExport_FilterOrderAndTags() {
+	// Arrange
+	createdAt := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	older := suite.createRequest("app-a", "Crash, with \"quotes\"\nand a newline", createdAt)
+	newer := suite.createRequest("app-a", "Login fails", createdAt.Add(time.Hour))
+	suite.createRequest("app-b", "Other app", createdAt)
+	deleted := suite.createRequest("app-a", "Deleted", createdAt)
+	suite.Require().NoError(suite.repo.Delete(deleted.ID))
+
+	payments := &models.Tag{Name: "payments"}
+	login := &models.Tag{Name: "login"}
+	suite.Require().NoError(suite.tagRepo.Create(payments))
+	suite.Require().NoError(suite.tagRepo.Create(login))
+	suite.Require().NoError(suite.tagRepo.AddToSupportRequest(older, []*models.Tag{payments, login}))
+
+	// Act
+	requests := suite.export(SupportRequestFilter{App: "app-a", SortBy: "created_at", SortOrder: SortOrderAsc})
+
+	// Assert
+	suite.Require().Len(requests, 2)
+	assert.Equal(suite.T(), older.ID, requests[0].ID)
+	assert.Equal(suite.T(), older.Message, requests[0].Message)
+	assert.Equal(suite.T(), []string{"login", "payments"}, requests[0].ToResponse().Tags)
+	assert.Equal(suite.T(), newer.ID, requests[1].ID)
+	assert.Empty(suite.T(), requests[1].Tags)
+}
+
+func (suite *SupportRequestExportTestSuite) TestExport_StopsOnError() {
+	// Arrange
+	createdAt := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	suite.createRequest("app-a", "First", createdAt)
+	suite.createRequest("app-a", "Second", createdAt)
+	stop := errors.New("client went away")
+
+	// Act
+	calls := 0
+	err := suite.repo.Export(SupportRequestFilter{}, func(*models.SupportRequest) error {
+		calls++
+		return stop
+	})
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, stop)
+	assert.Equal(suite.T(), 1, calls)
+}
+
+func (suite *SupportRequestExportTestSuite) TestExport_Empty() {
+	// Act
+	requests := suite.export(SupportRequestFilter{})
+
+	// Assert
+	assert.Empty(suite.T(), requests)
+}
+
+func TestSupportRequestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(SupportRequestExportTestSuite))
+}
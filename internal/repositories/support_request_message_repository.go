@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SupportRequestMessageRepository defines the interface for support request message data operations
+type SupportRequestMessageRepository interface {
+	Create(message *models.SupportRequestMessage, supportRequest *models.SupportRequest, events ...*models.AuditEvent) error
+	GetBySupportRequestID(supportRequestID uint, includeInternal bool) ([]*models.SupportRequestMessage, error)
+}
+
+// supportRequestMessageRepository implements SupportRequestMessageRepository
+type supportRequestMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewSupportRequestMessageRepository creates a new support request message repository
+func NewSupportRequestMessageRepository(db *gorm.DB) SupportRequestMessageRepository {
+	return &supportRequestMessageRepository{
+		db: db,
+	}
+}
+
+// Create creates a new support request message and saves the support request it was posted on,
+// recording the given audit events in the same transaction
+func (r *supportRequestMessageRepository) Create(message *models.SupportRequestMessage, supportRequest *models.SupportRequest, events ...*models.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(supportRequest).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, events)
+	})
+}
+
+// GetBySupportRequestID retrieves the conversation of a support request, oldest message first
+func (r *supportRequestMessageRepository) GetBySupportRequestID(supportRequestID uint, includeInternal bool) ([]*models.SupportRequestMessage, error) {
+	var messages []*models.SupportRequestMessage
+
+	query := r.db.Where("support_request_id = ?", supportRequestID)
+	if !includeInternal {
+		query = query.Where("visibility = ?", models.MessageVisibilityPublic)
+	}
+
+	err := query.Order("created_at ASC, id ASC").Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SupportRequestMessageRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo SupportRequestMessageRepository
+}
+
+func (suite *SupportRequestMessageRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewSupportRequestMessageRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{}, &models.SupportRequestMessage{}, &models.AuditEvent{})
+	suite.Require().NoError(err)
+}
+
+func (suite *SupportRequestMessageRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_request_messages")
+	suite.db.Exec("DELETE FROM support_requests")
+	suite.db.Exec("DELETE FROM audit_events")
+}
+
+func (suite *SupportRequestMessageRepositoryTestSuite) TestCreate() {
+	// Arrange
+	supportRequest := &models.SupportRequest{
+		Type:        models.SupportRequestTypeSupport,
+		Message:     "Where is my invoice?",
+		Platform:    models.PlatformWeb,
+		AppVersion:  "1.0.0",
+		DeviceModel: "Chrome",
+		App:         "test-app",
+		Status:      models.StatusNew,
+	}
+	suite.Require().NoError(suite.db.Create(supportRequest).Error)
+	agentID := uint(1)
+	message := &models.SupportRequestMessage{
+		SupportRequestID: supportRequest.ID,
+		AuthorType:       models.MessageAuthorAgent,
+		AuthorUserID:     &agentID,
+		Body:             "We are looking into it",
+		Visibility:       models.MessageVisibilityPublic,
+	}
+	supportRequest.Status = models.StatusInProgress
+	event := &models.AuditEvent{ActorUsername: "agent", Action: models.AuditActionUpdate, EntityType: models.AuditEntitySupportRequest, EntityID: supportRequest.ID, Changes: "{}"}
+
+	// Act
+	err := suite.repo.Create(message, supportRequest, event)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotZero(suite.T(), message.ID)
+	assert.NotZero(suite.T(), message.CreatedAt)
+
+	var saved models.SupportRequest
+	suite.Require().NoError(suite.db.First(&saved, supportRequest.ID).Error)
+	assert.Equal(suite.T(), models.StatusInProgress, saved.Status)
+
+	var events int64
+	suite.db.Model(&models.AuditEvent{}).Count(&events)
+	assert.Equal(suite.T(), int64(1), events)
+}
+
+func (suite *SupportRequestMessageRepositoryTestSuite) TestGetBySupportRequestID() {
+	// Arrange
+	messages := []*models.SupportRequestMessage{
+		{SupportRequestID: 1, AuthorType: models.MessageAuthorSubmitter, Body: "First", Visibility: models.MessageVisibilityPublic},
+		{SupportRequestID: 1, AuthorType: models.MessageAuthorAgent, Body: "Internal", Visibility: models.MessageVisibilityInternal},
+		{SupportRequestID: 1, AuthorType: models.MessageAuthorAgent, Body: "Reply", Visibility: models.MessageVisibilityPublic},
+		{SupportRequestID: 2, AuthorType: models.MessageAuthorSubmitter, Body: "Other ticket", Visibility: models.MessageVisibilityPublic},
+	}
+	for _, message := range messages {
+		suite.Require().NoError(suite.db.Create(message).Error)
+	}
+
+	// Act
+	all, err := suite.repo.GetBySupportRequestID(1, true)
+	suite.Require().NoError(err)
+	public, err := suite.repo.GetBySupportRequestID(1, false)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Require().Len(all, 3)
+	assert.Equal(suite.T(), "First", all[0].Body)
+	assert.Equal(suite.T(), "Internal", all[1].Body)
+	assert.Equal(suite.T(), "Reply", all[2].Body)
+
+	suite.Require().Len(public, 2)
+	assert.Equal(suite.T(), "First", public[0].Body)
+	assert.Equal(suite.T(), "Reply", public[1].Body)
+}
+
+func (suite *SupportRequestMessageRepositoryTestSuite) TestGetBySupportRequestID_Empty() {
+	// Act
+	messages, err := suite.repo.GetBySupportRequestID(99, true)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), messages)
+}
+
+func TestSupportRequestMessageRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(SupportRequestMessageRepositoryTestSuite))
+}
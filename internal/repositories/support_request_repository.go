@@ -1,18 +1,103 @@
 package repositories
 
 import (
+	"strings"
 	"support-app-backend/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Sort orders accepted by SupportRequestFilter
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// SLA states accepted by SupportRequestFilter
+const (
+	SLAStateBreached = "breached" // An unmet deadline has passed
+	SLAStateDueSoon  = "due_soon" // An unmet deadline falls within the due soon window and none has passed
+)
+
+// Tag match modes accepted by SupportRequestFilter
+const (
+	TagMatchAny = "any" // Requests carrying at least one of the tags
+	TagMatchAll = "all" // Requests carrying every one of the tags
+)
+
+// SupportRequestSortFields lists the columns support requests can be sorted by
+var SupportRequestSortFields = []string{
+	"id",
+	"created_at",
+	"updated_at",
+	"status",
+	"type",
+	"platform",
+	"app",
+	"app_version",
+}
+
+// SupportRequestFilter holds the optional criteria used to narrow down support request listings.
+// Zero values are ignored, so an empty filter matches every support request.
+type SupportRequestFilter struct {
+	Scope               models.OrganizationScope // Organization the caller may see, set from the caller rather than the query
+	Statuses            []models.Status
+	Types               []models.SupportRequestType
+	Platforms           []models.Platform
+	Priorities          []models.Priority
+	App                 string
+	AppVersion          string
+	UserEmail           string
+	AssigneeID          *uint    // Only requests assigned to this user
+	Unassigned          bool     // Only requests without an assignee, ignored when AssigneeID is set
+	Tags                []string // Tag names, combined according to TagMatch
+	TagMatch            string   // TagMatchAny or TagMatchAll, defaults to TagMatchAny
+	CreatedAfter        *time.Time
+	CreatedBefore       *time.Time
+	UpdatedAfter        *time.Time
+	UpdatedBefore       *time.Time
+	SLA                 string        // SLAStateBreached or SLAStateDueSoon, only matches requests the SLA still applies to
+	SLAAsOf             time.Time     // Reference time for SLA, required when SLA is set
+	SLADueSoon          time.Duration // Window after SLAAsOf that counts as due soon
+	SuspectedDuplicates bool          // Only requests flagged as a suspected duplicate and not merged yet
+	SortBy              string        // One of SupportRequestSortFields, defaults to created_at
+	SortOrder           string        // SortOrderAsc or SortOrderDesc, defaults to SortOrderDesc
+}
+
+// SupportRequestSearchHit is a support request matched by a full-text search
+type SupportRequestSearchHit struct {
+	Request *models.SupportRequest
+	Rank    float64 // Relevance score, higher is better
+	Snippet string  // Excerpt with matches wrapped in <mark></mark>
+}
+
+// IsValidSupportRequestSortField reports whether field can be used to sort support requests
+func IsValidSupportRequestSortField(field string) bool {
+	for _, f := range SupportRequestSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 // SupportRequestRepository defines the interface for support request data operations
 type SupportRequestRepository interface {
 	Create(request *models.SupportRequest) error
-	GetByID(id uint) (*models.SupportRequest, error)
-	GetAll(offset, limit int) ([]*models.SupportRequest, int64, error)
-	Update(request *models.SupportRequest) error
-	Delete(id uint) error
+	GetByID(id uint, scope models.OrganizationScope) (*models.SupportRequest, error)
+	GetByIDs(ids []uint, scope models.OrganizationScope) ([]*models.SupportRequest, error)
+	GetByTrackingTokenHash(hash string) (*models.SupportRequest, error)
+	GetAll(filter SupportRequestFilter, offset, limit int) ([]*models.SupportRequest, int64, error)
+	Search(query string, filter SupportRequestFilter, offset, limit int) ([]*SupportRequestSearchHit, int64, error)
+	Stats(filter SupportRequestFilter, interval string) (*SupportRequestStats, error)
+	Export(filter SupportRequestFilter, fn func(*models.SupportRequest) error) error
+	Update(request *models.SupportRequest, events ...*models.AuditEvent) error
+	Delete(id uint, events ...*models.AuditEvent) error
+	ApplyChanges(changes []*SupportRequestChange) error
+	FindDuplicateCandidates(request *models.SupportRequest, since time.Time) ([]*models.SupportRequest, error)
+	Merge(merge *SupportRequestMerge) error
 }
 
 // supportRequestRepository implements SupportRequestRepository
@@ -32,28 +117,40 @@ func (r *supportRequestRepository) Create(request *models.SupportRequest) error
 	return r.db.Create(request).Error
 }
 
-// GetByID retrieves a support request by ID
-func (r *supportRequestRepository) GetByID(id uint) (*models.SupportRequest, error) {
+// GetByID retrieves a support request by ID. Requests outside scope are reported as not found.
+func (r *supportRequestRepository) GetByID(id uint, scope models.OrganizationScope) (*models.SupportRequest, error) {
+	var request models.SupportRequest
+	err := applyOrganizationScope(r.db, scope).Preload("Tags", orderTagsByName).First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetByTrackingTokenHash retrieves the support request whose tracking token has the given hash
+func (r *supportRequestRepository) GetByTrackingTokenHash(hash string) (*models.SupportRequest, error) {
 	var request models.SupportRequest
-	err := r.db.First(&request, id).Error
+	err := r.db.Where("tracking_token_hash = ?", hash).First(&request).Error
 	if err != nil {
 		return nil, err
 	}
 	return &request, nil
 }
 
-// GetAll retrieves all support requests with pagination
-func (r *supportRequestRepository) GetAll(offset, limit int) ([]*models.SupportRequest, int64, error) {
+// GetAll retrieves support requests matching the filter with pagination
+func (r *supportRequestRepository) GetAll(filter SupportRequestFilter, offset, limit int) ([]*models.SupportRequest, int64, error) {
 	var requests []*models.SupportRequest
 	var total int64
 
-	// Count total records
-	if err := r.db.Model(&models.SupportRequest{}).Count(&total).Error; err != nil {
+	query := applySupportRequestFilter(r.db.Model(&models.SupportRequest{}), filter)
+
+	// Count total matching records
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err := r.db.Offset(offset).Limit(limit).Order("created_at DESC").Find(&requests).Error
+	err := query.Preload("Tags", orderTagsByName).Offset(offset).Limit(limit).Order(supportRequestOrderClause(filter)).Find(&requests).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -61,12 +158,164 @@ func (r *supportRequestRepository) GetAll(offset, limit int) ([]*models.SupportR
 	return requests, total, nil
 }
 
-// Update updates a support request
-func (r *supportRequestRepository) Update(request *models.SupportRequest) error {
-	return r.db.Save(request).Error
+// Search performs a ranked full-text search over support request messages and admin notes.
+// PostgreSQL uses the tsvector expression indexed by migration 004; other databases (the SQLite
+// database used in tests) fall back to case-insensitive LIKE matching ranked in memory.
+func (r *supportRequestRepository) Search(query string, filter SupportRequestFilter, offset, limit int) ([]*SupportRequestSearchHit, int64, error) {
+	if r.db.Dialector.Name() == "postgres" {
+		return r.searchPostgres(query, filter, offset, limit)
+	}
+	return r.searchFallback(query, filter, offset, limit)
+}
+
+// Update updates a support request and records the given audit events in the same transaction.
+// Associations such as tags are managed through their own repositories and are left untouched.
+func (r *supportRequestRepository) Update(request *models.SupportRequest, events ...*models.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(request).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, events)
+	})
+}
+
+// Delete soft deletes a support request and records the given audit events in the same transaction
+func (r *supportRequestRepository) Delete(id uint, events ...*models.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.SupportRequest{}, id).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, events)
+	})
 }
 
-// Delete soft deletes a support request
-func (r *supportRequestRepository) Delete(id uint) error {
-	return r.db.Delete(&models.SupportRequest{}, id).Error
+// applyOrganizationScope limits query to the support requests visible in scope
+func applyOrganizationScope(query *gorm.DB, scope models.OrganizationScope) *gorm.DB {
+	if scope.IsUnscoped() {
+		return query
+	}
+	return query.Where("support_requests.organization_id = ?", *scope.OrganizationID)
+}
+
+// applySupportRequestFilter adds the WHERE conditions described by filter to query
+func applySupportRequestFilter(query *gorm.DB, filter SupportRequestFilter) *gorm.DB {
+	query = applyOrganizationScope(query, filter.Scope)
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+	if len(filter.Platforms) > 0 {
+		query = query.Where("platform IN ?", filter.Platforms)
+	}
+	if len(filter.Priorities) > 0 {
+		query = query.Where("priority IN ?", filter.Priorities)
+	}
+	if filter.App != "" {
+		query = query.Where("app = ?", filter.App)
+	}
+	if filter.AppVersion != "" {
+		query = query.Where("app_version = ?", filter.AppVersion)
+	}
+	if filter.UserEmail != "" {
+		query = query.Where("LOWER(user_email) = ?", strings.ToLower(filter.UserEmail))
+	}
+	if filter.AssigneeID != nil {
+		query = query.Where("assignee_id = ?", *filter.AssigneeID)
+	} else if filter.Unassigned {
+		query = query.Where("assignee_id IS NULL")
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.UpdatedAfter != nil {
+		query = query.Where("updated_at >= ?", *filter.UpdatedAfter)
+	}
+	if filter.UpdatedBefore != nil {
+		query = query.Where("updated_at < ?", *filter.UpdatedBefore)
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where("support_requests.id IN (?)", taggedSupportRequestIDs(query, filter.Tags, filter.TagMatch))
+	}
+	if filter.SuspectedDuplicates {
+		query = query.Where("duplicate_of_id IS NOT NULL AND merged_into_id IS NULL")
+	}
+	switch filter.SLA {
+	case SLAStateBreached:
+		query = query.Where("status NOT IN ?", models.SLAStoppedStatuses).
+			Where(slaDeadlineBefore, filter.SLAAsOf, filter.SLAAsOf)
+	case SLAStateDueSoon:
+		dueSoon := filter.SLAAsOf.Add(filter.SLADueSoon)
+		query = query.Where("status NOT IN ?", models.SLAStoppedStatuses).
+			Where(slaDeadlineBefore, dueSoon, dueSoon).
+			Not(slaDeadlineBefore, filter.SLAAsOf, filter.SLAAsOf)
+	}
+	return query
+}
+
+// taggedSupportRequestIDs builds a subquery selecting the IDs of support requests tagged with any
+// (or, for TagMatchAll, every) one of names
+func taggedSupportRequestIDs(query *gorm.DB, names []string, match string) *gorm.DB {
+	subquery := query.Session(&gorm.Session{NewDB: true}).
+		Table("support_request_tags").
+		Select("support_request_tags.support_request_id").
+		Joins("JOIN tags ON tags.id = support_request_tags.tag_id").
+		Where("tags.name IN ?", names)
+	if match == TagMatchAll {
+		subquery = subquery.
+			Group("support_request_tags.support_request_id").
+			Having("COUNT(DISTINCT tags.id) = ?", len(uniqueStrings(names)))
+	}
+	return subquery
+}
+
+// uniqueStrings returns values without duplicates, keeping the first occurrence
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// orderTagsByName sorts preloaded tags alphabetically
+func orderTagsByName(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name ASC")
+}
+
+// slaDeadlineBefore matches support requests with an unmet deadline before the given time (passed twice).
+// The first response deadline is met once an agent has replied; the resolution deadline is met by a
+// status in models.SLAStoppedStatuses, which callers exclude separately.
+// The IS NOT NULL guards keep the expression false rather than NULL when a deadline is unset, so it
+// can safely be negated.
+const slaDeadlineBefore = "(first_responded_at IS NULL AND first_response_due_at IS NOT NULL AND first_response_due_at < ?) OR " +
+	"(resolution_due_at IS NOT NULL AND resolution_due_at < ?)"
+
+// supportRequestOrderClause builds the ORDER BY clause for filter, falling back to newest first.
+// Only whitelisted columns are used so the clause is safe to pass to GORM verbatim.
+func supportRequestOrderClause(filter SupportRequestFilter) string {
+	sortBy := "created_at"
+	if IsValidSupportRequestSortField(filter.SortBy) {
+		sortBy = filter.SortBy
+	}
+
+	sortOrder := "DESC"
+	if strings.EqualFold(filter.SortOrder, SortOrderAsc) {
+		sortOrder = "ASC"
+	}
+
+	if sortBy == "id" {
+		return "id " + sortOrder
+	}
+
+	// Tie-break on id so pagination is stable when the sort column has duplicates
+	return sortBy + " " + sortOrder + ", id " + sortOrder
 }
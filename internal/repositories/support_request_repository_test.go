@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"strings"
 	"support-app-backend/internal/models"
 	"testing"
 	"time"
@@ -32,7 +33,7 @@ func (suite *SupportRequestRepositoryTestSuite) SetupSuite() {
 	suite.repo = NewSupportRequestRepository(db)
 
 	// Auto migrate
-	err = db.AutoMigrate(&models.SupportRequest{})
+	err = db.AutoMigrate(&models.SupportRequest{}, &models.Attachment{}, &models.AuditEvent{})
 	suite.Require().NoError(err)
 }
 
@@ -42,7 +43,11 @@ func (suite *SupportRequestRepositoryTestSuite) SetupTest() {
 		return
 	}
 	// Clean up before each test
+	suite.db.Exec("DELETE FROM support_request_tags")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM attachments")
 	suite.db.Exec("DELETE FROM support_requests")
+	suite.db.Exec("DELETE FROM audit_events")
 }
 
 func (suite *SupportRequestRepositoryTestSuite) TearDownSuite() {
@@ -81,6 +86,33 @@ func (suite *SupportRequestRepositoryTestSuite) TestCreate() {
 	assert.NotZero(suite.T(), request.UpdatedAt)
 }
 
+func (suite *SupportRequestRepositoryTestSuite) TestCreate_WithAttachments() {
+	// Arrange
+	request := &models.SupportRequest{
+		Type:        models.SupportRequestTypeBugReport,
+		Message:     "Crash on launch",
+		Platform:    models.PlatformIOS,
+		AppVersion:  "1.0.0",
+		DeviceModel: "iPhone 13",
+		App:         "test-app",
+		Status:      models.StatusNew,
+		Attachments: []*models.Attachment{
+			{FileName: "crash.log", ContentType: "text/plain", Size: 11, StorageKey: "attachments/abc", Checksum: strings.Repeat("0", 64)},
+		},
+	}
+
+	// Act
+	err := suite.repo.Create(request)
+
+	// Assert
+	suite.Require().NoError(err)
+	var attachments []*models.Attachment
+	suite.Require().NoError(suite.db.Where("support_request_id = ?", request.ID).Find(&attachments).Error)
+	suite.Require().Len(attachments, 1)
+	assert.Equal(suite.T(), "attachments/abc", attachments[0].StorageKey)
+	assert.Equal(suite.T(), request.Attachments[0].ID, attachments[0].ID)
+}
+
 func (suite *SupportRequestRepositoryTestSuite) TestGetByID() {
 	if suite.db == nil {
 		suite.T().Skip("Database not available")
@@ -104,7 +136,7 @@ func (suite *SupportRequestRepositoryTestSuite) TestGetByID() {
 	suite.Require().NoError(err)
 
 	// Act
-	retrievedRequest, err := suite.repo.GetByID(originalRequest.ID)
+	retrievedRequest, err := suite.repo.GetByID(originalRequest.ID, models.OrganizationScope{})
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -121,13 +153,106 @@ func (suite *SupportRequestRepositoryTestSuite) TestGetByID_NotFound() {
 	}
 
 	// Act
-	retrievedRequest, err := suite.repo.GetByID(999)
+	retrievedRequest, err := suite.repo.GetByID(999, models.OrganizationScope{})
 
 	// Assert
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), retrievedRequest)
 }
 
+func (suite *SupportRequestRepositoryTestSuite) TestGetByTrackingTokenHash() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	hash := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+	tracked := &models.SupportRequest{
+		Type:              models.SupportRequestTypeSupport,
+		Message:           "Tracked message",
+		Platform:          models.PlatformIOS,
+		AppVersion:        "1.0.0",
+		DeviceModel:       "iPhone 13",
+		App:               "test-app",
+		Status:            models.StatusNew,
+		TrackingTokenHash: &hash,
+	}
+	untracked := &models.SupportRequest{
+		Type:        models.SupportRequestTypeSupport,
+		Message:     "Submitted before tracking tokens",
+		Platform:    models.PlatformIOS,
+		AppVersion:  "1.0.0",
+		DeviceModel: "iPhone 13",
+		App:         "test-app",
+		Status:      models.StatusNew,
+	}
+	suite.Require().NoError(suite.repo.Create(tracked))
+	suite.Require().NoError(suite.repo.Create(untracked))
+
+	// Act
+	found, err := suite.repo.GetByTrackingTokenHash(hash)
+	_, missingErr := suite.repo.GetByTrackingTokenHash("unknown")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), tracked.ID, found.ID)
+	assert.ErrorIs(suite.T(), missingErr, gorm.ErrRecordNotFound)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetByID_OutsideScope() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	organizationID := uint(1)
+	owned := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-a", OrganizationID: &organizationID, Status: models.StatusNew}
+	unowned := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew}
+	suite.Require().NoError(suite.repo.Create(owned))
+	suite.Require().NoError(suite.repo.Create(unowned))
+
+	// Act
+	_, ownScopeErr := suite.repo.GetByID(owned.ID, models.ScopeToOrganization(1))
+	_, otherScopeErr := suite.repo.GetByID(owned.ID, models.ScopeToOrganization(2))
+	_, unownedErr := suite.repo.GetByID(unowned.ID, models.ScopeToOrganization(1))
+	_, unscopedErr := suite.repo.GetByID(unowned.ID, models.OrganizationScope{})
+
+	// Assert
+	assert.NoError(suite.T(), ownScopeErr)
+	assert.ErrorIs(suite.T(), otherScopeErr, gorm.ErrRecordNotFound)
+	assert.ErrorIs(suite.T(), unownedErr, gorm.ErrRecordNotFound)
+	assert.NoError(suite.T(), unscopedErr)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_Scope() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	first, second := uint(1), uint(2)
+	for _, organizationID := range []*uint{&first, &first, &second, nil} {
+		req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", OrganizationID: organizationID, Status: models.StatusNew}
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	// Act
+	scoped, scopedTotal, err := suite.repo.GetAll(SupportRequestFilter{Scope: models.ScopeToOrganization(1)}, 0, 10)
+	suite.Require().NoError(err)
+	_, unscopedTotal, err := suite.repo.GetAll(SupportRequestFilter{}, 0, 10)
+	suite.Require().NoError(err)
+
+	// Assert
+	assert.Equal(suite.T(), int64(2), scopedTotal)
+	for _, req := range scoped {
+		assert.Equal(suite.T(), first, *req.OrganizationID)
+	}
+	assert.Equal(suite.T(), int64(4), unscopedTotal)
+}
+
 func (suite *SupportRequestRepositoryTestSuite) TestGetAll() {
 	if suite.db == nil {
 		suite.T().Skip("Database not available")
@@ -161,7 +286,7 @@ func (suite *SupportRequestRepositoryTestSuite) TestGetAll() {
 	}
 
 	// Act
-	retrievedRequests, total, err := suite.repo.GetAll(0, 10)
+	retrievedRequests, total, err := suite.repo.GetAll(SupportRequestFilter{}, 0, 10)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -203,7 +328,7 @@ func (suite *SupportRequestRepositoryTestSuite) TestUpdate() {
 	assert.NoError(suite.T(), err)
 
 	// Verify update
-	updatedRequest, err := suite.repo.GetByID(request.ID)
+	updatedRequest, err := suite.repo.GetByID(request.ID, models.OrganizationScope{})
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), models.StatusInProgress, updatedRequest.Status)
 	assert.Equal(suite.T(), "Admin updated this", *updatedRequest.AdminNotes)
@@ -237,11 +362,336 @@ func (suite *SupportRequestRepositoryTestSuite) TestDelete() {
 	assert.NoError(suite.T(), err)
 
 	// Verify deletion (soft delete)
-	deletedRequest, err := suite.repo.GetByID(request.ID)
+	deletedRequest, err := suite.repo.GetByID(request.ID, models.OrganizationScope{})
 	assert.Error(suite.T(), err) // Should not be found due to soft delete
 	assert.Nil(suite.T(), deletedRequest)
 }
 
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_WithFilter() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	userEmail := "Tester@Example.com"
+	requests := []*models.SupportRequest{
+		{Type: models.SupportRequestTypeBugReport, UserEmail: &userEmail, Message: "Crash", Platform: models.PlatformIOS, AppVersion: "1.4.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew},
+		{Type: models.SupportRequestTypeBugReport, Message: "Freeze", Platform: models.PlatformIOS, AppVersion: "1.4.0", DeviceModel: "iPhone 14", App: "app-x", Status: models.StatusInProgress},
+		{Type: models.SupportRequestTypeBugReport, Message: "Fixed", Platform: models.PlatformIOS, AppVersion: "1.3.0", DeviceModel: "iPhone 12", App: "app-x", Status: models.StatusResolved},
+		{Type: models.SupportRequestTypeFeedback, Message: "Nice", Platform: models.PlatformAndroid, AppVersion: "1.4.0", DeviceModel: "Pixel 7", App: "app-y", Status: models.StatusNew},
+	}
+	for _, req := range requests {
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	// Act - open iOS bug reports for app-x
+	filter := SupportRequestFilter{
+		Statuses:  []models.Status{models.StatusNew, models.StatusInProgress},
+		Types:     []models.SupportRequestType{models.SupportRequestTypeBugReport},
+		Platforms: []models.Platform{models.PlatformIOS},
+		App:       "app-x",
+	}
+	results, total, err := suite.repo.GetAll(filter, 0, 1)
+
+	// Assert - total reflects the filter, not the page
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+	assert.Len(suite.T(), results, 1)
+
+	// Email filter is case-insensitive
+	results, total, err = suite.repo.GetAll(SupportRequestFilter{UserEmail: "tester@example.com"}, 0, 10)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), "Crash", results[0].Message)
+
+	// App version filter
+	_, total, err = suite.repo.GetAll(SupportRequestFilter{AppVersion: "1.4.0"}, 0, 10)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), total)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_DateRange() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	now := time.Now()
+	old := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Old", Platform: models.PlatformWeb, AppVersion: "1.0.0", DeviceModel: "Chrome", App: "app-x", Status: models.StatusNew}
+	recent := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Recent", Platform: models.PlatformWeb, AppVersion: "1.0.0", DeviceModel: "Chrome", App: "app-x", Status: models.StatusNew}
+	suite.Require().NoError(suite.repo.Create(old))
+	suite.Require().NoError(suite.repo.Create(recent))
+	suite.db.Model(old).UpdateColumn("created_at", now.Add(-72*time.Hour))
+
+	// Act
+	since := now.Add(-24 * time.Hour)
+	results, total, err := suite.repo.GetAll(SupportRequestFilter{CreatedAfter: &since}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), "Recent", results[0].Message)
+
+	results, total, err = suite.repo.GetAll(SupportRequestFilter{CreatedBefore: &since}, 0, 10)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), "Old", results[0].Message)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_Sort() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	for _, version := range []string{"2.0.0", "1.0.0", "3.0.0"} {
+		req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Message " + version, Platform: models.PlatformIOS, AppVersion: version, DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew}
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	// Act
+	results, _, err := suite.repo.GetAll(SupportRequestFilter{SortBy: "app_version", SortOrder: SortOrderAsc}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.Require().Len(results, 3)
+	assert.Equal(suite.T(), "1.0.0", results[0].AppVersion)
+	assert.Equal(suite.T(), "2.0.0", results[1].AppVersion)
+	assert.Equal(suite.T(), "3.0.0", results[2].AppVersion)
+
+	// Unknown sort fields fall back to the default ordering instead of reaching SQL
+	_, _, err = suite.repo.GetAll(SupportRequestFilter{SortBy: "id; DROP TABLE support_requests"}, 0, 10)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_Assignee() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	agentID := uint(5)
+	otherAgentID := uint(6)
+	for _, assignee := range []*uint{&agentID, &otherAgentID, nil} {
+		req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew, AssigneeID: assignee}
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	// Act
+	results, total, err := suite.repo.GetAll(SupportRequestFilter{AssigneeID: &agentID}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), agentID, *results[0].AssigneeID)
+
+	results, total, err = suite.repo.GetAll(SupportRequestFilter{Unassigned: true}, 0, 10)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Nil(suite.T(), results[0].AssigneeID)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_SLA() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	now := time.Now()
+	at := func(offset time.Duration) *time.Time {
+		t := now.Add(offset)
+		return &t
+	}
+	requests := []*models.SupportRequest{
+		// First response overdue
+		{Message: "Unanswered", Status: models.StatusNew, FirstResponseDueAt: at(-time.Hour), ResolutionDueAt: at(24 * time.Hour)},
+		// Answered in time, but resolution overdue
+		{Message: "Slow fix", Status: models.StatusInProgress, FirstResponseDueAt: at(-48 * time.Hour), FirstRespondedAt: at(-50 * time.Hour), ResolutionDueAt: at(-time.Minute)},
+		// Overdue but resolved, so the SLA no longer applies
+		{Message: "Done", Status: models.StatusResolved, FirstResponseDueAt: at(-time.Hour), ResolutionDueAt: at(-time.Hour)},
+		// First response due in 30 minutes
+		{Message: "Due soon", Status: models.StatusNew, FirstResponseDueAt: at(30 * time.Minute), ResolutionDueAt: at(48 * time.Hour)},
+		// Answered, resolution far away
+		{Message: "On track", Status: models.StatusInProgress, FirstResponseDueAt: at(30 * time.Minute), FirstRespondedAt: at(-time.Minute), ResolutionDueAt: at(48 * time.Hour)},
+		// No deadlines at all
+		{Message: "No SLA", Status: models.StatusNew},
+	}
+	for _, req := range requests {
+		req.Type = models.SupportRequestTypeSupport
+		req.Platform = models.PlatformIOS
+		req.AppVersion = "1.0.0"
+		req.DeviceModel = "iPhone 13"
+		req.App = "app-x"
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	messages := func(results []*models.SupportRequest) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Message)
+		}
+		return out
+	}
+
+	// Act
+	breached, total, err := suite.repo.GetAll(SupportRequestFilter{SLA: SLAStateBreached, SLAAsOf: now}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+	assert.ElementsMatch(suite.T(), []string{"Unanswered", "Slow fix"}, messages(breached))
+
+	dueSoon, total, err := suite.repo.GetAll(SupportRequestFilter{SLA: SLAStateDueSoon, SLAAsOf: now, SLADueSoon: time.Hour}, 0, 10)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), []string{"Due soon"}, messages(dueSoon))
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_Priority() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	for _, priority := range []models.Priority{models.PriorityLow, models.PriorityHigh, models.PriorityUrgent} {
+		req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: string(priority), Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew, Priority: priority}
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	// Act
+	_, total, err := suite.repo.GetAll(SupportRequestFilter{Priorities: []models.Priority{models.PriorityHigh, models.PriorityUrgent}}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestGetAll_Tags() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	payments := &models.Tag{Name: "payments"}
+	login := &models.Tag{Name: "login"}
+	suite.Require().NoError(suite.db.Create(payments).Error)
+	suite.Require().NoError(suite.db.Create(login).Error)
+	for message, tags := range map[string][]*models.Tag{
+		"both":     {payments, login},
+		"payments": {payments},
+		"login":    {login},
+		"none":     nil,
+	} {
+		req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: message, Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew, Tags: tags}
+		suite.Require().NoError(suite.repo.Create(req))
+	}
+
+	messages := func(results []*models.SupportRequest) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Message)
+		}
+		return out
+	}
+
+	// Act
+	anyResults, anyTotal, err := suite.repo.GetAll(SupportRequestFilter{Tags: []string{"payments", "login"}}, 0, 10)
+	suite.Require().NoError(err)
+	allResults, allTotal, err := suite.repo.GetAll(SupportRequestFilter{Tags: []string{"payments", "login", "login"}, TagMatch: TagMatchAll}, 0, 10)
+	suite.Require().NoError(err)
+
+	// Assert
+	assert.Equal(suite.T(), int64(3), anyTotal)
+	assert.ElementsMatch(suite.T(), []string{"both", "payments", "login"}, messages(anyResults))
+	assert.Equal(suite.T(), int64(1), allTotal)
+	assert.Equal(suite.T(), []string{"both"}, messages(allResults))
+	// Tags are loaded alphabetically
+	var tagNames []string
+	for _, tag := range allResults[0].Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	assert.Equal(suite.T(), []string{"login", "payments"}, tagNames)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestUpdate_LeavesTagsUntouched() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	tag := &models.Tag{Name: "payments"}
+	req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew, Tags: []*models.Tag{tag}}
+	suite.Require().NoError(suite.repo.Create(req))
+
+	// Act
+	req.Tags = nil
+	req.Status = models.StatusInProgress
+	err := suite.repo.Update(req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	found, err := suite.repo.GetByID(req.ID, models.OrganizationScope{})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.StatusInProgress, found.Status)
+	assert.Len(suite.T(), found.Tags, 1)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestUpdate_WritesAuditEvent() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew}
+	suite.Require().NoError(suite.repo.Create(req))
+	req.Status = models.StatusResolved
+
+	// Act
+	err := suite.repo.Update(req, &models.AuditEvent{
+		Action:     models.AuditActionUpdate,
+		EntityType: models.AuditEntitySupportRequest,
+		EntityID:   req.ID,
+		Changes:    `{"status":{"before":"new","after":"resolved"}}`,
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	var events []models.AuditEvent
+	suite.Require().NoError(suite.db.Find(&events).Error)
+	assert.Len(suite.T(), events, 1)
+	assert.Equal(suite.T(), req.ID, events[0].EntityID)
+}
+
+func (suite *SupportRequestRepositoryTestSuite) TestDelete_RollsBackWhenAuditEventFails() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+
+	// Arrange
+	req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew}
+	suite.Require().NoError(suite.repo.Create(req))
+	existing := &models.AuditEvent{Action: models.AuditActionDelete, EntityType: models.AuditEntitySupportRequest, EntityID: req.ID, Changes: "{}"}
+	suite.Require().NoError(suite.db.Create(existing).Error)
+
+	// Act: reusing the primary key makes the audit insert fail
+	err := suite.repo.Delete(req.ID, &models.AuditEvent{ID: existing.ID, Action: models.AuditActionDelete, EntityType: models.AuditEntitySupportRequest, EntityID: req.ID, Changes: "{}"})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	_, err = suite.repo.GetByID(req.ID, models.OrganizationScope{})
+	assert.NoError(suite.T(), err, "the delete should have been rolled back")
+}
+
 func TestSupportRequestRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(SupportRequestRepositoryTestSuite))
 }
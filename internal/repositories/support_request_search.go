@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// searchDocument must match the expression indexed in migrations/004_add_support_request_search.up.sql
+	searchDocument = "to_tsvector('english', coalesce(message, '') || ' ' || coalesce(admin_notes, ''))"
+	searchQuery    = "websearch_to_tsquery('english', ?)"
+	// searchHeadline strips the sentinels from the document first so user text can't forge highlights
+	searchHeadline = "ts_headline('english', translate(coalesce(message, '') || ' ' || coalesce(admin_notes, ''), ?, ''), " + searchQuery + ", ?)"
+
+	// headlineStart and headlineStop delimit matches in ts_headline output until it has been HTML-escaped
+	headlineStart   = "\x02"
+	headlineStop    = "\x03"
+	headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxWords=35, MinWords=15, MaxFragments=2"
+
+	snippetRadius = 60
+)
+
+// searchRow is the scan target for PostgreSQL search queries
+type searchRow struct {
+	models.SupportRequest
+	Rank    float64
+	Snippet string
+}
+
+// searchPostgres ranks matches with ts_rank and highlights them with ts_headline
+func (r *supportRequestRepository) searchPostgres(query string, filter SupportRequestFilter, offset, limit int) ([]*SupportRequestSearchHit, int64, error) {
+	base := applySupportRequestFilter(r.db.Model(&models.SupportRequest{}), filter).
+		Where(searchDocument+" @@ "+searchQuery, query)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []searchRow
+	err := base.
+		Select("support_requests.*, ts_rank("+searchDocument+", "+searchQuery+") AS rank, "+searchHeadline+" AS snippet", query, headlineStart+headlineStop, query, headlineOptions).
+		Order("rank DESC, created_at DESC").
+		Offset(offset).Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]*SupportRequestSearchHit, len(rows))
+	requests := make([]*models.SupportRequest, len(rows))
+	for i := range rows {
+		request := rows[i].SupportRequest
+		requests[i] = &request
+		hits[i] = &SupportRequestSearchHit{Request: &request, Rank: rows[i].Rank, Snippet: escapeHeadline(rows[i].Snippet)}
+	}
+	if err := r.loadTags(requests); err != nil {
+		return nil, 0, err
+	}
+	return hits, total, nil
+}
+
+// searchFallback requires every search term to appear in the message or admin notes, then ranks
+// and paginates in memory. It is intended for SQLite test databases, not large data sets.
+func (r *supportRequestRepository) searchFallback(query string, filter SupportRequestFilter, offset, limit int) ([]*SupportRequestSearchHit, int64, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []*SupportRequestSearchHit{}, 0, nil
+	}
+
+	db := applySupportRequestFilter(r.db.Model(&models.SupportRequest{}), filter)
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		db = db.Where("(LOWER(message) LIKE ? ESCAPE '\\' OR LOWER(COALESCE(admin_notes, '')) LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+
+	var requests []*models.SupportRequest
+	if err := db.Preload("Tags", orderTagsByName).Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]*SupportRequestSearchHit, len(requests))
+	for i, request := range requests {
+		text := request.Message
+		if request.AdminNotes != nil {
+			text += " " + *request.AdminNotes
+		}
+		hits[i] = &SupportRequestSearchHit{
+			Request: request,
+			Rank:    rankText(text, terms),
+			Snippet: highlightSnippet(text, terms),
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].Request.CreatedAt.After(hits[j].Request.CreatedAt)
+	})
+
+	total := int64(len(hits))
+	if offset >= len(hits) {
+		return []*SupportRequestSearchHit{}, total, nil
+	}
+	end := offset + limit
+	if end > len(hits) {
+		end = len(hits)
+	}
+	return hits[offset:end], total, nil
+}
+
+// loadTags fills in the tags of requests scanned without GORM's preloading
+func (r *supportRequestRepository) loadTags(requests []*models.SupportRequest) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint]*models.SupportRequest, len(requests))
+	ids := make([]uint, len(requests))
+	for i, request := range requests {
+		byID[request.ID] = request
+		ids[i] = request.ID
+	}
+
+	var rows []struct {
+		SupportRequestID uint
+		models.Tag
+	}
+	err := r.db.Table("tags").
+		Select("tags.*, support_request_tags.support_request_id").
+		Joins("JOIN support_request_tags ON support_request_tags.tag_id = tags.id").
+		Where("support_request_tags.support_request_id IN ?", ids).
+		Order("tags.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range rows {
+		tag := rows[i].Tag
+		request := byID[rows[i].SupportRequestID]
+		request.Tags = append(request.Tags, &tag)
+	}
+	return nil
+}
+
+// searchTerms lower-cases and splits a search query into unique terms
+func searchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		term = strings.Trim(term, "\"'")
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// escapeLike escapes LIKE wildcards so terms are matched literally
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
+
+// rankText scores text by how often the terms occur, normalised by its length
+func rankText(text string, terms []string) float64 {
+	lower := strings.ToLower(text)
+	occurrences := 0
+	for _, term := range terms {
+		occurrences += strings.Count(lower, term)
+	}
+	words := len(strings.Fields(lower))
+	if words == 0 {
+		return 0
+	}
+	return float64(occurrences) / float64(words)
+}
+
+// highlightSnippet returns the text around the first match with every term wrapped in <mark></mark>
+func highlightSnippet(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Lower-casing changed byte offsets, so matches can't be mapped back onto text
+		lower = text
+	}
+
+	first := -1
+	for _, term := range terms {
+		if idx := strings.Index(lower, term); idx >= 0 && (first < 0 || idx < first) {
+			first = idx
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	start := first - snippetRadius
+	if start < 0 {
+		start = 0
+	}
+	end := first + snippetRadius
+	if end > len(text) {
+		end = len(text)
+	}
+	// Avoid cutting multi-byte characters in half
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	b.WriteString(markTerms(text[start:end], terms))
+	if end < len(text) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
+
+// escapeHeadline HTML-escapes a ts_headline result and turns the sentinels into <mark></mark>
+func escapeHeadline(headline string) string {
+	return strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>").Replace(html.EscapeString(headline))
+}
+
+// markTerms HTML-escapes fragment and wraps case-insensitive occurrences of terms in <mark></mark>
+func markTerms(fragment string, terms []string) string {
+	lower := strings.ToLower(fragment)
+	if len(lower) != len(fragment) {
+		return html.EscapeString(fragment)
+	}
+	var b strings.Builder
+	plain := 0
+	for i := 0; i < len(fragment); {
+		matched := 0
+		for _, term := range terms {
+			if len(term) > matched && strings.HasPrefix(lower[i:], term) {
+				matched = len(term)
+			}
+		}
+		if matched > 0 {
+			b.WriteString(html.EscapeString(fragment[plain:i]))
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(fragment[i : i+matched]))
+			b.WriteString("</mark>")
+			i += matched
+			plain = i
+			continue
+		}
+		i++
+	}
+	b.WriteString(html.EscapeString(fragment[plain:]))
+	return b.String()
+}
+
+// isRuneStart reports whether b is the first byte of a UTF-8 encoded rune
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
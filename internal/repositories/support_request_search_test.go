@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"strings"
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SupportRequestSearchTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo SupportRequestRepository
+}
+
+func (suite *SupportRequestSearchTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing (exercises the LIKE fallback)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewSupportRequestRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{})
+	suite.Require().NoError(err)
+}
+
+func (suite *SupportRequestSearchTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_requests")
+}
+
+func (suite *SupportRequestSearchTestSuite) createRequest(message string, notes *string, platform models.Platform) *models.SupportRequest {
+	request := &models.SupportRequest{
+		Type:        models.SupportRequestTypeBugReport,
+		Message:     message,
+		AdminNotes:  notes,
+		Platform:    platform,
+		AppVersion:  "1.0.0",
+		DeviceModel: "iPhone 13",
+		App:         "test-app",
+		Status:      models.StatusNew,
+	}
+	suite.Require().NoError(suite.repo.Create(request))
+	return request
+}
+
+func (suite *SupportRequestSearchTestSuite) TestSearch_MatchesAllTerms() {
+	// Arrange
+	suite.createRequest("The app crashes on launch every time", nil, models.PlatformIOS)
+	suite.createRequest("Crash when opening settings", nil, models.PlatformIOS)
+	suite.createRequest("Love the new design", nil, models.PlatformAndroid)
+
+	// Act
+	hits, total, err := suite.repo.Search("crash launch", SupportRequestFilter{}, 0, 10)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), total)
+	suite.Require().Len(hits, 1)
+	assert.Equal(suite.T(), "The app crashes on launch every time", hits[0].Request.Message)
+	assert.Greater(suite.T(), hits[0].Rank, 0.0)
+	assert.Equal(suite.T(), "The app <mark>crash</mark>es on <mark>launch</mark> every time", hits[0].Snippet)
+}
+
+func (suite *SupportRequestSearchTestSuite) TestSearch_AdminNotesAndRanking() {
+	// Arrange
+	notes := "Customer reports a crash, likely the same crash as #12"
+	suite.createRequest("Something is wrong", &notes, models.PlatformIOS)
+	suite.createRequest("Crash after update, a long description of what happened before the crash occurred in the app", nil, models.PlatformIOS)
+
+	// Act
+	hits, total, err := suite.repo.Search("CRASH", SupportRequestFilter{}, 0, 10)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(2), total)
+	suite.Require().Len(hits, 2)
+	// The shorter document with the same number of matches ranks first
+	assert.Equal(suite.T(), "Something is wrong", hits[0].Request.Message)
+	assert.GreaterOrEqual(suite.T(), hits[0].Rank, hits[1].Rank)
+}
+
+func (suite *SupportRequestSearchTestSuite) TestSearch_FilterAndPagination() {
+	// Arrange
+	suite.createRequest("Login crash on iOS", nil, models.PlatformIOS)
+	suite.createRequest("Login crash on Android", nil, models.PlatformAndroid)
+	suite.createRequest("Another login crash on iOS", nil, models.PlatformIOS)
+
+	// Act
+	hits, total, err := suite.repo.Search("login", SupportRequestFilter{Platforms: []models.Platform{models.PlatformIOS}}, 1, 1)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(2), total)
+	assert.Len(suite.T(), hits, 1)
+
+	hits, total, err = suite.repo.Search("login", SupportRequestFilter{}, 10, 10)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(3), total)
+	assert.Empty(suite.T(), hits)
+}
+
+func (suite *SupportRequestSearchTestSuite) TestSearch_WildcardsMatchedLiterally() {
+	// Arrange
+	suite.createRequest("Progress stuck at 100% forever", nil, models.PlatformWeb)
+	suite.createRequest("Progress stuck at 1000 forever", nil, models.PlatformWeb)
+
+	// Act
+	hits, total, err := suite.repo.Search("100%", SupportRequestFilter{}, 0, 10)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), "Progress stuck at 100% forever", hits[0].Request.Message)
+}
+
+func (suite *SupportRequestSearchTestSuite) TestSearch_SnippetEscapesHTML() {
+	// Arrange
+	suite.createRequest(`Crash <script>alert("xss")</script> on <b>launch</b>`, nil, models.PlatformWeb)
+
+	// Act
+	hits, _, err := suite.repo.Search("crash", SupportRequestFilter{}, 0, 10)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(hits, 1)
+	assert.Equal(suite.T(), "<mark>Crash</mark> &lt;script&gt;alert(&#34;xss&#34;)&lt;/script&gt; on &lt;b&gt;launch&lt;/b&gt;", hits[0].Snippet)
+	assert.NotContains(suite.T(), hits[0].Snippet, "<script>")
+}
+
+func (suite *SupportRequestSearchTestSuite) TestSearch_EmptyQuery() {
+	// Act
+	hits, total, err := suite.repo.Search("   ", SupportRequestFilter{}, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Empty(suite.T(), hits)
+}
+
+func TestSupportRequestSearchTestSuite(t *testing.T) {
+	suite.Run(t, new(SupportRequestSearchTestSuite))
+}
+
+func TestHighlightSnippet_TruncatesLongText(t *testing.T) {
+	text := strings.Repeat("lorem ipsum ", 20) + "the crash happened here " + strings.Repeat("dolor sit ", 20)
+
+	snippet := highlightSnippet(text, []string{"crash"})
+
+	assert.True(t, strings.HasPrefix(snippet, "..."))
+	assert.True(t, strings.HasSuffix(snippet, "..."))
+	assert.Contains(t, snippet, "<mark>crash</mark>")
+	assert.Less(t, len(snippet), len(text))
+}
+
+func TestEscapeHeadline_KeepsOnlyMarkDelimiters(t *testing.T) {
+	headline := "<script>alert(1)</script> " + headlineStart + "crash" + headlineStop + " & <mark>"
+
+	escaped := escapeHeadline(headline)
+
+	assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; <mark>crash</mark> &amp; &lt;mark&gt;", escaped)
+}
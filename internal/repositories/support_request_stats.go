@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SupportRequestStats holds aggregates over the support requests matching a filter
+type SupportRequestStats struct {
+	ByStatus                   []models.SupportRequestCount
+	ByType                     []models.SupportRequestCount
+	ByPlatform                 []models.SupportRequestCount
+	ByApp                      []models.SupportRequestCount
+	ByAppVersion               []models.AppVersionCount
+	Series                     []models.SupportRequestBucket // Non-empty buckets only, oldest first
+	MedianFirstResponseSeconds *float64                      // Nil when no matching request has been responded to
+	MedianResolutionSeconds    *float64                      // Nil when no matching request is resolved
+}
+
+// statsBucketExpressions format the first day of the bucket a support request was created in as
+// YYYY-MM-DD in UTC, per database and interval
+var statsBucketExpressions = map[string]map[string]string{
+	"postgres": {
+		models.StatsIntervalDay:   "to_char(date_trunc('day', created_at AT TIME ZONE 'UTC'), 'YYYY-MM-DD')",
+		models.StatsIntervalWeek:  "to_char(date_trunc('week', created_at AT TIME ZONE 'UTC'), 'YYYY-MM-DD')",
+		models.StatsIntervalMonth: "to_char(date_trunc('month', created_at AT TIME ZONE 'UTC'), 'YYYY-MM-DD')",
+	},
+	"sqlite": {
+		models.StatsIntervalDay:   "strftime('%Y-%m-%d', created_at)",
+		models.StatsIntervalWeek:  "strftime('%Y-%m-%d', created_at, 'weekday 0', '-6 days')",
+		models.StatsIntervalMonth: "strftime('%Y-%m-01', created_at)",
+	},
+}
+
+// Stats counts the support requests matching the filter by status, type, platform, app and app
+// version, buckets them by creation time and computes median response and resolution times. The
+// medians are computed with window functions, which PostgreSQL and SQLite both support.
+func (r *supportRequestRepository) Stats(filter SupportRequestFilter, interval string) (*SupportRequestStats, error) {
+	dialect := r.db.Dialector.Name()
+	if dialect != "postgres" {
+		dialect = "sqlite"
+	}
+
+	stats := &SupportRequestStats{}
+	counts := []struct {
+		column string
+		target *[]models.SupportRequestCount
+	}{
+		{"status", &stats.ByStatus},
+		{"type", &stats.ByType},
+		{"platform", &stats.ByPlatform},
+		{"app", &stats.ByApp},
+	}
+	for _, count := range counts {
+		*count.target = []models.SupportRequestCount{}
+		err := r.filtered(filter).
+			Select(count.column + " AS value, COUNT(*) AS count").
+			Group(count.column).
+			Order("count DESC, value ASC").
+			Scan(count.target).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stats.ByAppVersion = []models.AppVersionCount{}
+	err := r.filtered(filter).
+		Select("app, app_version, COUNT(*) AS count").
+		Group("app, app_version").
+		Order("count DESC, app ASC, app_version ASC").
+		Scan(&stats.ByAppVersion).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Series = []models.SupportRequestBucket{}
+	err = r.filtered(filter).
+		Select(statsBucketExpressions[dialect][interval] + " AS start, COUNT(*) AS count").
+		Group("start").
+		Order("start ASC").
+		Scan(&stats.Series).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.MedianFirstResponseSeconds, err = r.medianSeconds(filter, dialect, "first_responded_at"); err != nil {
+		return nil, err
+	}
+	if stats.MedianResolutionSeconds, err = r.medianSeconds(filter, dialect, "resolved_at"); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// filtered starts a support request query narrowed by filter
+func (r *supportRequestRepository) filtered(filter SupportRequestFilter) *gorm.DB {
+	return applySupportRequestFilter(r.db.Model(&models.SupportRequest{}), filter)
+}
+
+// medianSeconds returns the median number of seconds between creation and column over the matching
+// support requests where column is set, or nil when there are none. The durations are numbered in
+// order and the middle one, or the average of the middle two, is taken.
+func (r *supportRequestRepository) medianSeconds(filter SupportRequestFilter, dialect, column string) (*float64, error) {
+	duration := "(julianday(" + column + ") - julianday(created_at)) * 86400"
+	if dialect == "postgres" {
+		duration = "EXTRACT(EPOCH FROM (" + column + " - created_at))"
+	}
+
+	durations := r.filtered(filter).
+		Select(duration + " AS duration").
+		Where(column + " IS NOT NULL")
+	ranked := r.db.Table("(?) AS durations", durations).
+		Select("duration, ROW_NUMBER() OVER (ORDER BY duration) AS position, COUNT(*) OVER () AS total")
+
+	var median *float64
+	err := r.db.Table("(?) AS ranked", ranked).
+		Select("AVG(duration)").
+		Where("position IN ((total + 1) / 2, (total + 2) / 2)").
+		Row().Scan(&median)
+	if err != nil {
+		return nil, err
+	}
+	return median, nil
+}
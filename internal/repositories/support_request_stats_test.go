@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SupportRequestStatsTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo SupportRequestRepository
+}
+
+func (suite *SupportRequestStatsTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewSupportRequestRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{})
+	suite.Require().NoError(err)
+}
+
+func (suite *SupportRequestStatsTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_requests")
+}
+
+// createRequest stores a support request created at createdAt, responded to and resolved after the
+// given delays when they are positive
+func (suite *SupportRequestStatsTestSuite) createRequest(app, version string, platform models.Platform, status models.Status, createdAt time.Time, respondAfter, resolveAfter time.Duration) *models.SupportRequest {
+	request := &models.SupportRequest{
+		Type:        models.SupportRequestTypeBugReport,
+		Message:     "Crash",
+		Platform:    platform,
+		AppVersion:  version,
+		DeviceModel: "iPhone 13",
+		App:         app,
+		Status:      status,
+		CreatedAt:   createdAt,
+	}
+	if respondAfter > 0 {
+		respondedAt := createdAt.Add(respondAfter)
+		request.FirstRespondedAt = &respondedAt
+	}
+	if resolveAfter > 0 {
+		resolvedAt := createdAt.Add(resolveAfter)
+		request.ResolvedAt = &resolvedAt
+	}
+	suite.Require().NoError(suite.repo.Create(request))
+	return request
+}
+
+func (suite *SupportRequestStatsTestSuite) TestStats_Counts() {
+	// Arrange
+	monday := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, monday, 0, 0)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, monday, 0, 0)
+	suite.createRequest("app-a", "1.1.0", models.PlatformAndroid, models.StatusResolved, monday, 0, 0)
+	suite.createRequest("app-b", "1.0.0", models.PlatformIOS, models.StatusNew, monday, 0, 0)
+
+	// Act
+	stats, err := suite.repo.Stats(SupportRequestFilter{}, models.StatsIntervalDay)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []models.SupportRequestCount{{Value: "new", Count: 3}, {Value: "resolved", Count: 1}}, stats.ByStatus)
+	assert.Equal(suite.T(), []models.SupportRequestCount{{Value: "bug_report", Count: 4}}, stats.ByType)
+	assert.Equal(suite.T(), []models.SupportRequestCount{{Value: "iOS", Count: 3}, {Value: "Android", Count: 1}}, stats.ByPlatform)
+	assert.Equal(suite.T(), []models.SupportRequestCount{{Value: "app-a", Count: 3}, {Value: "app-b", Count: 1}}, stats.ByApp)
+	assert.Equal(suite.T(), []models.AppVersionCount{
+		{App: "app-a", AppVersion: "1.0.0", Count: 2},
+		{App: "app-a", AppVersion: "1.1.0", Count: 1},
+		{App: "app-b", AppVersion: "1.0.0", Count: 1},
+	}, stats.ByAppVersion)
+}
+
+func (suite *SupportRequestStatsTestSuite) TestStats_AppliesFilterAndSkipsDeleted() {
+	// Arrange
+	createdAt := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, createdAt, 0, 0)
+	suite.createRequest("app-b", "1.0.0", models.PlatformIOS, models.StatusNew, createdAt, 0, 0)
+	deleted := suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, createdAt, 0, 0)
+	suite.Require().NoError(suite.repo.Delete(deleted.ID))
+
+	// Act
+	stats, err := suite.repo.Stats(SupportRequestFilter{App: "app-a"}, models.StatsIntervalDay)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []models.SupportRequestCount{{Value: "app-a", Count: 1}}, stats.ByApp)
+}
+
+func (suite *SupportRequestStatsTestSuite) TestStats_Series() {
+	// Arrange
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, time.Date(2025, 6, 9, 0, 30, 0, 0, time.UTC), 0, 0)   // Monday
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, time.Date(2025, 6, 15, 23, 30, 0, 0, time.UTC), 0, 0) // Sunday
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC), 0, 0)   // Next Monday
+	// Created on June 30 in UTC, which is July 1 in Tokyo
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, time.Date(2025, 7, 1, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60)), 0, 0)
+
+	tests := []struct {
+		interval string
+		want     []models.SupportRequestBucket
+	}{
+		{models.StatsIntervalDay, []models.SupportRequestBucket{{Start: "2025-06-09", Count: 1}, {Start: "2025-06-15", Count: 1}, {Start: "2025-06-16", Count: 1}, {Start: "2025-06-30", Count: 1}}},
+		{models.StatsIntervalWeek, []models.SupportRequestBucket{{Start: "2025-06-09", Count: 2}, {Start: "2025-06-16", Count: 1}, {Start: "2025-06-30", Count: 1}}},
+		{models.StatsIntervalMonth, []models.SupportRequestBucket{{Start: "2025-06-01", Count: 4}}},
+	}
+
+	for _, tt := range tests {
+		// Act
+		stats, err := suite.repo.Stats(SupportRequestFilter{}, tt.interval)
+
+		// Assert
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), tt.want, stats.Series, tt.interval)
+	}
+}
+
+func (suite *SupportRequestStatsTestSuite) TestStats_Medians() {
+	// Arrange
+	createdAt := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusResolved, createdAt, time.Hour, 10*time.Hour)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusResolved, createdAt, 2*time.Hour, 20*time.Hour)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusInProgress, createdAt, 9*time.Hour, 0)
+	suite.createRequest("app-a", "1.0.0", models.PlatformIOS, models.StatusNew, createdAt, 0, 0)
+
+	// Act
+	stats, err := suite.repo.Stats(SupportRequestFilter{}, models.StatsIntervalDay)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().NotNil(stats.MedianFirstResponseSeconds)
+	assert.InDelta(suite.T(), 2*3600, *stats.MedianFirstResponseSeconds, 1)
+	suite.Require().NotNil(stats.MedianResolutionSeconds)
+	assert.InDelta(suite.T(), 15*3600, *stats.MedianResolutionSeconds, 1)
+}
+
+func (suite *SupportRequestStatsTestSuite) TestStats_Empty() {
+	// Act
+	stats, err := suite.repo.Stats(SupportRequestFilter{}, models.StatsIntervalWeek)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), stats.ByStatus)
+	assert.NotNil(suite.T(), stats.Series)
+	assert.Nil(suite.T(), stats.MedianFirstResponseSeconds)
+	assert.Nil(suite.T(), stats.MedianResolutionSeconds)
+}
+
+func TestSupportRequestStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(SupportRequestStatsTestSuite))
+}
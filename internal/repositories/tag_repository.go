@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// TagRepository defines the interface for tag data operations
+type TagRepository interface {
+	Create(tag *models.Tag) error
+	GetByID(id uint) (*models.Tag, error)
+	GetByName(name string) (*models.Tag, error)
+	GetByNames(names []string) ([]*models.Tag, error)
+	GetAll() ([]*models.Tag, error)
+	Update(tag *models.Tag) error
+	Delete(id uint) error
+	AddToSupportRequest(request *models.SupportRequest, tags []*models.Tag) error
+	RemoveFromSupportRequest(request *models.SupportRequest, tag *models.Tag) error
+}
+
+// tagRepository implements TagRepository
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+// Create creates a new tag
+func (r *tagRepository) Create(tag *models.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+// GetByID retrieves a tag by ID
+func (r *tagRepository) GetByID(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.First(&tag, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByName retrieves a tag by its (normalized) name
+func (r *tagRepository) GetByName(name string) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.Where("name = ?", name).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByNames retrieves the tags with the given names. Unknown names are skipped.
+func (r *tagRepository) GetByNames(names []string) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	err := r.db.Where("name IN ?", names).Order("name ASC").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetAll retrieves every tag ordered by name
+func (r *tagRepository) GetAll() ([]*models.Tag, error) {
+	var tags []*models.Tag
+	err := r.db.Order("name ASC").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Update updates a tag
+func (r *tagRepository) Update(tag *models.Tag) error {
+	return r.db.Save(tag).Error
+}
+
+// Delete deletes a tag and removes it from every support request
+func (r *tagRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM support_request_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+}
+
+// AddToSupportRequest tags a support request. Tags it already carries are left as they are.
+func (r *tagRepository) AddToSupportRequest(request *models.SupportRequest, tags []*models.Tag) error {
+	return r.db.Model(request).Omit("Tags.*").Association("Tags").Append(tags)
+}
+
+// RemoveFromSupportRequest removes a tag from a support request
+func (r *tagRepository) RemoveFromSupportRequest(request *models.SupportRequest, tag *models.Tag) error {
+	return r.db.Model(request).Association("Tags").Delete(tag)
+}
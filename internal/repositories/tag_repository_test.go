@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type TagRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo TagRepository
+}
+
+func (suite *TagRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewTagRepository(db)
+
+	err = db.AutoMigrate(&models.SupportRequest{}, &models.Tag{})
+	suite.Require().NoError(err)
+}
+
+func (suite *TagRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM support_request_tags")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM support_requests")
+}
+
+func (suite *TagRepositoryTestSuite) newSupportRequest() *models.SupportRequest {
+	req := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "app-x", Status: models.StatusNew}
+	suite.Require().NoError(suite.db.Create(req).Error)
+	return req
+}
+
+func (suite *TagRepositoryTestSuite) tagNamesOf(request *models.SupportRequest) []string {
+	var tags []*models.Tag
+	suite.Require().NoError(suite.db.Model(request).Association("Tags").Find(&tags))
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+func (suite *TagRepositoryTestSuite) TestCreateAndGet() {
+	// Arrange
+	tag := &models.Tag{Name: "payments"}
+
+	// Act
+	err := suite.repo.Create(tag)
+	suite.Require().NoError(err)
+	byID, errByID := suite.repo.GetByID(tag.ID)
+	byName, errByName := suite.repo.GetByName("payments")
+
+	// Assert
+	assert.NoError(suite.T(), errByID)
+	assert.NoError(suite.T(), errByName)
+	assert.Equal(suite.T(), "payments", byID.Name)
+	assert.Equal(suite.T(), tag.ID, byName.ID)
+}
+
+func (suite *TagRepositoryTestSuite) TestCreate_DuplicateName() {
+	suite.Require().NoError(suite.repo.Create(&models.Tag{Name: "dup"}))
+
+	err := suite.repo.Create(&models.Tag{Name: "dup"})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TagRepositoryTestSuite) TestGetAllAndGetByNames() {
+	// Arrange
+	for _, name := range []string{"zeta", "alpha", "login"} {
+		suite.Require().NoError(suite.repo.Create(&models.Tag{Name: name}))
+	}
+
+	// Act
+	all, err := suite.repo.GetAll()
+	suite.Require().NoError(err)
+	some, err := suite.repo.GetByNames([]string{"zeta", "alpha", "unknown"})
+	suite.Require().NoError(err)
+
+	// Assert
+	assert.Len(suite.T(), all, 3)
+	assert.Equal(suite.T(), "alpha", all[0].Name)
+	assert.Len(suite.T(), some, 2)
+}
+
+func (suite *TagRepositoryTestSuite) TestAddAndRemoveFromSupportRequest() {
+	// Arrange
+	request := suite.newSupportRequest()
+	payments := &models.Tag{Name: "payments"}
+	login := &models.Tag{Name: "login"}
+	suite.Require().NoError(suite.repo.Create(payments))
+	suite.Require().NoError(suite.repo.Create(login))
+
+	// Act
+	err := suite.repo.AddToSupportRequest(request, []*models.Tag{payments, login})
+	suite.Require().NoError(err)
+	// Adding a tag twice is a no-op
+	err = suite.repo.AddToSupportRequest(request, []*models.Tag{payments})
+	suite.Require().NoError(err)
+
+	// Assert
+	assert.ElementsMatch(suite.T(), []string{"payments", "login"}, suite.tagNamesOf(request))
+
+	err = suite.repo.RemoveFromSupportRequest(request, payments)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"login"}, suite.tagNamesOf(request))
+}
+
+func (suite *TagRepositoryTestSuite) TestDelete_RemovesTagFromSupportRequests() {
+	// Arrange
+	request := suite.newSupportRequest()
+	tag := &models.Tag{Name: "payments"}
+	suite.Require().NoError(suite.repo.Create(tag))
+	suite.Require().NoError(suite.repo.AddToSupportRequest(request, []*models.Tag{tag}))
+
+	// Act
+	err := suite.repo.Delete(tag.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.GetByID(tag.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	assert.Empty(suite.T(), suite.tagNamesOf(request))
+}
+
+func TestTagRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TagRepositoryTestSuite))
+}
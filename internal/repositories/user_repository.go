@@ -14,9 +14,9 @@ type UserRepository interface {
 	GetByUsername(username string) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetAll(offset, limit int) ([]*models.User, int64, error)
-	Update(user *models.User) error
+	Update(user *models.User, events ...*models.AuditEvent) error
 	UpdateLastLogin(userID uint) error
-	Delete(id uint) error
+	Delete(id uint, events ...*models.AuditEvent) error
 	UserExists(username, email string) (bool, error)
 }
 
@@ -86,9 +86,14 @@ func (r *userRepository) GetAll(offset, limit int) ([]*models.User, int64, error
 	return users, total, nil
 }
 
-// Update updates a user
-func (r *userRepository) Update(user *models.User) error {
-	return r.db.Save(user).Error
+// Update updates a user and records the given audit events in the same transaction
+func (r *userRepository) Update(user *models.User, events ...*models.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, events)
+	})
 }
 
 // UpdateLastLogin updates the user's last login timestamp
@@ -97,9 +102,14 @@ func (r *userRepository) UpdateLastLogin(userID uint) error {
 	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("last_login_at", now).Error
 }
 
-// Delete soft deletes a user
-func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&models.User{}, id).Error
+// Delete soft deletes a user and records the given audit events in the same transaction
+func (r *userRepository) Delete(id uint, events ...*models.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.User{}, id).Error; err != nil {
+			return err
+		}
+		return createAuditEvents(tx, events)
+	})
 }
 
 // UserExists checks if a user with the given username or email already exists
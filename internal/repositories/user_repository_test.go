@@ -27,7 +27,7 @@ func (suite *UserRepositoryTestSuite) SetupSuite() {
 	require.NoError(suite.T(), err)
 
 	// Auto migrate
-	err = db.AutoMigrate(&models.User{})
+	err = db.AutoMigrate(&models.User{}, &models.AuditEvent{})
 	require.NoError(suite.T(), err)
 
 	suite.db = db
@@ -44,6 +44,7 @@ func (suite *UserRepositoryTestSuite) TearDownSuite() {
 func (suite *UserRepositoryTestSuite) SetupTest() {
 	// Clean up database before each test
 	suite.db.Exec("DELETE FROM users")
+	suite.db.Exec("DELETE FROM audit_events")
 }
 
 func (suite *UserRepositoryTestSuite) TestCreate_Success() {
@@ -256,6 +257,34 @@ func (suite *UserRepositoryTestSuite) TestDelete_NotFound() {
 	assert.NoError(suite.T(), err) // GORM doesn't error on deleting non-existent records
 }
 
+func (suite *UserRepositoryTestSuite) TestDelete_WritesAuditEvent() {
+	user := &models.User{Username: "audited", Email: "audited@example.com", Role: models.UserRoleUser, IsActive: true}
+	require.NoError(suite.T(), suite.repo.Create(user))
+
+	err := suite.repo.Delete(user.ID, &models.AuditEvent{Action: models.AuditActionDelete, EntityType: models.AuditEntityUser, EntityID: user.ID, Changes: "{}"})
+
+	assert.NoError(suite.T(), err)
+	var count int64
+	suite.db.Model(&models.AuditEvent{}).Where("entity_type = ? AND entity_id = ?", models.AuditEntityUser, user.ID).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func (suite *UserRepositoryTestSuite) TestUpdate_RollsBackWhenAuditEventFails() {
+	user := &models.User{Username: "audited", Email: "audited@example.com", Role: models.UserRoleUser, IsActive: true}
+	require.NoError(suite.T(), suite.repo.Create(user))
+	existing := &models.AuditEvent{Action: models.AuditActionUpdate, EntityType: models.AuditEntityUser, EntityID: user.ID, Changes: "{}"}
+	require.NoError(suite.T(), suite.db.Create(existing).Error)
+
+	// Reusing the primary key makes the audit insert fail
+	user.Role = models.UserRoleAdmin
+	err := suite.repo.Update(user, &models.AuditEvent{ID: existing.ID, Action: models.AuditActionUpdate, EntityType: models.AuditEntityUser, EntityID: user.ID, Changes: "{}"})
+
+	assert.Error(suite.T(), err)
+	found, err := suite.repo.GetByID(user.ID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.UserRoleUser, found.Role)
+}
+
 func (suite *UserRepositoryTestSuite) TestGetAll_Success() {
 	// Create test users
 	users := []*models.User{
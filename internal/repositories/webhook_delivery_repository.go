@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository defines the interface for webhook delivery log operations
+type WebhookDeliveryRepository interface {
+	Create(deliveries ...*models.WebhookDelivery) error
+	GetByID(id uint) (*models.WebhookDelivery, error)
+	GetByWebhookID(webhookID uint, offset, limit int) ([]*models.WebhookDelivery, int64, error)
+	GetDue(now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	Claim(delivery *models.WebhookDelivery, now, leaseUntil time.Time) (bool, error)
+	Update(delivery *models.WebhookDelivery) error
+}
+
+// webhookDeliveryRepository implements WebhookDeliveryRepository
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+// Create queues one or more deliveries
+func (r *webhookDeliveryRepository) Create(deliveries ...*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Create(deliveries).Error
+}
+
+// GetByID retrieves a delivery by ID
+func (r *webhookDeliveryRepository) GetByID(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetByWebhookID retrieves the delivery log of a webhook with pagination, newest first
+func (r *webhookDeliveryRepository) GetByWebhookID(webhookID uint, offset, limit int) ([]*models.WebhookDelivery, int64, error) {
+	var deliveries []*models.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+
+	// Count total matching records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := query.Offset(offset).Limit(limit).Order("created_at DESC, id DESC").Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// GetDue retrieves pending deliveries whose next attempt is due, oldest first
+func (r *webhookDeliveryRepository) GetDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Claim leases a due delivery until leaseUntil so no other worker attempts it at the same time.
+// It reports false when the delivery was already claimed or changed since it was loaded.
+func (r *webhookDeliveryRepository) Claim(delivery *models.WebhookDelivery, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ? AND next_attempt_at <= ?",
+			delivery.ID, models.WebhookDeliveryPending, delivery.Attempts, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	delivery.NextAttemptAt = &leaseUntil
+	return true, nil
+}
+
+// Update updates a delivery
+func (r *webhookDeliveryRepository) Update(delivery *models.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
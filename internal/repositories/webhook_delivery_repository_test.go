@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type WebhookDeliveryRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo WebhookDeliveryRepository
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewWebhookDeliveryRepository(db)
+
+	err = db.AutoMigrate(&models.WebhookDelivery{})
+	suite.Require().NoError(err)
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM webhook_deliveries")
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) newDelivery(webhookID uint, status models.WebhookDeliveryStatus, nextAttemptAt time.Time) *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       "evt",
+		Event:         models.WebhookEventSupportRequestCreated,
+		Payload:       "{}",
+		Status:        status,
+		NextAttemptAt: &nextAttemptAt,
+	}
+	suite.Require().NoError(suite.repo.Create(delivery))
+	return delivery
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) TestGetByWebhookID() {
+	// Arrange
+	now := time.Now()
+	suite.newDelivery(1, models.WebhookDeliverySucceeded, now)
+	newest := suite.newDelivery(1, models.WebhookDeliveryPending, now)
+	suite.newDelivery(2, models.WebhookDeliveryPending, now)
+
+	// Act
+	deliveries, total, err := suite.repo.GetByWebhookID(1, 0, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+	assert.Equal(suite.T(), newest.ID, deliveries[0].ID)
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) TestGetDue() {
+	// Arrange
+	now := time.Now()
+	due := suite.newDelivery(1, models.WebhookDeliveryPending, now.Add(-time.Minute))
+	suite.newDelivery(1, models.WebhookDeliveryPending, now.Add(time.Minute))
+	suite.newDelivery(1, models.WebhookDeliveryFailed, now.Add(-time.Minute))
+	suite.newDelivery(1, models.WebhookDeliverySucceeded, now.Add(-time.Minute))
+
+	// Act
+	deliveries, err := suite.repo.GetDue(now, 10)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deliveries, 1)
+	assert.Equal(suite.T(), due.ID, deliveries[0].ID)
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) TestClaim() {
+	// Arrange
+	now := time.Now()
+	delivery := suite.newDelivery(1, models.WebhookDeliveryPending, now.Add(-time.Minute))
+	stale := *delivery
+	leaseUntil := now.Add(30 * time.Second)
+
+	// Act
+	claimed, err := suite.repo.Claim(delivery, now, leaseUntil)
+	claimedAgain, errAgain := suite.repo.Claim(&stale, now, leaseUntil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), claimed)
+	assert.NoError(suite.T(), errAgain)
+	assert.False(suite.T(), claimedAgain)
+	due, err := suite.repo.GetDue(now, 10)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), due)
+}
+
+func (suite *WebhookDeliveryRepositoryTestSuite) TestUpdate() {
+	// Arrange
+	delivery := suite.newDelivery(1, models.WebhookDeliveryPending, time.Now())
+	status := 200
+	delivery.Status = models.WebhookDeliverySucceeded
+	delivery.Attempts = 1
+	delivery.ResponseStatus = &status
+	delivery.NextAttemptAt = nil
+
+	// Act
+	err := suite.repo.Update(delivery)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	found, err := suite.repo.GetByID(delivery.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.WebhookDeliverySucceeded, found.Status)
+	assert.Equal(suite.T(), 1, found.Attempts)
+	assert.Equal(suite.T(), &status, found.ResponseStatus)
+	assert.Nil(suite.T(), found.NextAttemptAt)
+}
+
+func TestWebhookDeliveryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookDeliveryRepositoryTestSuite))
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository defines the interface for webhook subscription data operations
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	GetByID(id uint) (*models.Webhook, error)
+	GetAll() ([]*models.Webhook, error)
+	GetActiveByEvent(event models.WebhookEventType) ([]*models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	Delete(id uint) error
+}
+
+// webhookRepository implements WebhookRepository
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// Create creates a new webhook
+func (r *webhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// GetByID retrieves a webhook by ID
+func (r *webhookRepository) GetByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.First(&webhook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetAll retrieves every webhook ordered by ID
+func (r *webhookRepository) GetAll() ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.Order("id ASC").Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// GetActiveByEvent retrieves the active webhooks subscribed to event
+func (r *webhookRepository) GetActiveByEvent(event models.WebhookEventType) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	// Events is a comma-separated list, so wrap both sides in commas to match whole entries only
+	err := r.db.Where("is_active = ?", true).
+		Where("(',' || events || ',') LIKE ?", "%,"+string(event)+",%").
+		Order("id ASC").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Update updates a webhook
+func (r *webhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete soft deletes a webhook. Its delivery log is kept.
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Webhook{}, id).Error
+}
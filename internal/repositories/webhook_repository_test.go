@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type WebhookRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo WebhookRepository
+}
+
+func (suite *WebhookRepositoryTestSuite) SetupSuite() {
+	// Use in-memory SQLite for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		suite.T().Skip("Skipping repository tests - SQLite not available")
+		return
+	}
+
+	suite.db = db
+	suite.repo = NewWebhookRepository(db)
+
+	err = db.AutoMigrate(&models.Webhook{})
+	suite.Require().NoError(err)
+}
+
+func (suite *WebhookRepositoryTestSuite) SetupTest() {
+	if suite.db == nil {
+		suite.T().Skip("Database not available")
+		return
+	}
+	suite.db.Exec("DELETE FROM webhooks")
+}
+
+func (suite *WebhookRepositoryTestSuite) newWebhook(events string, active bool) *models.Webhook {
+	webhook := &models.Webhook{URL: "https://hooks.example.com/" + events, Secret: "s3cr3t-signing-key-123", Events: events, IsActive: true}
+	suite.Require().NoError(suite.repo.Create(webhook))
+	if !active {
+		// is_active defaults to true, so false has to be written explicitly
+		suite.Require().NoError(suite.db.Model(webhook).Update("is_active", false).Error)
+	}
+	return webhook
+}
+
+func (suite *WebhookRepositoryTestSuite) TestCreateAndGetByID() {
+	// Arrange
+	webhook := suite.newWebhook("support_request.created", true)
+
+	// Act
+	found, err := suite.repo.GetByID(webhook.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), webhook.URL, found.URL)
+	assert.Equal(suite.T(), "s3cr3t-signing-key-123", found.Secret)
+	assert.True(suite.T(), found.IsActive)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestGetActiveByEvent() {
+	// Arrange
+	created := suite.newWebhook("support_request.created,support_request.deleted", true)
+	suite.newWebhook("support_request.updated", true)
+	suite.newWebhook("support_request.created", false)
+
+	// Act
+	webhooks, err := suite.repo.GetActiveByEvent(models.WebhookEventSupportRequestCreated)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), webhooks, 1)
+	assert.Equal(suite.T(), created.ID, webhooks[0].ID)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestGetActiveByEvent_MatchesWholeEntries() {
+	// Arrange
+	suite.newWebhook("support_request.status_changed", true)
+
+	// Act
+	webhooks, err := suite.repo.GetActiveByEvent(models.WebhookEventType("support_request.status"))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), webhooks)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestUpdate() {
+	// Arrange
+	webhook := suite.newWebhook("support_request.created", true)
+	webhook.URL = "https://hooks.example.com/changed"
+	webhook.IsActive = false
+
+	// Act
+	err := suite.repo.Update(webhook)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	found, err := suite.repo.GetByID(webhook.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "https://hooks.example.com/changed", found.URL)
+	assert.False(suite.T(), found.IsActive)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestDelete() {
+	// Arrange
+	webhook := suite.newWebhook("support_request.created", true)
+
+	// Act
+	err := suite.repo.Delete(webhook.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.GetByID(webhook.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	webhooks, err := suite.repo.GetAll()
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), webhooks)
+}
+
+func TestWebhookRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookRepositoryTestSuite))
+}
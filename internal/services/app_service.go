@@ -0,0 +1,257 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAppNotFound        = errors.New("app not found")
+	ErrAppExists          = errors.New("app already exists")
+	ErrInvalidAppSlug     = errors.New("invalid app: must start with a lowercase letter or digit and contain only lowercase letters, digits, '.', '_' and '-'")
+	ErrAppKeyNotFound     = errors.New("app key not found")
+	ErrInvalidAppKey      = errors.New("invalid app key")
+	ErrUnknownApp         = errors.New("unknown app")
+	ErrAppDisabled        = errors.New("app is disabled")
+	ErrPlatformNotAllowed = errors.New("platform not allowed for this app")
+)
+
+// appKeyPrefix starts every intake key, so keys are easy to recognize in client code and logs
+const appKeyPrefix = "ak_"
+
+// appKeyPrefixLength is how much of a key is kept to recognize it in listings
+const appKeyPrefixLength = 10
+
+// AppService defines the interface for the app registry and the intake keys of apps
+type AppService interface {
+	ListApps() ([]*models.AppResponse, error)
+	GetApp(id uint) (*models.AppResponse, error)
+	CreateApp(req *models.CreateAppRequest) (*models.AppResponse, error)
+	UpdateApp(id uint, req *models.UpdateAppRequest) (*models.AppResponse, error)
+	DeleteApp(id uint) error
+	ListAppKeys(appID uint) ([]*models.AppKeyResponse, error)
+	CreateAppKey(appID uint, req *models.CreateAppKeyRequest) (*models.AppKeyResponse, error)
+	RevokeAppKey(appID, keyID uint) error
+	AuthenticateAppKey(key string) (*models.App, error)
+}
+
+// appService implements AppService
+type appService struct {
+	appRepo    repositories.AppRepository
+	appKeyRepo repositories.AppKeyRepository
+}
+
+// NewAppService creates a new app service
+func NewAppService(appRepo repositories.AppRepository, appKeyRepo repositories.AppKeyRepository) AppService {
+	return &appService{
+		appRepo:    appRepo,
+		appKeyRepo: appKeyRepo,
+	}
+}
+
+// ListApps retrieves all registered apps
+func (s *appService) ListApps() ([]*models.AppResponse, error) {
+	apps, err := s.appRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.AppResponse, len(apps))
+	for i, app := range apps {
+		responses[i] = app.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetApp retrieves a registered app by ID
+func (s *appService) GetApp(id uint) (*models.AppResponse, error) {
+	app, err := s.getApp(id)
+	if err != nil {
+		return nil, err
+	}
+	return app.ToResponse(), nil
+}
+
+// CreateApp registers an app. New apps are active.
+func (s *appService) CreateApp(req *models.CreateAppRequest) (*models.AppResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	slug := strings.TrimSpace(req.Slug)
+	if !models.IsValidAppSlug(slug) {
+		return nil, ErrInvalidAppSlug
+	}
+	displayName := strings.TrimSpace(req.DisplayName)
+	if displayName == "" {
+		return nil, ErrInvalidRequest
+	}
+	if err := validatePlatforms(req.Platforms); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.appRepo.GetBySlug(slug); err == nil {
+		return nil, ErrAppExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	app := &models.App{
+		Slug:        slug,
+		DisplayName: displayName,
+		IsActive:    true,
+	}
+	app.SetPlatforms(req.Platforms)
+	if err := s.appRepo.Create(app); err != nil {
+		return nil, err
+	}
+	return app.ToResponse(), nil
+}
+
+// UpdateApp changes the display name, platforms or active flag of an app. The slug cannot change,
+// since support requests refer to the app by it.
+func (s *appService) UpdateApp(id uint, req *models.UpdateAppRequest) (*models.AppResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	app, err := s.getApp(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DisplayName != nil {
+		displayName := strings.TrimSpace(*req.DisplayName)
+		if displayName == "" {
+			return nil, ErrInvalidRequest
+		}
+		app.DisplayName = displayName
+	}
+	if req.Platforms != nil {
+		if err := validatePlatforms(req.Platforms); err != nil {
+			return nil, err
+		}
+		app.SetPlatforms(req.Platforms)
+	}
+	if req.IsActive != nil {
+		app.IsActive = *req.IsActive
+	}
+
+	if err := s.appRepo.Update(app); err != nil {
+		return nil, err
+	}
+	return app.ToResponse(), nil
+}
+
+// DeleteApp unregisters an app and revokes its keys. Its support requests are kept, but new ones
+// are rejected.
+func (s *appService) DeleteApp(id uint) error {
+	if _, err := s.getApp(id); err != nil {
+		return err
+	}
+	return s.appRepo.Delete(id)
+}
+
+// ListAppKeys retrieves the intake keys of an app. Only key prefixes are returned.
+func (s *appService) ListAppKeys(appID uint) ([]*models.AppKeyResponse, error) {
+	if _, err := s.getApp(appID); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.appKeyRepo.GetByAppID(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.AppKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = key.ToResponse()
+	}
+	return responses, nil
+}
+
+// CreateAppKey issues a new intake key for an app. The full key is only part of this response.
+func (s *appService) CreateAppKey(appID uint, req *models.CreateAppKeyRequest) (*models.AppKeyResponse, error) {
+	if req == nil || strings.TrimSpace(req.Name) == "" {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := s.getApp(appID); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	plain := appKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	key := &models.AppKey{
+		AppID:   appID,
+		Name:    strings.TrimSpace(req.Name),
+		Prefix:  plain[:appKeyPrefixLength],
+		KeyHash: hashToken(plain),
+	}
+	if err := s.appKeyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	response := key.ToResponse()
+	response.Key = plain
+	return response, nil
+}
+
+// RevokeAppKey deletes an intake key of an app. Clients still sending it are rejected.
+func (s *appService) RevokeAppKey(appID, keyID uint) error {
+	if err := s.appKeyRepo.Delete(appID, keyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAppKeyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AuthenticateAppKey returns the app an intake key belongs to, or ErrInvalidAppKey for unknown and
+// revoked keys. Whether the app currently accepts support requests is checked on submission.
+func (s *appService) AuthenticateAppKey(key string) (*models.App, error) {
+	if !strings.HasPrefix(key, appKeyPrefix) {
+		return nil, ErrInvalidAppKey
+	}
+
+	appKey, err := s.appKeyRepo.GetByKeyHash(hashToken(key))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAppKey
+		}
+		return nil, err
+	}
+	return appKey.App, nil
+}
+
+// getApp loads an app, mapping missing records to ErrAppNotFound
+func (s *appService) getApp(id uint) (*models.App, error) {
+	app, err := s.appRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAppNotFound
+		}
+		return nil, err
+	}
+	return app, nil
+}
+
+// validatePlatforms rejects unknown platforms
+func validatePlatforms(platforms []models.Platform) error {
+	for _, platform := range platforms {
+		if !platform.IsValid() {
+			return fmt.Errorf("%w: unknown platform %q", ErrInvalidRequest, platform)
+		}
+	}
+	return nil
+}
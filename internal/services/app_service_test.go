@@ -0,0 +1,225 @@
+package services
+
+import (
+	"strings"
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockAppKeyRepository is a mock implementation of AppKeyRepository
+type MockAppKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAppKeyRepository) Create(key *models.AppKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockAppKeyRepository) GetByKeyHash(keyHash string) (*models.AppKey, error) {
+	args := m.Called(keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppKey), args.Error(1)
+}
+
+func (m *MockAppKeyRepository) GetByAppID(appID uint) ([]*models.AppKey, error) {
+	args := m.Called(appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AppKey), args.Error(1)
+}
+
+func (m *MockAppKeyRepository) Delete(appID, id uint) error {
+	args := m.Called(appID, id)
+	return args.Error(0)
+}
+
+func setupAppService() (AppService, *MockAppRepository, *MockAppKeyRepository) {
+	mockAppRepo := new(MockAppRepository)
+	mockAppKeyRepo := new(MockAppKeyRepository)
+	return NewAppService(mockAppRepo, mockAppKeyRepo), mockAppRepo, mockAppKeyRepo
+}
+
+func TestAppService_CreateApp(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, _ := setupAppService()
+	mockAppRepo.On("GetBySlug", "acme-ios").Return(nil, gorm.ErrRecordNotFound)
+	mockAppRepo.On("Create", mock.MatchedBy(func(app *models.App) bool {
+		return app.Slug == "acme-ios" && app.DisplayName == "Acme" && app.Platforms == "iOS" && app.IsActive
+	})).Return(nil)
+
+	// Act
+	response, err := service.CreateApp(&models.CreateAppRequest{Slug: " acme-ios ", DisplayName: "Acme", Platforms: []models.Platform{models.PlatformIOS}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Platform{models.PlatformIOS}, response.Platforms)
+	assert.True(t, response.IsActive)
+	mockAppRepo.AssertExpectations(t)
+}
+
+func TestAppService_CreateApp_Exists(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, _ := setupAppService()
+	mockAppRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios"}, nil)
+
+	// Act
+	response, err := service.CreateApp(&models.CreateAppRequest{Slug: "acme-ios", DisplayName: "Acme"})
+
+	// Assert
+	assert.Equal(t, ErrAppExists, err)
+	assert.Nil(t, response)
+	mockAppRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAppService_CreateApp_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *models.CreateAppRequest
+		wantErr error
+	}{
+		{"uppercase slug", &models.CreateAppRequest{Slug: "Acme", DisplayName: "Acme"}, ErrInvalidAppSlug},
+		{"slug with spaces", &models.CreateAppRequest{Slug: "acme ios", DisplayName: "Acme"}, ErrInvalidAppSlug},
+		{"blank display name", &models.CreateAppRequest{Slug: "acme-ios", DisplayName: "  "}, ErrInvalidRequest},
+		{"unknown platform", &models.CreateAppRequest{Slug: "acme-ios", DisplayName: "Acme", Platforms: []models.Platform{"Windows"}}, ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, mockAppRepo, _ := setupAppService()
+
+			// Act
+			response, err := service.CreateApp(tt.req)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, response)
+			mockAppRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestAppService_UpdateApp_Disable(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, _ := setupAppService()
+	inactive := false
+	mockAppRepo.On("GetByID", uint(1)).Return(&models.App{ID: 1, Slug: "acme-ios", DisplayName: "Acme", Platforms: "iOS", IsActive: true}, nil)
+	mockAppRepo.On("Update", mock.MatchedBy(func(app *models.App) bool {
+		return !app.IsActive && app.DisplayName == "Acme" && app.Platforms == "iOS"
+	})).Return(nil)
+
+	// Act
+	response, err := service.UpdateApp(1, &models.UpdateAppRequest{IsActive: &inactive})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, response.IsActive)
+	mockAppRepo.AssertExpectations(t)
+}
+
+func TestAppService_UpdateApp_NotFound(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, _ := setupAppService()
+	mockAppRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	response, err := service.UpdateApp(9, &models.UpdateAppRequest{})
+
+	// Assert
+	assert.Equal(t, ErrAppNotFound, err)
+	assert.Nil(t, response)
+}
+
+func TestAppService_CreateAppKey(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, mockAppKeyRepo := setupAppService()
+	mockAppRepo.On("GetByID", uint(1)).Return(&models.App{ID: 1, Slug: "acme-ios"}, nil)
+
+	var stored *models.AppKey
+	mockAppKeyRepo.On("Create", mock.AnythingOfType("*models.AppKey")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.AppKey)
+	})
+
+	// Act
+	response, err := service.CreateAppKey(1, &models.CreateAppKeyRequest{Name: "iOS 2.x"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.Key, appKeyPrefix))
+	assert.Equal(t, response.Key[:appKeyPrefixLength], response.Prefix)
+	assert.Equal(t, hashToken(response.Key), stored.KeyHash)
+	assert.Equal(t, "iOS 2.x", stored.Name)
+}
+
+func TestAppService_CreateAppKey_AppNotFound(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, mockAppKeyRepo := setupAppService()
+	mockAppRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	response, err := service.CreateAppKey(9, &models.CreateAppKeyRequest{Name: "iOS 2.x"})
+
+	// Assert
+	assert.Equal(t, ErrAppNotFound, err)
+	assert.Nil(t, response)
+	mockAppKeyRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAppService_RevokeAppKey_NotFound(t *testing.T) {
+	// Arrange
+	service, _, mockAppKeyRepo := setupAppService()
+	mockAppKeyRepo.On("Delete", uint(1), uint(4)).Return(gorm.ErrRecordNotFound)
+
+	// Act
+	err := service.RevokeAppKey(1, 4)
+
+	// Assert
+	assert.Equal(t, ErrAppKeyNotFound, err)
+}
+
+func TestAppService_AuthenticateAppKey(t *testing.T) {
+	// Arrange
+	service, _, mockAppKeyRepo := setupAppService()
+	app := &models.App{ID: 1, Slug: "acme-ios", IsActive: true}
+	mockAppKeyRepo.On("GetByKeyHash", hashToken("ak_valid")).Return(&models.AppKey{ID: 4, AppID: 1, App: app}, nil)
+
+	// Act
+	result, err := service.AuthenticateAppKey("ak_valid")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, app, result)
+}
+
+func TestAppService_AuthenticateAppKey_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"unknown key", "ak_unknown"},
+		{"wrong prefix", "sk_unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, _, mockAppKeyRepo := setupAppService()
+			mockAppKeyRepo.On("GetByKeyHash", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+
+			// Act
+			result, err := service.AuthenticateAppKey(tt.key)
+
+			// Assert
+			assert.Equal(t, ErrInvalidAppKey, err)
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAssigneeNotFound  = errors.New("assignee not found")
+	ErrAssigneeInactive  = errors.New("assignee is not an active user")
+	ErrAssigneeNotMember = errors.New("assignee is not a member of the support request's organization")
+)
+
+// AssignmentService defines the interface for assigning support requests to agents
+type AssignmentService interface {
+	AssignSupportRequest(supportRequestID uint, scope models.OrganizationScope, assigneeID uint) (*models.SupportRequestResponse, error)
+	UnassignSupportRequest(supportRequestID uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error)
+}
+
+// assignmentService implements AssignmentService
+type assignmentService struct {
+	supportRepo repositories.SupportRequestRepository
+	userRepo    repositories.UserRepository
+	orgRepo     repositories.OrganizationRepository
+}
+
+// NewAssignmentService creates a new assignment service
+func NewAssignmentService(supportRepo repositories.SupportRequestRepository, userRepo repositories.UserRepository, orgRepo repositories.OrganizationRepository) AssignmentService {
+	return &assignmentService{
+		supportRepo: supportRepo,
+		userRepo:    userRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+// AssignSupportRequest assigns a support request to an active user, replacing any previous assignee.
+// Self-assignment is the same operation with the caller's own user ID. The assignee must be able to
+// see the request: a member of its organization, or platform staff.
+func (s *assignmentService) AssignSupportRequest(supportRequestID uint, scope models.OrganizationScope, assigneeID uint) (*models.SupportRequestResponse, error) {
+	supportRequest, err := s.getSupportRequest(supportRequestID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	assignee, err := s.userRepo.GetByID(assigneeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAssigneeNotFound
+		}
+		return nil, err
+	}
+	if !assignee.IsActive {
+		return nil, ErrAssigneeInactive
+	}
+	if err := s.checkAssigneeAccess(supportRequest, assignee.ID); err != nil {
+		return nil, err
+	}
+
+	supportRequest.AssigneeID = &assignee.ID
+	if err := s.supportRepo.Update(supportRequest); err != nil {
+		return nil, err
+	}
+
+	return supportRequest.ToResponse(), nil
+}
+
+// UnassignSupportRequest removes the assignee of a support request, returning it to the shared queue
+func (s *assignmentService) UnassignSupportRequest(supportRequestID uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error) {
+	supportRequest, err := s.getSupportRequest(supportRequestID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	supportRequest.AssigneeID = nil
+	if err := s.supportRepo.Update(supportRequest); err != nil {
+		return nil, err
+	}
+
+	return supportRequest.ToResponse(), nil
+}
+
+// checkAssigneeAccess returns ErrAssigneeNotMember unless the user can see the support request
+func (s *assignmentService) checkAssigneeAccess(supportRequest *models.SupportRequest, userID uint) error {
+	memberships, err := s.orgRepo.GetMembershipsByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if len(memberships) == 0 {
+		return nil // Platform staff see every organization
+	}
+	for _, membership := range memberships {
+		if supportRequest.OrganizationID != nil && membership.OrganizationID == *supportRequest.OrganizationID {
+			return nil
+		}
+	}
+	return ErrAssigneeNotMember
+}
+
+// getSupportRequest loads a support request within scope, mapping missing records to ErrSupportRequestNotFound
+func (s *assignmentService) getSupportRequest(id uint, scope models.OrganizationScope) (*models.SupportRequest, error) {
+	supportRequest, err := s.supportRepo.GetByID(id, scope)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSupportRequestNotFound
+		}
+		return nil, err
+	}
+	return supportRequest, nil
+}
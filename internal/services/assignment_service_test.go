@@ -0,0 +1,148 @@
+package services
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// platformStaffOrgRepo returns an organization repository in which nobody belongs to an organization
+func platformStaffOrgRepo() *MockOrganizationRepository {
+	mockOrgRepo := new(MockOrganizationRepository)
+	mockOrgRepo.On("GetMembershipsByUserID", mock.Anything).Return([]*models.OrganizationMember{}, nil).Maybe()
+	return mockOrgRepo
+}
+
+func TestAssignmentService_AssignSupportRequest(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewAssignmentService(mockSupportRepo, mockUserRepo, platformStaffOrgRepo())
+
+	supportRequest := &models.SupportRequest{ID: 1, Status: models.StatusNew}
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(supportRequest, nil)
+	mockUserRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+	mockSupportRepo.On("Update", mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.AssigneeID != nil && *req.AssigneeID == 2
+	})).Return(nil)
+
+	// Act
+	result, err := service.AssignSupportRequest(1, models.OrganizationScope{}, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), *result.AssigneeID)
+	mockSupportRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAssignmentService_AssignSupportRequest_InvalidAssignee(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *models.User
+		userErr     error
+		expectedErr error
+	}{
+		{"missing user", nil, gorm.ErrRecordNotFound, ErrAssigneeNotFound},
+		{"inactive user", &models.User{ID: 2, IsActive: false}, nil, ErrAssigneeInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSupportRepo := new(MockSupportRequestRepository)
+			mockUserRepo := new(MockUserRepository)
+			service := NewAssignmentService(mockSupportRepo, mockUserRepo, platformStaffOrgRepo())
+
+			mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+			mockUserRepo.On("GetByID", uint(2)).Return(tt.user, tt.userErr)
+
+			// Act
+			result, err := service.AssignSupportRequest(1, models.OrganizationScope{}, 2)
+
+			// Assert
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Nil(t, result)
+			mockSupportRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
+
+func TestAssignmentService_AssignSupportRequest_NotFound(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewAssignmentService(mockSupportRepo, new(MockUserRepository), new(MockOrganizationRepository))
+
+	mockSupportRepo.On("GetByID", uint(99), models.OrganizationScope{}).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	result, err := service.AssignSupportRequest(99, models.OrganizationScope{}, 2)
+
+	// Assert
+	assert.Equal(t, ErrSupportRequestNotFound, err)
+	assert.Nil(t, result)
+}
+
+func TestAssignmentService_UnassignSupportRequest(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewAssignmentService(mockSupportRepo, new(MockUserRepository), new(MockOrganizationRepository))
+
+	assigneeID := uint(2)
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, AssigneeID: &assigneeID}, nil)
+	mockSupportRepo.On("Update", mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.AssigneeID == nil
+	})).Return(nil)
+
+	// Act
+	result, err := service.UnassignSupportRequest(1, models.OrganizationScope{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result.AssigneeID)
+	mockSupportRepo.AssertExpectations(t)
+}
+
+func TestAssignmentService_AssignSupportRequest_OrganizationMembers(t *testing.T) {
+	acme, globex := uint(1), uint(2)
+
+	tests := []struct {
+		name        string
+		requestOrg  *uint
+		memberships []*models.OrganizationMember
+		expectedErr error
+	}{
+		{"member of the organization", &acme, []*models.OrganizationMember{{OrganizationID: acme, UserID: 2}}, nil},
+		{"member of another organization", &acme, []*models.OrganizationMember{{OrganizationID: globex, UserID: 2}}, ErrAssigneeNotMember},
+		{"member assigned a request without organization", nil, []*models.OrganizationMember{{OrganizationID: acme, UserID: 2}}, ErrAssigneeNotMember},
+		{"platform staff", &acme, []*models.OrganizationMember{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSupportRepo := new(MockSupportRequestRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockOrgRepo := new(MockOrganizationRepository)
+			service := NewAssignmentService(mockSupportRepo, mockUserRepo, mockOrgRepo)
+
+			scope := models.OrganizationScope{OrganizationID: tt.requestOrg}
+			mockSupportRepo.On("GetByID", uint(1), scope).Return(&models.SupportRequest{ID: 1, OrganizationID: tt.requestOrg}, nil)
+			mockUserRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+			mockOrgRepo.On("GetMembershipsByUserID", uint(2)).Return(tt.memberships, nil)
+			mockSupportRepo.On("Update", mock.Anything).Return(nil).Maybe()
+
+			// Act
+			_, err := service.AssignSupportRequest(1, scope, 2)
+
+			// Assert
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr != nil {
+				mockSupportRepo.AssertNotCalled(t, "Update", mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"support-app-backend/internal/storage"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrTooManyAttachments       = errors.New("too many attachments")
+	ErrAttachmentTooLarge       = errors.New("attachment too large")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type not allowed")
+)
+
+// sniffLength is the number of bytes http.DetectContentType looks at
+const sniffLength = 512
+
+// AttachmentLimits holds the per-request and per-file upload restrictions
+type AttachmentLimits struct {
+	MaxSize      int64    // Maximum size of a single file in bytes
+	MaxCount     int      // Maximum number of files per support request
+	AllowedTypes []string // Allowed content types, detected from the file contents
+}
+
+// AttachmentService defines the interface for attachment business logic
+type AttachmentService interface {
+	StoreUploads(files []*multipart.FileHeader) ([]*models.Attachment, error)
+	DiscardUploads(attachments []*models.Attachment)
+	ListAttachments(supportRequestID uint, scope models.OrganizationScope) ([]*models.AttachmentResponse, error)
+	OpenAttachment(supportRequestID uint, scope models.OrganizationScope, attachmentID uint) (*models.Attachment, io.ReadCloser, error)
+}
+
+// attachmentService implements AttachmentService
+type attachmentService struct {
+	attachmentRepo repositories.AttachmentRepository
+	supportRepo    repositories.SupportRequestRepository
+	blobs          storage.BlobStorage
+	limits         AttachmentLimits
+}
+
+// NewAttachmentService creates a new attachment service
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, supportRepo repositories.SupportRequestRepository, blobs storage.BlobStorage, limits AttachmentLimits) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		supportRepo:    supportRepo,
+		blobs:          blobs,
+		limits:         limits,
+	}
+}
+
+// StoreUploads checks the number, size and content type of uploaded files and writes them to blob
+// storage, returning attachments to be saved with the support request they were submitted with. If
+// any file can't be stored, the files stored before it are removed again.
+func (s *attachmentService) StoreUploads(files []*multipart.FileHeader) ([]*models.Attachment, error) {
+	contentTypes, err := s.validateUploads(files)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*models.Attachment, 0, len(files))
+	for i, file := range files {
+		attachment, err := s.store(file, contentTypes[i])
+		if err != nil {
+			s.DiscardUploads(attachments)
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// validateUploads checks uploaded files before anything is stored, and returns their detected content types
+func (s *attachmentService) validateUploads(files []*multipart.FileHeader) ([]string, error) {
+	if len(files) > s.limits.MaxCount {
+		return nil, fmt.Errorf("%w: at most %d files are allowed", ErrTooManyAttachments, s.limits.MaxCount)
+	}
+
+	contentTypes := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.Size > s.limits.MaxSize {
+			return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrAttachmentTooLarge, sanitizeFileName(file.Filename), s.limits.MaxSize)
+		}
+
+		contentType, err := detectContentType(file)
+		if err != nil {
+			return nil, err
+		}
+		if !s.isAllowedType(contentType) {
+			return nil, fmt.Errorf("%w: %s has type %s", ErrAttachmentTypeNotAllowed, sanitizeFileName(file.Filename), contentType)
+		}
+		contentTypes = append(contentTypes, contentType)
+	}
+
+	return contentTypes, nil
+}
+
+// DiscardUploads removes the blobs of stored uploads whose support request was never saved
+func (s *attachmentService) DiscardUploads(attachments []*models.Attachment) {
+	for _, attachment := range attachments {
+		if err := s.blobs.Delete(attachment.StorageKey); err != nil && !errors.Is(err, storage.ErrBlobNotFound) {
+			log.Printf("Warning: Failed to remove attachment blob %s: %v", attachment.StorageKey, err)
+		}
+	}
+}
+
+// ListAttachments retrieves the attachment metadata of a support request
+func (s *attachmentService) ListAttachments(supportRequestID uint, scope models.OrganizationScope) ([]*models.AttachmentResponse, error) {
+	if err := s.checkSupportRequest(supportRequestID, scope); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetBySupportRequestID(supportRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = attachment.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// OpenAttachment returns an attachment's metadata and a reader for its contents. The caller must close the reader.
+func (s *attachmentService) OpenAttachment(supportRequestID uint, scope models.OrganizationScope, attachmentID uint) (*models.Attachment, io.ReadCloser, error) {
+	if err := s.checkSupportRequest(supportRequestID, scope); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+
+	// Don't let an attachment be fetched through another support request's URL
+	if attachment.SupportRequestID != supportRequestID {
+		return nil, nil, ErrAttachmentNotFound
+	}
+
+	content, err := s.blobs.Open(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// store writes a single validated file to blob storage and returns its unsaved attachment record
+func (s *attachmentService) store(file *multipart.FileHeader, contentType string) (*models.Attachment, error) {
+	key, err := newStorageKey()
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	hash := sha256.New()
+	if err := s.blobs.Put(key, io.TeeReader(src, hash)); err != nil {
+		// Don't leave a partly written blob behind
+		_ = s.blobs.Delete(key)
+		return nil, err
+	}
+
+	return &models.Attachment{
+		FileName:    sanitizeFileName(file.Filename),
+		ContentType: contentType,
+		Size:        file.Size,
+		StorageKey:  key,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// isAllowedType reports whether contentType is in the configured allow list
+func (s *attachmentService) isAllowedType(contentType string) bool {
+	for _, allowed := range s.limits.AllowedTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectContentType sniffs the content type from the file contents rather than trusting the client
+func detectContentType(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	buf := make([]byte, sniffLength)
+	n, err := io.ReadFull(src, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(buf[:n])
+	// Drop parameters such as "; charset=utf-8"
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	return contentType, nil
+}
+
+// newStorageKey generates an unguessable blob key for an attachment. Files are stored before their
+// support request is saved, so the key can't contain its ID.
+func newStorageKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "attachments/" + hex.EncodeToString(b), nil
+}
+
+// sanitizeFileName strips directories and control characters from a client supplied file name
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+	if len(name) > 255 {
+		// Keep the end of the name so the extension survives, without splitting a rune
+		start := len(name) - 255
+		for start < len(name) && !utf8.RuneStart(name[start]) {
+			start++
+		}
+		name = name[start:]
+	}
+	return name
+}
+
+// checkSupportRequest returns ErrSupportRequestNotFound unless the support request exists within scope
+func (s *attachmentService) checkSupportRequest(id uint, scope models.OrganizationScope) error {
+	if _, err := s.supportRepo.GetByID(id, scope); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSupportRequestNotFound
+		}
+		return err
+	}
+	return nil
+}
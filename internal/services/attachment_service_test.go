@@ -0,0 +1,254 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockAttachmentRepository is a mock implementation of AttachmentRepository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(attachment *models.Attachment) error {
+	args := m.Called(attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) GetByID(id uint) (*models.Attachment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetBySupportRequestID(supportRequestID uint) ([]*models.Attachment, error) {
+	args := m.Called(supportRequestID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+var testAttachmentLimits = AttachmentLimits{
+	MaxSize:      1024,
+	MaxCount:     2,
+	AllowedTypes: []string{"image/png", "text/plain"},
+}
+
+// pngHeader is enough of a PNG signature for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// buildFileHeaders creates multipart file headers as they would arrive in a request
+func buildFileHeaders(t *testing.T, files map[string][]byte) []*multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, content := range files {
+		part, err := writer.CreateFormFile("attachments", name)
+		assert.NoError(t, err)
+		_, err = part.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", "/", body)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	assert.NoError(t, req.ParseMultipartForm(1<<20))
+
+	return req.MultipartForm.File["attachments"]
+}
+
+func TestAttachmentService_StoreUploads_Validates(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		files       map[string][]byte
+		expectedErr error
+	}{
+		{"valid png", map[string][]byte{"shot.png": pngHeader}, nil},
+		{"valid text", map[string][]byte{"crash.log": []byte("panic: boom")}, nil},
+		{"too many", map[string][]byte{"a.log": []byte("a"), "b.log": []byte("b"), "c.log": []byte("c")}, ErrTooManyAttachments},
+		{"too large", map[string][]byte{"big.log": bytes.Repeat([]byte("a"), 2048)}, ErrAttachmentTooLarge},
+		{"disallowed type", map[string][]byte{"doc.pdf": []byte("%PDF-1.4\n")}, ErrAttachmentTypeNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := NewAttachmentService(nil, nil, storage.NewLocalStorage(t.TempDir()), testAttachmentLimits)
+
+			// Act
+			_, err := service.StoreUploads(buildFileHeaders(t, tt.files))
+
+			// Assert
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestAttachmentService_StoreUploads(t *testing.T) {
+	// Arrange
+	mockAttachmentRepo := new(MockAttachmentRepository)
+	blobs := storage.NewLocalStorage(t.TempDir())
+	service := NewAttachmentService(mockAttachmentRepo, new(MockSupportRequestRepository), blobs, testAttachmentLimits)
+
+	files := buildFileHeaders(t, map[string][]byte{"../../crash.log": []byte("panic: boom")})
+
+	// Act
+	attachments, err := service.StoreUploads(files)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Zero(t, attachments[0].ID, "records are saved with the support request")
+	assert.Equal(t, "crash.log", attachments[0].FileName)
+	assert.Equal(t, "text/plain", attachments[0].ContentType)
+	assert.True(t, strings.HasPrefix(attachments[0].StorageKey, "attachments/"))
+	assert.Len(t, attachments[0].Checksum, 64)
+	mockAttachmentRepo.AssertNotCalled(t, "Create", mock.Anything)
+
+	content, err := blobs.Open(attachments[0].StorageKey)
+	assert.NoError(t, err)
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	assert.Equal(t, "panic: boom", string(data))
+}
+
+// failingBlobStorage is a BlobStorage that fails to store any blob after the first few
+type failingBlobStorage struct {
+	storage.BlobStorage
+	puts  int
+	limit int
+	keys  []string
+}
+
+func (s *failingBlobStorage) Put(key string, content io.Reader) error {
+	s.puts++
+	if s.puts > s.limit {
+		return assert.AnError
+	}
+	s.keys = append(s.keys, key)
+	return s.BlobStorage.Put(key, content)
+}
+
+func TestAttachmentService_StoreUploads_RemovesStoredBlobsWhenAFileFails(t *testing.T) {
+	// Arrange
+	blobs := &failingBlobStorage{BlobStorage: storage.NewLocalStorage(t.TempDir()), limit: 1}
+	service := NewAttachmentService(new(MockAttachmentRepository), new(MockSupportRequestRepository), blobs, testAttachmentLimits)
+
+	files := buildFileHeaders(t, map[string][]byte{"crash.log": []byte("panic: boom"), "screen.png": pngHeader})
+
+	// Act
+	attachments, err := service.StoreUploads(files)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, attachments)
+	assert.Len(t, blobs.keys, 1)
+	_, err = blobs.Open(blobs.keys[0])
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+}
+
+func TestAttachmentService_DiscardUploads(t *testing.T) {
+	// Arrange
+	blobs := storage.NewLocalStorage(t.TempDir())
+	service := NewAttachmentService(new(MockAttachmentRepository), new(MockSupportRequestRepository), blobs, testAttachmentLimits)
+	attachments, err := service.StoreUploads(buildFileHeaders(t, map[string][]byte{"crash.log": []byte("panic: boom")}))
+	assert.NoError(t, err)
+
+	// Act
+	service.DiscardUploads(attachments)
+
+	// Assert
+	_, err = blobs.Open(attachments[0].StorageKey)
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+}
+
+func TestAttachmentService_OpenAttachment(t *testing.T) {
+	// Arrange
+	mockAttachmentRepo := new(MockAttachmentRepository)
+	blobs := storage.NewLocalStorage(t.TempDir())
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewAttachmentService(mockAttachmentRepo, mockSupportRepo, blobs, testAttachmentLimits)
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+	assert.NoError(t, blobs.Put("support-requests/1/abc", strings.NewReader("hello")))
+	attachment := &models.Attachment{ID: 3, SupportRequestID: 1, StorageKey: "support-requests/1/abc"}
+	mockAttachmentRepo.On("GetByID", uint(3)).Return(attachment, nil)
+
+	// Act
+	result, content, err := service.OpenAttachment(1, models.OrganizationScope{}, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, attachment, result)
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestAttachmentService_OpenAttachment_WrongSupportRequest(t *testing.T) {
+	// Arrange
+	mockAttachmentRepo := new(MockAttachmentRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewAttachmentService(mockAttachmentRepo, mockSupportRepo, storage.NewLocalStorage(t.TempDir()), testAttachmentLimits)
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+	mockAttachmentRepo.On("GetByID", uint(3)).Return(&models.Attachment{ID: 3, SupportRequestID: 2}, nil)
+
+	// Act
+	result, content, err := service.OpenAttachment(1, models.OrganizationScope{}, 3)
+
+	// Assert
+	assert.Equal(t, ErrAttachmentNotFound, err)
+	assert.Nil(t, result)
+	assert.Nil(t, content)
+}
+
+func TestAttachmentService_OpenAttachment_OutsideScope(t *testing.T) {
+	// Arrange
+	mockAttachmentRepo := new(MockAttachmentRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewAttachmentService(mockAttachmentRepo, mockSupportRepo, storage.NewLocalStorage(t.TempDir()), testAttachmentLimits)
+
+	scope := models.ScopeToOrganization(2)
+	mockSupportRepo.On("GetByID", uint(1), scope).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	result, content, err := service.OpenAttachment(1, scope, 3)
+
+	// Assert
+	assert.Equal(t, ErrSupportRequestNotFound, err)
+	assert.Nil(t, result)
+	assert.Nil(t, content)
+	mockAttachmentRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "passwd", sanitizeFileName("../../etc/passwd"))
+	assert.Equal(t, "evil.txt", sanitizeFileName("C:\\Users\\evil.txt"))
+	assert.Equal(t, "ab.png", sanitizeFileName("a\"b\n.png"))
+	assert.Equal(t, "attachment", sanitizeFileName(""))
+	assert.Len(t, sanitizeFileName(strings.Repeat("a", 300)+".png"), 255)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+)
+
+// auditIgnoredFields are left out of audit diffs because they change on every write
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// AuditService defines the interface for reading the audit log
+type AuditService interface {
+	ListAuditEvents(filter repositories.AuditEventFilter, page, pageSize int) ([]*models.AuditEventResponse, int64, error)
+}
+
+// auditService implements AuditService
+type auditService struct {
+	repo repositories.AuditEventRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(repo repositories.AuditEventRepository) AuditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+// ListAuditEvents retrieves audit events matching the filter with pagination, newest first
+func (s *auditService) ListAuditEvents(filter repositories.AuditEventFilter, page, pageSize int) ([]*models.AuditEventResponse, int64, error) {
+	if filter.Action != "" && !filter.Action.IsValid() {
+		return nil, 0, fmt.Errorf("%w: unknown action %q", ErrInvalidFilter, filter.Action)
+	}
+	if filter.EntityType != "" && !filter.EntityType.IsValid() {
+		return nil, 0, fmt.Errorf("%w: unknown entity type %q", ErrInvalidFilter, filter.EntityType)
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, 0, fmt.Errorf("%w: created_after must be before created_before", ErrInvalidFilter)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20 // Default page size
+	}
+
+	offset := (page - 1) * pageSize
+
+	events, total, err := s.repo.GetAll(filter, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*models.AuditEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = event.ToResponse()
+	}
+
+	return responses, total, nil
+}
+
+// newAuditEvent builds an audit event recording the fields that differ between the JSON forms of
+// before and after. Pass nil as after for deletions.
+func newAuditEvent(actor models.AuditActor, action models.AuditAction, entityType models.AuditEntityType, entityID uint, before, after interface{}) (*models.AuditEvent, error) {
+	changes, err := auditDiff(before, after)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &models.AuditEvent{
+		ActorUsername: actor.Username,
+		Action:        action,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Changes:       string(encoded),
+		IPAddress:     actor.IPAddress,
+	}
+	if actor.UserID != 0 {
+		actorID := actor.UserID
+		event.ActorID = &actorID
+	}
+	return event, nil
+}
+
+// auditDiff compares the JSON forms of before and after field by field
+func auditDiff(before, after interface{}) (map[string]models.AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.AuditChange)
+	for name, value := range beforeFields {
+		if !auditIgnoredFields[name] && !reflect.DeepEqual(value, afterFields[name]) {
+			changes[name] = models.AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, seen := beforeFields[name]; !seen && !auditIgnoredFields[name] && value != nil {
+			changes[name] = models.AuditChange{After: value}
+		}
+	}
+	return changes, nil
+}
+
+// auditFields decodes the JSON form of value into a field map, treating nil as no fields
+func auditFields(value interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if value == nil {
+		return fields, nil
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"encoding/json"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditEventRepository is a mock implementation of AuditEventRepository
+type MockAuditEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditEventRepository) Create(event *models.AuditEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockAuditEventRepository) GetAll(filter repositories.AuditEventFilter, offset, limit int) ([]*models.AuditEvent, int64, error) {
+	args := m.Called(filter, offset, limit)
+	return args.Get(0).([]*models.AuditEvent), args.Get(1).(int64), args.Error(2)
+}
+
+// testActor is the admin recorded as performing audited actions in service tests
+var testActor = models.AuditActor{UserID: 1, Username: "admin", IPAddress: "203.0.113.7"}
+
+// withAuditEvents appends the audit events passed to a repository write method to its mock arguments
+func withAuditEvents(args []interface{}, events []*models.AuditEvent) []interface{} {
+	for _, event := range events {
+		args = append(args, event)
+	}
+	return args
+}
+
+// auditChanges decodes the changes recorded in an audit event
+func auditChanges(t *testing.T, event *models.AuditEvent) map[string]models.AuditChange {
+	changes := map[string]models.AuditChange{}
+	assert.NoError(t, json.Unmarshal([]byte(event.Changes), &changes))
+	return changes
+}
+
+func TestAuditService_ListAuditEvents(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockAuditEventRepository)
+	service := NewAuditService(mockRepo)
+
+	filter := repositories.AuditEventFilter{Action: models.AuditActionUpdate, EntityType: models.AuditEntityUser}
+	events := []*models.AuditEvent{{ID: 1, Action: models.AuditActionUpdate, EntityType: models.AuditEntityUser, EntityID: 2, Changes: `{"role":{"before":"user","after":"admin"}}`}}
+	mockRepo.On("GetAll", filter, 20, 20).Return(events, int64(21), nil)
+
+	// Act
+	responses, total, err := service.ListAuditEvents(filter, 2, 20)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), total)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, models.AuditChange{Before: "user", After: "admin"}, responses[0].Changes["role"])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuditService_ListAuditEvents_InvalidFilter(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter repositories.AuditEventFilter
+	}{
+		{"unknown action", repositories.AuditEventFilter{Action: "create"}},
+		{"unknown entity type", repositories.AuditEventFilter{EntityType: "tag"}},
+		{"inverted created range", repositories.AuditEventFilter{CreatedAfter: &now, CreatedBefore: &earlier}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockAuditEventRepository)
+			service := NewAuditService(mockRepo)
+
+			// Act
+			responses, total, err := service.ListAuditEvents(tt.filter, 1, 20)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			assert.Nil(t, responses)
+			assert.Equal(t, int64(0), total)
+			mockRepo.AssertNotCalled(t, "GetAll")
+		})
+	}
+}
+
+func TestNewAuditEvent(t *testing.T) {
+	// Arrange
+	actor := models.AuditActor{UserID: 1, Username: "admin", IPAddress: "203.0.113.7"}
+	before := models.UserInfo{ID: 2, Username: "jane", Role: models.UserRoleUser, IsActive: true}
+	after := models.UserInfo{ID: 2, Username: "jane", Role: models.UserRoleAdmin, IsActive: true}
+
+	// Act
+	event, err := newAuditEvent(actor, models.AuditActionUpdate, models.AuditEntityUser, 2, before, after)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), *event.ActorID)
+	assert.Equal(t, "admin", event.ActorUsername)
+	assert.Equal(t, "203.0.113.7", event.IPAddress)
+	assert.Equal(t, models.AuditActionUpdate, event.Action)
+	assert.Equal(t, models.AuditEntityUser, event.EntityType)
+	assert.Equal(t, uint(2), event.EntityID)
+	assert.Equal(t, map[string]models.AuditChange{"role": {Before: "user", After: "admin"}}, auditChanges(t, event))
+}
+
+func TestNewAuditEvent_Delete(t *testing.T) {
+	// Act
+	event, err := newAuditEvent(models.AuditActor{}, models.AuditActionDelete, models.AuditEntityUser, 2, models.UserInfo{ID: 2, Username: "jane"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, event.ActorID, "anonymous actors have no ID")
+	changes := auditChanges(t, event)
+	assert.Equal(t, models.AuditChange{Before: "jane"}, changes["username"])
+}
+
+func TestAuditDiff_IgnoresUpdatedAt(t *testing.T) {
+	// Arrange
+	before := &models.SupportRequestResponse{ID: 1, Status: models.StatusNew, UpdatedAt: time.Now().Add(-time.Hour)}
+	after := &models.SupportRequestResponse{ID: 1, Status: models.StatusNew, UpdatedAt: time.Now()}
+
+	// Act
+	changes, err := auditDiff(before, after)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -1,7 +1,14 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strconv"
+	"support-app-backend/internal/config"
 	"support-app-backend/internal/models"
 	"support-app-backend/internal/repositories"
 	"time"
@@ -11,53 +18,91 @@ import (
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid username or password")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserExists         = errors.New("user already exists")
-	ErrUserInactive       = errors.New("user account is inactive")
-	ErrInvalidToken       = errors.New("invalid token")
+	ErrInvalidCredentials    = errors.New("invalid username or password")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrUserExists            = errors.New("user already exists")
+	ErrUserInactive          = errors.New("user account is inactive")
+	ErrInvalidToken          = errors.New("invalid token")
+	ErrTokenReused           = errors.New("refresh token reuse detected")
+	ErrMFAEnrollmentRequired = errors.New("MFA enrollment required")
 )
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
-	Login(req *models.LoginRequest) (*models.LoginResponse, error)
+	Login(req *models.LoginRequest, clientIP string) (*models.LoginResponse, error)
+	CompleteMFALogin(req *models.MFALoginRequest, clientIP string) (*models.LoginResponse, error)
+	BeginMFALoginEnrollment(mfaToken string) (*models.MFAEnrollmentResponse, error)
+	Refresh(refreshToken string) (*models.LoginResponse, error)
+	Logout(refreshToken string) error
 	CreateUser(req *models.CreateUserRequest) (*models.UserInfo, error)
 	GetUserByID(id uint) (*models.UserInfo, error)
 	GetAllUsers(page, pageSize int) ([]*models.UserInfo, int64, error)
-	UpdateUser(id uint, req *models.UpdateUserRequest) (*models.UserInfo, error)
+	UpdateUser(id uint, req *models.UpdateUserRequest, actor models.AuditActor) (*models.UserInfo, error)
 	ChangePassword(userID uint, req *models.ChangePasswordRequest) error
-	DeleteUser(id uint) error
+	DeleteUser(id uint, actor models.AuditActor) error
+	GetUserLockStatus(id uint) (*models.UserLockStatus, error)
+	UnlockUser(id uint, actor models.AuditActor) error
 	ValidateToken(tokenString string) (*models.User, error)
 	CreateDefaultAdmin() error
 }
 
 // authService implements AuthService
 type authService struct {
-	userRepo  repositories.UserRepository
-	jwtSecret string
+	userRepo        repositories.UserRepository
+	tokenRepo       repositories.RefreshTokenRepository
+	roleRepo        repositories.RoleRepository
+	limiter         LoginLimiter
+	mfa             MFAService
+	jwtSecret       string
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	mfaChallengeTTL time.Duration
+	now             func() time.Time
 }
 
 // NewAuthService creates a new authentication service
-func NewAuthService(userRepo repositories.UserRepository, jwtSecret string) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, tokenRepo repositories.RefreshTokenRepository, roleRepo repositories.RoleRepository, limiter LoginLimiter, mfa MFAService, cfg config.JWTConfig) AuthService {
 	return &authService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:        userRepo,
+		tokenRepo:       tokenRepo,
+		roleRepo:        roleRepo,
+		limiter:         limiter,
+		mfa:             mfa,
+		jwtSecret:       cfg.SecretKey,
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		refreshTokenTTL: cfg.RefreshTokenTTL,
+		mfaChallengeTTL: cfg.MFAChallengeTTL,
+		now:             time.Now,
 	}
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *authService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
+// Login authenticates a user and starts a session: a short-lived access token and the first refresh
+// token of a new token family. Failed logins count against the username and the client IP; while
+// either is locked every login fails with ErrLoginLocked, whether or not the username exists.
+// When the user needs a second factor, only an MFA challenge is returned (see CompleteMFALogin).
+func (s *authService) Login(req *models.LoginRequest, clientIP string) (*models.LoginResponse, error) {
 	if req == nil {
 		return nil, ErrInvalidRequest
 	}
 
+	if err := s.limiter.Check(req.Username, clientIP); err != nil {
+		return nil, err
+	}
+
 	// Get user by username
 	user, err := s.userRepo.GetByUsername(req.Username)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrInvalidCredentials
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
 		}
-		return nil, err
+		// Spend as long as a real password check so response times don't reveal unknown usernames
+		dummyUser.CheckPassword(req.Password)
+		return nil, s.failLogin(req.Username, clientIP)
+	}
+
+	// Verify password before revealing anything about the account
+	if !user.CheckPassword(req.Password) {
+		return nil, s.failLogin(req.Username, clientIP)
 	}
 
 	// Check if user is active
@@ -65,32 +110,227 @@ func (s *authService) Login(req *models.LoginRequest) (*models.LoginResponse, er
 		return nil, ErrUserInactive
 	}
 
-	// Verify password
-	if !user.CheckPassword(req.Password) {
-		return nil, ErrInvalidCredentials
+	// Failures are only cleared once the second factor is right too, so knowing the password does not
+	// buy unlimited guesses at the code
+	if s.mfa.IsRequired(user) {
+		return s.mfaChallenge(user)
+	}
+
+	return s.startSession(user)
+}
+
+// CompleteMFALogin finishes a login with the challenge token from Login and a TOTP or recovery code.
+// Wrong codes count as failed logins. When MFA is mandatory but was not set up, the code confirms the
+// enrollment begun with BeginMFALoginEnrollment and the response carries the new recovery codes.
+func (s *authService) CompleteMFALogin(req *models.MFALoginRequest, clientIP string) (*models.LoginResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	user, err := s.challengeUser(req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.limiter.Check(user.Username, clientIP); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := s.mfa.VerifyLogin(user, req.Code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			if err := s.limiter.RecordFailure(user.Username, clientIP); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	response, err := s.startSession(user)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+// BeginMFALoginEnrollment starts MFA enrollment in the middle of a login, for users who must use MFA
+// but have not set it up yet
+func (s *authService) BeginMFALoginEnrollment(mfaToken string) (*models.MFAEnrollmentResponse, error) {
+	user, err := s.challengeUser(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.mfa.BeginEnrollment(user)
+}
+
+// startSession records a successful login and issues the first tokens of a new token family
+func (s *authService) startSession(user *models.User) (*models.LoginResponse, error) {
+	if err := s.limiter.RecordSuccess(user.Username); err != nil {
+		return nil, err
 	}
 
 	// Update last login
 	s.userRepo.UpdateLastLogin(user.ID)
 
-	// Generate JWT token
-	token, expiresAt, err := s.generateJWT(user)
+	// Start a new token family
+	familyID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, plainRefreshToken, err := s.newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.Create(refreshToken); err != nil {
+		return nil, err
+	}
+
+	return s.tokenResponse(user, refreshToken, plainRefreshToken)
+}
+
+// mfaChallenge returns the challenge of a login waiting for its second factor. The challenge token is a
+// JWT with its own audience and no jti, so it is never accepted as an access token.
+func (s *authService) mfaChallenge(user *models.User) (*models.LoginResponse, error) {
+	now := s.now()
+	expiresAt := now.Add(s.mfaChallengeTTL)
+
+	claims := &jwt.RegisteredClaims{
+		Subject:   strconv.FormatUint(uint64(user.ID), 10),
+		Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.jwtSecret))
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.LoginResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		User:      user.ToUserInfo(),
+		MFAChallenge: &models.MFAChallengeResponse{
+			MFARequired:        true,
+			EnrollmentRequired: !user.MFAEnabled,
+			MFAToken:           token,
+			ExpiresAt:          expiresAt,
+		},
 	}, nil
 }
 
+// challengeUser returns the active user an MFA challenge token was issued to
+func (s *authService) challengeUser(tokenString string) (*models.User, error) {
+	claims := &jwt.RegisteredClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.jwtSecret), nil
+	}, jwt.WithAudience(mfaChallengeAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithTimeFunc(s.now))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(uint(userID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+	return user, nil
+}
+
+// failLogin records a failed login and returns the error to report for it
+func (s *authService) failLogin(username, clientIP string) error {
+	if err := s.limiter.RecordFailure(username, clientIP); err != nil {
+		return err
+	}
+	return ErrInvalidCredentials
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token. Each refresh token
+// works once; presenting one that was already exchanged revokes its whole family, logging out both the
+// legitimate client and whoever copied the token.
+func (s *authService) Refresh(plainRefreshToken string) (*models.LoginResponse, error) {
+	current, err := s.getRefreshToken(plainRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if current.IsUsed() {
+		if err := s.tokenRepo.RevokeFamily(current.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenReused
+	}
+	if current.IsExpired(now) {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+	if !user.MFAEnabled && s.mfa.IsRequired(user) {
+		// MFA became mandatory for this user after the session started
+		if err := s.tokenRepo.RevokeFamily(current.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrMFAEnrollmentRequired
+	}
+
+	next, plainNext, err := s.newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.tokenRepo.Rotate(current, next, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Another refresh with the same token won the race, so the token was used twice
+		if err := s.tokenRepo.RevokeFamily(current.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenReused
+	}
+
+	return s.tokenResponse(user, next, plainNext)
+}
+
+// Logout revokes the token family of a refresh token, invalidating its refresh tokens and the access
+// tokens issued with them. Logging out an already revoked session succeeds.
+func (s *authService) Logout(plainRefreshToken string) error {
+	token, err := s.tokenRepo.GetByTokenHash(hashToken(plainRefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	if token.IsRevoked() {
+		return nil
+	}
+	return s.tokenRepo.RevokeFamily(token.FamilyID, s.now())
+}
+
 // CreateUser creates a new user
 func (s *authService) CreateUser(req *models.CreateUserRequest) (*models.UserInfo, error) {
 	if req == nil {
 		return nil, ErrInvalidRequest
 	}
+	if err := validateRole(s.roleRepo, req.Role); err != nil {
+		return nil, err
+	}
 
 	// Check if user already exists
 	exists, err := s.userRepo.UserExists(req.Username, req.Email)
@@ -162,8 +402,8 @@ func (s *authService) GetAllUsers(page, pageSize int) ([]*models.UserInfo, int64
 	return userInfos, total, nil
 }
 
-// UpdateUser updates a user
-func (s *authService) UpdateUser(id uint, req *models.UpdateUserRequest) (*models.UserInfo, error) {
+// UpdateUser updates a user, recording the change in the audit log
+func (s *authService) UpdateUser(id uint, req *models.UpdateUserRequest, actor models.AuditActor) (*models.UserInfo, error) {
 	if req == nil {
 		return nil, ErrInvalidRequest
 	}
@@ -176,28 +416,38 @@ func (s *authService) UpdateUser(id uint, req *models.UpdateUserRequest) (*model
 		}
 		return nil, err
 	}
+	before := user.ToUserInfo()
 
 	// Update fields if provided
 	if req.Email != nil {
 		user.Email = *req.Email
 	}
 	if req.Role != nil {
+		if err := validateRole(s.roleRepo, *req.Role); err != nil {
+			return nil, err
+		}
 		user.Role = *req.Role
 	}
 	if req.IsActive != nil {
 		user.IsActive = *req.IsActive
 	}
 
+	userInfo := user.ToUserInfo()
+	event, err := newAuditEvent(actor, models.AuditActionUpdate, models.AuditEntityUser, user.ID, before, userInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Save updated user
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.Update(user, event); err != nil {
 		return nil, err
 	}
 
-	userInfo := user.ToUserInfo()
 	return &userInfo, nil
 }
 
-// ChangePassword changes a user's password
+// ChangePassword changes a user's password and ends all of their sessions, so that a stolen session
+// doesn't outlive the old password
 func (s *authService) ChangePassword(userID uint, req *models.ChangePasswordRequest) error {
 	if req == nil {
 		return ErrInvalidRequest
@@ -223,13 +473,51 @@ func (s *authService) ChangePassword(userID uint, req *models.ChangePasswordRequ
 	}
 
 	// Save user
-	return s.userRepo.Update(user)
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeUser(user.ID, s.now()); err != nil {
+		return fmt.Errorf("password was changed but sessions could not be revoked: %w", err)
+	}
+	return nil
 }
 
-// DeleteUser deletes a user
-func (s *authService) DeleteUser(id uint) error {
+// DeleteUser deletes a user, recording the deletion in the audit log
+func (s *authService) DeleteUser(id uint, actor models.AuditActor) error {
 	// Check if user exists
-	_, err := s.userRepo.GetByID(id)
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	event, err := newAuditEvent(actor, models.AuditActionDelete, models.AuditEntityUser, id, user.ToUserInfo(), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.Delete(id, event)
+}
+
+// GetUserLockStatus returns the login lockout state of a user
+func (s *authService) GetUserLockStatus(id uint) (*models.UserLockStatus, error) {
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return s.limiter.Status(user.Username)
+}
+
+// UnlockUser lifts a user's login lockout and clears their failed logins, recording the unlock in the
+// audit log. Lockouts of client IPs are left alone.
+func (s *authService) UnlockUser(id uint, actor models.AuditActor) error {
+	user, err := s.userRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrUserNotFound
@@ -237,10 +525,20 @@ func (s *authService) DeleteUser(id uint) error {
 		return err
 	}
 
-	return s.userRepo.Delete(id)
+	before, err := s.limiter.Status(user.Username)
+	if err != nil {
+		return err
+	}
+	event, err := newAuditEvent(actor, models.AuditActionUpdate, models.AuditEntityUser, id, before, &models.UserLockStatus{})
+	if err != nil {
+		return err
+	}
+
+	return s.limiter.Unlock(user.Username, event)
 }
 
-// ValidateToken validates a JWT token and returns the user
+// ValidateToken validates a JWT access token and returns the user. Tokens whose session was revoked
+// are rejected even before they expire.
 func (s *authService) ValidateToken(tokenString string) (*models.User, error) {
 	// Parse token
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
@@ -253,7 +551,19 @@ func (s *authService) ValidateToken(tokenString string) (*models.User, error) {
 
 	// Extract claims
 	claims, ok := token.Claims.(*JWTClaims)
-	if !ok {
+	if !ok || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	// Check the session the token belongs to is still alive
+	session, err := s.tokenRepo.GetByAccessTokenID(claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if session.IsRevoked() || session.UserID != claims.UserID {
 		return nil, ErrInvalidToken
 	}
 
@@ -304,17 +614,72 @@ func (s *authService) CreateDefaultAdmin() error {
 	return s.userRepo.Create(adminUser)
 }
 
-// generateJWT generates a JWT token for the user
-func (s *authService) generateJWT(user *models.User) (string, time.Time, error) {
-	expiresAt := time.Now().Add(24 * time.Hour)
+// getRefreshToken looks up a refresh token by its value, rejecting unknown and revoked tokens
+func (s *authService) getRefreshToken(plainRefreshToken string) (*models.RefreshToken, error) {
+	token, err := s.tokenRepo.GetByTokenHash(hashToken(plainRefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if token.IsRevoked() {
+		return nil, ErrInvalidToken
+	}
+	return token, nil
+}
+
+// newRefreshToken creates an unsaved refresh token in the given family, returning it with its plain value
+func (s *authService) newRefreshToken(userID uint, familyID string) (*models.RefreshToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	plain := base64.RawURLEncoding.EncodeToString(b)
+
+	accessTokenID, err := newTokenID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &models.RefreshToken{
+		UserID:        userID,
+		FamilyID:      familyID,
+		TokenHash:     hashToken(plain),
+		AccessTokenID: accessTokenID,
+		ExpiresAt:     s.now().Add(s.refreshTokenTTL),
+	}, plain, nil
+}
+
+// tokenResponse issues the access token paired with refreshToken
+func (s *authService) tokenResponse(user *models.User, refreshToken *models.RefreshToken, plainRefreshToken string) (*models.LoginResponse, error) {
+	token, expiresAt, err := s.generateJWT(user, refreshToken.AccessTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LoginResponse{
+		Token:                 token,
+		ExpiresAt:             expiresAt,
+		RefreshToken:          plainRefreshToken,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+		User:                  user.ToUserInfo(),
+	}, nil
+}
+
+// generateJWT generates a JWT access token for the user with the given token ID (jti)
+func (s *authService) generateJWT(user *models.User, tokenID string) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.accessTokenTTL)
 
 	claims := &JWTClaims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Role:     string(user.Role),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.Username,
 		},
 	}
@@ -335,3 +700,25 @@ type JWTClaims struct {
 	Role     string `json:"role"`
 	jwt.RegisteredClaims
 }
+
+// newTokenID generates a random identifier for token families and access tokens
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// mfaChallengeAudience is the audience of MFA challenge tokens
+const mfaChallengeAudience = "mfa-challenge"
+
+// dummyUser has the hash of a random password. Checking passwords of unknown usernames against it makes
+// them take as long as for real users.
+var dummyUser = &models.User{PasswordHash: "$2a$10$5OJdZkbAJjk1//g7UanJmOpJVA38Xa.v7X.phHDQXSb0V7/JM2MvC"}
+
+// hashToken returns the hex SHA-256 of a token. Refresh tokens are random and long, so a fast hash is enough.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
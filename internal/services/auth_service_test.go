@@ -1,6 +1,7 @@
 package services
 
 import (
+	"support-app-backend/internal/config"
 	"support-app-backend/internal/models"
 	"testing"
 	"time"
@@ -45,13 +46,14 @@ func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) Update(user *models.User) error {
-	args := m.Called(user)
+// Update expects one extra argument per audit event, so calls without events match On("Update", user)
+func (m *MockUserRepository) Update(user *models.User, events ...*models.AuditEvent) error {
+	args := m.Called(withAuditEvents([]interface{}{user}, events)...)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockUserRepository) Delete(id uint, events ...*models.AuditEvent) error {
+	args := m.Called(withAuditEvents([]interface{}{id}, events)...)
 	return args.Error(0)
 }
 
@@ -73,14 +75,179 @@ func (m *MockUserRepository) UserExists(username, email string) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+// MockRefreshTokenRepository is a mock implementation of RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(token *models.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) GetByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) GetByAccessTokenID(accessTokenID string) (*models.RefreshToken, error) {
+	args := m.Called(accessTokenID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Rotate(used, next *models.RefreshToken, usedAt time.Time) (bool, error) {
+	args := m.Called(used, next, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	args := m.Called(familyID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeUser(userID uint, revokedAt time.Time) error {
+	args := m.Called(userID, revokedAt)
+	return args.Error(0)
+}
+
+// MockLoginLimiter is a mock implementation of LoginLimiter
+type MockLoginLimiter struct {
+	mock.Mock
+}
+
+func (m *MockLoginLimiter) Check(username, clientIP string) error {
+	args := m.Called(username, clientIP)
+	return args.Error(0)
+}
+
+func (m *MockLoginLimiter) RecordFailure(username, clientIP string) error {
+	args := m.Called(username, clientIP)
+	return args.Error(0)
+}
+
+func (m *MockLoginLimiter) RecordSuccess(username string) error {
+	args := m.Called(username)
+	return args.Error(0)
+}
+
+func (m *MockLoginLimiter) Status(username string) (*models.UserLockStatus, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserLockStatus), args.Error(1)
+}
+
+func (m *MockLoginLimiter) Unlock(username string, events ...*models.AuditEvent) error {
+	args := m.Called(username, events)
+	return args.Error(0)
+}
+
+// MockMFAService is a mock implementation of MFAService
+type MockMFAService struct {
+	mock.Mock
+}
+
+func (m *MockMFAService) Enroll(userID uint) (*models.MFAEnrollmentResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockMFAService) Confirm(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	args := m.Called(userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockMFAService) Disable(userID uint, req *models.DisableMFARequest) error {
+	args := m.Called(userID, req)
+	return args.Error(0)
+}
+
+func (m *MockMFAService) RegenerateRecoveryCodes(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	args := m.Called(userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockMFAService) IsRequired(user *models.User) bool {
+	args := m.Called(user)
+	return args.Bool(0)
+}
+
+func (m *MockMFAService) BeginEnrollment(user *models.User) (*models.MFAEnrollmentResponse, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockMFAService) VerifyLogin(user *models.User, code string) ([]string, error) {
+	args := m.Called(user, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+var testJWTConfig = config.JWTConfig{
+	SecretKey:       "test-jwt-secret-key-that-is-long-enough-for-testing",
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: 24 * time.Hour,
+	MFAChallengeTTL: 5 * time.Minute,
+}
+
 func setupAuthService() (AuthService, *MockUserRepository) {
-	mockRepo := new(MockUserRepository)
-	service := NewAuthService(mockRepo, "test-jwt-secret-key-that-is-long-enough-for-testing")
+	service, mockRepo, _ := setupAuthServiceWithTokens()
 	return service, mockRepo
 }
 
+func setupAuthServiceWithTokens() (AuthService, *MockUserRepository, *MockRefreshTokenRepository) {
+	service, mockRepo, mockTokenRepo, mockLimiter := setupAuthServiceWithLimiter()
+	mockLimiter.On("Check", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockLimiter.On("RecordFailure", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockLimiter.On("RecordSuccess", mock.Anything).Return(nil).Maybe()
+	return service, mockRepo, mockTokenRepo
+}
+
+func setupAuthServiceWithLimiter() (AuthService, *MockUserRepository, *MockRefreshTokenRepository, *MockLoginLimiter) {
+	service, mockRepo, mockTokenRepo, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+	mockMFA.On("IsRequired", mock.Anything).Return(false).Maybe()
+	return service, mockRepo, mockTokenRepo, mockLimiter
+}
+
+func setupAuthServiceWithMFA() (AuthService, *MockUserRepository, *MockRefreshTokenRepository, *MockLoginLimiter, *MockMFAService) {
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	mockLimiter := new(MockLoginLimiter)
+	mockMFA := new(MockMFAService)
+	service := NewAuthService(mockRepo, mockTokenRepo, new(MockRoleRepository), mockLimiter, mockMFA, testJWTConfig)
+	return service, mockRepo, mockTokenRepo, mockLimiter, mockMFA
+}
+
+func setupAuthServiceWithRoles() (AuthService, *MockUserRepository, *MockRoleRepository) {
+	mockRepo := new(MockUserRepository)
+	mockRoleRepo := new(MockRoleRepository)
+	service := NewAuthService(mockRepo, new(MockRefreshTokenRepository), mockRoleRepo, new(MockLoginLimiter), new(MockMFAService), testJWTConfig)
+	return service, mockRepo, mockRoleRepo
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
-	service, mockRepo := setupAuthService()
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
 
 	user := &models.User{
 		ID:       1,
@@ -98,12 +265,23 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 	mockRepo.On("GetByUsername", "testuser").Return(user, nil)
 	mockRepo.On("UpdateLastLogin", uint(1)).Return(nil)
+	var stored *models.RefreshToken
+	mockTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*models.RefreshToken) }).
+		Return(nil)
 
-	response, err := service.Login(req)
+	response, err := service.Login(req, "203.0.113.7")
 
 	require.NoError(t, err)
 	assert.NotNil(t, response)
 	assert.NotEmpty(t, response.Token)
+	assert.NotEmpty(t, response.RefreshToken)
+	// Only the hash of the refresh token is stored
+	assert.Equal(t, hashToken(response.RefreshToken), stored.TokenHash)
+	assert.NotEqual(t, response.RefreshToken, stored.TokenHash)
+	assert.Equal(t, uint(1), stored.UserID)
+	assert.NotEmpty(t, stored.FamilyID)
+	assert.Equal(t, stored.ExpiresAt, response.RefreshTokenExpiresAt)
 	assert.Equal(t, user.Username, response.User.Username)
 	assert.Equal(t, user.Email, response.User.Email)
 	assert.Equal(t, user.Role, response.User.Role)
@@ -114,7 +292,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 func TestAuthService_Login_NilRequest(t *testing.T) {
 	service, _ := setupAuthService()
 
-	response, err := service.Login(nil)
+	response, err := service.Login(nil, "203.0.113.7")
 
 	assert.Nil(t, response)
 	assert.Equal(t, ErrInvalidRequest, err)
@@ -130,13 +308,140 @@ func TestAuthService_Login_UserNotFound(t *testing.T) {
 
 	mockRepo.On("GetByUsername", "nonexistent").Return(nil, gorm.ErrRecordNotFound)
 
-	response, err := service.Login(req)
+	response, err := service.Login(req, "203.0.113.7")
 
 	assert.Nil(t, response)
 	assert.Equal(t, ErrInvalidCredentials, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAuthService_Login_Locked(t *testing.T) {
+	service, mockRepo, _, mockLimiter := setupAuthServiceWithLimiter()
+	mockLimiter.On("Check", "testuser", "203.0.113.7").Return(ErrLoginLocked)
+
+	response, err := service.Login(&models.LoginRequest{Username: "testuser", Password: "password123"}, "203.0.113.7")
+
+	assert.Nil(t, response)
+	assert.Equal(t, ErrLoginLocked, err)
+	mockRepo.AssertNotCalled(t, "GetByUsername", mock.Anything)
+}
+
+func TestAuthService_Login_FailuresAreRecorded(t *testing.T) {
+	user := &models.User{ID: 1, Username: "testuser", IsActive: true}
+	user.SetPassword("password123")
+
+	tests := []struct {
+		name     string
+		username string
+		user     *models.User
+	}{
+		{name: "wrong password", username: "testuser", user: user},
+		{name: "unknown username", username: "nonexistent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _, mockLimiter := setupAuthServiceWithLimiter()
+			mockLimiter.On("Check", tt.username, "203.0.113.7").Return(nil)
+			mockLimiter.On("RecordFailure", tt.username, "203.0.113.7").Return(nil)
+			if tt.user != nil {
+				mockRepo.On("GetByUsername", tt.username).Return(tt.user, nil)
+			} else {
+				mockRepo.On("GetByUsername", tt.username).Return(nil, gorm.ErrRecordNotFound)
+			}
+
+			response, err := service.Login(&models.LoginRequest{Username: tt.username, Password: "wrongpassword"}, "203.0.113.7")
+
+			assert.Nil(t, response)
+			assert.Equal(t, ErrInvalidCredentials, err)
+			mockLimiter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_Login_SuccessClearsFailures(t *testing.T) {
+	service, mockRepo, mockTokenRepo, mockLimiter := setupAuthServiceWithLimiter()
+	user := &models.User{ID: 1, Username: "testuser", IsActive: true}
+	user.SetPassword("password123")
+	mockLimiter.On("Check", "testuser", "203.0.113.7").Return(nil)
+	mockLimiter.On("RecordSuccess", "testuser").Return(nil)
+	mockRepo.On("GetByUsername", "testuser").Return(user, nil)
+	mockRepo.On("UpdateLastLogin", uint(1)).Return(nil)
+	mockTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	response, err := service.Login(&models.LoginRequest{Username: "testuser", Password: "password123"}, "203.0.113.7")
+
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+	mockLimiter.AssertExpectations(t)
+	mockLimiter.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_InactiveUserWithWrongPassword(t *testing.T) {
+	service, mockRepo := setupAuthService()
+	user := &models.User{ID: 1, Username: "testuser", IsActive: false}
+	user.SetPassword("password123")
+	mockRepo.On("GetByUsername", "testuser").Return(user, nil)
+
+	response, err := service.Login(&models.LoginRequest{Username: "testuser", Password: "wrongpassword"}, "203.0.113.7")
+
+	// The account state is only revealed to callers who know the password
+	assert.Nil(t, response)
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func TestAuthService_GetUserLockStatus(t *testing.T) {
+	service, mockRepo, _, mockLimiter := setupAuthServiceWithLimiter()
+	until := time.Now().Add(time.Minute)
+	status := &models.UserLockStatus{Locked: true, LockedUntil: &until}
+	mockRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Username: "testuser"}, nil)
+	mockLimiter.On("Status", "testuser").Return(status, nil)
+
+	result, err := service.GetUserLockStatus(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, status, result)
+}
+
+func TestAuthService_GetUserLockStatus_UserNotFound(t *testing.T) {
+	service, mockRepo := setupAuthService()
+	mockRepo.On("GetByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := service.GetUserLockStatus(99)
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestAuthService_UnlockUser(t *testing.T) {
+	service, mockRepo, _, mockLimiter := setupAuthServiceWithLimiter()
+	until := time.Now().Add(time.Minute)
+	mockRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2, Username: "testuser"}, nil)
+	mockLimiter.On("Status", "testuser").Return(&models.UserLockStatus{Locked: true, LockedUntil: &until, FailedAttempts: 0}, nil)
+	var events []*models.AuditEvent
+	mockLimiter.On("Unlock", "testuser", mock.Anything).
+		Run(func(args mock.Arguments) { events = args.Get(1).([]*models.AuditEvent) }).
+		Return(nil)
+
+	err := service.UnlockUser(2, models.AuditActor{UserID: 1, Username: "admin"})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.AuditActionUpdate, events[0].Action)
+	assert.Equal(t, models.AuditEntityUser, events[0].EntityType)
+	assert.Equal(t, uint(2), events[0].EntityID)
+	assert.Contains(t, events[0].Changes, "locked")
+}
+
+func TestAuthService_UnlockUser_UserNotFound(t *testing.T) {
+	service, mockRepo := setupAuthService()
+	mockRepo.On("GetByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.UnlockUser(99, models.AuditActor{UserID: 1, Username: "admin"})
+
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
 func TestAuthService_Login_UserInactive(t *testing.T) {
 	service, mockRepo := setupAuthService()
 
@@ -156,7 +461,7 @@ func TestAuthService_Login_UserInactive(t *testing.T) {
 
 	mockRepo.On("GetByUsername", "testuser").Return(user, nil)
 
-	response, err := service.Login(req)
+	response, err := service.Login(req, "203.0.113.7")
 
 	assert.Nil(t, response)
 	assert.Equal(t, ErrUserInactive, err)
@@ -182,7 +487,7 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 
 	mockRepo.On("GetByUsername", "testuser").Return(user, nil)
 
-	response, err := service.Login(req)
+	response, err := service.Login(req, "203.0.113.7")
 
 	assert.Nil(t, response)
 	assert.Equal(t, ErrInvalidCredentials, err)
@@ -368,9 +673,17 @@ func TestAuthService_UpdateUser_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", uint(1)).Return(user, nil)
-	mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(nil)
-
-	response, err := service.UpdateUser(1, req)
+	mockRepo.On("Update", mock.AnythingOfType("*models.User"), mock.MatchedBy(func(event *models.AuditEvent) bool {
+		changes := auditChanges(t, event)
+		return event.Action == models.AuditActionUpdate &&
+			event.EntityType == models.AuditEntityUser &&
+			event.EntityID == 1 &&
+			event.ActorUsername == testActor.Username &&
+			changes["role"] == models.AuditChange{Before: "user", After: "admin"} &&
+			len(changes) == 3
+	})).Return(nil)
+
+	response, err := service.UpdateUser(1, req, testActor)
 
 	require.NoError(t, err)
 	assert.NotNil(t, response)
@@ -387,7 +700,7 @@ func TestAuthService_UpdateUser_NotFound(t *testing.T) {
 
 	mockRepo.On("GetByID", uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
-	response, err := service.UpdateUser(999, req)
+	response, err := service.UpdateUser(999, req, testActor)
 
 	assert.Nil(t, response)
 	assert.Equal(t, ErrUserNotFound, err)
@@ -404,9 +717,11 @@ func TestAuthService_DeleteUser_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", uint(1)).Return(user, nil)
-	mockRepo.On("Delete", uint(1)).Return(nil)
+	mockRepo.On("Delete", uint(1), mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionDelete && event.EntityType == models.AuditEntityUser && event.EntityID == 1
+	})).Return(nil)
 
-	err := service.DeleteUser(1)
+	err := service.DeleteUser(1, testActor)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -417,14 +732,14 @@ func TestAuthService_DeleteUser_NotFound(t *testing.T) {
 
 	mockRepo.On("GetByID", uint(999)).Return(nil, gorm.ErrRecordNotFound)
 
-	err := service.DeleteUser(999)
+	err := service.DeleteUser(999, testActor)
 
 	assert.Equal(t, ErrUserNotFound, err)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestAuthService_ChangePassword_Success(t *testing.T) {
-	service, mockRepo := setupAuthService()
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
 
 	user := &models.User{
 		ID:       1,
@@ -440,12 +755,14 @@ func TestAuthService_ChangePassword_Success(t *testing.T) {
 
 	mockRepo.On("GetByID", uint(1)).Return(user, nil)
 	mockRepo.On("Update", mock.AnythingOfType("*models.User")).Return(nil)
+	mockTokenRepo.On("RevokeUser", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
 
 	err := service.ChangePassword(1, req)
 
 	assert.NoError(t, err)
 	assert.True(t, user.CheckPassword("newpassword123"))
 	mockRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
 }
 
 func TestAuthService_ChangePassword_UserNotFound(t *testing.T) {
@@ -465,7 +782,7 @@ func TestAuthService_ChangePassword_UserNotFound(t *testing.T) {
 }
 
 func TestAuthService_ChangePassword_WrongCurrentPassword(t *testing.T) {
-	service, mockRepo := setupAuthService()
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
 
 	user := &models.User{
 		ID:       1,
@@ -485,10 +802,11 @@ func TestAuthService_ChangePassword_WrongCurrentPassword(t *testing.T) {
 
 	assert.Equal(t, ErrInvalidCredentials, err)
 	mockRepo.AssertExpectations(t)
+	mockTokenRepo.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything)
 }
 
 func TestAuthService_ValidateToken_Success(t *testing.T) {
-	service, mockRepo := setupAuthService()
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
 
 	user := &models.User{
 		ID:       1,
@@ -500,8 +818,9 @@ func TestAuthService_ValidateToken_Success(t *testing.T) {
 
 	// Generate a valid token first
 	authSvc := service.(*authService)
-	token, _, err := authSvc.generateJWT(user)
+	token, _, err := authSvc.generateJWT(user, "jti-1")
 	require.NoError(t, err)
+	mockTokenRepo.On("GetByAccessTokenID", "jti-1").Return(&models.RefreshToken{UserID: 1, AccessTokenID: "jti-1"}, nil)
 
 	mockRepo.On("GetByID", uint(1)).Return(user, nil)
 
@@ -524,7 +843,7 @@ func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 }
 
 func TestAuthService_ValidateToken_UserNotFound(t *testing.T) {
-	service, mockRepo := setupAuthService()
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
 
 	user := &models.User{
 		ID:       1,
@@ -536,8 +855,9 @@ func TestAuthService_ValidateToken_UserNotFound(t *testing.T) {
 
 	// Generate a valid token first
 	authSvc := service.(*authService)
-	token, _, err := authSvc.generateJWT(user)
+	token, _, err := authSvc.generateJWT(user, "jti-1")
 	require.NoError(t, err)
+	mockTokenRepo.On("GetByAccessTokenID", "jti-1").Return(&models.RefreshToken{UserID: 1, AccessTokenID: "jti-1"}, nil)
 
 	mockRepo.On("GetByID", uint(1)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -549,7 +869,7 @@ func TestAuthService_ValidateToken_UserNotFound(t *testing.T) {
 }
 
 func TestAuthService_ValidateToken_UserInactive(t *testing.T) {
-	service, mockRepo := setupAuthService()
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
 
 	user := &models.User{
 		ID:       1,
@@ -561,8 +881,9 @@ func TestAuthService_ValidateToken_UserInactive(t *testing.T) {
 
 	// Generate a valid token first
 	authSvc := service.(*authService)
-	token, _, err := authSvc.generateJWT(user)
+	token, _, err := authSvc.generateJWT(user, "jti-1")
 	require.NoError(t, err)
+	mockTokenRepo.On("GetByAccessTokenID", "jti-1").Return(&models.RefreshToken{UserID: 1, AccessTokenID: "jti-1"}, nil)
 
 	// Make user inactive
 	user.IsActive = false
@@ -617,10 +938,391 @@ func TestAuthService_GenerateJWT_Success(t *testing.T) {
 	}
 
 	authSvc := service.(*authService)
-	token, expiresAt, err := authSvc.generateJWT(user)
+	token, expiresAt, err := authSvc.generateJWT(user, "jti-1")
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 	assert.True(t, expiresAt.After(time.Now()))
-	assert.True(t, expiresAt.Before(time.Now().Add(25*time.Hour)))
+	assert.True(t, expiresAt.Before(time.Now().Add(16*time.Minute)))
+}
+
+func TestAuthService_ValidateToken_RevokedSession(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true}
+	token, _, err := service.(*authService).generateJWT(user, "jti-1")
+	require.NoError(t, err)
+
+	revokedAt := time.Now()
+	mockTokenRepo.On("GetByAccessTokenID", "jti-1").Return(&models.RefreshToken{UserID: 1, AccessTokenID: "jti-1", RevokedAt: &revokedAt}, nil)
+
+	validatedUser, err := service.ValidateToken(token)
+
+	assert.Nil(t, validatedUser)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestAuthService_ValidateToken_UnknownTokenID(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true}
+	token, _, err := service.(*authService).generateJWT(user, "jti-unknown")
+	require.NoError(t, err)
+	mockTokenRepo.On("GetByAccessTokenID", "jti-unknown").Return(nil, gorm.ErrRecordNotFound)
+
+	validatedUser, err := service.ValidateToken(token)
+
+	assert.Nil(t, validatedUser)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestAuthService_ValidateToken_MissingTokenID(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	// Tokens issued before refresh tokens existed carry no jti
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true}
+	token, _, err := service.(*authService).generateJWT(user, "")
+	require.NoError(t, err)
+
+	validatedUser, err := service.ValidateToken(token)
+
+	assert.Nil(t, validatedUser)
+	assert.Equal(t, ErrInvalidToken, err)
+	mockTokenRepo.AssertNotCalled(t, "GetByAccessTokenID", mock.Anything)
+}
+
+func TestAuthService_Refresh_RotatesToken(t *testing.T) {
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true}
+	current := &models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", TokenHash: hashToken("old-token"), ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.On("GetByTokenHash", hashToken("old-token")).Return(current, nil)
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	var next *models.RefreshToken
+	mockTokenRepo.On("Rotate", current, mock.AnythingOfType("*models.RefreshToken"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { next = args.Get(1).(*models.RefreshToken) }).
+		Return(true, nil)
+
+	response, err := service.Refresh("old-token")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	assert.NotEqual(t, "old-token", response.RefreshToken)
+	assert.Equal(t, hashToken(response.RefreshToken), next.TokenHash)
+	assert.Equal(t, "family", next.FamilyID)
+
+	// The new access token is bound to the new refresh token
+	mockTokenRepo.On("GetByAccessTokenID", next.AccessTokenID).Return(next, nil)
+	validatedUser, err := service.ValidateToken(response.Token)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), validatedUser.ID)
+}
+
+func TestAuthService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	usedAt := time.Now().Add(-time.Minute)
+	used := &models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+	mockTokenRepo.On("GetByTokenHash", hashToken("stolen-token")).Return(used, nil)
+	mockTokenRepo.On("RevokeFamily", "family", mock.AnythingOfType("time.Time")).Return(nil)
+
+	response, err := service.Refresh("stolen-token")
+
+	assert.Nil(t, response)
+	assert.Equal(t, ErrTokenReused, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Refresh_LostRaceRevokesFamily(t *testing.T) {
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
+
+	current := &models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.On("GetByTokenHash", hashToken("token")).Return(current, nil)
+	mockRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, IsActive: true}, nil)
+	mockTokenRepo.On("Rotate", current, mock.Anything, mock.Anything).Return(false, nil)
+	mockTokenRepo.On("RevokeFamily", "family", mock.AnythingOfType("time.Time")).Return(nil)
+
+	_, err := service.Refresh("token")
+
+	assert.Equal(t, ErrTokenReused, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Refresh_InvalidTokens(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	revokedAt := time.Now()
+	mockTokenRepo.On("GetByTokenHash", hashToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+	mockTokenRepo.On("GetByTokenHash", hashToken("revoked")).Return(&models.RefreshToken{FamilyID: "f1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	mockTokenRepo.On("GetByTokenHash", hashToken("expired")).Return(&models.RefreshToken{FamilyID: "f2", ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+	for _, token := range []string{"unknown", "revoked", "expired"} {
+		response, err := service.Refresh(token)
+		assert.Nil(t, response, token)
+		assert.Equal(t, ErrInvalidToken, err, token)
+	}
+}
+
+func TestAuthService_Refresh_InactiveUser(t *testing.T) {
+	service, mockRepo, mockTokenRepo := setupAuthServiceWithTokens()
+
+	mockTokenRepo.On("GetByTokenHash", hashToken("token")).Return(&models.RefreshToken{UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, IsActive: false}, nil)
+
+	_, err := service.Refresh("token")
+
+	assert.Equal(t, ErrUserInactive, err)
+	mockTokenRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	mockTokenRepo.On("GetByTokenHash", hashToken("token")).Return(&models.RefreshToken{FamilyID: "family"}, nil)
+	mockTokenRepo.On("RevokeFamily", "family", mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := service.Logout("token")
+
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Logout_AlreadyRevoked(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	revokedAt := time.Now()
+	mockTokenRepo.On("GetByTokenHash", hashToken("token")).Return(&models.RefreshToken{FamilyID: "family", RevokedAt: &revokedAt}, nil)
+
+	err := service.Logout("token")
+
+	assert.NoError(t, err)
+	mockTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Logout_UnknownToken(t *testing.T) {
+	service, _, mockTokenRepo := setupAuthServiceWithTokens()
+
+	mockTokenRepo.On("GetByTokenHash", hashToken("token")).Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.Logout("token")
+
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func mfaChallengeFor(t *testing.T, service AuthService, mockRepo *MockUserRepository, mockLimiter *MockLoginLimiter, mockMFA *MockMFAService, user *models.User) string {
+	t.Helper()
+	mockRepo.On("GetByUsername", user.Username).Return(user, nil).Once()
+	mockLimiter.On("Check", user.Username, "203.0.113.7").Return(nil)
+	mockMFA.On("IsRequired", user).Return(true)
+
+	response, err := service.Login(&models.LoginRequest{Username: user.Username, Password: "password123"}, "203.0.113.7")
+	require.NoError(t, err)
+	require.NotNil(t, response.MFAChallenge)
+	return response.MFAChallenge.MFAToken
+}
+
+func TestAuthService_Login_MFARequiredReturnsChallenge(t *testing.T) {
+	service, mockRepo, mockTokenRepo, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true, MFAEnabled: true}
+	user.SetPassword("password123")
+	token := mfaChallengeFor(t, service, mockRepo, mockLimiter, mockMFA, user)
+
+	assert.NotEmpty(t, token)
+	// No session is started and failed attempts are not cleared before the second factor
+	mockLimiter.AssertNotCalled(t, "RecordSuccess", mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateLastLogin", mock.Anything)
+	mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+
+	// The challenge token is not an access token
+	_, err := service.ValidateToken(token)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestAuthService_Login_MFAEnrollmentRequired(t *testing.T) {
+	service, mockRepo, _, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "admin", Role: models.UserRoleAdmin, IsActive: true}
+	user.SetPassword("password123")
+	mockRepo.On("GetByUsername", "admin").Return(user, nil)
+	mockLimiter.On("Check", "admin", "203.0.113.7").Return(nil)
+	mockMFA.On("IsRequired", user).Return(true)
+
+	response, err := service.Login(&models.LoginRequest{Username: "admin", Password: "password123"}, "203.0.113.7")
+
+	require.NoError(t, err)
+	require.NotNil(t, response.MFAChallenge)
+	assert.True(t, response.MFAChallenge.MFARequired)
+	assert.True(t, response.MFAChallenge.EnrollmentRequired)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), response.MFAChallenge.ExpiresAt, time.Minute)
+	assert.Empty(t, response.Token)
+}
+
+func TestAuthService_CompleteMFALogin_Success(t *testing.T) {
+	service, mockRepo, mockTokenRepo, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true, MFAEnabled: true}
+	user.SetPassword("password123")
+	token := mfaChallengeFor(t, service, mockRepo, mockLimiter, mockMFA, user)
+
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockMFA.On("VerifyLogin", user, "123456").Return(nil, nil)
+	mockLimiter.On("RecordSuccess", "testuser").Return(nil)
+	mockRepo.On("UpdateLastLogin", uint(1)).Return(nil)
+	mockTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	response, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: token, Code: "123456"}, "203.0.113.7")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.Nil(t, response.MFAChallenge)
+	assert.Empty(t, response.RecoveryCodes)
+	mockLimiter.AssertExpectations(t)
+}
+
+func TestAuthService_CompleteMFALogin_ReturnsRecoveryCodesAfterEnrollment(t *testing.T) {
+	service, mockRepo, mockTokenRepo, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "admin", Role: models.UserRoleAdmin, IsActive: true, MFASecret: "SECRET"}
+	user.SetPassword("password123")
+	token := mfaChallengeFor(t, service, mockRepo, mockLimiter, mockMFA, user)
+
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockMFA.On("VerifyLogin", user, "123456").Return([]string{"abcde-fghij"}, nil)
+	mockLimiter.On("RecordSuccess", "admin").Return(nil)
+	mockRepo.On("UpdateLastLogin", uint(1)).Return(nil)
+	mockTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+	response, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: token, Code: "123456"}, "203.0.113.7")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcde-fghij"}, response.RecoveryCodes)
+}
+
+func TestAuthService_CompleteMFALogin_InvalidCodeCountsAsFailure(t *testing.T) {
+	service, mockRepo, mockTokenRepo, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true, MFAEnabled: true}
+	user.SetPassword("password123")
+	token := mfaChallengeFor(t, service, mockRepo, mockLimiter, mockMFA, user)
+
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockMFA.On("VerifyLogin", user, "000000").Return(nil, ErrInvalidMFACode)
+	mockLimiter.On("RecordFailure", "testuser", "203.0.113.7").Return(nil)
+
+	response, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: token, Code: "000000"}, "203.0.113.7")
+
+	assert.Nil(t, response)
+	assert.Equal(t, ErrInvalidMFACode, err)
+	mockLimiter.AssertExpectations(t)
+	mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthService_CompleteMFALogin_Locked(t *testing.T) {
+	service, mockRepo, _, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser, IsActive: true, MFAEnabled: true}
+	user.SetPassword("password123")
+	token := mfaChallengeFor(t, service, mockRepo, mockLimiter, mockMFA, user)
+
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockLimiter.On("Check", "testuser", "198.51.100.1").Return(ErrLoginLocked)
+
+	_, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: token, Code: "123456"}, "198.51.100.1")
+
+	assert.Equal(t, ErrLoginLocked, err)
+	mockMFA.AssertNotCalled(t, "VerifyLogin", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_CompleteMFALogin_InvalidToken(t *testing.T) {
+	service, _, _, _, mockMFA := setupAuthServiceWithMFA()
+
+	for _, token := range []string{"garbage", mustGenerateAccessToken(t, service)} {
+		response, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: token, Code: "123456"}, "203.0.113.7")
+		assert.Nil(t, response)
+		assert.Equal(t, ErrInvalidToken, err)
+	}
+	mockMFA.AssertNotCalled(t, "VerifyLogin", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_BeginMFALoginEnrollment(t *testing.T) {
+	service, mockRepo, _, mockLimiter, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Username: "admin", Role: models.UserRoleAdmin, IsActive: true}
+	user.SetPassword("password123")
+	token := mfaChallengeFor(t, service, mockRepo, mockLimiter, mockMFA, user)
+
+	enrollment := &models.MFAEnrollmentResponse{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockMFA.On("BeginEnrollment", user).Return(enrollment, nil)
+
+	response, err := service.BeginMFALoginEnrollment(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, enrollment, response)
+}
+
+func TestAuthService_Refresh_MFAEnrollmentRequired(t *testing.T) {
+	service, mockRepo, mockTokenRepo, _, mockMFA := setupAuthServiceWithMFA()
+
+	user := &models.User{ID: 1, Role: models.UserRoleAdmin, IsActive: true}
+	mockTokenRepo.On("GetByTokenHash", hashToken("token")).Return(&models.RefreshToken{UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockMFA.On("IsRequired", user).Return(true)
+	mockTokenRepo.On("RevokeFamily", "family", mock.AnythingOfType("time.Time")).Return(nil)
+
+	_, err := service.Refresh("token")
+
+	assert.Equal(t, ErrMFAEnrollmentRequired, err)
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// mustGenerateAccessToken returns a validly signed access token, which must not pass as an MFA challenge
+func mustGenerateAccessToken(t *testing.T, service AuthService) string {
+	t.Helper()
+	token, _, err := service.(*authService).generateJWT(&models.User{ID: 1, Username: "testuser", Role: models.UserRoleUser}, "token-id")
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthService_CreateUser_CustomRole(t *testing.T) {
+	service, mockRepo, mockRoleRepo := setupAuthServiceWithRoles()
+
+	req := &models.CreateUserRequest{Username: "newuser", Email: "new@example.com", Password: "password123", Role: "triage"}
+	mockRoleRepo.On("GetByName", models.UserRole("triage")).Return(&models.Role{ID: 1, Name: "triage"}, nil)
+	mockRepo.On("UserExists", "newuser", "new@example.com").Return(false, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*models.User")).Return(nil)
+
+	response, err := service.CreateUser(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.UserRole("triage"), response.Role)
+}
+
+func TestAuthService_CreateUser_UnknownRole(t *testing.T) {
+	service, mockRepo, mockRoleRepo := setupAuthServiceWithRoles()
+
+	req := &models.CreateUserRequest{Username: "newuser", Email: "new@example.com", Password: "password123", Role: "superuser"}
+	mockRoleRepo.On("GetByName", models.UserRole("superuser")).Return(nil, gorm.ErrRecordNotFound)
+
+	response, err := service.CreateUser(req)
+
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthService_UpdateUser_UnknownRole(t *testing.T) {
+	service, mockRepo, mockRoleRepo := setupAuthServiceWithRoles()
+
+	role := models.UserRole("superuser")
+	mockRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Role: models.UserRoleUser, IsActive: true}, nil)
+	mockRoleRepo.On("GetByName", role).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.UpdateUser(1, &models.UpdateUserRequest{Role: &role}, models.AuditActor{UserID: 2, Username: "admin"})
+
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
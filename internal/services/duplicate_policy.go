@@ -0,0 +1,94 @@
+package services
+
+import (
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"time"
+	"unicode"
+)
+
+// DuplicatePolicy decides whether a new support request is a suspected duplicate of a recent one
+// from the same submitter, app and device, by comparing the character trigrams of their messages
+type DuplicatePolicy struct {
+	Window     time.Duration // How far back requests are compared, zero disables the check
+	Similarity float64       // Minimum Jaccard similarity of the message trigrams, from 0 to 1
+}
+
+// NewDuplicatePolicy builds a duplicate policy from the intake configuration
+func NewDuplicatePolicy(cfg config.IntakeConfig) DuplicatePolicy {
+	return DuplicatePolicy{
+		Window:     cfg.DuplicateWindow,
+		Similarity: cfg.DuplicateSimilarity,
+	}
+}
+
+// Enabled reports whether new requests are checked for duplicates
+func (p DuplicatePolicy) Enabled() bool {
+	return p.Window > 0
+}
+
+// FindDuplicate returns the candidate whose message is most similar to the message of request, or
+// nil when none is similar enough. Candidates that are themselves suspected duplicates stand for
+// the request they duplicate, so flags always point at the earliest request.
+func (p DuplicatePolicy) FindDuplicate(request *models.SupportRequest, candidates []*models.SupportRequest) *uint {
+	trigrams := messageTrigrams(request.Message)
+
+	var best *models.SupportRequest
+	bestSimilarity := 0.0
+	for _, candidate := range candidates {
+		similarity := trigramSimilarity(trigrams, messageTrigrams(candidate.Message))
+		if similarity >= p.Similarity && similarity > bestSimilarity {
+			best = candidate
+			bestSimilarity = similarity
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	if best.DuplicateOfID != nil {
+		return best.DuplicateOfID
+	}
+	return &best.ID
+}
+
+// normalizeMessage lowercases a message and keeps only its words, separated by single spaces, so
+// that punctuation, case and whitespace don't affect the similarity
+func normalizeMessage(message string) string {
+	words := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// messageTrigrams returns the set of character trigrams of a normalized message. Messages shorter
+// than three characters are their own single trigram.
+func messageTrigrams(message string) map[string]bool {
+	runes := []rune(normalizeMessage(message))
+	trigrams := make(map[string]bool)
+	if len(runes) < 3 {
+		if len(runes) > 0 {
+			trigrams[string(runes)] = true
+		}
+		return trigrams
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		trigrams[string(runes[i:i+3])] = true
+	}
+	return trigrams
+}
+
+// trigramSimilarity returns the Jaccard similarity of two trigram sets: 1 for identical messages,
+// 0 for messages without a trigram in common
+func trigramSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if b[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
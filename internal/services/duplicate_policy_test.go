@@ -0,0 +1,59 @@
+package services
+
+import (
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDuplicatePolicy(t *testing.T) {
+	// Act
+	policy := NewDuplicatePolicy(config.IntakeConfig{DuplicateWindow: time.Hour, DuplicateSimilarity: 0.7})
+
+	// Assert
+	assert.True(t, policy.Enabled())
+	assert.Equal(t, time.Hour, policy.Window)
+	assert.Equal(t, 0.7, policy.Similarity)
+	assert.False(t, DuplicatePolicy{}.Enabled())
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected func(float64) bool
+	}{
+		{"identical", "The app crashes on launch", "The app crashes on launch", func(s float64) bool { return s == 1 }},
+		{"case and punctuation", "The app crashes on launch!", "the APP   crashes, on launch", func(s float64) bool { return s == 1 }},
+		{"small edit", "The app crashes on launch since the update", "The app crashes on launch since the last update", func(s float64) bool { return s >= 0.8 }},
+		{"different", "The app crashes on launch", "How do I change my password?", func(s float64) bool { return s < 0.2 }},
+		{"empty", "", "!!!", func(s float64) bool { return s == 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similarity := trigramSimilarity(messageTrigrams(tt.a), messageTrigrams(tt.b))
+			assert.True(t, tt.expected(similarity), "similarity %f", similarity)
+		})
+	}
+}
+
+func TestDuplicatePolicy_FindDuplicate(t *testing.T) {
+	// Arrange
+	policy := DuplicatePolicy{Window: time.Hour, Similarity: 0.8}
+	originalID := uint(1)
+	request := &models.SupportRequest{Message: "The app crashes on launch"}
+	unrelated := &models.SupportRequest{ID: 3, Message: "Please add a dark mode"}
+	similar := &models.SupportRequest{ID: 2, Message: "The app crashes on launch!!", DuplicateOfID: &originalID}
+	exact := &models.SupportRequest{ID: 4, Message: "the app crashes on launch"}
+
+	// Act & Assert
+	assert.Nil(t, policy.FindDuplicate(request, nil))
+	assert.Nil(t, policy.FindDuplicate(request, []*models.SupportRequest{unrelated}))
+	assert.Equal(t, originalID, *policy.FindDuplicate(request, []*models.SupportRequest{unrelated, similar}))
+	assert.Equal(t, uint(4), *policy.FindDuplicate(&models.SupportRequest{Message: "THE APP CRASHES ON LAUNCH."}, []*models.SupportRequest{exact}))
+}
@@ -0,0 +1,36 @@
+package services
+
+import "support-app-backend/internal/models"
+
+// EventPublisher receives support request lifecycle events. Publishing must not fail the change that
+// caused the event, so implementations handle their own errors.
+type EventPublisher interface {
+	Publish(event models.WebhookEventType, data interface{})
+}
+
+// publishSupportRequestUpdate publishes support_request.updated and, when the status moved,
+// support_request.status_changed
+func publishSupportRequestUpdate(events EventPublisher, before, after *models.SupportRequestResponse) {
+	events.Publish(models.WebhookEventSupportRequestUpdated, after)
+	if before.Status != after.Status {
+		events.Publish(models.WebhookEventSupportRequestStatusChanged, &models.SupportRequestStatusChange{
+			SupportRequest: after,
+			PreviousStatus: before.Status,
+		})
+	}
+}
+
+// eventPublishers publishes every event to several publishers in order
+type eventPublishers []EventPublisher
+
+// NewEventPublishers returns a publisher forwarding every event to each of publishers
+func NewEventPublishers(publishers ...EventPublisher) EventPublisher {
+	return eventPublishers(publishers)
+}
+
+// Publish forwards event to every publisher
+func (p eventPublishers) Publish(event models.WebhookEventType, data interface{}) {
+	for _, publisher := range p {
+		publisher.Publish(event, data)
+	}
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdempotencyKeyInUse    = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key was already used with a different request")
+)
+
+// idempotencyLockTimeout is how long a key stays reserved by a request that never completes, for
+// example because the server stopped. It allows for slow uploads of attachments.
+const idempotencyLockTimeout = 10 * time.Minute
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
+// idempotencyCleanupInterval is how often expired keys are deleted. Keys that expired since are
+// replaced when they are used again.
+const idempotencyCleanupInterval = time.Hour
+
+// IdempotencyService defines the interface for idempotency keys of support request submissions
+type IdempotencyService interface {
+	Begin(scope, key string) (*models.IdempotencyKey, error)
+	Check(key *models.IdempotencyKey, requestHash string) error
+	Complete(key *models.IdempotencyKey, idempotencyKey, requestHash string, status int, body []byte) error
+	Response(key *models.IdempotencyKey, idempotencyKey string) ([]byte, error)
+	Release(key *models.IdempotencyKey) error
+}
+
+// idempotencyService implements IdempotencyService
+type idempotencyService struct {
+	repo repositories.IdempotencyKeyRepository
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewIdempotencyService creates a new idempotency service that replays responses for ttl
+func NewIdempotencyService(repo repositories.IdempotencyKeyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{
+		repo: repo,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// Begin reserves a key for a new request. When the key was already used, the completed key is
+// returned instead, to be checked and replayed; ErrIdempotencyKeyInUse is returned while the
+// first request is still being processed. Only a hash of the key is stored.
+func (s *idempotencyService) Begin(scope, key string) (*models.IdempotencyKey, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("%w: Idempotency-Key must be at most %d characters", ErrInvalidRequest, maxIdempotencyKeyLength)
+	}
+	for _, r := range key {
+		if r < 0x20 || r > 0x7e {
+			return nil, fmt.Errorf("%w: Idempotency-Key must be printable ASCII", ErrInvalidRequest)
+		}
+	}
+
+	now := s.now()
+	idempotencyKey := &models.IdempotencyKey{
+		Scope:     scope,
+		Key:       hashToken(key),
+		ExpiresAt: now.Add(idempotencyLockTimeout),
+	}
+	reserved, err := s.repo.Reserve(idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return idempotencyKey, nil
+	}
+
+	existing, err := s.repo.Get(scope, idempotencyKey.Key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Released by the first request in the meantime; the client can retry right away
+			return nil, ErrIdempotencyKeyInUse
+		}
+		return nil, err
+	}
+	if !existing.ExpiresAt.After(now) {
+		// Not cleaned up yet; the key can be used again
+		if err := s.repo.Delete(existing.ID); err != nil {
+			return nil, err
+		}
+		if reserved, err = s.repo.Reserve(idempotencyKey); err != nil {
+			return nil, err
+		}
+		if reserved {
+			return idempotencyKey, nil
+		}
+		return nil, ErrIdempotencyKeyInUse
+	}
+	if !existing.IsCompleted() {
+		return nil, ErrIdempotencyKeyInUse
+	}
+	return existing, nil
+}
+
+// Check returns ErrIdempotencyKeyMismatch unless a completed key was used for a request with the same hash
+func (s *idempotencyService) Check(key *models.IdempotencyKey, requestHash string) error {
+	if key.RequestHash != requestHash {
+		return ErrIdempotencyKeyMismatch
+	}
+	return nil
+}
+
+// Complete stores the response to the request a key was reserved for, to be replayed until the key
+// expires. The response includes the tracking token, so it is encrypted with the Idempotency-Key,
+// which only the client knows.
+func (s *idempotencyService) Complete(key *models.IdempotencyKey, idempotencyKey, requestHash string, status int, body []byte) error {
+	gcm, err := idempotencyResponseCipher(idempotencyKey)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	key.RequestHash = requestHash
+	key.ResponseStatus = status
+	key.ResponseBody = base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, body, nil))
+	key.ExpiresAt = s.now().Add(s.ttl)
+	return s.repo.Update(key)
+}
+
+// Response decrypts the stored response of a completed key with its Idempotency-Key
+func (s *idempotencyService) Response(key *models.IdempotencyKey, idempotencyKey string) ([]byte, error) {
+	gcm, err := idempotencyResponseCipher(idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(key.ResponseBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("stored idempotent response is too short")
+	}
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+}
+
+// Release frees a reserved key whose request failed, so that it can be retried with the same key
+func (s *idempotencyService) Release(key *models.IdempotencyKey) error {
+	return s.repo.Delete(key.ID)
+}
+
+// idempotencyResponseCipher returns the cipher for the stored responses of an Idempotency-Key. The
+// key it uses can't be derived from the hash of the Idempotency-Key stored with the response.
+func idempotencyResponseCipher(idempotencyKey string) (cipher.AEAD, error) {
+	secret := sha256.Sum256([]byte("idempotency-response:" + idempotencyKey))
+	block, err := aes.NewCipher(secret[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IdempotencyKeyCleaner deletes expired idempotency keys in the background, so that submissions
+// don't have to
+type IdempotencyKeyCleaner struct {
+	repo     repositories.IdempotencyKeyRepository
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewIdempotencyKeyCleaner creates a cleaner that deletes expired keys every hour
+func NewIdempotencyKeyCleaner(repo repositories.IdempotencyKeyRepository) *IdempotencyKeyCleaner {
+	return &IdempotencyKeyCleaner{
+		repo:     repo,
+		interval: idempotencyCleanupInterval,
+		now:      time.Now,
+	}
+}
+
+// Run deletes expired keys every interval until ctx is cancelled
+func (c *IdempotencyKeyCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.DeleteExpired(); err != nil {
+			log.Printf("Warning: Failed to delete expired idempotency keys: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteExpired deletes the keys that have expired
+func (c *IdempotencyKeyCleaner) DeleteExpired() error {
+	return c.repo.DeleteExpired(c.now())
+}
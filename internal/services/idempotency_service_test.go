@@ -0,0 +1,205 @@
+package services
+
+import (
+	"strings"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockIdempotencyKeyRepository is a mock implementation of IdempotencyKeyRepository
+type MockIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyKeyRepository) Reserve(key *models.IdempotencyKey) (bool, error) {
+	args := m.Called(key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockIdempotencyKeyRepository) Get(scope, key string) (*models.IdempotencyKey, error) {
+	args := m.Called(scope, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IdempotencyKey), args.Error(1)
+}
+
+func (m *MockIdempotencyKeyRepository) Update(key *models.IdempotencyKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyKeyRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyKeyRepository) DeleteExpired(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
+
+// setupIdempotencyService creates an idempotency service with a fixed clock
+func setupIdempotencyService(now time.Time) (IdempotencyService, *MockIdempotencyKeyRepository) {
+	repo := new(MockIdempotencyKeyRepository)
+	service := NewIdempotencyService(repo, 24*time.Hour).(*idempotencyService)
+	service.now = func() time.Time { return now }
+	return service, repo
+}
+
+func TestIdempotencyService_Begin_ReservesNewKey(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	service, repo := setupIdempotencyService(now)
+	repo.On("Reserve", mock.MatchedBy(func(key *models.IdempotencyKey) bool {
+		return key.Scope == "app:1" && key.Key == hashToken("retry-1") && key.ExpiresAt.Equal(now.Add(idempotencyLockTimeout))
+	})).Return(true, nil)
+
+	// Act
+	key, err := service.Begin("app:1", "retry-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, key.IsCompleted())
+	repo.AssertExpectations(t)
+}
+
+func TestIdempotencyService_Begin_ReturnsCompletedKey(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	service, repo := setupIdempotencyService(now)
+	completed := &models.IdempotencyKey{ID: 7, Key: hashToken("retry-1"), RequestHash: "abc", ResponseStatus: 201, ExpiresAt: now.Add(time.Hour)}
+	repo.On("Reserve", mock.Anything).Return(false, nil)
+	repo.On("Get", "", hashToken("retry-1")).Return(completed, nil)
+
+	// Act
+	key, err := service.Begin("", "retry-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, completed, key)
+	assert.NoError(t, service.Check(key, "abc"))
+	assert.ErrorIs(t, service.Check(key, "def"), ErrIdempotencyKeyMismatch)
+}
+
+func TestIdempotencyService_Begin_InUse(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *models.IdempotencyKey
+		err      error
+	}{
+		{"first request still processing", &models.IdempotencyKey{ID: 7, ExpiresAt: time.Date(2024, 6, 3, 10, 5, 0, 0, time.UTC)}, nil},
+		{"first request released the key", nil, gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+			service, repo := setupIdempotencyService(now)
+			repo.On("Reserve", mock.Anything).Return(false, nil)
+			repo.On("Get", "", hashToken("retry-1")).Return(tt.existing, tt.err)
+
+			// Act
+			key, err := service.Begin("", "retry-1")
+
+			// Assert
+			assert.ErrorIs(t, err, ErrIdempotencyKeyInUse)
+			assert.Nil(t, key)
+		})
+	}
+}
+
+func TestIdempotencyService_Begin_ReplacesExpiredKey(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	service, repo := setupIdempotencyService(now)
+	expired := &models.IdempotencyKey{ID: 7, Key: hashToken("retry-1"), ResponseStatus: 201, ExpiresAt: now.Add(-time.Minute)}
+	repo.On("Reserve", mock.Anything).Return(false, nil).Once()
+	repo.On("Get", "", hashToken("retry-1")).Return(expired, nil)
+	repo.On("Delete", uint(7)).Return(nil)
+	repo.On("Reserve", mock.Anything).Return(true, nil).Once()
+
+	// Act
+	key, err := service.Begin("", "retry-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, key.IsCompleted())
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "DeleteExpired", mock.Anything)
+}
+
+func TestIdempotencyService_Begin_InvalidKey(t *testing.T) {
+	for _, key := range []string{strings.Repeat("a", 256), "line\nbreak", "unicodé"} {
+		// Arrange
+		service, repo := setupIdempotencyService(time.Now())
+
+		// Act
+		_, err := service.Begin("", key)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+		repo.AssertNotCalled(t, "Reserve", mock.Anything)
+	}
+}
+
+func TestIdempotencyService_Complete(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	service, repo := setupIdempotencyService(now)
+	key := &models.IdempotencyKey{ID: 7, Key: hashToken("retry-1"), ExpiresAt: now.Add(idempotencyLockTimeout)}
+	repo.On("Update", key).Return(nil)
+	body := `{"data":{"id":1,"tracking_token":"secret-token"}}`
+
+	// Act
+	err := service.Complete(key, "retry-1", "abc", 201, []byte(body))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, key.IsCompleted())
+	assert.Equal(t, "abc", key.RequestHash)
+	assert.NotContains(t, key.ResponseBody, "secret-token", "the stored response is encrypted")
+	assert.Equal(t, now.Add(24*time.Hour), key.ExpiresAt, "completed keys are replayed for the configured window")
+
+	response, err := service.Response(key, "retry-1")
+	require.NoError(t, err)
+	assert.Equal(t, body, string(response))
+	_, err = service.Response(key, "retry-2")
+	assert.Error(t, err, "only the Idempotency-Key decrypts the response")
+}
+
+func TestIdempotencyService_Release(t *testing.T) {
+	// Arrange
+	service, repo := setupIdempotencyService(time.Now())
+	repo.On("Delete", uint(7)).Return(nil)
+
+	// Act
+	err := service.Release(&models.IdempotencyKey{ID: 7})
+
+	// Assert
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestIdempotencyKeyCleaner_DeleteExpired(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	repo := new(MockIdempotencyKeyRepository)
+	cleaner := NewIdempotencyKeyCleaner(repo)
+	cleaner.now = func() time.Time { return now }
+	repo.On("DeleteExpired", now).Return(nil)
+
+	// Act
+	err := cleaner.DeleteExpired()
+
+	// Assert
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
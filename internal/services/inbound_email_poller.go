@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/mail"
+	"time"
+)
+
+// InboundEmailPoller receives the mail delivered to a local Maildir. Processed messages are moved to
+// cur/; messages that can never be processed are flagged as trashed, and messages that failed for
+// another reason stay in new/ and are tried again on the next poll.
+type InboundEmailPoller struct {
+	maildir *mail.Maildir
+	service InboundEmailService
+	config  config.InboundEmailConfig
+}
+
+// NewInboundEmailPoller creates a new poller of the Maildir at cfg.MaildirPath
+func NewInboundEmailPoller(service InboundEmailService, cfg config.InboundEmailConfig) *InboundEmailPoller {
+	return &InboundEmailPoller{
+		maildir: mail.NewMaildir(cfg.MaildirPath),
+		service: service,
+		config:  cfg,
+	}
+}
+
+// Run polls the Maildir every PollInterval until ctx is cancelled
+func (p *InboundEmailPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Poll(ctx); err != nil {
+			log.Printf("Warning: Failed to poll inbound Maildir: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll receives every message waiting in the Maildir and returns how many were moved out of new/
+func (p *InboundEmailPoller) Poll(ctx context.Context) (int, error) {
+	names, err := p.maildir.New()
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+
+		raw, err := p.maildir.Read(name, p.config.MaxSize)
+		if err == nil {
+			_, err = p.service.ReceiveEmail(raw)
+		}
+
+		switch {
+		case err == nil:
+			err = p.maildir.MarkSeen(name)
+		case isPermanentInboundError(err):
+			log.Printf("Warning: Rejected inbound email %s: %v", name, err)
+			err = p.maildir.MarkTrashed(name)
+		default:
+			log.Printf("Warning: Failed to receive inbound email %s, retrying on the next poll: %v", name, err)
+			continue
+		}
+		if err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// isPermanentInboundError reports whether an email failed for a reason that retrying won't fix
+func isPermanentInboundError(err error) bool {
+	return errors.Is(err, ErrInvalidRequest) || errors.Is(err, mail.ErrMessageTooLarge) ||
+		errors.Is(err, ErrUnknownApp) || errors.Is(err, ErrAppDisabled) || errors.Is(err, ErrPlatformNotAllowed)
+}
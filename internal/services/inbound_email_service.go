@@ -0,0 +1,300 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	netmail "net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// maxInboundMessageLength bounds the text of a support request or reply received by email, matching
+// the limit of replies posted through the API
+const maxInboundMessageLength = 10000
+
+// maxInboundSubjectLength bounds the subject recorded for a received email
+const maxInboundSubjectLength = 255
+
+// inboundEmailLockTimeout is how long a Message-ID stays reserved by a delivery that never finishes
+// processing, for example because the server stopped
+const inboundEmailLockTimeout = 10 * time.Minute
+
+// ErrInboundEmailInProgress is returned for a delivery of an email whose Message-ID is still being
+// processed, so that the provider delivers it again later
+var ErrInboundEmailInProgress = errors.New("an email with this Message-ID is still being processed")
+
+var (
+	// subjectReferencePattern finds support request references such as SR-42 in subjects
+	subjectReferencePattern = regexp.MustCompile(`(?i)\bSR-(\d+)\b`)
+	// notificationMessageIDPattern finds the support request in the Message-ID of a notification
+	notificationMessageIDPattern = regexp.MustCompile(`^sr-(\d+)\.`)
+)
+
+// InboundEmailService defines the interface for support requests received by email
+type InboundEmailService interface {
+	ReceiveEmail(raw []byte) (*models.InboundEmailResponse, error)
+}
+
+// inboundEmailService implements InboundEmailService
+type inboundEmailService struct {
+	inboundRepo    repositories.InboundEmailRepository
+	supportRepo    repositories.SupportRequestRepository
+	appRepo        repositories.AppRepository
+	supportService SupportRequestService
+	messageService SupportRequestMessageService
+	config         config.InboundEmailConfig
+	ownAddress     string
+	now            func() time.Time
+}
+
+// NewInboundEmailService creates a new inbound email service. Mail from mailFrom, the sender of the
+// notifications, is ignored so that bounced notifications can't loop.
+func NewInboundEmailService(inboundRepo repositories.InboundEmailRepository, supportRepo repositories.SupportRequestRepository, appRepo repositories.AppRepository, supportService SupportRequestService, messageService SupportRequestMessageService, cfg config.InboundEmailConfig, mailFrom string) InboundEmailService {
+	ownAddress := ""
+	if address, err := netmail.ParseAddress(mailFrom); err == nil {
+		ownAddress = address.Address
+	}
+	return &inboundEmailService{
+		inboundRepo:    inboundRepo,
+		supportRepo:    supportRepo,
+		appRepo:        appRepo,
+		supportService: supportService,
+		messageService: messageService,
+		config:         cfg,
+		ownAddress:     ownAddress,
+		now:            time.Now,
+	}
+}
+
+// ReceiveEmail processes a raw RFC 5322 email. A reply from the submitter of an open support request,
+// found by a reference in the subject or the Message-ID of a notification in In-Reply-To or
+// References, is added to its conversation; any other email creates a support request. Automatic
+// replies are ignored, and an email with a Message-ID that was already processed is not processed again.
+func (s *inboundEmailService) ReceiveEmail(raw []byte) (*models.InboundEmailResponse, error) {
+	if int64(len(raw)) > s.config.MaxSize {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, mail.ErrMessageTooLarge)
+	}
+	msg, err := mail.ParseInbound(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	record := &models.InboundEmail{
+		Sender:  msg.From,
+		Subject: truncateText(msg.Subject, maxInboundSubjectLength),
+		Action:  models.InboundEmailIgnored,
+	}
+	if msg.MessageID != "" {
+		// Reserve the Message-ID before processing, so that concurrent deliveries of the same email
+		// aren't both processed
+		record.MessageID = &msg.MessageID
+		record.Action = models.InboundEmailProcessing
+		existing, err := s.reserve(record)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			response := existing.ToResponse()
+			response.Duplicate = true
+			return response, nil
+		}
+	}
+
+	record.Action = models.InboundEmailIgnored
+	if !msg.AutoGenerated && !strings.EqualFold(msg.From, s.ownAddress) {
+		supportRequestID, action, err := s.process(msg)
+		if err != nil {
+			if record.ID != 0 {
+				// Free the Message-ID, so that the email can be delivered again
+				if releaseErr := s.inboundRepo.Delete(record.ID); releaseErr != nil {
+					log.Printf("Warning: Failed to release inbound email %s: %v", msg.MessageID, releaseErr)
+				}
+			}
+			return nil, err
+		}
+		record.SupportRequestID = &supportRequestID
+		record.Action = action
+	}
+
+	// The email was processed, so failing now would only make the sender deliver it again
+	if record.ID != 0 {
+		err = s.inboundRepo.Update(record)
+	} else {
+		err = s.inboundRepo.Create(record)
+	}
+	if err != nil {
+		log.Printf("Warning: Failed to record inbound email from %s: %v", msg.From, err)
+	}
+	return record.ToResponse(), nil
+}
+
+// reserve records an email by its Message-ID before it is processed. When the Message-ID was already
+// processed, that record is returned instead; ErrInboundEmailInProgress is returned while another
+// delivery is still processing it.
+func (s *inboundEmailService) reserve(record *models.InboundEmail) (*models.InboundEmail, error) {
+	reserved, err := s.inboundRepo.Reserve(record)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	existing, err := s.inboundRepo.GetByMessageID(*record.MessageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Released by the other delivery in the meantime; the email can be delivered again
+			return nil, ErrInboundEmailInProgress
+		}
+		return nil, err
+	}
+	if existing.Action != models.InboundEmailProcessing {
+		return existing, nil
+	}
+	if existing.CreatedAt.After(s.now().Add(-inboundEmailLockTimeout)) {
+		return nil, ErrInboundEmailInProgress
+	}
+
+	// The other delivery never finished, so take the Message-ID over
+	if err := s.inboundRepo.Delete(existing.ID); err != nil {
+		return nil, err
+	}
+	if reserved, err = s.inboundRepo.Reserve(record); err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, ErrInboundEmailInProgress
+	}
+	return nil, nil
+}
+
+// process adds the email to the support request it replies to, or creates a new one
+func (s *inboundEmailService) process(msg *mail.InboundMessage) (uint, models.InboundEmailAction, error) {
+	thread, err := s.findThread(msg)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if thread != nil {
+		body := truncateText(mail.StripQuotedReply(msg.Body), maxInboundMessageLength)
+		if body == "" {
+			return 0, "", fmt.Errorf("%w: the email has no text", ErrInvalidRequest)
+		}
+		if _, err := s.messageService.AddSubmitterReply(thread.ID, models.AuditActor{Username: msg.From}, &models.CreateSupportRequestMessageRequest{Body: body}); err != nil {
+			return 0, "", err
+		}
+		return thread.ID, models.InboundEmailReplied, nil
+	}
+
+	message := strings.TrimSpace(msg.Subject + "\n\n" + msg.Body)
+	if message == "" {
+		return 0, "", fmt.Errorf("%w: the email has no subject or text", ErrInvalidRequest)
+	}
+	app, err := s.recipientApp(msg.Recipients)
+	if err != nil {
+		return 0, "", err
+	}
+
+	sender := msg.From
+	response, err := s.supportService.CreateSupportRequest(&models.CreateSupportRequestRequest{
+		Type:      models.SupportRequestTypeSupport,
+		UserEmail: &sender,
+		Message:   truncateText(message, maxInboundMessageLength),
+		Platform:  models.PlatformEmail,
+		App:       app,
+	}, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	return response.ID, models.InboundEmailCreated, nil
+}
+
+// findThread returns the open support request an email replies to, or nil. Candidates come from
+// In-Reply-To, then References from newest to oldest, then the subject; only requests submitted from
+// the sender's address qualify, so that guessing a reference doesn't give access to a conversation.
+// Closed requests aren't continued by email.
+func (s *inboundEmailService) findThread(msg *mail.InboundMessage) (*models.SupportRequest, error) {
+	var candidates []uint
+	messageIDs := append([]string{}, msg.InReplyTo...)
+	for i := len(msg.References) - 1; i >= 0; i-- {
+		messageIDs = append(messageIDs, msg.References[i])
+	}
+	for _, messageID := range messageIDs {
+		if match := notificationMessageIDPattern.FindStringSubmatch(messageID); match != nil {
+			if id, err := strconv.ParseUint(match[1], 10, 32); err == nil {
+				candidates = append(candidates, uint(id))
+			}
+		}
+	}
+	for _, match := range subjectReferencePattern.FindAllStringSubmatch(msg.Subject, -1) {
+		if id, err := strconv.ParseUint(match[1], 10, 32); err == nil {
+			candidates = append(candidates, uint(id))
+		}
+	}
+
+	for _, id := range candidates {
+		supportRequest, err := s.supportRepo.GetByID(id, models.OrganizationScope{})
+		if err == nil && supportRequest.IsMerged() {
+			// Replies to a merged request continue the request it was merged into
+			supportRequest, err = s.supportRepo.GetByID(*supportRequest.MergedIntoID, models.OrganizationScope{})
+		}
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if supportRequest.UserEmail == nil || !strings.EqualFold(*supportRequest.UserEmail, msg.From) {
+			continue
+		}
+		if supportRequest.Status == models.StatusClosed || supportRequest.Status == models.StatusSpam {
+			continue
+		}
+		return supportRequest, nil
+	}
+	return nil, nil
+}
+
+// recipientApp returns the slug of the app an email was sent to: the registered app named by the
+// plus address of a recipient, such as support+my-app@example.com, or the default app
+func (s *inboundEmailService) recipientApp(recipients []string) (string, error) {
+	for _, recipient := range recipients {
+		local, _, found := strings.Cut(recipient, "@")
+		if !found {
+			continue
+		}
+		_, tag, found := strings.Cut(local, "+")
+		if !found || tag == "" {
+			continue
+		}
+		tag = strings.ToLower(tag)
+		if _, err := s.appRepo.GetBySlug(tag); err == nil {
+			return tag, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+	}
+
+	if s.config.DefaultApp == "" {
+		return "", fmt.Errorf("%w: the email is not addressed to an app and no default app is configured", ErrUnknownApp)
+	}
+	return s.config.DefaultApp, nil
+}
+
+// truncateText shortens text to at most max characters
+func truncateText(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return string([]rune(text)[:max])
+}
@@ -0,0 +1,442 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockInboundEmailRepository is a mock implementation of InboundEmailRepository
+type MockInboundEmailRepository struct {
+	mock.Mock
+}
+
+func (m *MockInboundEmailRepository) Create(email *models.InboundEmail) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockInboundEmailRepository) Reserve(email *models.InboundEmail) (bool, error) {
+	args := m.Called(email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInboundEmailRepository) Update(email *models.InboundEmail) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockInboundEmailRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockInboundEmailRepository) GetByMessageID(messageID string) (*models.InboundEmail, error) {
+	args := m.Called(messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.InboundEmail), args.Error(1)
+}
+
+type inboundEmailFixture struct {
+	service     InboundEmailService
+	inboundRepo *MockInboundEmailRepository
+	supportRepo *MockSupportRequestRepository
+	messageRepo *MockSupportRequestMessageRepository
+	appRepo     *MockAppRepository
+}
+
+// setupInboundEmailService wires the inbound email service to real support request and message
+// services backed by mock repositories
+func setupInboundEmailService(defaultApp string) *inboundEmailFixture {
+	f := &inboundEmailFixture{
+		inboundRepo: new(MockInboundEmailRepository),
+		supportRepo: new(MockSupportRequestRepository),
+		messageRepo: new(MockSupportRequestMessageRepository),
+		appRepo:     new(MockAppRepository),
+	}
+	supportService := NewSupportRequestService(f.supportRepo, f.appRepo, defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	messageService := NewSupportRequestMessageService(f.messageRepo, f.supportRepo, &recordingReplyNotifier{}, &recordingEventPublisher{})
+	cfg := config.InboundEmailConfig{DefaultApp: defaultApp, MaxSize: 1 << 20}
+	f.service = NewInboundEmailService(f.inboundRepo, f.supportRepo, f.appRepo, supportService, messageService, cfg, "Support <support@example.com>")
+	return f
+}
+
+// inboundEmail builds a raw email from headers and a body
+func inboundEmail(body string, headers ...string) []byte {
+	return []byte(strings.Join(headers, "\r\n") + "\r\n\r\n" + body)
+}
+
+// expectCreatedSupportRequest makes the support repository accept a new request with ID 100 that
+// matches
+func (f *inboundEmailFixture) expectCreatedSupportRequest(matches func(*models.SupportRequest) bool) {
+	f.supportRepo.On("Create", mock.MatchedBy(matches)).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.SupportRequest).ID = 100
+	}).Return(nil)
+}
+
+func TestInboundEmailService_ReceiveEmail_CreatesSupportRequest(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("")
+	f.inboundRepo.On("Reserve", mock.MatchedBy(func(email *models.InboundEmail) bool {
+		return *email.MessageID == "new@mail.example.com" && email.Action == models.InboundEmailProcessing
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.InboundEmail).ID = 5
+	}).Return(true, nil)
+	f.appRepo.On("GetBySlug", "unknown").Return(nil, gorm.ErrRecordNotFound)
+	f.appRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", IsActive: true}, nil)
+	f.expectCreatedSupportRequest(func(req *models.SupportRequest) bool {
+		return req.App == "acme-ios" && req.Platform == models.PlatformEmail && *req.UserEmail == "jane@example.com" &&
+			req.Message == "Login fails\n\nI can't log in since the update."
+	})
+	f.inboundRepo.On("Update", mock.MatchedBy(func(email *models.InboundEmail) bool {
+		return email.ID == 5 && email.Action == models.InboundEmailCreated && *email.SupportRequestID == 100
+	})).Return(nil)
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("I can't log in since the update.",
+		"From: Jane <jane@example.com>",
+		"To: support+unknown@example.com, support+acme-ios@example.com",
+		"Subject: Login fails",
+		"Message-ID: <new@mail.example.com>",
+	))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.InboundEmailCreated, response.Action)
+	assert.Equal(t, "SR-100", response.Reference)
+	assert.False(t, response.Duplicate)
+	f.supportRepo.AssertExpectations(t)
+	f.inboundRepo.AssertExpectations(t)
+}
+
+func TestInboundEmailService_ReceiveEmail_DefaultApp(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("acme-ios")
+	f.appRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", IsActive: true}, nil)
+	f.expectCreatedSupportRequest(func(req *models.SupportRequest) bool { return req.App == "acme-ios" })
+	f.inboundRepo.On("Create", mock.Anything).Return(nil)
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("Hello", "From: jane@example.com", "To: support@example.com", "Subject: Help"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.InboundEmailCreated, response.Action)
+	f.inboundRepo.AssertNotCalled(t, "Reserve", mock.Anything)
+}
+
+func TestInboundEmailService_ReceiveEmail_NoApp(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("")
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("Hello", "From: jane@example.com", "To: support@example.com", "Subject: Help"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownApp)
+	assert.Nil(t, response)
+	f.inboundRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestInboundEmailService_ReceiveEmail_ThreadsReplies(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+	}{
+		{"notification In-Reply-To", []string{"Subject: Re: Your request", "In-Reply-To: <sr-42.0a1b2c3d@example.com>"}},
+		{"notification in References", []string{"Subject: Re: Your request", "References: <sr-42.0a1b2c3d@example.com> <other@mail.example.com>"}},
+		{"subject reference", []string{"Subject: Re: [Acme] New reply to your request SR-42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := setupInboundEmailService("")
+			email := "Jane@Example.com"
+			f.supportRepo.On("GetByID", uint(42), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 42, UserEmail: &email, Status: models.StatusWaitingOnCustomer}, nil)
+			f.messageRepo.On("Create", mock.MatchedBy(func(message *models.SupportRequestMessage) bool {
+				return message.SupportRequestID == 42 && message.AuthorType == models.MessageAuthorSubmitter && message.Body == "Here is the screenshot."
+			}), mock.MatchedBy(func(req *models.SupportRequest) bool {
+				return req.Status == models.StatusInProgress
+			}), mock.MatchedBy(func(events []*models.AuditEvent) bool {
+				// The status change is audited under the sender's address
+				return len(events) == 1 && events[0].ActorUsername == "jane@example.com"
+			})).Return(nil)
+			f.inboundRepo.On("Create", mock.MatchedBy(func(record *models.InboundEmail) bool {
+				return record.Action == models.InboundEmailReplied && *record.SupportRequestID == 42
+			})).Return(nil)
+
+			// Act
+			headers := append([]string{"From: jane@example.com"}, tt.headers...)
+			response, err := f.service.ReceiveEmail(inboundEmail("Here is the screenshot.\r\n\r\n> Could you send a screenshot?", headers...))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, models.InboundEmailReplied, response.Action)
+			assert.Equal(t, uint(42), *response.SupportRequestID)
+			f.messageRepo.AssertExpectations(t)
+			f.supportRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestInboundEmailService_ReceiveEmail_ThreadsRepliesToMergedRequests(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("")
+	email := "jane@example.com"
+	targetID := uint(50)
+	f.supportRepo.On("GetByID", uint(42), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 42, UserEmail: &email, Status: models.StatusClosed, MergedIntoID: &targetID}, nil)
+	f.supportRepo.On("GetByID", targetID, models.OrganizationScope{}).Return(&models.SupportRequest{ID: 50, UserEmail: &email, Status: models.StatusInProgress}, nil)
+	f.messageRepo.On("Create", mock.MatchedBy(func(message *models.SupportRequestMessage) bool {
+		return message.SupportRequestID == 50
+	}), mock.Anything, mock.Anything).Return(nil)
+	f.inboundRepo.On("Create", mock.Anything).Return(nil)
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("Still broken", "From: jane@example.com", "Subject: Re: SR-42"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.InboundEmailReplied, response.Action)
+	assert.Equal(t, uint(50), *response.SupportRequestID)
+	f.messageRepo.AssertExpectations(t)
+}
+
+func TestInboundEmailService_ReceiveEmail_DoesNotThreadOntoOthersRequests(t *testing.T) {
+	other := "mallory@example.com"
+	submitter := "jane@example.com"
+
+	tests := []struct {
+		name    string
+		request *models.SupportRequest
+	}{
+		{"other submitter", &models.SupportRequest{ID: 42, UserEmail: &other, Status: models.StatusInProgress}},
+		{"no submitter email", &models.SupportRequest{ID: 42, Status: models.StatusInProgress}},
+		{"closed", &models.SupportRequest{ID: 42, UserEmail: &submitter, Status: models.StatusClosed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := setupInboundEmailService("acme-ios")
+			f.supportRepo.On("GetByID", uint(42), models.OrganizationScope{}).Return(tt.request, nil)
+			f.appRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", IsActive: true}, nil)
+			f.expectCreatedSupportRequest(func(req *models.SupportRequest) bool { return *req.UserEmail == "jane@example.com" })
+			f.inboundRepo.On("Create", mock.Anything).Return(nil)
+
+			// Act
+			response, err := f.service.ReceiveEmail(inboundEmail("Still broken", "From: jane@example.com", "Subject: Re: SR-42"))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, models.InboundEmailCreated, response.Action)
+			f.messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInboundEmailService_ReceiveEmail_IgnoresAutomaticMail(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+	}{
+		{"out of office", []string{"From: jane@example.com", "Auto-Submitted: auto-replied", "Subject: Out of office"}},
+		{"own notification", []string{"From: Support <SUPPORT@example.com>", "Subject: [Acme] Your request SR-42 is resolved"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := setupInboundEmailService("acme-ios")
+			f.inboundRepo.On("Create", mock.MatchedBy(func(record *models.InboundEmail) bool {
+				return record.Action == models.InboundEmailIgnored && record.SupportRequestID == nil
+			})).Return(nil)
+
+			// Act
+			response, err := f.service.ReceiveEmail(inboundEmail("I am away until Monday.", tt.headers...))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, models.InboundEmailIgnored, response.Action)
+			f.supportRepo.AssertNotCalled(t, "Create", mock.Anything)
+			f.inboundRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInboundEmailService_ReceiveEmail_Duplicate(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("acme-ios")
+	requestID := uint(100)
+	f.inboundRepo.On("Reserve", mock.Anything).Return(false, nil)
+	f.inboundRepo.On("GetByMessageID", "new@mail.example.com").Return(&models.InboundEmail{Action: models.InboundEmailCreated, SupportRequestID: &requestID}, nil)
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("Hello", "From: jane@example.com", "Subject: Help", "Message-ID: <new@mail.example.com>"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, response.Duplicate)
+	assert.Equal(t, models.InboundEmailCreated, response.Action)
+	assert.Equal(t, uint(100), *response.SupportRequestID)
+	f.supportRepo.AssertNotCalled(t, "Create", mock.Anything)
+	f.inboundRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestInboundEmailService_ReceiveEmail_ConcurrentDelivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *models.InboundEmail
+		err      error
+	}{
+		{"still processing", &models.InboundEmail{ID: 5, Action: models.InboundEmailProcessing, CreatedAt: time.Now()}, nil},
+		{"released in the meantime", nil, gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := setupInboundEmailService("acme-ios")
+			f.inboundRepo.On("Reserve", mock.Anything).Return(false, nil)
+			f.inboundRepo.On("GetByMessageID", "new@mail.example.com").Return(tt.existing, tt.err)
+
+			// Act
+			response, err := f.service.ReceiveEmail(inboundEmail("Hello", "From: jane@example.com", "Subject: Help", "Message-ID: <new@mail.example.com>"))
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInboundEmailInProgress)
+			assert.Nil(t, response)
+			f.supportRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestInboundEmailService_ReceiveEmail_TakesOverStaleReservation(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("acme-ios")
+	stale := &models.InboundEmail{ID: 5, Action: models.InboundEmailProcessing, CreatedAt: time.Now().Add(-time.Hour)}
+	f.inboundRepo.On("Reserve", mock.Anything).Return(false, nil).Once()
+	f.inboundRepo.On("GetByMessageID", "new@mail.example.com").Return(stale, nil)
+	f.inboundRepo.On("Delete", uint(5)).Return(nil)
+	f.inboundRepo.On("Reserve", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.InboundEmail).ID = 6
+	}).Return(true, nil).Once()
+	f.appRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", IsActive: true}, nil)
+	f.expectCreatedSupportRequest(func(req *models.SupportRequest) bool { return req.App == "acme-ios" })
+	f.inboundRepo.On("Update", mock.MatchedBy(func(email *models.InboundEmail) bool {
+		return email.ID == 6 && email.Action == models.InboundEmailCreated
+	})).Return(nil)
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("Hello", "From: jane@example.com", "Subject: Help", "Message-ID: <new@mail.example.com>"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.InboundEmailCreated, response.Action)
+	f.inboundRepo.AssertExpectations(t)
+}
+
+func TestInboundEmailService_ReceiveEmail_ReleasesMessageIDOnFailure(t *testing.T) {
+	// Arrange
+	f := setupInboundEmailService("")
+	f.inboundRepo.On("Reserve", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.InboundEmail).ID = 5
+	}).Return(true, nil)
+	f.inboundRepo.On("Delete", uint(5)).Return(nil)
+
+	// Act
+	response, err := f.service.ReceiveEmail(inboundEmail("Hello", "From: jane@example.com", "Subject: Help", "Message-ID: <new@mail.example.com>"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownApp)
+	assert.Nil(t, response)
+	f.inboundRepo.AssertExpectations(t)
+	f.inboundRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestInboundEmailService_ReceiveEmail_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"malformed", []byte("not an email")},
+		{"empty", inboundEmail("", "From: jane@example.com")},
+		{"too large", inboundEmail(strings.Repeat("a", 1<<20), "From: jane@example.com", "Subject: Big")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := setupInboundEmailService("acme-ios")
+			f.appRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", IsActive: true}, nil).Maybe()
+
+			// Act
+			response, err := f.service.ReceiveEmail(tt.raw)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.Nil(t, response)
+		})
+	}
+}
+
+// stubInboundEmailService returns a fixed error for emails containing a marker
+type stubInboundEmailService struct {
+	errors   map[string]error
+	received []string
+}
+
+func (s *stubInboundEmailService) ReceiveEmail(raw []byte) (*models.InboundEmailResponse, error) {
+	s.received = append(s.received, string(raw))
+	for marker, err := range s.errors {
+		if strings.Contains(string(raw), marker) {
+			return nil, err
+		}
+	}
+	return &models.InboundEmailResponse{Action: models.InboundEmailCreated}, nil
+}
+
+func TestInboundEmailPoller_Poll(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, sub), 0o755))
+	}
+	messages := map[string]string{
+		"1.M1.host": "ok",
+		"2.M2.host": "invalid",
+		"3.M3.host": "database down",
+	}
+	for name, content := range messages {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "new", name), []byte(content), 0o644))
+	}
+	service := &stubInboundEmailService{errors: map[string]error{
+		"invalid":       ErrInvalidRequest,
+		"database down": errors.New("connection refused"),
+	}}
+	poller := NewInboundEmailPoller(service, config.InboundEmailConfig{MaildirPath: dir, MaxSize: 1 << 20})
+
+	// Act
+	done, err := poller.Poll(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, done)
+	assert.Len(t, service.received, 3)
+	assert.FileExists(t, filepath.Join(dir, "cur", "1.M1.host:2,S"))
+	assert.FileExists(t, filepath.Join(dir, "cur", "2.M2.host:2,ST"))
+	assert.FileExists(t, filepath.Join(dir, "new", "3.M3.host"), "transient failures are retried")
+}
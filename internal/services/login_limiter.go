@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+)
+
+var (
+	ErrLoginLocked = errors.New("too many failed login attempts")
+)
+
+// LoginLimiter tracks failed logins and temporarily locks usernames and client IPs that fail too often
+type LoginLimiter interface {
+	Check(username, clientIP string) error
+	RecordFailure(username, clientIP string) error
+	RecordSuccess(username string) error
+	Status(username string) (*models.UserLockStatus, error)
+	Unlock(username string, events ...*models.AuditEvent) error
+}
+
+// loginLimiter implements LoginLimiter
+type loginLimiter struct {
+	repo             repositories.LoginThrottleRepository
+	maxFailures      int
+	maxFailuresPerIP int
+	failureWindow    time.Duration
+	lockout          time.Duration
+	maxLockout       time.Duration
+	now              func() time.Time
+}
+
+// NewLoginLimiter creates a login limiter. A username or IP is locked once it reaches its failure limit
+// within the failure window; every further lockout in a row lasts twice as long, up to the maximum.
+func NewLoginLimiter(repo repositories.LoginThrottleRepository, cfg config.LoginConfig) LoginLimiter {
+	return &loginLimiter{
+		repo:             repo,
+		maxFailures:      cfg.MaxFailures,
+		maxFailuresPerIP: cfg.MaxFailuresPerIP,
+		failureWindow:    cfg.FailureWindow,
+		lockout:          cfg.Lockout,
+		maxLockout:       cfg.MaxLockout,
+		now:              time.Now,
+	}
+}
+
+// Check returns ErrLoginLocked when the username or the client IP is locked
+func (l *loginLimiter) Check(username, clientIP string) error {
+	throttles, err := l.repo.GetByKeys(loginThrottleKeys(username, clientIP)...)
+	if err != nil {
+		return err
+	}
+	now := l.now()
+	for _, throttle := range throttles {
+		if throttle.IsLocked(now) {
+			return ErrLoginLocked
+		}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login against both the username and the client IP
+func (l *loginLimiter) RecordFailure(username, clientIP string) error {
+	now := l.now()
+	for _, key := range loginThrottleKeys(username, clientIP) {
+		limit := l.maxFailures
+		if strings.HasPrefix(key, "ip:") {
+			limit = l.maxFailuresPerIP
+		}
+		err := l.repo.Update(key, func(throttle *models.LoginThrottle) {
+			l.fail(throttle, limit, now)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fail counts one failure, locking the throttle when it reaches limit. Failures are forgotten once
+// the failure window has passed since the last one; lockouts only once it has passed since the last
+// lockout ended, so guessing again as soon as a lockout expires keeps escalating towards the maximum.
+func (l *loginLimiter) fail(throttle *models.LoginThrottle, limit int, now time.Time) {
+	if throttle.LastFailureAt != nil && now.Sub(*throttle.LastFailureAt) > l.failureWindow {
+		throttle.Failures = 0
+	}
+	if throttle.LockedUntil != nil && now.Sub(*throttle.LockedUntil) > l.failureWindow {
+		throttle.Lockouts = 0
+	}
+	throttle.Failures++
+	throttle.LastFailureAt = &now
+
+	if throttle.Failures >= limit {
+		until := now.Add(l.lockoutDuration(throttle.Lockouts))
+		throttle.LockedUntil = &until
+		throttle.Lockouts++
+		throttle.Failures = 0
+	}
+}
+
+// lockoutDuration returns the length of a lockout following the given number of lockouts in a row
+func (l *loginLimiter) lockoutDuration(previous int) time.Duration {
+	d := l.lockout
+	for i := 0; i < previous && d < l.maxLockout; i++ {
+		d *= 2
+	}
+	if d > l.maxLockout {
+		d = l.maxLockout
+	}
+	return d
+}
+
+// RecordSuccess forgets the failures and lockouts of a username after a successful login. The client IP
+// keeps its failures, so logging into one account does not buy more guesses at others.
+func (l *loginLimiter) RecordSuccess(username string) error {
+	return l.repo.Delete(loginUserKey(username))
+}
+
+// Status returns the lockout state of a username
+func (l *loginLimiter) Status(username string) (*models.UserLockStatus, error) {
+	throttles, err := l.repo.GetByKeys(loginUserKey(username))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.UserLockStatus{}
+	if len(throttles) == 0 {
+		return status, nil
+	}
+	throttle := throttles[0]
+	now := l.now()
+	if throttle.IsLocked(now) {
+		status.Locked = true
+		status.LockedUntil = throttle.LockedUntil
+	}
+	if throttle.LastFailureAt != nil && now.Sub(*throttle.LastFailureAt) <= l.failureWindow {
+		status.FailedAttempts = throttle.Failures
+	}
+	return status, nil
+}
+
+// Unlock lifts the lockout of a username and forgets its failures, recording the given audit events
+func (l *loginLimiter) Unlock(username string, events ...*models.AuditEvent) error {
+	return l.repo.Delete(loginUserKey(username), events...)
+}
+
+// loginThrottleKeys returns the throttle keys of a login attempt
+func loginThrottleKeys(username, clientIP string) []string {
+	keys := []string{loginUserKey(username)}
+	if clientIP != "" {
+		keys = append(keys, "ip:"+clientIP)
+	}
+	return keys
+}
+
+// loginUserKey returns the throttle key of a username. Usernames are compared case-insensitively so
+// varying the case does not give an attacker fresh attempts.
+func loginUserKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLoginThrottleRepository is a mock implementation of LoginThrottleRepository
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottleRepository) GetByKeys(keys ...string) ([]*models.LoginThrottle, error) {
+	args := m.Called(keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LoginThrottle), args.Error(1)
+}
+
+// Update applies update to the throttle returned for key, so tests can inspect the result
+func (m *MockLoginThrottleRepository) Update(key string, update func(throttle *models.LoginThrottle)) error {
+	args := m.Called(key)
+	if throttle, ok := args.Get(0).(*models.LoginThrottle); ok {
+		update(throttle)
+	}
+	return args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) Delete(key string, events ...*models.AuditEvent) error {
+	args := m.Called(key, events)
+	return args.Error(0)
+}
+
+var testLoginConfig = config.LoginConfig{
+	MaxFailures:      3,
+	MaxFailuresPerIP: 10,
+	FailureWindow:    15 * time.Minute,
+	Lockout:          time.Minute,
+	MaxLockout:       10 * time.Minute,
+}
+
+func setupLoginLimiter(now time.Time) (*loginLimiter, *MockLoginThrottleRepository) {
+	mockRepo := new(MockLoginThrottleRepository)
+	limiter := NewLoginLimiter(mockRepo, testLoginConfig).(*loginLimiter)
+	limiter.now = func() time.Time { return now }
+	return limiter, mockRepo
+}
+
+func TestLoginLimiter_Check(t *testing.T) {
+	now := time.Now()
+	until := now.Add(time.Minute)
+	expired := now.Add(-time.Second)
+
+	tests := []struct {
+		name      string
+		throttles []*models.LoginThrottle
+		expected  error
+	}{
+		{name: "no failures", throttles: []*models.LoginThrottle{}},
+		{name: "failures below the limit", throttles: []*models.LoginThrottle{{Key: "user:alice", Failures: 2}}},
+		{name: "expired lockout", throttles: []*models.LoginThrottle{{Key: "user:alice", LockedUntil: &expired}}},
+		{name: "username locked", throttles: []*models.LoginThrottle{{Key: "user:alice", LockedUntil: &until}}, expected: ErrLoginLocked},
+		{name: "ip locked", throttles: []*models.LoginThrottle{{Key: "ip:203.0.113.7", LockedUntil: &until}}, expected: ErrLoginLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			limiter, mockRepo := setupLoginLimiter(now)
+			mockRepo.On("GetByKeys", []string{"user:alice", "ip:203.0.113.7"}).Return(tt.throttles, nil)
+
+			// Act
+			err := limiter.Check("Alice", "203.0.113.7")
+
+			// Assert
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestLoginLimiter_RecordFailure_CountsUsernameAndIP(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter, mockRepo := setupLoginLimiter(now)
+	earlier := now.Add(-time.Minute)
+	user := &models.LoginThrottle{ID: 1, Key: "user:alice", Failures: 1, LastFailureAt: &earlier}
+	ip := &models.LoginThrottle{ID: 2, Key: "ip:203.0.113.7"}
+	mockRepo.On("Update", "user:alice").Return(user, nil)
+	mockRepo.On("Update", "ip:203.0.113.7").Return(ip, nil)
+
+	// Act
+	err := limiter.RecordFailure("alice", "203.0.113.7")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, user.Failures)
+	assert.Equal(t, 1, ip.Failures)
+	assert.Nil(t, user.LockedUntil)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoginLimiter_RecordFailure_RepositoryError(t *testing.T) {
+	// Arrange
+	limiter, mockRepo := setupLoginLimiter(time.Now())
+	mockRepo.On("Update", "user:alice").Return(nil, errors.New("database error"))
+
+	// Act
+	err := limiter.RecordFailure("alice", "203.0.113.7")
+
+	// Assert
+	assert.EqualError(t, err, "database error")
+	mockRepo.AssertNotCalled(t, "Update", "ip:203.0.113.7")
+}
+
+func TestLoginLimiter_FailLocksWithProgressiveBackoff(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter, _ := setupLoginLimiter(now)
+	throttle := &models.LoginThrottle{Key: "user:alice"}
+
+	// Act & Assert: every third failure locks, each lockout twice as long as the one before, up to the maximum
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute}
+	for i, lockout := range expected {
+		at := now.Add(time.Duration(i) * time.Minute)
+		limiter.fail(throttle, 3, at)
+		limiter.fail(throttle, 3, at)
+		assert.Nil(t, throttle.LockedUntil, "lockout %d", i)
+
+		limiter.fail(throttle, 3, at)
+		require.NotNil(t, throttle.LockedUntil, "lockout %d", i)
+		assert.Equal(t, at.Add(lockout), *throttle.LockedUntil, "lockout %d", i)
+		assert.Equal(t, 0, throttle.Failures)
+		throttle.LockedUntil = nil
+	}
+}
+
+func TestLoginLimiter_FailForgetsOldFailures(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter, _ := setupLoginLimiter(now)
+	longAgo := now.Add(-time.Hour)
+	throttle := &models.LoginThrottle{Key: "user:alice", Failures: 2, Lockouts: 3, LockedUntil: &longAgo, LastFailureAt: &longAgo}
+
+	// Act
+	limiter.fail(throttle, 3, now)
+
+	// Assert
+	assert.Equal(t, 1, throttle.Failures)
+	assert.Equal(t, 0, throttle.Lockouts)
+	assert.Equal(t, longAgo, *throttle.LockedUntil)
+}
+
+func TestLoginLimiter_FailEscalatesToMaxLockoutLongerThanWindow(t *testing.T) {
+	// Arrange: the default limits, where lockouts soon outlast the failure window
+	now := time.Now()
+	limiter, _ := setupLoginLimiter(now)
+	limiter.failureWindow = 15 * time.Minute
+	limiter.lockout = time.Minute
+	limiter.maxLockout = time.Hour
+	throttle := &models.LoginThrottle{Key: "user:alice"}
+
+	// Act & Assert: guessing again as soon as each lockout ends keeps doubling it up to the maximum
+	expected := []time.Duration{
+		time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 32 * time.Minute, time.Hour, time.Hour,
+	}
+	at := now
+	for i, lockout := range expected {
+		for j := 0; j < 3; j++ {
+			limiter.fail(throttle, 3, at)
+		}
+		require.NotNil(t, throttle.LockedUntil, "lockout %d", i)
+		assert.Equal(t, at.Add(lockout), *throttle.LockedUntil, "lockout %d", i)
+		at = *throttle.LockedUntil
+	}
+}
+
+func TestLoginLimiter_RecordSuccess(t *testing.T) {
+	// Arrange
+	limiter, mockRepo := setupLoginLimiter(time.Now())
+	mockRepo.On("Delete", "user:alice", []*models.AuditEvent(nil)).Return(nil)
+
+	// Act
+	err := limiter.RecordSuccess("Alice")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoginLimiter_Status(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter, mockRepo := setupLoginLimiter(now)
+	until := now.Add(time.Minute)
+	mockRepo.On("GetByKeys", []string{"user:alice"}).
+		Return([]*models.LoginThrottle{{Key: "user:alice", Failures: 1, LockedUntil: &until, LastFailureAt: &now}}, nil)
+
+	// Act
+	status, err := limiter.Status("alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, status.Locked)
+	assert.Equal(t, &until, status.LockedUntil)
+	assert.Equal(t, 1, status.FailedAttempts)
+}
+
+func TestLoginLimiter_StatusWithoutFailures(t *testing.T) {
+	// Arrange
+	limiter, mockRepo := setupLoginLimiter(time.Now())
+	mockRepo.On("GetByKeys", []string{"user:alice"}).Return([]*models.LoginThrottle{}, nil)
+
+	// Act
+	status, err := limiter.Status("alice")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &models.UserLockStatus{}, status)
+}
+
+func TestLoginLimiter_Unlock(t *testing.T) {
+	// Arrange
+	limiter, mockRepo := setupLoginLimiter(time.Now())
+	event := &models.AuditEvent{Action: models.AuditActionUpdate}
+	mockRepo.On("Delete", "user:alice", []*models.AuditEvent{event}).Return(nil)
+
+	// Act
+	err := limiter.Unlock("alice", event)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"support-app-backend/internal/totp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidMFACode    = errors.New("invalid MFA code")
+	ErrMFAAlreadyEnabled = errors.New("MFA is already enabled")
+	ErrMFANotEnabled     = errors.New("MFA is not enabled")
+	ErrMFANotEnrolling   = errors.New("MFA enrollment has not been started")
+	ErrMFAMandatory      = errors.New("MFA is required for this account")
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes are issued at a time
+	recoveryCodeCount = 10
+	// totpSkew is how many 30 second steps of clock drift are tolerated either way
+	totpSkew = 1
+)
+
+// recoveryCodeEncoding renders recovery codes in lowercase base32, which avoids easily confused characters
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// MFAService defines the interface for TOTP two-factor authentication
+type MFAService interface {
+	Enroll(userID uint) (*models.MFAEnrollmentResponse, error)
+	Confirm(userID uint, code string) (*models.MFARecoveryCodesResponse, error)
+	Disable(userID uint, req *models.DisableMFARequest) error
+	RegenerateRecoveryCodes(userID uint, code string) (*models.MFARecoveryCodesResponse, error)
+
+	// IsRequired, BeginEnrollment and VerifyLogin back the second step of a login
+	IsRequired(user *models.User) bool
+	BeginEnrollment(user *models.User) (*models.MFAEnrollmentResponse, error)
+	VerifyLogin(user *models.User, code string) ([]string, error)
+}
+
+// mfaService implements MFAService
+type mfaService struct {
+	userRepo         repositories.UserRepository
+	codeRepo         repositories.MFARecoveryCodeRepository
+	issuer           string
+	requireForAdmins bool
+	now              func() time.Time
+}
+
+// NewMFAService creates a new MFA service
+func NewMFAService(userRepo repositories.UserRepository, codeRepo repositories.MFARecoveryCodeRepository, cfg config.MFAConfig) MFAService {
+	return &mfaService{
+		userRepo:         userRepo,
+		codeRepo:         codeRepo,
+		issuer:           cfg.Issuer,
+		requireForAdmins: cfg.RequireForAdmins,
+		now:              time.Now,
+	}
+}
+
+// Enroll starts MFA enrollment for a user, replacing any unconfirmed secret
+func (s *mfaService) Enroll(userID uint) (*models.MFAEnrollmentResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.BeginEnrollment(user)
+}
+
+// Confirm finishes MFA enrollment with a code from the authenticator app and returns the recovery codes
+func (s *mfaService) Confirm(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if !user.IsEnrollingMFA() {
+		return nil, ErrMFANotEnrolling
+	}
+
+	codes, err := s.enable(user, code)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns MFA off after checking the password and, once MFA is enabled, a TOTP or recovery code.
+// Admins cannot turn it off while MFA is required for them.
+func (s *mfaService) Disable(userID uint, req *models.DisableMFARequest) error {
+	if req == nil {
+		return ErrInvalidRequest
+	}
+
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled && !user.IsEnrollingMFA() {
+		return ErrMFANotEnabled
+	}
+	if s.requireForAdmins && user.Role == models.UserRoleAdmin {
+		return ErrMFAMandatory
+	}
+	if !user.CheckPassword(req.Password) {
+		return ErrInvalidCredentials
+	}
+	if user.MFAEnabled {
+		if err := s.verify(user, req.Code); err != nil {
+			return err
+		}
+	}
+
+	user.MFAEnabled = false
+	user.MFASecret = ""
+	user.MFALastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	return s.codeRepo.DeleteByUser(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces a user's recovery codes after checking a TOTP or recovery code
+func (s *mfaService) RegenerateRecoveryCodes(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+	if err := s.verify(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// IsRequired reports whether a login of user needs a second factor: MFA is enabled, or the user is an
+// admin and MFA is required for admins
+func (s *mfaService) IsRequired(user *models.User) bool {
+	return user.MFAEnabled || (s.requireForAdmins && user.Role == models.UserRoleAdmin)
+}
+
+// BeginEnrollment generates a new TOTP secret for user. It takes effect once confirmed with a code.
+func (s *mfaService) BeginEnrollment(user *models.User) (*models.MFAEnrollmentResponse, error) {
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.MFASecret = secret
+	user.MFALastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &models.MFAEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, user.Username, secret),
+	}, nil
+}
+
+// VerifyLogin checks the second factor of a login. For a user still enrolling, a valid TOTP code
+// finishes the enrollment and the new recovery codes are returned.
+func (s *mfaService) VerifyLogin(user *models.User, code string) ([]string, error) {
+	if user.IsEnrollingMFA() {
+		return s.enable(user, code)
+	}
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnrolling
+	}
+	return nil, s.verify(user, code)
+}
+
+// enable turns MFA on for an enrolling user after checking a TOTP code, and issues recovery codes
+func (s *mfaService) enable(user *models.User, code string) ([]string, error) {
+	step, ok := totp.Validate(user.MFASecret, normalizeMFACode(code), s.now(), totpSkew)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.MFAEnabled = true
+	user.MFALastStep = step
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verify checks a TOTP code or consumes a recovery code. A TOTP code is refused if it, or a later one,
+// was already accepted.
+func (s *mfaService) verify(user *models.User, code string) error {
+	code = normalizeMFACode(code)
+
+	if isTOTPCode(code) {
+		step, ok := totp.Validate(user.MFASecret, code, s.now(), totpSkew)
+		if !ok || step <= user.MFALastStep {
+			return ErrInvalidMFACode
+		}
+		user.MFALastStep = step
+		return s.userRepo.Update(user)
+	}
+
+	used, err := s.codeRepo.Use(user.ID, hashToken(code), s.now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// replaceRecoveryCodes issues a fresh set of recovery codes for a user, invalidating the old ones
+func (s *mfaService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]*models.MFARecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		plain := recoveryCodeEncoding.EncodeToString(b)[:10]
+		codes[i] = plain[:5] + "-" + plain[5:]
+		records[i] = &models.MFARecoveryCode{UserID: userID, CodeHash: hashToken(plain)}
+	}
+
+	if err := s.codeRepo.Replace(userID, records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// getUser retrieves a user by ID, mapping a missing record to ErrUserNotFound
+func (s *mfaService) getUser(userID uint) (*models.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// normalizeMFACode strips the separators people type into codes and lowercases recovery codes
+func normalizeMFACode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+// isTOTPCode reports whether a normalized code looks like a TOTP code rather than a recovery code
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
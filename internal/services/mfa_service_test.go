@@ -0,0 +1,322 @@
+package services
+
+import (
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/totp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockMFARecoveryCodeRepository is a mock implementation of MFARecoveryCodeRepository
+type MockMFARecoveryCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARecoveryCodeRepository) Replace(userID uint, codes []*models.MFARecoveryCode) error {
+	args := m.Called(userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockMFARecoveryCodeRepository) Use(userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	args := m.Called(userID, codeHash, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARecoveryCodeRepository) DeleteByUser(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+// testMFASecret is the RFC 6238 SHA-1 test key, base32 encoded
+const testMFASecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func setupMFAService(requireForAdmins bool, now time.Time) (*mfaService, *MockUserRepository, *MockMFARecoveryCodeRepository) {
+	mockUserRepo := new(MockUserRepository)
+	mockCodeRepo := new(MockMFARecoveryCodeRepository)
+	service := NewMFAService(mockUserRepo, mockCodeRepo, config.MFAConfig{
+		Issuer:           "Support App",
+		RequireForAdmins: requireForAdmins,
+	}).(*mfaService)
+	service.now = func() time.Time { return now }
+	return service, mockUserRepo, mockCodeRepo
+}
+
+func currentCode(t *testing.T, now time.Time) string {
+	code, err := totp.CodeAt(testMFASecret, totp.Step(now))
+	require.NoError(t, err)
+	return code
+}
+
+func TestMFAService_Enroll_GeneratesSecret(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupMFAService(false, time.Now())
+	user := &models.User{ID: 1, Username: "alice", MFALastStep: 42}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockUserRepo.On("Update", user).Return(nil)
+
+	// Act
+	enrollment, err := service.Enroll(1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Equal(t, enrollment.Secret, user.MFASecret)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/Support%20App:alice?")
+	assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+	assert.False(t, user.MFAEnabled)
+	assert.Zero(t, user.MFALastStep)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestMFAService_Enroll_AlreadyEnabled(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupMFAService(false, time.Now())
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+
+	// Act
+	enrollment, err := service.Enroll(1)
+
+	// Assert
+	assert.Nil(t, enrollment)
+	assert.Equal(t, ErrMFAAlreadyEnabled, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestMFAService_Enroll_UserNotFound(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupMFAService(false, time.Now())
+	mockUserRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	_, err := service.Enroll(9)
+
+	// Assert
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestMFAService_Confirm_EnablesAndIssuesRecoveryCodes(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, mockCodeRepo := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFASecret: testMFASecret}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockUserRepo.On("Update", user).Return(nil)
+	var stored []*models.MFARecoveryCode
+	mockCodeRepo.On("Replace", uint(1), mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).([]*models.MFARecoveryCode) }).
+		Return(nil)
+
+	// Act
+	response, err := service.Confirm(1, currentCode(t, now))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, user.MFAEnabled)
+	assert.Equal(t, totp.Step(now), user.MFALastStep)
+	require.Len(t, response.RecoveryCodes, recoveryCodeCount)
+	require.Len(t, stored, recoveryCodeCount)
+	for i, code := range response.RecoveryCodes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		// Only the hash of the normalized code is stored
+		assert.Equal(t, hashToken(normalizeMFACode(code)), stored[i].CodeHash)
+		assert.Equal(t, uint(1), stored[i].UserID)
+	}
+}
+
+func TestMFAService_Confirm_InvalidCode(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, mockCodeRepo := setupMFAService(false, time.Now())
+	user := &models.User{ID: 1, MFASecret: testMFASecret}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+
+	// Act
+	_, err := service.Confirm(1, "000000")
+
+	// Assert
+	assert.Equal(t, ErrInvalidMFACode, err)
+	assert.False(t, user.MFAEnabled)
+	mockCodeRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+}
+
+func TestMFAService_Confirm_NotEnrolling(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupMFAService(false, time.Now())
+	mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1}, nil)
+
+	// Act
+	_, err := service.Confirm(1, "123456")
+
+	// Assert
+	assert.Equal(t, ErrMFANotEnrolling, err)
+}
+
+func TestMFAService_Disable_Success(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, mockCodeRepo := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret}
+	require.NoError(t, user.SetPassword("password123"))
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockUserRepo.On("Update", user).Return(nil)
+	mockCodeRepo.On("DeleteByUser", uint(1)).Return(nil)
+
+	// Act
+	err := service.Disable(1, &models.DisableMFARequest{Password: "password123", Code: currentCode(t, now)})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, user.MFAEnabled)
+	assert.Empty(t, user.MFASecret)
+	mockCodeRepo.AssertExpectations(t)
+}
+
+func TestMFAService_Disable_WrongPassword(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, _ := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret}
+	require.NoError(t, user.SetPassword("password123"))
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+
+	// Act
+	err := service.Disable(1, &models.DisableMFARequest{Password: "wrong", Code: currentCode(t, now)})
+
+	// Assert
+	assert.Equal(t, ErrInvalidCredentials, err)
+	assert.True(t, user.MFAEnabled)
+}
+
+func TestMFAService_Disable_MandatoryForAdmins(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupMFAService(true, time.Now())
+	user := &models.User{ID: 1, Role: models.UserRoleAdmin, MFAEnabled: true, MFASecret: testMFASecret}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+
+	// Act
+	err := service.Disable(1, &models.DisableMFARequest{Password: "password123"})
+
+	// Assert
+	assert.Equal(t, ErrMFAMandatory, err)
+}
+
+func TestMFAService_Disable_NotEnabled(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupMFAService(false, time.Now())
+	mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1}, nil)
+
+	// Act
+	err := service.Disable(1, &models.DisableMFARequest{Password: "password123"})
+
+	// Assert
+	assert.Equal(t, ErrMFANotEnabled, err)
+}
+
+func TestMFAService_RegenerateRecoveryCodes_WithRecoveryCode(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, mockCodeRepo := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockCodeRepo.On("Use", uint(1), hashToken("abcdefghij"), now).Return(true, nil)
+	mockCodeRepo.On("Replace", uint(1), mock.Anything).Return(nil)
+
+	// Act
+	response, err := service.RegenerateRecoveryCodes(1, "ABCDE-FGHIJ")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, response.RecoveryCodes, recoveryCodeCount)
+	mockCodeRepo.AssertExpectations(t)
+}
+
+func TestMFAService_RegenerateRecoveryCodes_UsedRecoveryCode(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, mockCodeRepo := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret}
+	mockUserRepo.On("GetByID", uint(1)).Return(user, nil)
+	mockCodeRepo.On("Use", uint(1), hashToken("abcdefghij"), now).Return(false, nil)
+
+	// Act
+	_, err := service.RegenerateRecoveryCodes(1, "abcde-fghij")
+
+	// Assert
+	assert.Equal(t, ErrInvalidMFACode, err)
+	mockCodeRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+}
+
+func TestMFAService_IsRequired(t *testing.T) {
+	service, _, _ := setupMFAService(true, time.Now())
+	optional, _, _ := setupMFAService(false, time.Now())
+
+	assert.True(t, service.IsRequired(&models.User{Role: models.UserRoleAdmin}))
+	assert.False(t, service.IsRequired(&models.User{Role: models.UserRoleUser}))
+	assert.True(t, service.IsRequired(&models.User{Role: models.UserRoleUser, MFAEnabled: true}))
+	assert.False(t, optional.IsRequired(&models.User{Role: models.UserRoleAdmin}))
+}
+
+func TestMFAService_VerifyLogin_TOTPCode(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, _ := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret}
+	mockUserRepo.On("Update", user).Return(nil)
+
+	// Act
+	codes, err := service.VerifyLogin(user, currentCode(t, now))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, codes)
+	assert.Equal(t, totp.Step(now), user.MFALastStep)
+}
+
+func TestMFAService_VerifyLogin_RejectsReplayedCode(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, _, mockCodeRepo := setupMFAService(false, now)
+	user := &models.User{ID: 1, MFAEnabled: true, MFASecret: testMFASecret, MFALastStep: totp.Step(now)}
+
+	// Act
+	_, err := service.VerifyLogin(user, currentCode(t, now))
+
+	// Assert
+	assert.Equal(t, ErrInvalidMFACode, err)
+	mockCodeRepo.AssertNotCalled(t, "Use", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAService_VerifyLogin_FinishesEnrollment(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, mockUserRepo, mockCodeRepo := setupMFAService(true, now)
+	user := &models.User{ID: 1, Role: models.UserRoleAdmin, MFASecret: testMFASecret}
+	mockUserRepo.On("Update", user).Return(nil)
+	mockCodeRepo.On("Replace", uint(1), mock.Anything).Return(nil)
+
+	// Act
+	codes, err := service.VerifyLogin(user, currentCode(t, now))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+	assert.True(t, user.MFAEnabled)
+}
+
+func TestMFAService_VerifyLogin_EnrollmentNotStarted(t *testing.T) {
+	// Arrange
+	service, _, _ := setupMFAService(true, time.Now())
+	user := &models.User{ID: 1, Role: models.UserRoleAdmin}
+
+	// Act
+	_, err := service.VerifyLogin(user, "123456")
+
+	// Assert
+	assert.Equal(t, ErrMFANotEnrolling, err)
+}
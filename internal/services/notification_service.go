@@ -0,0 +1,284 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"text/template"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotificationTemplateNotFound = errors.New("notification template not found")
+	ErrInvalidUnsubscribeToken      = errors.New("invalid unsubscribe token")
+)
+
+// notificationTemplate is the subject and body source of a notification email
+type notificationTemplate struct {
+	Subject string
+	Body    string
+}
+
+// defaultNotificationTemplates are sent for apps without a custom template
+var defaultNotificationTemplates = map[models.NotificationEvent]notificationTemplate{
+	models.NotificationEventStatusChanged: {
+		Subject: "[{{.App}}] Your request {{.Reference}} is {{.StatusText}}",
+		Body: `Hello,
+
+Your support request {{.Reference}} for {{.App}} is {{.StatusText}}.
+
+To stop receiving emails about this request, open {{.UnsubscribeURL}}
+`,
+	},
+	models.NotificationEventReply: {
+		Subject: "[{{.App}}] New reply to your request {{.Reference}}",
+		Body: `Hello,
+
+There is a new reply to your support request {{.Reference}} for {{.App}}:
+
+{{.Reply}}
+
+To stop receiving emails about this request, open {{.UnsubscribeURL}}
+`,
+	},
+}
+
+// notificationStatusTexts describe statuses in notification emails
+var notificationStatusTexts = map[models.Status]string{
+	models.StatusNew:               "received",
+	models.StatusInProgress:        "being worked on",
+	models.StatusWaitingOnCustomer: "waiting for your reply",
+	models.StatusResolved:          "resolved",
+	models.StatusClosed:            "closed",
+	models.StatusReopened:          "reopened",
+	models.StatusSpam:              "closed",
+}
+
+// notificationData is what notification templates are executed with
+type notificationData struct {
+	App            string        // Display name of the app
+	RequestID      uint          // Support request ID
+	Reference      string        // Support request reference, such as SR-42
+	Status         models.Status // Current status
+	StatusText     string        // Current status in words, such as "waiting for your reply"
+	PreviousStatus models.Status // Status before the change, for status_changed
+	Reply          string        // Body of the agent reply, for reply
+	UnsubscribeURL string        // Link that stops notifications about the support request
+}
+
+// sampleNotificationData is used to check custom templates before they are saved
+var sampleNotificationData = notificationData{
+	App:            "My Awesome App",
+	RequestID:      42,
+	Reference:      models.SupportRequestReference(42),
+	Status:         models.StatusWaitingOnCustomer,
+	StatusText:     notificationStatusTexts[models.StatusWaitingOnCustomer],
+	PreviousStatus: models.StatusInProgress,
+	Reply:          "Could you send us a screenshot of the error?",
+	UnsubscribeURL: "https://support.example.com/api/v1/support-request/unsubscribe/42.token",
+}
+
+// NotificationService defines the interface for managing the emails sent to support request submitters
+type NotificationService interface {
+	ListTemplates(appID uint) ([]*models.NotificationTemplateResponse, error)
+	SetTemplate(appID uint, event models.NotificationEvent, req *models.SetNotificationTemplateRequest) (*models.NotificationTemplateResponse, error)
+	DeleteTemplate(appID uint, event models.NotificationEvent) error
+	Unsubscribe(token string) error
+}
+
+// notificationService implements NotificationService
+type notificationService struct {
+	appRepo      repositories.AppRepository
+	templateRepo repositories.NotificationTemplateRepository
+	supportRepo  repositories.SupportRequestRepository
+	secret       []byte
+	now          func() time.Time
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(appRepo repositories.AppRepository, templateRepo repositories.NotificationTemplateRepository, supportRepo repositories.SupportRequestRepository, cfg config.NotificationConfig) NotificationService {
+	return &notificationService{
+		appRepo:      appRepo,
+		templateRepo: templateRepo,
+		supportRepo:  supportRepo,
+		secret:       []byte(cfg.UnsubscribeSecret),
+		now:          time.Now,
+	}
+}
+
+// ListTemplates returns the template of every notification event for an app, custom or default
+func (s *notificationService) ListTemplates(appID uint) ([]*models.NotificationTemplateResponse, error) {
+	if err := s.checkApp(appID); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRepo.GetByAppID(appID)
+	if err != nil {
+		return nil, err
+	}
+	custom := make(map[models.NotificationEvent]*models.NotificationTemplate, len(templates))
+	for _, template := range templates {
+		custom[template.Event] = template
+	}
+
+	responses := make([]*models.NotificationTemplateResponse, len(models.NotificationEvents))
+	for i, event := range models.NotificationEvents {
+		if template, ok := custom[event]; ok {
+			responses[i] = template.ToResponse()
+			continue
+		}
+		responses[i] = &models.NotificationTemplateResponse{
+			Event:   event,
+			Subject: defaultNotificationTemplates[event].Subject,
+			Body:    defaultNotificationTemplates[event].Body,
+		}
+	}
+	return responses, nil
+}
+
+// SetTemplate replaces the template of an app for an event. The templates must parse and render
+// with sample data.
+func (s *notificationService) SetTemplate(appID uint, event models.NotificationEvent, req *models.SetNotificationTemplateRequest) (*models.NotificationTemplateResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	if !event.IsValid() {
+		return nil, fmt.Errorf("%w: unknown notification event %q", ErrInvalidRequest, event)
+	}
+	if err := s.checkApp(appID); err != nil {
+		return nil, err
+	}
+
+	custom := notificationTemplate{Subject: strings.TrimSpace(req.Subject), Body: req.Body}
+	if _, _, err := custom.render(sampleNotificationData); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	template := &models.NotificationTemplate{AppID: appID, Event: event, Subject: custom.Subject, Body: custom.Body}
+	if err := s.templateRepo.Upsert(template); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.templateRepo.GetByAppAndEvent(appID, event)
+	if err != nil {
+		return nil, err
+	}
+	return saved.ToResponse(), nil
+}
+
+// DeleteTemplate removes the custom template of an app for an event, restoring the default
+func (s *notificationService) DeleteTemplate(appID uint, event models.NotificationEvent) error {
+	if !event.IsValid() {
+		return fmt.Errorf("%w: unknown notification event %q", ErrInvalidRequest, event)
+	}
+	if err := s.checkApp(appID); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(appID, event); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotificationTemplateNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe stops notifications about the support request an unsubscribe token was mailed for.
+// Unsubscribing again succeeds without changes.
+func (s *notificationService) Unsubscribe(token string) error {
+	id, ok := parseUnsubscribeToken(s.secret, token)
+	if !ok {
+		return ErrInvalidUnsubscribeToken
+	}
+
+	supportRequest, err := s.supportRepo.GetByID(id, models.OrganizationScope{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidUnsubscribeToken
+		}
+		return err
+	}
+	if supportRequest.UnsubscribedAt != nil {
+		return nil
+	}
+
+	now := s.now()
+	supportRequest.UnsubscribedAt = &now
+	return s.supportRepo.Update(supportRequest)
+}
+
+// checkApp maps missing apps to ErrAppNotFound
+func (s *notificationService) checkApp(appID uint) error {
+	if _, err := s.appRepo.GetByID(appID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAppNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// render executes the subject and body templates with data
+func (t notificationTemplate) render(data notificationData) (string, string, error) {
+	subject, err := executeNotificationTemplate("subject", t.Subject, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeNotificationTemplate("body", t.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+// executeNotificationTemplate parses and executes a single template source
+func executeNotificationTemplate(name, source string, data notificationData) (string, error) {
+	parsed, err := template.New(name).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var b strings.Builder
+	if err := parsed.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// unsubscribeToken signs the ID of a support request, so that anyone with the token can stop the
+// notifications about that request without an account. Tokens don't expire and need no storage.
+func unsubscribeToken(secret []byte, id uint) string {
+	return strconv.FormatUint(uint64(id), 10) + "." + unsubscribeSignature(secret, id)
+}
+
+// parseUnsubscribeToken returns the support request ID of a token made by unsubscribeToken
+func parseUnsubscribeToken(secret []byte, token string) (uint, bool) {
+	idPart, signature, found := strings.Cut(token, ".")
+	if !found {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(idPart, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	if !hmac.Equal([]byte(signature), []byte(unsubscribeSignature(secret, uint(id)))) {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// unsubscribeSignature returns the truncated HMAC-SHA256 of a support request ID
+func unsubscribeSignature(secret []byte, id uint) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "support-request-unsubscribe:%d", id)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockNotificationTemplateRepository is a mock implementation of NotificationTemplateRepository
+type MockNotificationTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationTemplateRepository) GetByAppID(appID uint) ([]*models.NotificationTemplate, error) {
+	args := m.Called(appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NotificationTemplate), args.Error(1)
+}
+
+func (m *MockNotificationTemplateRepository) GetByAppAndEvent(appID uint, event models.NotificationEvent) (*models.NotificationTemplate, error) {
+	args := m.Called(appID, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationTemplate), args.Error(1)
+}
+
+func (m *MockNotificationTemplateRepository) Upsert(template *models.NotificationTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockNotificationTemplateRepository) Delete(appID uint, event models.NotificationEvent) error {
+	args := m.Called(appID, event)
+	return args.Error(0)
+}
+
+const testUnsubscribeSecret = "unsubscribe-secret"
+
+func setupNotificationService() (NotificationService, *MockAppRepository, *MockNotificationTemplateRepository, *MockSupportRequestRepository) {
+	mockAppRepo := new(MockAppRepository)
+	mockTemplateRepo := new(MockNotificationTemplateRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewNotificationService(mockAppRepo, mockTemplateRepo, mockSupportRepo, config.NotificationConfig{UnsubscribeSecret: testUnsubscribeSecret})
+	return service, mockAppRepo, mockTemplateRepo, mockSupportRepo
+}
+
+func TestNotificationService_ListTemplates(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, mockTemplateRepo, _ := setupNotificationService()
+	mockAppRepo.On("GetByID", uint(1)).Return(&models.App{ID: 1}, nil)
+	mockTemplateRepo.On("GetByAppID", uint(1)).Return([]*models.NotificationTemplate{
+		{AppID: 1, Event: models.NotificationEventReply, Subject: "Reply to {{.Reference}}", Body: "{{.Reply}}"},
+	}, nil)
+
+	// Act
+	responses, err := service.ListTemplates(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, responses, 2)
+	assert.Equal(t, models.NotificationEventStatusChanged, responses[0].Event)
+	assert.False(t, responses[0].Custom)
+	assert.Equal(t, defaultNotificationTemplates[models.NotificationEventStatusChanged].Subject, responses[0].Subject)
+	assert.Equal(t, models.NotificationEventReply, responses[1].Event)
+	assert.True(t, responses[1].Custom)
+	assert.Equal(t, "Reply to {{.Reference}}", responses[1].Subject)
+}
+
+func TestNotificationService_ListTemplates_AppNotFound(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, _, _ := setupNotificationService()
+	mockAppRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	responses, err := service.ListTemplates(9)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAppNotFound)
+	assert.Nil(t, responses)
+}
+
+func TestNotificationService_SetTemplate(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, mockTemplateRepo, _ := setupNotificationService()
+	mockAppRepo.On("GetByID", uint(1)).Return(&models.App{ID: 1}, nil)
+	mockTemplateRepo.On("Upsert", mock.MatchedBy(func(template *models.NotificationTemplate) bool {
+		return template.AppID == 1 && template.Event == models.NotificationEventStatusChanged && template.Subject == "{{.Reference}}: {{.StatusText}}"
+	})).Return(nil)
+	mockTemplateRepo.On("GetByAppAndEvent", uint(1), models.NotificationEventStatusChanged).Return(&models.NotificationTemplate{
+		AppID: 1, Event: models.NotificationEventStatusChanged, Subject: "{{.Reference}}: {{.StatusText}}", Body: "Now {{.StatusText}}",
+	}, nil)
+
+	// Act
+	response, err := service.SetTemplate(1, models.NotificationEventStatusChanged, &models.SetNotificationTemplateRequest{Subject: " {{.Reference}}: {{.StatusText}} ", Body: "Now {{.StatusText}}"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Custom)
+	mockTemplateRepo.AssertExpectations(t)
+}
+
+func TestNotificationService_SetTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		event models.NotificationEvent
+		req   *models.SetNotificationTemplateRequest
+	}{
+		{"unknown event", "created", &models.SetNotificationTemplateRequest{Subject: "Hi", Body: "Hi"}},
+		{"syntax error", models.NotificationEventReply, &models.SetNotificationTemplateRequest{Subject: "{{.Reference", Body: "Hi"}},
+		{"unknown field", models.NotificationEventReply, &models.SetNotificationTemplateRequest{Subject: "Hi", Body: "{{.Password}}"}},
+		{"nil request", models.NotificationEventReply, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, mockAppRepo, mockTemplateRepo, _ := setupNotificationService()
+			mockAppRepo.On("GetByID", uint(1)).Return(&models.App{ID: 1}, nil).Maybe()
+
+			// Act
+			response, err := service.SetTemplate(1, tt.event, tt.req)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.Nil(t, response)
+			mockTemplateRepo.AssertNotCalled(t, "Upsert", mock.Anything)
+		})
+	}
+}
+
+func TestNotificationService_DeleteTemplate_NotFound(t *testing.T) {
+	// Arrange
+	service, mockAppRepo, mockTemplateRepo, _ := setupNotificationService()
+	mockAppRepo.On("GetByID", uint(1)).Return(&models.App{ID: 1}, nil)
+	mockTemplateRepo.On("Delete", uint(1), models.NotificationEventReply).Return(gorm.ErrRecordNotFound)
+
+	// Act
+	err := service.DeleteTemplate(1, models.NotificationEventReply)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotificationTemplateNotFound)
+}
+
+func TestNotificationService_Unsubscribe(t *testing.T) {
+	// Arrange
+	service, _, _, mockSupportRepo := setupNotificationService()
+	mockSupportRepo.On("GetByID", uint(7), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 7}, nil)
+	mockSupportRepo.On("Update", mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.ID == 7 && req.UnsubscribedAt != nil
+	})).Return(nil)
+
+	// Act
+	err := service.Unsubscribe(unsubscribeToken([]byte(testUnsubscribeSecret), 7))
+
+	// Assert
+	assert.NoError(t, err)
+	mockSupportRepo.AssertExpectations(t)
+}
+
+func TestNotificationService_Unsubscribe_AlreadyUnsubscribed(t *testing.T) {
+	// Arrange
+	service, _, _, mockSupportRepo := setupNotificationService()
+	unsubscribedAt := time.Now().Add(-time.Hour)
+	mockSupportRepo.On("GetByID", uint(7), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 7, UnsubscribedAt: &unsubscribedAt}, nil)
+
+	// Act
+	err := service.Unsubscribe(unsubscribeToken([]byte(testUnsubscribeSecret), 7))
+
+	// Assert
+	assert.NoError(t, err)
+	mockSupportRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestNotificationService_Unsubscribe_InvalidToken(t *testing.T) {
+	valid := unsubscribeToken([]byte(testUnsubscribeSecret), 7)
+	_, signature, _ := strings.Cut(valid, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", "7"},
+		{"signed with another secret", unsubscribeToken([]byte("other-secret"), 7)},
+		{"signature of another request", "8." + signature},
+		{"not a number", "seven." + signature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, _, _, mockSupportRepo := setupNotificationService()
+
+			// Act
+			err := service.Unsubscribe(tt.token)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+			mockSupportRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestNotificationService_Unsubscribe_DeletedRequest(t *testing.T) {
+	// Arrange
+	service, _, _, mockSupportRepo := setupNotificationService()
+	mockSupportRepo.On("GetByID", uint(7), models.OrganizationScope{}).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := service.Unsubscribe(unsubscribeToken([]byte(testUnsubscribeSecret), 7))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+}
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrOrganizationExists    = errors.New("organization already exists")
+	ErrOrganizationRequired  = errors.New("organization required: send the X-Organization-ID header")
+	ErrNotOrganizationMember = errors.New("not a member of the organization")
+	ErrMemberNotFound        = errors.New("organization member not found")
+	ErrAppOwned              = errors.New("app already belongs to an organization")
+)
+
+// OrganizationService defines the interface for organizations, their members and apps
+type OrganizationService interface {
+	ListOrganizations() ([]*models.OrganizationResponse, error)
+	GetOrganization(id uint) (*models.OrganizationResponse, error)
+	CreateOrganization(req *models.CreateOrganizationRequest) (*models.OrganizationResponse, error)
+	UpdateOrganization(id uint, req *models.UpdateOrganizationRequest) (*models.OrganizationResponse, error)
+	DeleteOrganization(id uint) error
+	ListMembers(organizationID uint) ([]*models.OrganizationMemberResponse, error)
+	SetMember(organizationID, userID uint, req *models.SetOrganizationMemberRequest) (*models.OrganizationMemberResponse, error)
+	RemoveMember(organizationID, userID uint) error
+	ListApps(organizationID uint) ([]*models.AppResponse, error)
+	AddApp(organizationID uint, req *models.AddOrganizationAppRequest) (*models.AppResponse, error)
+	RemoveApp(organizationID uint, slug string) error
+	ListUserOrganizations(userID uint) ([]*models.UserOrganizationResponse, error)
+	ResolveScope(userID uint, requestedID *uint) (models.OrganizationScope, models.UserRole, error)
+}
+
+// organizationService implements OrganizationService
+type organizationService struct {
+	orgRepo  repositories.OrganizationRepository
+	appRepo  repositories.AppRepository
+	userRepo repositories.UserRepository
+	roleRepo repositories.RoleRepository
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(orgRepo repositories.OrganizationRepository, appRepo repositories.AppRepository, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository) OrganizationService {
+	return &organizationService{
+		orgRepo:  orgRepo,
+		appRepo:  appRepo,
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+	}
+}
+
+// ListOrganizations retrieves every organization
+func (s *organizationService) ListOrganizations() ([]*models.OrganizationResponse, error) {
+	organizations, err := s.orgRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.OrganizationResponse, len(organizations))
+	for i, organization := range organizations {
+		responses[i] = organization.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetOrganization retrieves an organization by ID
+func (s *organizationService) GetOrganization(id uint) (*models.OrganizationResponse, error) {
+	organization, err := s.getOrganization(id)
+	if err != nil {
+		return nil, err
+	}
+	return organization.ToResponse(), nil
+}
+
+// CreateOrganization creates an organization with a unique name
+func (s *organizationService) CreateOrganization(req *models.CreateOrganizationRequest) (*models.OrganizationResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrInvalidRequest
+	}
+	if err := s.checkNameAvailable(name); err != nil {
+		return nil, err
+	}
+
+	organization := &models.Organization{Name: name}
+	if err := s.orgRepo.Create(organization); err != nil {
+		return nil, err
+	}
+	return organization.ToResponse(), nil
+}
+
+// UpdateOrganization renames an organization
+func (s *organizationService) UpdateOrganization(id uint, req *models.UpdateOrganizationRequest) (*models.OrganizationResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	organization, err := s.getOrganization(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrInvalidRequest
+		}
+		if name != organization.Name {
+			if err := s.checkNameAvailable(name); err != nil {
+				return nil, err
+			}
+			organization.Name = name
+		}
+	}
+
+	if err := s.orgRepo.Update(organization); err != nil {
+		return nil, err
+	}
+	return organization.ToResponse(), nil
+}
+
+// DeleteOrganization deletes an organization with its memberships. Its apps stay registered, and its
+// support requests are kept but are only visible to platform staff afterwards.
+func (s *organizationService) DeleteOrganization(id uint) error {
+	if _, err := s.getOrganization(id); err != nil {
+		return err
+	}
+	return s.orgRepo.Delete(id)
+}
+
+// ListMembers retrieves the members of an organization
+func (s *organizationService) ListMembers(organizationID uint) ([]*models.OrganizationMemberResponse, error) {
+	if _, err := s.getOrganization(organizationID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.GetMembers(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.OrganizationMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = member.ToResponse()
+	}
+	return responses, nil
+}
+
+// SetMember adds a user to an organization with a role, or changes the role of an existing member.
+// The role applies to the organization's support requests only.
+func (s *organizationService) SetMember(organizationID, userID uint, req *models.SetOrganizationMemberRequest) (*models.OrganizationMemberResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := s.getOrganization(organizationID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := validateRole(s.roleRepo, req.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.SaveMember(&models.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           req.Role,
+	}); err != nil {
+		return nil, err
+	}
+
+	member, err := s.orgRepo.GetMember(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	member.User = user
+	return member.ToResponse(), nil
+}
+
+// RemoveMember removes a user from an organization. A user left without memberships becomes
+// platform staff again, limited by their own role.
+func (s *organizationService) RemoveMember(organizationID, userID uint) error {
+	if err := s.orgRepo.DeleteMember(organizationID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListApps retrieves the apps an organization owns
+func (s *organizationService) ListApps(organizationID uint) ([]*models.AppResponse, error) {
+	if _, err := s.getOrganization(organizationID); err != nil {
+		return nil, err
+	}
+
+	apps, err := s.appRepo.GetByOrganizationID(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.AppResponse, len(apps))
+	for i, app := range apps {
+		responses[i] = app.ToResponse()
+	}
+	return responses, nil
+}
+
+// AddApp gives an organization ownership of an app, registering the app if needed. Support requests
+// already submitted from the app that don't belong to an organization are handed over too.
+func (s *organizationService) AddApp(organizationID uint, req *models.AddOrganizationAppRequest) (*models.AppResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	slug := strings.TrimSpace(req.App)
+	if slug == "" {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := s.getOrganization(organizationID); err != nil {
+		return nil, err
+	}
+
+	app, err := s.appRepo.GetBySlug(slug)
+	if err == nil {
+		if app.OrganizationID != nil {
+			return nil, ErrAppOwned
+		}
+		app.OrganizationID = &organizationID
+		if err := s.appRepo.Update(app); err != nil {
+			return nil, err
+		}
+		return app.ToResponse(), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if !models.IsValidAppSlug(slug) {
+		return nil, ErrInvalidAppSlug
+	}
+
+	app = &models.App{OrganizationID: &organizationID, Slug: slug, DisplayName: slug, IsActive: true}
+	if err := s.appRepo.Create(app); err != nil {
+		return nil, err
+	}
+	return app.ToResponse(), nil
+}
+
+// RemoveApp takes an app away from an organization. The app stays registered. Support requests
+// already submitted from it stay with the organization; new ones belong to no organization.
+func (s *organizationService) RemoveApp(organizationID uint, slug string) error {
+	app, err := s.appRepo.GetBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAppNotFound
+		}
+		return err
+	}
+	if app.OrganizationID == nil || *app.OrganizationID != organizationID {
+		return ErrAppNotFound
+	}
+	app.OrganizationID = nil
+	return s.appRepo.Update(app)
+}
+
+// ListUserOrganizations retrieves the organizations a user belongs to and their role in each
+func (s *organizationService) ListUserOrganizations(userID uint) ([]*models.UserOrganizationResponse, error) {
+	memberships, err := s.orgRepo.GetMembershipsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.UserOrganizationResponse, 0, len(memberships))
+	for _, membership := range memberships {
+		response := &models.UserOrganizationResponse{ID: membership.OrganizationID, Role: membership.Role}
+		if membership.Organization != nil {
+			response.Name = membership.Organization.Name
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// ResolveScope works out which organization a request by the user acts in, and the role the user
+// has there. requestedID is the organization the caller asked for, if any.
+//
+// Platform staff, users without memberships, see every organization unless they ask for one and keep
+// their own role, so the returned role is empty. Members are confined to one of their organizations,
+// implied when they only have one, and get their role in it.
+func (s *organizationService) ResolveScope(userID uint, requestedID *uint) (models.OrganizationScope, models.UserRole, error) {
+	memberships, err := s.orgRepo.GetMembershipsByUserID(userID)
+	if err != nil {
+		return models.OrganizationScope{}, "", err
+	}
+
+	if len(memberships) == 0 {
+		if requestedID == nil {
+			return models.OrganizationScope{}, "", nil
+		}
+		if _, err := s.getOrganization(*requestedID); err != nil {
+			return models.OrganizationScope{}, "", err
+		}
+		return models.ScopeToOrganization(*requestedID), "", nil
+	}
+
+	if requestedID == nil {
+		if len(memberships) > 1 {
+			return models.OrganizationScope{}, "", ErrOrganizationRequired
+		}
+		return models.ScopeToOrganization(memberships[0].OrganizationID), memberships[0].Role, nil
+	}
+	for _, membership := range memberships {
+		if membership.OrganizationID == *requestedID {
+			return models.ScopeToOrganization(membership.OrganizationID), membership.Role, nil
+		}
+	}
+	return models.OrganizationScope{}, "", ErrNotOrganizationMember
+}
+
+// getOrganization loads an organization, mapping missing records to ErrOrganizationNotFound
+func (s *organizationService) getOrganization(id uint) (*models.Organization, error) {
+	organization, err := s.orgRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+// checkNameAvailable returns ErrOrganizationExists if another organization has the name
+func (s *organizationService) checkNameAvailable(name string) error {
+	if _, err := s.orgRepo.GetByName(name); err == nil {
+		return ErrOrganizationExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
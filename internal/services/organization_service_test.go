@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockOrganizationRepository is a mock implementation of OrganizationRepository
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(organization *models.Organization) error {
+	args := m.Called(organization)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetByID(id uint) (*models.Organization, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetByName(name string) (*models.Organization, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetAll() ([]*models.Organization, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) Update(organization *models.Organization) error {
+	args := m.Called(organization)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) SaveMember(member *models.OrganizationMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetMember(organizationID, userID uint) (*models.OrganizationMember, error) {
+	args := m.Called(organizationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMembers(organizationID uint) ([]*models.OrganizationMember, error) {
+	args := m.Called(organizationID)
+	return args.Get(0).([]*models.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMembershipsByUserID(userID uint) ([]*models.OrganizationMember, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) DeleteMember(organizationID, userID uint) error {
+	args := m.Called(organizationID, userID)
+	return args.Error(0)
+}
+
+// MockAppRepository is a mock implementation of AppRepository
+type MockAppRepository struct {
+	mock.Mock
+}
+
+func (m *MockAppRepository) Create(app *models.App) error {
+	args := m.Called(app)
+	return args.Error(0)
+}
+
+func (m *MockAppRepository) GetByID(id uint) (*models.App, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.App), args.Error(1)
+}
+
+func (m *MockAppRepository) GetAll() ([]*models.App, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.App), args.Error(1)
+}
+
+func (m *MockAppRepository) Update(app *models.App) error {
+	args := m.Called(app)
+	return args.Error(0)
+}
+
+func (m *MockAppRepository) GetBySlug(slug string) (*models.App, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.App), args.Error(1)
+}
+
+func (m *MockAppRepository) GetByOrganizationID(organizationID uint) ([]*models.App, error) {
+	args := m.Called(organizationID)
+	return args.Get(0).([]*models.App), args.Error(1)
+}
+
+func (m *MockAppRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupOrganizationService() (OrganizationService, *MockOrganizationRepository, *MockAppRepository, *MockUserRepository, *MockRoleRepository) {
+	mockOrgRepo := new(MockOrganizationRepository)
+	mockAppRepo := new(MockAppRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockRoleRepo := new(MockRoleRepository)
+	service := NewOrganizationService(mockOrgRepo, mockAppRepo, mockUserRepo, mockRoleRepo)
+	return service, mockOrgRepo, mockAppRepo, mockUserRepo, mockRoleRepo
+}
+
+func TestOrganizationService_CreateOrganization(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByName", "Acme Corp").Return(nil, gorm.ErrRecordNotFound)
+	mockOrgRepo.On("Create", mock.MatchedBy(func(organization *models.Organization) bool {
+		return organization.Name == "Acme Corp"
+	})).Return(nil)
+
+	// Act
+	response, err := service.CreateOrganization(&models.CreateOrganizationRequest{Name: "  Acme Corp "})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corp", response.Name)
+	mockOrgRepo.AssertExpectations(t)
+}
+
+func TestOrganizationService_CreateOrganization_Exists(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByName", "Acme Corp").Return(&models.Organization{ID: 1, Name: "Acme Corp"}, nil)
+
+	// Act
+	_, err := service.CreateOrganization(&models.CreateOrganizationRequest{Name: "Acme Corp"})
+
+	// Assert
+	assert.Equal(t, ErrOrganizationExists, err)
+	mockOrgRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOrganizationService_UpdateOrganization(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	organization := &models.Organization{ID: 1, Name: "Acme Corp"}
+	mockOrgRepo.On("GetByID", uint(1)).Return(organization, nil)
+	mockOrgRepo.On("GetByName", "Acme Inc").Return(nil, gorm.ErrRecordNotFound)
+	mockOrgRepo.On("Update", organization).Return(nil)
+	name := "Acme Inc"
+
+	// Act
+	response, err := service.UpdateOrganization(1, &models.UpdateOrganizationRequest{Name: &name})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Inc", response.Name)
+}
+
+func TestOrganizationService_DeleteOrganization_NotFound(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := service.DeleteOrganization(9)
+
+	// Assert
+	assert.Equal(t, ErrOrganizationNotFound, err)
+	mockOrgRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestOrganizationService_SetMember(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, mockUserRepo, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByID", uint(1)).Return(&models.Organization{ID: 1, Name: "Acme Corp"}, nil)
+	mockUserRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2, Username: "jane"}, nil)
+	mockOrgRepo.On("SaveMember", &models.OrganizationMember{OrganizationID: 1, UserID: 2, Role: models.UserRoleAgent}).Return(nil)
+	mockOrgRepo.On("GetMember", uint(1), uint(2)).Return(&models.OrganizationMember{ID: 5, OrganizationID: 1, UserID: 2, Role: models.UserRoleAgent}, nil)
+
+	// Act
+	response, err := service.SetMember(1, 2, &models.SetOrganizationMemberRequest{Role: models.UserRoleAgent})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "jane", response.Username)
+	assert.Equal(t, models.UserRoleAgent, response.Role)
+	mockOrgRepo.AssertExpectations(t)
+}
+
+func TestOrganizationService_SetMember_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		userErr error
+		role    models.UserRole
+		want    error
+	}{
+		{"unknown user", gorm.ErrRecordNotFound, models.UserRoleAgent, ErrUserNotFound},
+		{"unknown role", nil, "superuser", ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, mockOrgRepo, _, mockUserRepo, mockRoleRepo := setupOrganizationService()
+			mockOrgRepo.On("GetByID", uint(1)).Return(&models.Organization{ID: 1}, nil)
+			if tt.userErr != nil {
+				mockUserRepo.On("GetByID", uint(2)).Return(nil, tt.userErr)
+			} else {
+				mockUserRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2}, nil)
+			}
+			mockRoleRepo.On("GetByName", tt.role).Return(nil, gorm.ErrRecordNotFound)
+
+			// Act
+			_, err := service.SetMember(1, 2, &models.SetOrganizationMemberRequest{Role: tt.role})
+
+			// Assert
+			assert.ErrorIs(t, err, tt.want)
+			mockOrgRepo.AssertNotCalled(t, "SaveMember", mock.Anything)
+		})
+	}
+}
+
+func TestOrganizationService_RemoveMember_NotFound(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	mockOrgRepo.On("DeleteMember", uint(1), uint(2)).Return(gorm.ErrRecordNotFound)
+
+	// Act
+	err := service.RemoveMember(1, 2)
+
+	// Assert
+	assert.Equal(t, ErrMemberNotFound, err)
+}
+
+func TestOrganizationService_AddApp(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, mockAppRepo, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByID", uint(1)).Return(&models.Organization{ID: 1}, nil)
+	mockAppRepo.On("GetBySlug", "acme-app").Return(nil, gorm.ErrRecordNotFound)
+	mockAppRepo.On("Create", mock.MatchedBy(func(app *models.App) bool {
+		return *app.OrganizationID == 1 && app.Slug == "acme-app" && app.DisplayName == "acme-app" && app.IsActive
+	})).Return(nil)
+
+	// Act
+	response, err := service.AddApp(1, &models.AddOrganizationAppRequest{App: " acme-app "})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "acme-app", response.Slug)
+	mockAppRepo.AssertExpectations(t)
+}
+
+func TestOrganizationService_AddApp_Registered(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, mockAppRepo, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByID", uint(1)).Return(&models.Organization{ID: 1}, nil)
+	mockAppRepo.On("GetBySlug", "acme-app").Return(&models.App{ID: 3, Slug: "acme-app", DisplayName: "Acme App"}, nil)
+	mockAppRepo.On("Update", mock.MatchedBy(func(app *models.App) bool {
+		return app.ID == 3 && *app.OrganizationID == 1
+	})).Return(nil)
+
+	// Act
+	response, err := service.AddApp(1, &models.AddOrganizationAppRequest{App: "acme-app"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme App", response.DisplayName)
+	mockAppRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOrganizationService_AddApp_InvalidSlug(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, mockAppRepo, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByID", uint(1)).Return(&models.Organization{ID: 1}, nil)
+	mockAppRepo.On("GetBySlug", "Acme App").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	_, err := service.AddApp(1, &models.AddOrganizationAppRequest{App: "Acme App"})
+
+	// Assert
+	assert.Equal(t, ErrInvalidAppSlug, err)
+}
+
+func TestOrganizationService_AddApp_OwnedElsewhere(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, mockAppRepo, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetByID", uint(1)).Return(&models.Organization{ID: 1}, nil)
+	mockAppRepo.On("GetBySlug", "acme-app").Return(&models.App{ID: 3, OrganizationID: uintPtr(2), Slug: "acme-app"}, nil)
+
+	// Act
+	_, err := service.AddApp(1, &models.AddOrganizationAppRequest{App: "acme-app"})
+
+	// Assert
+	assert.Equal(t, ErrAppOwned, err)
+	mockAppRepo.AssertNotCalled(t, "Create", mock.Anything)
+	mockAppRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestOrganizationService_RemoveApp_OtherOrganization(t *testing.T) {
+	// Arrange
+	service, _, mockAppRepo, _, _ := setupOrganizationService()
+	mockAppRepo.On("GetBySlug", "acme-app").Return(&models.App{ID: 3, OrganizationID: uintPtr(2), Slug: "acme-app"}, nil)
+
+	// Act
+	err := service.RemoveApp(1, "acme-app")
+
+	// Assert
+	assert.Equal(t, ErrAppNotFound, err)
+	mockAppRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestOrganizationService_RemoveApp_KeepsRegistration(t *testing.T) {
+	// Arrange
+	service, _, mockAppRepo, _, _ := setupOrganizationService()
+	mockAppRepo.On("GetBySlug", "acme-app").Return(&models.App{ID: 3, OrganizationID: uintPtr(1), Slug: "acme-app"}, nil)
+	mockAppRepo.On("Update", mock.MatchedBy(func(app *models.App) bool {
+		return app.ID == 3 && app.OrganizationID == nil
+	})).Return(nil)
+
+	// Act
+	err := service.RemoveApp(1, "acme-app")
+
+	// Assert
+	assert.NoError(t, err)
+	mockAppRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestOrganizationService_ListUserOrganizations(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetMembershipsByUserID", uint(2)).Return([]*models.OrganizationMember{
+		{OrganizationID: 1, UserID: 2, Role: models.UserRoleAgent, Organization: &models.Organization{ID: 1, Name: "Acme Corp"}},
+	}, nil)
+
+	// Act
+	organizations, err := service.ListUserOrganizations(2)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	assert.Equal(t, &models.UserOrganizationResponse{ID: 1, Name: "Acme Corp", Role: models.UserRoleAgent}, organizations[0])
+}
+
+func TestOrganizationService_ResolveScope(t *testing.T) {
+	acme, globex, initech := uint(1), uint(2), uint(3)
+	memberships := []*models.OrganizationMember{
+		{OrganizationID: acme, UserID: 2, Role: models.UserRoleAgent},
+		{OrganizationID: globex, UserID: 2, Role: models.UserRoleViewer},
+	}
+
+	tests := []struct {
+		name        string
+		memberships []*models.OrganizationMember
+		requested   *uint
+		wantScope   models.OrganizationScope
+		wantRole    models.UserRole
+		wantErr     error
+	}{
+		{"platform staff", []*models.OrganizationMember{}, nil, models.OrganizationScope{}, "", nil},
+		{"platform staff picking an organization", []*models.OrganizationMember{}, &acme, models.ScopeToOrganization(acme), "", nil},
+		{"platform staff picking an unknown organization", []*models.OrganizationMember{}, &initech, models.OrganizationScope{}, "", ErrOrganizationNotFound},
+		{"single membership", memberships[:1], nil, models.ScopeToOrganization(acme), models.UserRoleAgent, nil},
+		{"several memberships without a choice", memberships, nil, models.OrganizationScope{}, "", ErrOrganizationRequired},
+		{"several memberships picking one", memberships, &globex, models.ScopeToOrganization(globex), models.UserRoleViewer, nil},
+		{"member picking another organization", memberships, &initech, models.OrganizationScope{}, "", ErrNotOrganizationMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, mockOrgRepo, _, _, _ := setupOrganizationService()
+			mockOrgRepo.On("GetMembershipsByUserID", uint(2)).Return(tt.memberships, nil)
+			mockOrgRepo.On("GetByID", acme).Return(&models.Organization{ID: acme}, nil)
+			mockOrgRepo.On("GetByID", initech).Return(nil, gorm.ErrRecordNotFound)
+
+			// Act
+			scope, role, err := service.ResolveScope(2, tt.requested)
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantScope, scope)
+			assert.Equal(t, tt.wantRole, role)
+		})
+	}
+}
+
+func TestOrganizationService_ResolveScope_RepositoryError(t *testing.T) {
+	// Arrange
+	service, mockOrgRepo, _, _, _ := setupOrganizationService()
+	mockOrgRepo.On("GetMembershipsByUserID", uint(2)).Return(nil, errors.New("database error"))
+
+	// Act
+	_, _, err := service.ResolveScope(2, nil)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+// uintPtr returns a pointer to id
+func uintPtr(id uint) *uint {
+	return &id
+}
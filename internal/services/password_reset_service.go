@@ -0,0 +1,165 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
+// PasswordResetService defines the interface for self-service password resets
+type PasswordResetService interface {
+	RequestReset(req *models.PasswordResetRequest) error
+	ConfirmReset(req *models.PasswordResetConfirmRequest) error
+}
+
+// passwordResetService implements PasswordResetService
+type passwordResetService struct {
+	userRepo  repositories.UserRepository
+	resetRepo repositories.PasswordResetTokenRepository
+	tokenRepo repositories.RefreshTokenRepository
+	mailer    mail.Mailer
+	tokenTTL  time.Duration
+	resetURL  string
+	now       func() time.Time
+}
+
+// NewPasswordResetService creates a new password reset service
+func NewPasswordResetService(userRepo repositories.UserRepository, resetRepo repositories.PasswordResetTokenRepository, tokenRepo repositories.RefreshTokenRepository, mailer mail.Mailer, cfg config.PasswordResetConfig) PasswordResetService {
+	return &passwordResetService{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		tokenRepo: tokenRepo,
+		mailer:    mailer,
+		tokenTTL:  cfg.TokenTTL,
+		resetURL:  cfg.URL,
+		now:       time.Now,
+	}
+}
+
+// RequestReset mails a reset token to the active user with the given email. To avoid revealing which
+// emails have accounts it succeeds without doing anything for unknown or inactive users, and a failure to
+// send the mail is only logged.
+func (s *passwordResetService) RequestReset(req *models.PasswordResetRequest) error {
+	if req == nil {
+		return ErrInvalidRequest
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	plain := base64.RawURLEncoding.EncodeToString(b)
+
+	token := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(plain),
+		ExpiresAt: s.now().Add(s.tokenTTL),
+	}
+	if err := s.resetRepo.Create(token); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(s.resetMessage(user, plain)); err != nil {
+		log.Printf("Warning: failed to send password reset mail to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ConfirmReset sets a new password with a mailed reset token. The token is consumed and all of the user's
+// sessions are ended, so a stolen session does not outlive the reset.
+func (s *passwordResetService) ConfirmReset(req *models.PasswordResetConfirmRequest) error {
+	if req == nil {
+		return ErrInvalidRequest
+	}
+
+	token, err := s.resetRepo.GetByTokenHash(hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	now := s.now()
+	if token.IsUsed() || token.IsExpired(now) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if !user.IsActive {
+		return ErrInvalidResetToken
+	}
+
+	if err := user.SetPassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	redeemed, err := s.resetRepo.Redeem(token, user, now)
+	if err != nil {
+		return err
+	}
+	if !redeemed {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.tokenRepo.RevokeUser(user.ID, now); err != nil {
+		return fmt.Errorf("password was reset but sessions could not be revoked: %w", err)
+	}
+	return nil
+}
+
+// resetMessage builds the mail carrying a reset token
+func (s *passwordResetService) resetMessage(user *models.User, token string) mail.Message {
+	action := "Use this reset token"
+	value := token
+	if s.resetURL != "" {
+		action = "Open this link"
+		value = strings.ReplaceAll(s.resetURL, "{token}", url.QueryEscape(token))
+	}
+
+	body := fmt.Sprintf(`Hello %s,
+
+Someone asked to reset the password of your Support App account. %s to choose a new password:
+
+%s
+
+It expires in %s and can be used once. If you did not ask for a reset, ignore this mail; your password stays unchanged.
+`, user.Username, action, value, s.tokenTTL)
+
+	return mail.Message{
+		To:      user.Email,
+		Subject: "Reset your Support App password",
+		Body:    body,
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockPasswordResetTokenRepository is a mock implementation of PasswordResetTokenRepository
+type MockPasswordResetTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetTokenRepository) Create(token *models.PasswordResetToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetTokenRepository) GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockPasswordResetTokenRepository) Redeem(token *models.PasswordResetToken, user *models.User, usedAt time.Time) (bool, error) {
+	args := m.Called(token, user, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+// recordingMailer collects sent messages instead of delivering them
+type recordingMailer struct {
+	sent []mail.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(msg mail.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+type passwordResetFixture struct {
+	service   PasswordResetService
+	userRepo  *MockUserRepository
+	resetRepo *MockPasswordResetTokenRepository
+	tokenRepo *MockRefreshTokenRepository
+	mailer    *recordingMailer
+}
+
+func setupPasswordResetService(resetURL string) *passwordResetFixture {
+	f := &passwordResetFixture{
+		userRepo:  new(MockUserRepository),
+		resetRepo: new(MockPasswordResetTokenRepository),
+		tokenRepo: new(MockRefreshTokenRepository),
+		mailer:    &recordingMailer{},
+	}
+	f.service = NewPasswordResetService(f.userRepo, f.resetRepo, f.tokenRepo, f.mailer, config.PasswordResetConfig{
+		TokenTTL: time.Hour,
+		URL:      resetURL,
+	})
+	return f
+}
+
+func TestPasswordResetService_RequestReset_MailsToken(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("https://support.example.com/reset?token={token}")
+	user := &models.User{ID: 1, Username: "alice", Email: "alice@example.com", IsActive: true}
+	f.userRepo.On("GetByEmail", "alice@example.com").Return(user, nil)
+	var stored *models.PasswordResetToken
+	f.resetRepo.On("Create", mock.AnythingOfType("*models.PasswordResetToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*models.PasswordResetToken) }).
+		Return(nil)
+
+	// Act
+	err := f.service.RequestReset(&models.PasswordResetRequest{Email: "alice@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, f.mailer.sent, 1)
+	msg := f.mailer.sent[0]
+	assert.Equal(t, "alice@example.com", msg.To)
+
+	require.NotNil(t, stored)
+	assert.Equal(t, uint(1), stored.UserID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+
+	// The mail carries the plain token, only its hash is stored
+	start := strings.Index(msg.Body, "token=")
+	require.NotEqual(t, -1, start)
+	plain := strings.Fields(msg.Body[start+len("token="):])[0]
+	assert.Equal(t, hashToken(plain), stored.TokenHash)
+	assert.NotContains(t, msg.Body, stored.TokenHash)
+}
+
+func TestPasswordResetService_RequestReset_WithoutURLMailsBareToken(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	user := &models.User{ID: 1, Username: "alice", Email: "alice@example.com", IsActive: true}
+	f.userRepo.On("GetByEmail", "alice@example.com").Return(user, nil)
+	var stored *models.PasswordResetToken
+	f.resetRepo.On("Create", mock.AnythingOfType("*models.PasswordResetToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*models.PasswordResetToken) }).
+		Return(nil)
+
+	// Act
+	err := f.service.RequestReset(&models.PasswordResetRequest{Email: "alice@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, f.mailer.sent, 1)
+	found := false
+	for _, field := range strings.Fields(f.mailer.sent[0].Body) {
+		if hashToken(field) == stored.TokenHash {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestPasswordResetService_RequestReset_UnknownEmail(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	f.userRepo.On("GetByEmail", "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := f.service.RequestReset(&models.PasswordResetRequest{Email: "nobody@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, f.mailer.sent)
+	f.resetRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestPasswordResetService_RequestReset_InactiveUser(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	f.userRepo.On("GetByEmail", "alice@example.com").Return(&models.User{ID: 1, Email: "alice@example.com", IsActive: false}, nil)
+
+	// Act
+	err := f.service.RequestReset(&models.PasswordResetRequest{Email: "alice@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, f.mailer.sent)
+	f.resetRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestPasswordResetService_RequestReset_MailFailureIsNotReported(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	f.mailer.err = errors.New("smtp down")
+	f.userRepo.On("GetByEmail", "alice@example.com").Return(&models.User{ID: 1, Email: "alice@example.com", IsActive: true}, nil)
+	f.resetRepo.On("Create", mock.AnythingOfType("*models.PasswordResetToken")).Return(nil)
+
+	// Act
+	err := f.service.RequestReset(&models.PasswordResetRequest{Email: "alice@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetService_RequestReset_RepositoryError(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	f.userRepo.On("GetByEmail", "alice@example.com").Return(nil, errors.New("database error"))
+
+	// Act
+	err := f.service.RequestReset(&models.PasswordResetRequest{Email: "alice@example.com"})
+
+	// Assert
+	assert.EqualError(t, err, "database error")
+}
+
+func TestPasswordResetService_ConfirmReset_Success(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	token := &models.PasswordResetToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	user := &models.User{ID: 1, Email: "alice@example.com", IsActive: true}
+	f.resetRepo.On("GetByTokenHash", hashToken("plain-token")).Return(token, nil)
+	f.userRepo.On("GetByID", uint(1)).Return(user, nil)
+	f.resetRepo.On("Redeem", token, user, mock.AnythingOfType("time.Time")).Return(true, nil)
+	f.tokenRepo.On("RevokeUser", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Act
+	err := f.service.ConfirmReset(&models.PasswordResetConfirmRequest{Token: "plain-token", NewPassword: "brand-new-password"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, user.CheckPassword("brand-new-password"))
+	f.tokenRepo.AssertExpectations(t)
+}
+
+func TestPasswordResetService_ConfirmReset_InvalidTokens(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name  string
+		token *models.PasswordResetToken
+		err   error
+	}{
+		{name: "unknown", err: gorm.ErrRecordNotFound},
+		{name: "expired", token: &models.PasswordResetToken{ID: 3, UserID: 1, ExpiresAt: past}},
+		{name: "used", token: &models.PasswordResetToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &past}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := setupPasswordResetService("")
+			if tt.token != nil {
+				f.resetRepo.On("GetByTokenHash", hashToken("plain-token")).Return(tt.token, nil)
+			} else {
+				f.resetRepo.On("GetByTokenHash", hashToken("plain-token")).Return(nil, tt.err)
+			}
+
+			// Act
+			err := f.service.ConfirmReset(&models.PasswordResetConfirmRequest{Token: "plain-token", NewPassword: "brand-new-password"})
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidResetToken)
+			f.resetRepo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPasswordResetService_ConfirmReset_InactiveUser(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	token := &models.PasswordResetToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	f.resetRepo.On("GetByTokenHash", hashToken("plain-token")).Return(token, nil)
+	f.userRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, IsActive: false}, nil)
+
+	// Act
+	err := f.service.ConfirmReset(&models.PasswordResetConfirmRequest{Token: "plain-token", NewPassword: "brand-new-password"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+	f.resetRepo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPasswordResetService_ConfirmReset_ConcurrentRedeem(t *testing.T) {
+	// Arrange
+	f := setupPasswordResetService("")
+	token := &models.PasswordResetToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	user := &models.User{ID: 1, IsActive: true}
+	f.resetRepo.On("GetByTokenHash", hashToken("plain-token")).Return(token, nil)
+	f.userRepo.On("GetByID", uint(1)).Return(user, nil)
+	f.resetRepo.On("Redeem", token, user, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	// Act
+	err := f.service.ConfirmReset(&models.PasswordResetConfirmRequest{Token: "plain-token", NewPassword: "brand-new-password"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+	f.tokenRepo.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything)
+}
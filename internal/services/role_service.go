@@ -0,0 +1,222 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+	ErrRoleExists   = errors.New("role already exists")
+	ErrRoleInUse    = errors.New("role is assigned to users")
+	ErrBuiltinRole  = errors.New("built-in roles cannot be changed")
+	ErrInvalidRole  = errors.New("invalid role")
+)
+
+// RoleService defines the interface for roles and the permissions they grant
+type RoleService interface {
+	ListRoles() ([]*models.RoleResponse, error)
+	GetRole(name models.UserRole) (*models.RoleResponse, error)
+	CreateRole(req *models.CreateRoleRequest) (*models.RoleResponse, error)
+	UpdateRole(name models.UserRole, req *models.UpdateRoleRequest) (*models.RoleResponse, error)
+	DeleteRole(name models.UserRole) error
+	HasPermission(role models.UserRole, permission models.Permission) (bool, error)
+}
+
+// roleService implements RoleService
+type roleService struct {
+	roleRepo repositories.RoleRepository
+}
+
+// NewRoleService creates a new role service
+func NewRoleService(roleRepo repositories.RoleRepository) RoleService {
+	return &roleService{
+		roleRepo: roleRepo,
+	}
+}
+
+// ListRoles retrieves the built-in roles followed by the custom roles
+func (s *roleService) ListRoles() ([]*models.RoleResponse, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := models.BuiltinRoleResponses()
+	for _, role := range roles {
+		responses = append(responses, role.ToResponse())
+	}
+	return responses, nil
+}
+
+// GetRole retrieves a built-in or custom role by name
+func (s *roleService) GetRole(name models.UserRole) (*models.RoleResponse, error) {
+	if name.IsBuiltin() {
+		for _, response := range models.BuiltinRoleResponses() {
+			if response.Name == name {
+				return response, nil
+			}
+		}
+	}
+
+	role, err := s.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+	return role.ToResponse(), nil
+}
+
+// CreateRole creates a custom role. Names of built-in roles cannot be reused.
+func (s *roleService) CreateRole(req *models.CreateRoleRequest) (*models.RoleResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	if !models.IsValidRoleName(req.Name) {
+		return nil, fmt.Errorf("%w: name must start with a lowercase letter and contain only lowercase letters, digits, '_' and '-'", ErrInvalidRole)
+	}
+	name := models.UserRole(req.Name)
+	if name.IsBuiltin() {
+		return nil, ErrRoleExists
+	}
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.roleRepo.GetByName(name); err == nil {
+		return nil, ErrRoleExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:        name,
+		Description: req.Description,
+	}
+	role.SetPermissions(permissions)
+	if err := s.roleRepo.Create(role); err != nil {
+		return nil, err
+	}
+	return role.ToResponse(), nil
+}
+
+// UpdateRole changes the description or permissions of a custom role. Users with the role get the
+// new permissions on their next request.
+func (s *roleService) UpdateRole(name models.UserRole, req *models.UpdateRoleRequest) (*models.RoleResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	if name.IsBuiltin() {
+		return nil, ErrBuiltinRole
+	}
+
+	role, err := s.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	if req.Permissions != nil {
+		permissions, err := normalizePermissions(req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		role.SetPermissions(permissions)
+	}
+
+	if err := s.roleRepo.Update(role); err != nil {
+		return nil, err
+	}
+	return role.ToResponse(), nil
+}
+
+// DeleteRole deletes a custom role that no user has
+func (s *roleService) DeleteRole(name models.UserRole) error {
+	if name.IsBuiltin() {
+		return ErrBuiltinRole
+	}
+
+	role, err := s.getRole(name)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.roleRepo.CountUsers(name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRoleInUse
+	}
+	return s.roleRepo.Delete(role.ID)
+}
+
+// HasPermission reports whether role grants permission. Unknown roles grant nothing.
+func (s *roleService) HasPermission(role models.UserRole, permission models.Permission) (bool, error) {
+	permissions, ok := models.BuiltinRolePermissions[role]
+	if !ok {
+		custom, err := s.roleRepo.GetByName(role)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		permissions = custom.PermissionList()
+	}
+
+	for _, granted := range permissions {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getRole loads a custom role, mapping missing records to ErrRoleNotFound
+func (s *roleService) getRole(name models.UserRole) (*models.Role, error) {
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// validateRole rejects roles that are neither built-in nor defined as custom roles
+func validateRole(roleRepo repositories.RoleRepository, role models.UserRole) error {
+	if role.IsBuiltin() {
+		return nil
+	}
+	if _, err := roleRepo.GetByName(role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: unknown role %q", ErrInvalidRole, role)
+		}
+		return err
+	}
+	return nil
+}
+
+// normalizePermissions rejects unknown permissions and drops duplicates
+func normalizePermissions(permissions []models.Permission) ([]models.Permission, error) {
+	seen := make(map[models.Permission]bool, len(permissions))
+	normalized := make([]models.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return nil, fmt.Errorf("%w: unknown permission %q", ErrInvalidRole, permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			normalized = append(normalized, permission)
+		}
+	}
+	return normalized, nil
+}
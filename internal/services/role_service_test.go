@@ -0,0 +1,272 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockRoleRepository is a mock implementation of RoleRepository
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) Create(role *models.Role) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) GetByName(name models.UserRole) (*models.Role, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetAll() ([]*models.Role, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) Update(role *models.Role) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) CountUsers(name models.UserRole) (int64, error) {
+	args := m.Called(name)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupRoleService() (RoleService, *MockRoleRepository) {
+	mockRepo := new(MockRoleRepository)
+	return NewRoleService(mockRepo), mockRepo
+}
+
+func newTestRole(id uint, name string, permissions ...models.Permission) *models.Role {
+	role := &models.Role{ID: id, Name: models.UserRole(name)}
+	role.SetPermissions(permissions)
+	return role
+}
+
+func TestRoleService_ListRoles_BuiltinFirst(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetAll").Return([]*models.Role{newTestRole(1, "triage", models.PermissionTicketsRead)}, nil)
+
+	// Act
+	roles, err := service.ListRoles()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, roles, 5)
+	assert.Equal(t, models.UserRoleAdmin, roles[0].Name)
+	assert.True(t, roles[0].BuiltIn)
+	assert.Equal(t, models.Permissions, roles[0].Permissions)
+	assert.Equal(t, models.UserRoleAgent, roles[1].Name)
+	assert.Equal(t, models.UserRole("triage"), roles[4].Name)
+	assert.False(t, roles[4].BuiltIn)
+}
+
+func TestRoleService_GetRole(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetByName", models.UserRole("triage")).Return(newTestRole(1, "triage", models.PermissionTicketsRead), nil)
+	mockRepo.On("GetByName", models.UserRole("missing")).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	viewer, viewerErr := service.GetRole(models.UserRoleViewer)
+	triage, triageErr := service.GetRole("triage")
+	_, missingErr := service.GetRole("missing")
+
+	// Assert
+	require.NoError(t, viewerErr)
+	assert.Equal(t, []models.Permission{models.PermissionTicketsRead}, viewer.Permissions)
+	require.NoError(t, triageErr)
+	assert.Equal(t, []models.Permission{models.PermissionTicketsRead}, triage.Permissions)
+	assert.Equal(t, ErrRoleNotFound, missingErr)
+}
+
+func TestRoleService_CreateRole_Success(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetByName", models.UserRole("billing-agent")).Return(nil, gorm.ErrRecordNotFound)
+	var created *models.Role
+	mockRepo.On("Create", mock.AnythingOfType("*models.Role")).
+		Run(func(args mock.Arguments) { created = args.Get(0).(*models.Role) }).
+		Return(nil)
+
+	// Act
+	response, err := service.CreateRole(&models.CreateRoleRequest{
+		Name:        "billing-agent",
+		Description: "Handles billing tickets",
+		Permissions: []models.Permission{models.PermissionTicketsRead, models.PermissionTicketsUpdate, models.PermissionTicketsRead},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "tickets:read,tickets:update", created.Permissions)
+	assert.Equal(t, models.UserRole("billing-agent"), response.Name)
+	assert.Equal(t, []models.Permission{models.PermissionTicketsRead, models.PermissionTicketsUpdate}, response.Permissions)
+	assert.False(t, response.BuiltIn)
+}
+
+func TestRoleService_CreateRole_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *models.CreateRoleRequest
+		want error
+	}{
+		{"bad name", &models.CreateRoleRequest{Name: "Billing Agent"}, ErrInvalidRole},
+		{"unknown permission", &models.CreateRoleRequest{Name: "triage", Permissions: []models.Permission{"tickets:archive"}}, ErrInvalidRole},
+		{"built-in name", &models.CreateRoleRequest{Name: "agent"}, ErrRoleExists},
+		{"nil request", nil, ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, mockRepo := setupRoleService()
+
+			// Act
+			_, err := service.CreateRole(tt.req)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.want)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestRoleService_CreateRole_Exists(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetByName", models.UserRole("triage")).Return(newTestRole(1, "triage"), nil)
+
+	// Act
+	_, err := service.CreateRole(&models.CreateRoleRequest{Name: "triage", Permissions: []models.Permission{}})
+
+	// Assert
+	assert.Equal(t, ErrRoleExists, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRoleService_UpdateRole_Success(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	role := newTestRole(1, "triage", models.PermissionTicketsRead)
+	mockRepo.On("GetByName", models.UserRole("triage")).Return(role, nil)
+	mockRepo.On("Update", role).Return(nil)
+	description := "Triages new tickets"
+
+	// Act
+	response, err := service.UpdateRole("triage", &models.UpdateRoleRequest{
+		Description: &description,
+		Permissions: []models.Permission{models.PermissionTicketsRead, models.PermissionTagsManage},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, description, response.Description)
+	assert.Equal(t, []models.Permission{models.PermissionTicketsRead, models.PermissionTagsManage}, response.Permissions)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRoleService_UpdateRole_Builtin(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+
+	// Act
+	_, err := service.UpdateRole(models.UserRoleAgent, &models.UpdateRoleRequest{Permissions: models.Permissions})
+
+	// Assert
+	assert.Equal(t, ErrBuiltinRole, err)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestRoleService_DeleteRole_Success(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetByName", models.UserRole("triage")).Return(newTestRole(3, "triage"), nil)
+	mockRepo.On("CountUsers", models.UserRole("triage")).Return(int64(0), nil)
+	mockRepo.On("Delete", uint(3)).Return(nil)
+
+	// Act
+	err := service.DeleteRole("triage")
+
+	// Assert
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRoleService_DeleteRole_InUse(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetByName", models.UserRole("triage")).Return(newTestRole(3, "triage"), nil)
+	mockRepo.On("CountUsers", models.UserRole("triage")).Return(int64(2), nil)
+
+	// Act
+	err := service.DeleteRole("triage")
+
+	// Assert
+	assert.Equal(t, ErrRoleInUse, err)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestRoleService_DeleteRole_Builtin(t *testing.T) {
+	// Arrange
+	service, _ := setupRoleService()
+
+	// Act
+	err := service.DeleteRole(models.UserRoleViewer)
+
+	// Assert
+	assert.Equal(t, ErrBuiltinRole, err)
+}
+
+func TestRoleService_HasPermission(t *testing.T) {
+	// Arrange
+	service, mockRepo := setupRoleService()
+	mockRepo.On("GetByName", models.UserRole("triage")).Return(newTestRole(1, "triage", models.PermissionTicketsRead, models.PermissionTagsManage), nil)
+	mockRepo.On("GetByName", models.UserRole("deleted")).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetByName", models.UserRole("broken")).Return(nil, errors.New("database error"))
+
+	tests := []struct {
+		role       models.UserRole
+		permission models.Permission
+		want       bool
+	}{
+		{models.UserRoleAdmin, models.PermissionWebhooksManage, true},
+		{models.UserRoleAgent, models.PermissionTicketsUpdate, true},
+		{models.UserRoleAgent, models.PermissionTicketsDelete, false},
+		{models.UserRoleViewer, models.PermissionTicketsRead, true},
+		{models.UserRoleViewer, models.PermissionTicketsUpdate, false},
+		{models.UserRoleUser, models.PermissionTicketsRead, false},
+		{"triage", models.PermissionTagsManage, true},
+		{"triage", models.PermissionUsersManage, false},
+		{"deleted", models.PermissionTicketsRead, false},
+	}
+
+	for _, tt := range tests {
+		// Act
+		allowed, err := service.HasPermission(tt.role, tt.permission)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, allowed, "%s %s", tt.role, tt.permission)
+	}
+
+	_, err := service.HasPermission("broken", models.PermissionTicketsRead)
+	assert.Error(t, err)
+}
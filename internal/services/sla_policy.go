@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"time"
+)
+
+// SLATarget holds the time allowed for the first response and for resolution. Zero means no deadline.
+type SLATarget struct {
+	FirstResponse time.Duration
+	Resolution    time.Duration
+}
+
+// SLAPolicy computes support request deadlines from their priority and type
+type SLAPolicy struct {
+	Priorities    map[models.Priority]SLATarget
+	TypeOverrides map[models.SupportRequestType]map[models.Priority]SLATarget
+	DueSoonWindow time.Duration
+}
+
+// NewSLAPolicy builds an SLA policy from configuration, rejecting unknown priorities and types
+func NewSLAPolicy(cfg config.SLAConfig) (SLAPolicy, error) {
+	policy := SLAPolicy{
+		Priorities:    make(map[models.Priority]SLATarget),
+		TypeOverrides: make(map[models.SupportRequestType]map[models.Priority]SLATarget),
+		DueSoonWindow: cfg.DueSoonWindow,
+	}
+
+	for key, target := range cfg.Targets {
+		requestType, priorityName, hasType := strings.Cut(key, ":")
+		if !hasType {
+			priorityName = key
+		}
+
+		priority := models.Priority(priorityName)
+		if !priority.IsValid() {
+			return SLAPolicy{}, fmt.Errorf("unknown SLA priority %q", priorityName)
+		}
+		slaTarget := SLATarget{FirstResponse: target.FirstResponse, Resolution: target.Resolution}
+
+		if !hasType {
+			policy.Priorities[priority] = slaTarget
+			continue
+		}
+
+		supportRequestType := models.SupportRequestType(requestType)
+		if !supportRequestType.IsValid() {
+			return SLAPolicy{}, fmt.Errorf("unknown SLA request type %q", requestType)
+		}
+		if policy.TypeOverrides[supportRequestType] == nil {
+			policy.TypeOverrides[supportRequestType] = make(map[models.Priority]SLATarget)
+		}
+		policy.TypeOverrides[supportRequestType][priority] = slaTarget
+	}
+
+	return policy, nil
+}
+
+// Target returns the SLA target for a request type and priority, preferring a type override
+func (p SLAPolicy) Target(requestType models.SupportRequestType, priority models.Priority) SLATarget {
+	if target, ok := p.TypeOverrides[requestType][priority]; ok {
+		return target
+	}
+	return p.Priorities[priority]
+}
+
+// ApplyDeadlines sets the first response and resolution deadlines of a support request, counted from
+// its creation time
+func (p SLAPolicy) ApplyDeadlines(supportRequest *models.SupportRequest) {
+	target := p.Target(supportRequest.Type, supportRequest.Priority)
+
+	supportRequest.FirstResponseDueAt = deadline(supportRequest.CreatedAt, target.FirstResponse)
+	supportRequest.ResolutionDueAt = deadline(supportRequest.CreatedAt, target.Resolution)
+}
+
+// deadline returns start plus allowed, or nil when no time limit applies
+func deadline(start time.Time, allowed time.Duration) *time.Time {
+	if allowed <= 0 {
+		return nil
+	}
+	due := start.Add(allowed)
+	return &due
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultSLAPolicy builds the SLA policy of the default configuration
+func defaultSLAPolicy() SLAPolicy {
+	policy, err := NewSLAPolicy(config.DefaultSLAConfig())
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
+
+func TestNewSLAPolicy(t *testing.T) {
+	// Arrange
+	cfg := config.SLAConfig{
+		Targets: map[string]config.SLATarget{
+			"urgent":              {FirstResponse: time.Hour, Resolution: 4 * time.Hour},
+			"bug_report:urgent":   {FirstResponse: 30 * time.Minute, Resolution: 2 * time.Hour},
+			"feature_request:low": {},
+		},
+		DueSoonWindow: time.Hour,
+	}
+
+	// Act
+	policy, err := NewSLAPolicy(cfg)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, policy.DueSoonWindow)
+	assert.Equal(t, SLATarget{FirstResponse: time.Hour, Resolution: 4 * time.Hour}, policy.Target(models.SupportRequestTypeSupport, models.PriorityUrgent))
+	assert.Equal(t, SLATarget{FirstResponse: 30 * time.Minute, Resolution: 2 * time.Hour}, policy.Target(models.SupportRequestTypeBugReport, models.PriorityUrgent))
+	assert.Equal(t, SLATarget{}, policy.Target(models.SupportRequestTypeFeatureRequest, models.PriorityLow))
+}
+
+func TestNewSLAPolicy_UnknownKeys(t *testing.T) {
+	for _, key := range []string{"critical", "question:urgent", "bug_report:critical"} {
+		_, err := NewSLAPolicy(config.SLAConfig{Targets: map[string]config.SLATarget{key: {FirstResponse: time.Hour}}})
+		assert.Error(t, err, key)
+	}
+}
+
+func TestSLAPolicy_ApplyDeadlines(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	supportRequest := &models.SupportRequest{Type: models.SupportRequestTypeSupport, Priority: models.PriorityHigh, CreatedAt: createdAt}
+
+	// Act
+	defaultSLAPolicy().ApplyDeadlines(supportRequest)
+
+	// Assert
+	assert.Equal(t, createdAt.Add(4*time.Hour), *supportRequest.FirstResponseDueAt)
+	assert.Equal(t, createdAt.Add(24*time.Hour), *supportRequest.ResolutionDueAt)
+}
+
+func TestSLAPolicy_ApplyDeadlines_UnknownPriorityHasNoDeadlines(t *testing.T) {
+	// Arrange
+	supportRequest := &models.SupportRequest{Type: models.SupportRequestTypeSupport, CreatedAt: time.Now()}
+
+	// Act
+	SLAPolicy{}.ApplyDeadlines(supportRequest)
+
+	// Assert
+	assert.Nil(t, supportRequest.FirstResponseDueAt)
+	assert.Nil(t, supportRequest.ResolutionDueAt)
+}
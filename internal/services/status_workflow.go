@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"support-app-backend/internal/models"
+	"time"
+)
+
+// ErrInvalidStatusTransition is matched by every StatusTransitionError
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// StatusTransitionError reports a status change the support request workflow does not allow
+type StatusTransitionError struct {
+	From models.Status
+	To   models.Status
+}
+
+// Error implements the error interface
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change status from %s to %s", e.From, e.To)
+}
+
+// Is lets errors.Is match a StatusTransitionError against ErrInvalidStatusTransition
+func (e *StatusTransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
+
+// statusTransitions lists, for every status, the statuses a support request may move to next.
+// Resolved and closed requests have to be reopened before work resumes, and spam can only be
+// returned to the new queue.
+var statusTransitions = map[models.Status][]models.Status{
+	models.StatusNew:               {models.StatusInProgress, models.StatusWaitingOnCustomer, models.StatusResolved, models.StatusClosed, models.StatusSpam},
+	models.StatusInProgress:        {models.StatusWaitingOnCustomer, models.StatusResolved, models.StatusClosed, models.StatusSpam},
+	models.StatusWaitingOnCustomer: {models.StatusInProgress, models.StatusResolved, models.StatusClosed},
+	models.StatusResolved:          {models.StatusReopened, models.StatusClosed},
+	models.StatusClosed:            {models.StatusReopened},
+	models.StatusReopened:          {models.StatusInProgress, models.StatusWaitingOnCustomer, models.StatusResolved, models.StatusClosed},
+	models.StatusSpam:              {models.StatusNew},
+}
+
+// AllowedStatusTransitions returns the statuses a support request in status from may move to
+func AllowedStatusTransitions(from models.Status) []models.Status {
+	return statusTransitions[from]
+}
+
+// CanTransitionStatus reports whether the workflow allows moving from one status to another.
+// Keeping the current status is always allowed.
+func CanTransitionStatus(from, to models.Status) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionStatus moves a support request to a new status, recording when it was resolved or closed.
+// Reopening clears both timestamps.
+func transitionStatus(supportRequest *models.SupportRequest, to models.Status, now time.Time) error {
+	if !to.IsValid() {
+		return ErrInvalidRequest
+	}
+	if supportRequest.Status == to {
+		return nil
+	}
+	if !CanTransitionStatus(supportRequest.Status, to) {
+		return &StatusTransitionError{From: supportRequest.Status, To: to}
+	}
+
+	switch to {
+	case models.StatusResolved:
+		supportRequest.ResolvedAt = &now
+	case models.StatusClosed:
+		supportRequest.ClosedAt = &now
+	case models.StatusReopened:
+		supportRequest.ResolvedAt = nil
+		supportRequest.ClosedAt = nil
+	}
+
+	supportRequest.Status = to
+	return nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionStatus(t *testing.T) {
+	tests := []struct {
+		from     models.Status
+		to       models.Status
+		expected bool
+	}{
+		{models.StatusNew, models.StatusInProgress, true},
+		{models.StatusNew, models.StatusSpam, true},
+		{models.StatusInProgress, models.StatusWaitingOnCustomer, true},
+		{models.StatusWaitingOnCustomer, models.StatusInProgress, true},
+		{models.StatusResolved, models.StatusReopened, true},
+		{models.StatusResolved, models.StatusClosed, true},
+		{models.StatusClosed, models.StatusReopened, true},
+		{models.StatusSpam, models.StatusNew, true},
+		{models.StatusResolved, models.StatusResolved, true},
+		{models.StatusResolved, models.StatusNew, false},
+		{models.StatusResolved, models.StatusInProgress, false},
+		{models.StatusClosed, models.StatusInProgress, false},
+		{models.StatusSpam, models.StatusResolved, false},
+		{models.StatusInProgress, models.StatusNew, false},
+		{models.StatusInProgress, models.StatusReopened, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, CanTransitionStatus(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestStatusTransitions_CoverEveryStatus(t *testing.T) {
+	for _, status := range []models.Status{models.StatusNew, models.StatusInProgress, models.StatusWaitingOnCustomer, models.StatusResolved, models.StatusClosed, models.StatusReopened, models.StatusSpam} {
+		assert.NotEmpty(t, AllowedStatusTransitions(status), string(status))
+		for _, next := range AllowedStatusTransitions(status) {
+			assert.True(t, next.IsValid(), "%s -> %s", status, next)
+		}
+	}
+}
+
+func TestTransitionStatus_RecordsTimestamps(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	supportRequest := &models.SupportRequest{Status: models.StatusInProgress}
+
+	// Act & Assert - resolving records resolved_at
+	assert.NoError(t, transitionStatus(supportRequest, models.StatusResolved, now))
+	assert.Equal(t, models.StatusResolved, supportRequest.Status)
+	assert.Equal(t, now, *supportRequest.ResolvedAt)
+
+	// Closing records closed_at and keeps resolved_at
+	closedAt := now.Add(time.Hour)
+	assert.NoError(t, transitionStatus(supportRequest, models.StatusClosed, closedAt))
+	assert.Equal(t, closedAt, *supportRequest.ClosedAt)
+	assert.Equal(t, now, *supportRequest.ResolvedAt)
+
+	// Reopening clears both
+	assert.NoError(t, transitionStatus(supportRequest, models.StatusReopened, closedAt.Add(time.Hour)))
+	assert.Nil(t, supportRequest.ResolvedAt)
+	assert.Nil(t, supportRequest.ClosedAt)
+}
+
+func TestTransitionStatus_Illegal(t *testing.T) {
+	// Arrange
+	supportRequest := &models.SupportRequest{Status: models.StatusResolved}
+
+	// Act
+	err := transitionStatus(supportRequest, models.StatusNew, time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	var transitionErr *StatusTransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, models.StatusResolved, transitionErr.From)
+	assert.Equal(t, models.StatusNew, transitionErr.To)
+	assert.Equal(t, "cannot change status from resolved to new", err.Error())
+	assert.Equal(t, models.StatusResolved, supportRequest.Status)
+}
+
+func TestTransitionStatus_UnknownStatus(t *testing.T) {
+	supportRequest := &models.SupportRequest{Status: models.StatusNew}
+
+	assert.Equal(t, ErrInvalidRequest, transitionStatus(supportRequest, models.Status("archived"), time.Now()))
+}
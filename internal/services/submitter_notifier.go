@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// submitterNotificationQueueSize bounds how many notifications wait for Run before new ones are dropped
+const submitterNotificationQueueSize = 256
+
+// ReplyNotifier is told about public agent replies to support requests
+type ReplyNotifier interface {
+	NotifyReply(supportRequest *models.SupportRequest, message *models.SupportRequestMessage)
+}
+
+// submitterNotification is a notification waiting to be rendered and sent
+type submitterNotification struct {
+	event          models.NotificationEvent
+	supportRequest *models.SupportRequestResponse
+	previousStatus models.Status
+	reply          string
+}
+
+// SubmitterNotifier emails submitters who left an address when the status of their support request
+// changes or an agent replies publicly. It implements EventPublisher and ReplyNotifier; notifications
+// are queued and only rendered and sent while Run is active, so mail delivery never slows down the
+// change that caused it.
+type SubmitterNotifier struct {
+	appRepo      repositories.AppRepository
+	templateRepo repositories.NotificationTemplateRepository
+	mailer       mail.Mailer
+	config       config.NotificationConfig
+	secret       []byte
+	domain       string
+	queue        chan submitterNotification
+}
+
+// NewSubmitterNotifier creates a new submitter notifier sending mail from mailFrom
+func NewSubmitterNotifier(appRepo repositories.AppRepository, templateRepo repositories.NotificationTemplateRepository, mailer mail.Mailer, mailFrom string, cfg config.NotificationConfig) *SubmitterNotifier {
+	return &SubmitterNotifier{
+		appRepo:      appRepo,
+		templateRepo: templateRepo,
+		mailer:       mailer,
+		config:       cfg,
+		secret:       []byte(cfg.UnsubscribeSecret),
+		domain:       messageIDDomain(mailFrom),
+		queue:        make(chan submitterNotification, submitterNotificationQueueSize),
+	}
+}
+
+// Publish queues a status_changed notification for support_request.status_changed events and ignores
+// every other event
+func (n *SubmitterNotifier) Publish(event models.WebhookEventType, data interface{}) {
+	if event != models.WebhookEventSupportRequestStatusChanged {
+		return
+	}
+	change, ok := data.(*models.SupportRequestStatusChange)
+	if !ok || change.SupportRequest == nil {
+		return
+	}
+	// New requests are acknowledged by the API response, and spam is never answered
+	if change.SupportRequest.Status == models.StatusNew || change.SupportRequest.Status == models.StatusSpam {
+		return
+	}
+	// Closing a merged request isn't news to the submitter, the conversation continues on the other request
+	if change.SupportRequest.MergedIntoID != nil {
+		return
+	}
+
+	n.enqueue(submitterNotification{
+		event:          models.NotificationEventStatusChanged,
+		supportRequest: change.SupportRequest,
+		previousStatus: change.PreviousStatus,
+	})
+}
+
+// NotifyReply queues a reply notification for a public agent message
+func (n *SubmitterNotifier) NotifyReply(supportRequest *models.SupportRequest, message *models.SupportRequestMessage) {
+	n.enqueue(submitterNotification{
+		event:          models.NotificationEventReply,
+		supportRequest: supportRequest.ToResponse(),
+		reply:          message.Body,
+	})
+}
+
+// enqueue adds a notification to the queue unless notifications are disabled or the submitter can't
+// or doesn't want to be emailed
+func (n *SubmitterNotifier) enqueue(notification submitterNotification) {
+	request := notification.supportRequest
+	if !n.config.Enabled || request.UserEmail == nil || *request.UserEmail == "" || request.UnsubscribedAt != nil {
+		return
+	}
+
+	select {
+	case n.queue <- notification:
+	default:
+		log.Printf("Warning: Notification queue is full, dropping %s notification for support request %d", notification.event, request.ID)
+	}
+}
+
+// Run sends queued notifications until ctx is cancelled, then sends the ones still queued
+func (n *SubmitterNotifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			n.SendQueued()
+			return
+		case notification := <-n.queue:
+			n.send(notification)
+		}
+	}
+}
+
+// SendQueued sends every notification in the queue and returns how many were attempted
+func (n *SubmitterNotifier) SendQueued() int {
+	attempted := 0
+	for {
+		select {
+		case notification := <-n.queue:
+			n.send(notification)
+			attempted++
+		default:
+			return attempted
+		}
+	}
+}
+
+// send renders a notification with the template of its app and mails it
+func (n *SubmitterNotifier) send(notification submitterNotification) {
+	request := notification.supportRequest
+	msg, err := n.render(notification)
+	if err == nil {
+		err = n.mailer.Send(*msg)
+	}
+	if err != nil {
+		log.Printf("Warning: Failed to send %s notification for support request %d: %v", notification.event, request.ID, err)
+	}
+}
+
+// render builds the email of a notification
+func (n *SubmitterNotifier) render(notification submitterNotification) (*mail.Message, error) {
+	request := notification.supportRequest
+	appName := request.App
+	template := defaultNotificationTemplates[notification.event]
+
+	app, err := n.appRepo.GetBySlug(request.App)
+	switch {
+	case err == nil:
+		appName = app.DisplayName
+		custom, err := n.templateRepo.GetByAppAndEvent(app.ID, notification.event)
+		if err == nil {
+			template = notificationTemplate{Subject: custom.Subject, Body: custom.Body}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	unsubscribeURL := n.unsubscribeURL(request.ID)
+	subject, body, err := template.render(notificationData{
+		App:            appName,
+		RequestID:      request.ID,
+		Reference:      models.SupportRequestReference(request.ID),
+		Status:         request.Status,
+		StatusText:     notificationStatusTexts[request.Status],
+		PreviousStatus: notification.previousStatus,
+		Reply:          notification.reply,
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messageID, err := n.messageID(request.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &mail.Message{
+		To:      *request.UserEmail,
+		Subject: subject,
+		Body:    body,
+		Headers: map[string]string{
+			"Message-ID":            messageID,
+			"Auto-Submitted":        "auto-generated",
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
+
+// unsubscribeURL returns the link that stops notifications about a support request
+func (n *SubmitterNotifier) unsubscribeURL(id uint) string {
+	return strings.ReplaceAll(n.config.UnsubscribeURL, "{token}", url.PathEscape(unsubscribeToken(n.secret, id)))
+}
+
+// messageID returns a unique Message-ID naming the support request, so that replies to the
+// notification can be threaded back to it
+func (n *SubmitterNotifier) messageID(id uint) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<sr-%d.%s@%s>", id, hex.EncodeToString(b), n.domain), nil
+}
+
+// messageIDDomain returns the domain of the sender address, falling back to localhost
+func messageIDDomain(from string) string {
+	if address, err := netmail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(address.Address, "@"); at >= 0 && at < len(address.Address)-1 {
+			return address.Address[at+1:]
+		}
+	}
+	return "localhost"
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"strings"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/mail"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupSubmitterNotifier(cfg config.NotificationConfig) (*SubmitterNotifier, *MockAppRepository, *MockNotificationTemplateRepository, *mail.MemoryMailer) {
+	mockAppRepo := new(MockAppRepository)
+	mockTemplateRepo := new(MockNotificationTemplateRepository)
+	mailer := mail.NewMemoryMailer()
+	notifier := NewSubmitterNotifier(mockAppRepo, mockTemplateRepo, mailer, "Support <support@example.com>", cfg)
+	return notifier, mockAppRepo, mockTemplateRepo, mailer
+}
+
+func enabledNotificationConfig() config.NotificationConfig {
+	return config.NotificationConfig{
+		Enabled:           true,
+		UnsubscribeURL:    "https://support.example.com/api/v1/support-request/unsubscribe/{token}",
+		UnsubscribeSecret: testUnsubscribeSecret,
+	}
+}
+
+func statusChange(email *string, previous, status models.Status) *models.SupportRequestStatusChange {
+	return &models.SupportRequestStatusChange{
+		SupportRequest: &models.SupportRequestResponse{ID: 42, App: "acme-ios", UserEmail: email, Status: status},
+		PreviousStatus: previous,
+	}
+}
+
+func TestSubmitterNotifier_StatusChangedUsesDefaultTemplate(t *testing.T) {
+	// Arrange
+	notifier, mockAppRepo, mockTemplateRepo, mailer := setupSubmitterNotifier(enabledNotificationConfig())
+	mockAppRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", DisplayName: "Acme"}, nil)
+	mockTemplateRepo.On("GetByAppAndEvent", uint(1), models.NotificationEventStatusChanged).Return(nil, gorm.ErrRecordNotFound)
+	email := "jane@example.com"
+
+	// Act
+	notifier.Publish(models.WebhookEventSupportRequestStatusChanged, statusChange(&email, models.StatusInProgress, models.StatusResolved))
+	attempted := notifier.SendQueued()
+
+	// Assert
+	assert.Equal(t, 1, attempted)
+	messages := mailer.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "jane@example.com", messages[0].To)
+	assert.Equal(t, "[Acme] Your request SR-42 is resolved", messages[0].Subject)
+
+	unsubscribeURL := "https://support.example.com/api/v1/support-request/unsubscribe/" + unsubscribeToken([]byte(testUnsubscribeSecret), 42)
+	assert.Contains(t, messages[0].Body, unsubscribeURL)
+	assert.Equal(t, "<"+unsubscribeURL+">", messages[0].Headers["List-Unsubscribe"])
+	assert.Equal(t, "List-Unsubscribe=One-Click", messages[0].Headers["List-Unsubscribe-Post"])
+	assert.True(t, strings.HasPrefix(messages[0].Headers["Message-ID"], "<sr-42."))
+	assert.True(t, strings.HasSuffix(messages[0].Headers["Message-ID"], "@example.com>"))
+}
+
+func TestSubmitterNotifier_ReplyUsesAppTemplate(t *testing.T) {
+	// Arrange
+	notifier, mockAppRepo, mockTemplateRepo, mailer := setupSubmitterNotifier(enabledNotificationConfig())
+	mockAppRepo.On("GetBySlug", "acme-ios").Return(&models.App{ID: 1, Slug: "acme-ios", DisplayName: "Acme"}, nil)
+	mockTemplateRepo.On("GetByAppAndEvent", uint(1), models.NotificationEventReply).Return(&models.NotificationTemplate{
+		Subject: "Re: {{.Reference}}",
+		Body:    "{{.App}} support wrote:\n{{.Reply}}",
+	}, nil)
+	email := "jane@example.com"
+
+	// Act
+	notifier.NotifyReply(
+		&models.SupportRequest{ID: 42, App: "acme-ios", UserEmail: &email, Status: models.StatusInProgress},
+		&models.SupportRequestMessage{AuthorType: models.MessageAuthorAgent, Body: "Please update to 2.1", Visibility: models.MessageVisibilityPublic},
+	)
+	notifier.SendQueued()
+
+	// Assert
+	messages := mailer.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "Re: SR-42", messages[0].Subject)
+	assert.Equal(t, "Acme support wrote:\nPlease update to 2.1", messages[0].Body)
+}
+
+func TestSubmitterNotifier_UnknownAppUsesSlug(t *testing.T) {
+	// Arrange
+	notifier, mockAppRepo, _, mailer := setupSubmitterNotifier(enabledNotificationConfig())
+	mockAppRepo.On("GetBySlug", "acme-ios").Return(nil, gorm.ErrRecordNotFound)
+	email := "jane@example.com"
+
+	// Act
+	notifier.Publish(models.WebhookEventSupportRequestStatusChanged, statusChange(&email, models.StatusNew, models.StatusInProgress))
+	notifier.SendQueued()
+
+	// Assert
+	messages := mailer.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "[acme-ios] Your request SR-42 is being worked on", messages[0].Subject)
+}
+
+func TestSubmitterNotifier_SkipsNotifications(t *testing.T) {
+	email := "jane@example.com"
+	empty := ""
+	unsubscribed := statusChange(&email, models.StatusInProgress, models.StatusResolved)
+	unsubscribedAt := time.Now()
+	unsubscribed.SupportRequest.UnsubscribedAt = &unsubscribedAt
+	disabled := enabledNotificationConfig()
+	disabled.Enabled = false
+	merged := statusChange(&email, models.StatusNew, models.StatusClosed)
+	mergedInto := uint(41)
+	merged.SupportRequest.MergedIntoID = &mergedInto
+
+	tests := []struct {
+		name   string
+		config config.NotificationConfig
+		event  models.WebhookEventType
+		data   interface{}
+	}{
+		{"disabled", disabled, models.WebhookEventSupportRequestStatusChanged, statusChange(&email, models.StatusInProgress, models.StatusResolved)},
+		{"no email", enabledNotificationConfig(), models.WebhookEventSupportRequestStatusChanged, statusChange(nil, models.StatusInProgress, models.StatusResolved)},
+		{"empty email", enabledNotificationConfig(), models.WebhookEventSupportRequestStatusChanged, statusChange(&empty, models.StatusInProgress, models.StatusResolved)},
+		{"unsubscribed", enabledNotificationConfig(), models.WebhookEventSupportRequestStatusChanged, unsubscribed},
+		{"marked as spam", enabledNotificationConfig(), models.WebhookEventSupportRequestStatusChanged, statusChange(&email, models.StatusNew, models.StatusSpam)},
+		{"merged", enabledNotificationConfig(), models.WebhookEventSupportRequestStatusChanged, merged},
+		{"other event", enabledNotificationConfig(), models.WebhookEventSupportRequestUpdated, &models.SupportRequestResponse{ID: 42, UserEmail: &email}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			notifier, _, _, mailer := setupSubmitterNotifier(tt.config)
+
+			// Act
+			notifier.Publish(tt.event, tt.data)
+			attempted := notifier.SendQueued()
+
+			// Assert
+			assert.Equal(t, 0, attempted)
+			assert.Empty(t, mailer.Messages())
+		})
+	}
+}
+
+func TestSubmitterNotifier_DropsWhenQueueIsFull(t *testing.T) {
+	// Arrange
+	notifier, _, _, _ := setupSubmitterNotifier(enabledNotificationConfig())
+	email := "jane@example.com"
+
+	// Act
+	for i := 0; i < submitterNotificationQueueSize+1; i++ {
+		notifier.Publish(models.WebhookEventSupportRequestStatusChanged, statusChange(&email, models.StatusInProgress, models.StatusResolved))
+	}
+
+	// Assert
+	assert.Len(t, notifier.queue, submitterNotificationQueueSize)
+}
+
+func TestNewEventPublishers(t *testing.T) {
+	// Arrange
+	first := &recordingEventPublisher{}
+	second := &recordingEventPublisher{}
+	events := NewEventPublishers(first, second)
+
+	// Act
+	events.Publish(models.WebhookEventSupportRequestDeleted, &models.SupportRequestResponse{ID: 1})
+
+	// Assert
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestDeleted}, first.types())
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestDeleted}, second.types())
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxBulkSupportRequests bounds the number of support requests a single bulk action may select
+const maxBulkSupportRequests = 1000
+
+// SupportRequestSelection picks the support requests a bulk action applies to
+type SupportRequestSelection struct {
+	IDs    []uint                            // Support requests to act on, looked up within Filter.Scope
+	Filter repositories.SupportRequestFilter // Criteria used when IDs is empty; its Scope always applies
+}
+
+// SupportRequestBulkService defines the interface for changing many support requests at once
+type SupportRequestBulkService interface {
+	BulkUpdate(selection SupportRequestSelection, req *models.BulkUpdateSupportRequestsRequest, actor models.AuditActor) (*models.BulkSupportRequestsResponse, error)
+	BulkDelete(selection SupportRequestSelection, dryRun bool, actor models.AuditActor) (*models.BulkSupportRequestsResponse, error)
+}
+
+// supportRequestBulkService implements SupportRequestBulkService
+type supportRequestBulkService struct {
+	supportRepo repositories.SupportRequestRepository
+	tagRepo     repositories.TagRepository
+	userRepo    repositories.UserRepository
+	orgRepo     repositories.OrganizationRepository
+	slaPolicy   SLAPolicy
+	events      EventPublisher
+	now         func() time.Time
+}
+
+// NewSupportRequestBulkService creates a new bulk service that publishes lifecycle events to events
+func NewSupportRequestBulkService(supportRepo repositories.SupportRequestRepository, tagRepo repositories.TagRepository, userRepo repositories.UserRepository, orgRepo repositories.OrganizationRepository, slaPolicy SLAPolicy, events EventPublisher) SupportRequestBulkService {
+	return &supportRequestBulkService{
+		supportRepo: supportRepo,
+		tagRepo:     tagRepo,
+		userRepo:    userRepo,
+		orgRepo:     orgRepo,
+		slaPolicy:   slaPolicy,
+		events:      events,
+		now:         time.Now,
+	}
+}
+
+// bulkItem is a selected support request together with what the bulk action does to it
+type bulkItem struct {
+	result *models.BulkSupportRequestResult
+	before *models.SupportRequestResponse
+	after  *models.SupportRequestResponse
+	change *repositories.SupportRequestChange
+}
+
+// BulkUpdate applies the requested changes to every selected support request. Requests the change
+// is not allowed for, such as a status transition the workflow forbids, are reported as failed and
+// left alone; the others are written in a single transaction. A dry run reports the same outcomes
+// without writing anything.
+func (s *supportRequestBulkService) BulkUpdate(selection SupportRequestSelection, req *models.BulkUpdateSupportRequestsRequest, actor models.AuditActor) (*models.BulkSupportRequestsResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	if req.Status == nil && req.Priority == nil && req.AssigneeID == nil && !req.Unassign && len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+		return nil, fmt.Errorf("%w: no changes requested", ErrInvalidRequest)
+	}
+	if req.AssigneeID != nil && req.Unassign {
+		return nil, fmt.Errorf("%w: assignee_id and unassign cannot be combined", ErrInvalidRequest)
+	}
+	if req.Status != nil && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidRequest, *req.Status)
+	}
+	if req.Priority != nil && !req.Priority.IsValid() {
+		return nil, fmt.Errorf("%w: unknown priority %q", ErrInvalidRequest, *req.Priority)
+	}
+
+	for _, name := range req.AddTags {
+		for _, removed := range req.RemoveTags {
+			if models.NormalizeTagName(name) == models.NormalizeTagName(removed) {
+				return nil, fmt.Errorf("%w: tag %q is both added and removed", ErrInvalidRequest, models.NormalizeTagName(name))
+			}
+		}
+	}
+
+	addTags, err := s.existingTags(req.AddTags)
+	if err != nil {
+		return nil, err
+	}
+	removeTags, err := s.existingTags(req.RemoveTags)
+	if err != nil {
+		return nil, err
+	}
+
+	var assigneeOrganizations map[uint]bool
+	if req.AssigneeID != nil {
+		if assigneeOrganizations, err = s.assigneeOrganizations(*req.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	requests, missing, err := s.selectSupportRequests(selection)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*bulkItem, 0, len(requests))
+	for _, supportRequest := range requests {
+		item, err := s.updateItem(supportRequest, req, addTags, removeTags, assigneeOrganizations, actor)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return s.apply(items, missing, req.DryRun, func(item *bulkItem) {
+		publishSupportRequestUpdate(s.events, item.before, item.after)
+	})
+}
+
+// BulkDelete soft deletes every selected support request in a single transaction, or in a dry run
+// reports what would be deleted
+func (s *supportRequestBulkService) BulkDelete(selection SupportRequestSelection, dryRun bool, actor models.AuditActor) (*models.BulkSupportRequestsResponse, error) {
+	requests, missing, err := s.selectSupportRequests(selection)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*bulkItem, 0, len(requests))
+	for _, supportRequest := range requests {
+		before := supportRequest.ToResponse()
+		event, err := newAuditEvent(actor, models.AuditActionDelete, models.AuditEntitySupportRequest, supportRequest.ID, before, nil)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &bulkItem{
+			result: &models.BulkSupportRequestResult{ID: supportRequest.ID, Outcome: models.BulkOutcomeDeleted},
+			before: before,
+			change: &repositories.SupportRequestChange{Request: supportRequest, Delete: true, Event: event},
+		})
+	}
+
+	return s.apply(items, missing, dryRun, func(item *bulkItem) {
+		s.events.Publish(models.WebhookEventSupportRequestDeleted, item.before)
+	})
+}
+
+// updateItem works out the changes req makes to a support request without writing them
+func (s *supportRequestBulkService) updateItem(supportRequest *models.SupportRequest, req *models.BulkUpdateSupportRequestsRequest, addTags, removeTags []*models.Tag, assigneeOrganizations map[uint]bool, actor models.AuditActor) (*bulkItem, error) {
+	item := &bulkItem{
+		result: &models.BulkSupportRequestResult{ID: supportRequest.ID},
+		before: supportRequest.ToResponse(),
+	}
+
+	if req.Status != nil {
+		if err := transitionStatus(supportRequest, *req.Status, s.now()); err != nil {
+			item.result.Outcome = models.BulkOutcomeFailed
+			item.result.Error = err.Error()
+			return item, nil
+		}
+	}
+	if req.Priority != nil && *req.Priority != supportRequest.Priority {
+		supportRequest.Priority = *req.Priority
+		s.slaPolicy.ApplyDeadlines(supportRequest)
+	}
+	if req.AssigneeID != nil {
+		// Platform staff belong to no organization and may work every request
+		if len(assigneeOrganizations) > 0 && (supportRequest.OrganizationID == nil || !assigneeOrganizations[*supportRequest.OrganizationID]) {
+			item.result.Outcome = models.BulkOutcomeFailed
+			item.result.Error = ErrAssigneeNotMember.Error()
+			return item, nil
+		}
+		assigneeID := *req.AssigneeID
+		supportRequest.AssigneeID = &assigneeID
+	}
+	if req.Unassign {
+		supportRequest.AssigneeID = nil
+	}
+
+	change := &repositories.SupportRequestChange{Request: supportRequest}
+	change.AddTags, change.RemoveTags = tagChanges(supportRequest.Tags, addTags, removeTags)
+	supportRequest.Tags = applyTagChanges(supportRequest.Tags, change.AddTags, change.RemoveTags)
+
+	item.after = supportRequest.ToResponse()
+	changes, err := auditDiff(item.before, item.after)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		item.result.Outcome = models.BulkOutcomeUnchanged
+		return item, nil
+	}
+
+	change.Event, err = newAuditEvent(actor, models.AuditActionUpdate, models.AuditEntitySupportRequest, supportRequest.ID, item.before, item.after)
+	if err != nil {
+		return nil, err
+	}
+	item.change = change
+	item.result.Outcome = models.BulkOutcomeUpdated
+	item.result.Changes = changes
+	return item, nil
+}
+
+// apply writes the changes of items in one transaction unless dryRun is set, then publishes an
+// event per changed item and summarizes the outcomes. IDs that were not found are reported as
+// failed, after the items.
+func (s *supportRequestBulkService) apply(items []*bulkItem, missing []uint, dryRun bool, publish func(*bulkItem)) (*models.BulkSupportRequestsResponse, error) {
+	var changes []*repositories.SupportRequestChange
+	for _, item := range items {
+		if item.change != nil {
+			changes = append(changes, item.change)
+		}
+	}
+	if !dryRun && len(changes) > 0 {
+		if err := s.supportRepo.ApplyChanges(changes); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.change != nil {
+				publish(item)
+			}
+		}
+	}
+
+	response := &models.BulkSupportRequestsResponse{
+		DryRun:  dryRun,
+		Matched: len(items),
+		Results: make([]*models.BulkSupportRequestResult, 0, len(items)+len(missing)),
+	}
+	for _, item := range items {
+		response.Results = append(response.Results, item.result)
+	}
+	for _, id := range missing {
+		response.Results = append(response.Results, &models.BulkSupportRequestResult{
+			ID:      id,
+			Outcome: models.BulkOutcomeFailed,
+			Error:   ErrSupportRequestNotFound.Error(),
+		})
+	}
+	for _, result := range response.Results {
+		switch result.Outcome {
+		case models.BulkOutcomeUpdated, models.BulkOutcomeDeleted:
+			response.Changed++
+		case models.BulkOutcomeUnchanged:
+			response.Unchanged++
+		case models.BulkOutcomeFailed:
+			response.Failed++
+		}
+	}
+	return response, nil
+}
+
+// selectSupportRequests loads the selected support requests with their tags. For a selection by ID
+// it also returns the IDs that don't exist or are outside the scope.
+func (s *supportRequestBulkService) selectSupportRequests(selection SupportRequestSelection) ([]*models.SupportRequest, []uint, error) {
+	if len(selection.IDs) > 0 {
+		ids := uniqueIDs(selection.IDs)
+		if len(ids) > maxBulkSupportRequests {
+			return nil, nil, fmt.Errorf("%w: at most %d support requests can be changed at once", ErrInvalidRequest, maxBulkSupportRequests)
+		}
+
+		requests, err := s.supportRepo.GetByIDs(ids, selection.Filter.Scope)
+		if err != nil {
+			return nil, nil, err
+		}
+		found := make(map[uint]bool, len(requests))
+		for _, request := range requests {
+			found[request.ID] = true
+		}
+		var missing []uint
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		return requests, missing, nil
+	}
+
+	filter := selection.Filter
+	if isEmptySupportRequestFilter(filter) {
+		return nil, nil, fmt.Errorf("%w: select support requests with ids or a filter that narrows them down", ErrInvalidRequest)
+	}
+	if err := validateSupportRequestFilter(filter); err != nil {
+		return nil, nil, err
+	}
+	if filter.SLA != "" {
+		filter.SLAAsOf = s.now()
+		filter.SLADueSoon = s.slaPolicy.DueSoonWindow
+	}
+
+	requests, total, err := s.supportRepo.GetAll(filter, 0, maxBulkSupportRequests)
+	if err != nil {
+		return nil, nil, err
+	}
+	if total > maxBulkSupportRequests {
+		return nil, nil, fmt.Errorf("%w: the filter matches %d support requests, at most %d can be changed at once", ErrInvalidFilter, total, maxBulkSupportRequests)
+	}
+	return requests, nil, nil
+}
+
+// existingTags looks up tags by name, returning ErrTagNotFound naming any that don't exist
+func (s *supportRequestBulkService) existingTags(names []string) ([]*models.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized = append(normalized, models.NormalizeTagName(name))
+	}
+
+	tags, err := s.tagRepo.GetByNames(normalized)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingTagNames(normalized, tags); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTagNotFound, strings.Join(missing, ", "))
+	}
+	return tags, nil
+}
+
+// assigneeOrganizations checks that a user can be assigned and returns the organizations they
+// belong to, which is empty for platform staff
+func (s *supportRequestBulkService) assigneeOrganizations(userID uint) (map[uint]bool, error) {
+	assignee, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAssigneeNotFound
+		}
+		return nil, err
+	}
+	if !assignee.IsActive {
+		return nil, ErrAssigneeInactive
+	}
+
+	memberships, err := s.orgRepo.GetMembershipsByUserID(assignee.ID)
+	if err != nil {
+		return nil, err
+	}
+	organizations := make(map[uint]bool, len(memberships))
+	for _, membership := range memberships {
+		organizations[membership.OrganizationID] = true
+	}
+	return organizations, nil
+}
+
+// tagChanges returns the tags of add that current lacks and the tags of remove that current has
+func tagChanges(current, add, remove []*models.Tag) (added, removed []*models.Tag) {
+	has := make(map[string]bool, len(current))
+	for _, tag := range current {
+		has[tag.Name] = true
+	}
+	for _, tag := range add {
+		if !has[tag.Name] {
+			added = append(added, tag)
+			has[tag.Name] = true
+		}
+	}
+	for _, tag := range remove {
+		if has[tag.Name] {
+			removed = append(removed, tag)
+			has[tag.Name] = false
+		}
+	}
+	return added, removed
+}
+
+// applyTagChanges returns current with added appended and removed left out, ordered by name
+func applyTagChanges(current, added, removed []*models.Tag) []*models.Tag {
+	tags := make([]*models.Tag, 0, len(current)+len(added))
+	for _, tag := range current {
+		if !containsTag(removed, tag.Name) {
+			tags = append(tags, tag)
+		}
+	}
+	tags = append(tags, added...)
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags
+}
+
+// containsTag reports whether tags contains a tag named name
+func containsTag(tags []*models.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence of each
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// isEmptySupportRequestFilter reports whether filter would match every support request in its scope
+func isEmptySupportRequestFilter(filter repositories.SupportRequestFilter) bool {
+	filter.Scope = models.OrganizationScope{}
+	filter.SortBy = ""
+	filter.SortOrder = ""
+	filter.TagMatch = ""
+	return reflect.DeepEqual(filter, repositories.SupportRequestFilter{})
+}
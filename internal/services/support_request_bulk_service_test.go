@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkTestService creates a bulk service backed by fresh mocks in which nobody belongs to an organization
+func bulkTestService() (SupportRequestBulkService, *MockSupportRequestRepository, *MockTagRepository, *MockUserRepository, *recordingEventPublisher) {
+	mockSupportRepo := new(MockSupportRequestRepository)
+	mockTagRepo := new(MockTagRepository)
+	mockUserRepo := new(MockUserRepository)
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestBulkService(mockSupportRepo, mockTagRepo, mockUserRepo, platformStaffOrgRepo(), defaultSLAPolicy(), events)
+	return service, mockSupportRepo, mockTagRepo, mockUserRepo, events
+}
+
+func TestSupportRequestBulkService_BulkUpdate_ByIDs(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, mockTagRepo, _, events := bulkTestService()
+	spamTag := &models.Tag{ID: 7, Name: "spam"}
+	requests := []*models.SupportRequest{
+		{ID: 1, Status: models.StatusNew},
+		{ID: 2, Status: models.StatusSpam, Tags: []*models.Tag{spamTag}},
+		{ID: 3, Status: models.StatusClosed},
+	}
+	spam := models.StatusSpam
+	mockTagRepo.On("GetByNames", []string{"spam"}).Return([]*models.Tag{spamTag}, nil)
+	mockSupportRepo.On("GetByIDs", []uint{1, 2, 3, 4}, models.OrganizationScope{}).Return(requests, nil)
+
+	var applied []*repositories.SupportRequestChange
+	mockSupportRepo.On("ApplyChanges", mock.Anything).Run(func(args mock.Arguments) {
+		applied = args.Get(0).([]*repositories.SupportRequestChange)
+	}).Return(nil)
+
+	// Act
+	response, err := service.BulkUpdate(SupportRequestSelection{IDs: []uint{1, 2, 3, 4, 1}}, &models.BulkUpdateSupportRequestsRequest{
+		Status:  &spam,
+		AddTags: []string{"Spam"},
+	}, models.AuditActor{UserID: 9, Username: "admin"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, response.DryRun)
+	assert.Equal(t, 3, response.Matched)
+	assert.Equal(t, 1, response.Changed)
+	assert.Equal(t, 1, response.Unchanged)
+	assert.Equal(t, 2, response.Failed)
+
+	require.Len(t, response.Results, 4)
+	assert.Equal(t, models.BulkOutcomeUpdated, response.Results[0].Outcome)
+	assert.Equal(t, models.AuditChange{Before: "new", After: "spam"}, response.Results[0].Changes["status"])
+	assert.Equal(t, models.BulkOutcomeUnchanged, response.Results[1].Outcome)
+	assert.Equal(t, models.BulkOutcomeFailed, response.Results[2].Outcome)
+	assert.Equal(t, "cannot change status from closed to spam", response.Results[2].Error)
+	assert.Equal(t, uint(4), response.Results[3].ID)
+	assert.Equal(t, ErrSupportRequestNotFound.Error(), response.Results[3].Error)
+
+	require.Len(t, applied, 1)
+	assert.Equal(t, uint(1), applied[0].Request.ID)
+	assert.Equal(t, []*models.Tag{spamTag}, applied[0].AddTags)
+	require.NotNil(t, applied[0].Event)
+	assert.Equal(t, models.AuditActionUpdate, applied[0].Event.Action)
+
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestUpdated, models.WebhookEventSupportRequestStatusChanged}, events.types())
+	mockSupportRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestBulkService_BulkUpdate_DryRun(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, _, _, events := bulkTestService()
+	low := models.PriorityLow
+	filter := repositories.SupportRequestFilter{App: "test-app"}
+	mockSupportRepo.On("GetAll", filter, 0, maxBulkSupportRequests).Return([]*models.SupportRequest{{ID: 1, Priority: models.PriorityNormal}}, int64(1), nil)
+
+	// Act
+	response, err := service.BulkUpdate(SupportRequestSelection{Filter: filter}, &models.BulkUpdateSupportRequestsRequest{
+		Priority: &low,
+		DryRun:   true,
+	}, models.AuditActor{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 1, response.Changed)
+	assert.Equal(t, models.AuditChange{Before: "normal", After: "low"}, response.Results[0].Changes["priority"])
+	mockSupportRepo.AssertNotCalled(t, "ApplyChanges", mock.Anything)
+	assert.Empty(t, events.events)
+}
+
+func TestSupportRequestBulkService_BulkUpdate_TagsAndAssignee(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, mockTagRepo, mockUserRepo, _ := bulkTestService()
+	triage := &models.Tag{ID: 1, Name: "needs-triage"}
+	mockTagRepo.On("GetByNames", []string{"needs-triage"}).Return([]*models.Tag{triage}, nil)
+	mockUserRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2, IsActive: true}, nil)
+	mockSupportRepo.On("GetByIDs", []uint{1}, models.OrganizationScope{}).Return([]*models.SupportRequest{{ID: 1, Status: models.StatusNew, Tags: []*models.Tag{triage}}}, nil)
+	mockSupportRepo.On("ApplyChanges", mock.MatchedBy(func(changes []*repositories.SupportRequestChange) bool {
+		return len(changes) == 1 && len(changes[0].RemoveTags) == 1 && *changes[0].Request.AssigneeID == 2
+	})).Return(nil)
+	assigneeID := uint(2)
+
+	// Act
+	response, err := service.BulkUpdate(SupportRequestSelection{IDs: []uint{1}}, &models.BulkUpdateSupportRequestsRequest{
+		AssigneeID: &assigneeID,
+		RemoveTags: []string{"needs-triage"},
+	}, models.AuditActor{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Changed)
+	changes := response.Results[0].Changes
+	assert.Equal(t, []interface{}{"needs-triage"}, changes["tags"].Before)
+	assert.Nil(t, changes["tags"].After)
+	assert.Equal(t, float64(2), changes["assignee_id"].After)
+	mockSupportRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestBulkService_BulkUpdate_AssigneeOutsideOrganization(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockOrgRepo := new(MockOrganizationRepository)
+	service := NewSupportRequestBulkService(mockSupportRepo, new(MockTagRepository), mockUserRepo, mockOrgRepo, defaultSLAPolicy(), &recordingEventPublisher{})
+
+	organizationID := uint(1)
+	otherOrganizationID := uint(2)
+	mockUserRepo.On("GetByID", uint(5)).Return(&models.User{ID: 5, IsActive: true}, nil)
+	mockOrgRepo.On("GetMembershipsByUserID", uint(5)).Return([]*models.OrganizationMember{{OrganizationID: organizationID, UserID: 5}}, nil)
+	mockSupportRepo.On("GetByIDs", []uint{1, 2}, models.OrganizationScope{}).Return([]*models.SupportRequest{
+		{ID: 1, Status: models.StatusNew, OrganizationID: &organizationID},
+		{ID: 2, Status: models.StatusNew, OrganizationID: &otherOrganizationID},
+	}, nil)
+	mockSupportRepo.On("ApplyChanges", mock.MatchedBy(func(changes []*repositories.SupportRequestChange) bool {
+		return len(changes) == 1 && changes[0].Request.ID == 1
+	})).Return(nil)
+	assigneeID := uint(5)
+
+	// Act
+	response, err := service.BulkUpdate(SupportRequestSelection{IDs: []uint{1, 2}}, &models.BulkUpdateSupportRequestsRequest{AssigneeID: &assigneeID}, models.AuditActor{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.BulkOutcomeUpdated, response.Results[0].Outcome)
+	assert.Equal(t, models.BulkOutcomeFailed, response.Results[1].Outcome)
+	assert.Equal(t, ErrAssigneeNotMember.Error(), response.Results[1].Error)
+	mockSupportRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestBulkService_BulkUpdate_InvalidRequest(t *testing.T) {
+	spam := models.StatusSpam
+	unknown := models.Status("done")
+	assigneeID := uint(2)
+	tooMany := make([]uint, maxBulkSupportRequests+1)
+	for i := range tooMany {
+		tooMany[i] = uint(i + 1)
+	}
+
+	tests := []struct {
+		name      string
+		selection SupportRequestSelection
+		req       *models.BulkUpdateSupportRequestsRequest
+		err       error
+	}{
+		{"no changes", SupportRequestSelection{IDs: []uint{1}}, &models.BulkUpdateSupportRequestsRequest{}, ErrInvalidRequest},
+		{"unknown status", SupportRequestSelection{IDs: []uint{1}}, &models.BulkUpdateSupportRequestsRequest{Status: &unknown}, ErrInvalidRequest},
+		{"assign and unassign", SupportRequestSelection{IDs: []uint{1}}, &models.BulkUpdateSupportRequestsRequest{AssigneeID: &assigneeID, Unassign: true}, ErrInvalidRequest},
+		{"add and remove the same tag", SupportRequestSelection{IDs: []uint{1}}, &models.BulkUpdateSupportRequestsRequest{AddTags: []string{"spam"}, RemoveTags: []string{"Spam"}}, ErrInvalidRequest},
+		{"empty selection", SupportRequestSelection{Filter: repositories.SupportRequestFilter{SortBy: "id"}}, &models.BulkUpdateSupportRequestsRequest{Status: &spam}, ErrInvalidRequest},
+		{"invalid filter", SupportRequestSelection{Filter: repositories.SupportRequestFilter{Statuses: []models.Status{"done"}}}, &models.BulkUpdateSupportRequestsRequest{Status: &spam}, ErrInvalidFilter},
+		{"too many IDs", SupportRequestSelection{IDs: tooMany}, &models.BulkUpdateSupportRequestsRequest{Status: &spam}, ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, mockSupportRepo, _, _, _ := bulkTestService()
+
+			// Act
+			response, err := service.BulkUpdate(tt.selection, tt.req, models.AuditActor{})
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, response)
+			mockSupportRepo.AssertNotCalled(t, "ApplyChanges", mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestBulkService_BulkUpdate_UnknownTag(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, mockTagRepo, _, _ := bulkTestService()
+	mockTagRepo.On("GetByNames", []string{"spam"}).Return([]*models.Tag{}, nil)
+
+	// Act
+	response, err := service.BulkUpdate(SupportRequestSelection{IDs: []uint{1}}, &models.BulkUpdateSupportRequestsRequest{AddTags: []string{"spam"}}, models.AuditActor{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTagNotFound)
+	assert.Nil(t, response)
+	mockSupportRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+}
+
+func TestSupportRequestBulkService_BulkUpdate_FilterMatchesTooMany(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, _, _, _ := bulkTestService()
+	spam := models.StatusSpam
+	filter := repositories.SupportRequestFilter{App: "test-app"}
+	mockSupportRepo.On("GetAll", filter, 0, maxBulkSupportRequests).Return([]*models.SupportRequest{}, int64(maxBulkSupportRequests+1), nil)
+
+	// Act
+	response, err := service.BulkUpdate(SupportRequestSelection{Filter: filter}, &models.BulkUpdateSupportRequestsRequest{Status: &spam}, models.AuditActor{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+	assert.Nil(t, response)
+}
+
+func TestSupportRequestBulkService_BulkDelete(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, _, _, events := bulkTestService()
+	scope := models.ScopeToOrganization(1)
+	mockSupportRepo.On("GetByIDs", []uint{1, 2}, scope).Return([]*models.SupportRequest{{ID: 1}, {ID: 2}}, nil)
+	mockSupportRepo.On("ApplyChanges", mock.MatchedBy(func(changes []*repositories.SupportRequestChange) bool {
+		return len(changes) == 2 && changes[0].Delete && changes[1].Delete && changes[1].Event.Action == models.AuditActionDelete
+	})).Return(nil)
+
+	// Act
+	response, err := service.BulkDelete(SupportRequestSelection{IDs: []uint{1, 2}, Filter: repositories.SupportRequestFilter{Scope: scope}}, false, models.AuditActor{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.Changed)
+	assert.Equal(t, models.BulkOutcomeDeleted, response.Results[1].Outcome)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestDeleted, models.WebhookEventSupportRequestDeleted}, events.types())
+	mockSupportRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestBulkService_BulkDelete_RepositoryError(t *testing.T) {
+	// Arrange
+	service, mockSupportRepo, _, _, events := bulkTestService()
+	mockSupportRepo.On("GetByIDs", []uint{1}, models.OrganizationScope{}).Return([]*models.SupportRequest{{ID: 1}}, nil)
+	mockSupportRepo.On("ApplyChanges", mock.Anything).Return(errors.New("database error"))
+
+	// Act
+	response, err := service.BulkDelete(SupportRequestSelection{IDs: []uint{1}}, false, models.AuditActor{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Empty(t, events.events)
+}
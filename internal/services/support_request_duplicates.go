@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+)
+
+// flagDuplicate points a new support request at a recent request from the same submitter, app and
+// device with a near-identical message, if there is one
+func (s *supportRequestService) flagDuplicate(supportRequest *models.SupportRequest) error {
+	if !s.duplicates.Enabled() || supportRequest.UserEmail == nil || *supportRequest.UserEmail == "" {
+		return nil
+	}
+
+	candidates, err := s.repo.FindDuplicateCandidates(supportRequest, supportRequest.CreatedAt.Add(-s.duplicates.Window))
+	if err != nil {
+		return err
+	}
+	supportRequest.DuplicateOfID = s.duplicates.FindDuplicate(supportRequest, candidates)
+	return nil
+}
+
+// MergeSupportRequest folds a support request into another: its original message, replies,
+// attachments and tags move to the target, and it is closed and left as a stub pointing at the
+// target. Replies become internal when the requests have different submitters. Returns the target.
+func (s *supportRequestService) MergeSupportRequest(id, targetID uint, scope models.OrganizationScope, actor models.AuditActor) (*models.SupportRequestResponse, error) {
+	if id == targetID {
+		return nil, fmt.Errorf("%w: a support request cannot be merged into itself", ErrInvalidRequest)
+	}
+
+	source, err := s.repo.GetByID(id, scope)
+	if err != nil {
+		return nil, ErrSupportRequestNotFound
+	}
+	target, err := s.repo.GetByID(targetID, scope)
+	if err != nil {
+		return nil, ErrSupportRequestNotFound
+	}
+	if source.IsMerged() || target.IsMerged() {
+		return nil, ErrSupportRequestMerged
+	}
+	if !sameOrganization(source.OrganizationID, target.OrganizationID) {
+		return nil, fmt.Errorf("%w: the support requests belong to different organizations", ErrInvalidRequest)
+	}
+	if target.Status == models.StatusSpam {
+		return nil, fmt.Errorf("%w: cannot merge into a support request marked as spam", ErrInvalidRequest)
+	}
+
+	sourceBefore := source.ToResponse()
+	targetBefore := target.ToResponse()
+
+	if err := transitionStatus(source, models.StatusClosed, s.now()); err != nil {
+		return nil, err
+	}
+	source.MergedIntoID = &target.ID
+	if target.DuplicateOfID != nil && *target.DuplicateOfID == source.ID {
+		// The target takes the place of the request it was flagged as a duplicate of
+		target.DuplicateOfID = nil
+	}
+
+	added, _ := tagChanges(target.Tags, source.Tags, nil)
+	target.Tags = applyTagChanges(target.Tags, added, nil)
+	source.Tags = nil
+
+	sameSubmitter := sameEmail(source.UserEmail, target.UserEmail)
+	visibility := models.MessageVisibilityPublic
+	if !sameSubmitter {
+		visibility = models.MessageVisibilityInternal
+	}
+	message := &models.SupportRequestMessage{
+		SupportRequestID: target.ID,
+		AuthorType:       models.MessageAuthorSubmitter,
+		Body:             source.Message,
+		Visibility:       visibility,
+		CreatedAt:        source.CreatedAt,
+	}
+
+	sourceAfter := source.ToResponse()
+	targetAfter := target.ToResponse()
+	sourceEvent, err := newAuditEvent(actor, models.AuditActionMerge, models.AuditEntitySupportRequest, source.ID, sourceBefore, sourceAfter)
+	if err != nil {
+		return nil, err
+	}
+	targetEvent, err := newAuditEvent(actor, models.AuditActionMerge, models.AuditEntitySupportRequest, target.ID, targetBefore, targetAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Merge(&repositories.SupportRequestMerge{
+		Source:           source,
+		Target:           target,
+		Message:          message,
+		InternalMessages: !sameSubmitter,
+		Events:           []*models.AuditEvent{sourceEvent, targetEvent},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishSupportRequestUpdate(s.events, sourceBefore, sourceAfter)
+	publishSupportRequestUpdate(s.events, targetBefore, targetAfter)
+	return targetAfter, nil
+}
+
+// sameOrganization reports whether two organization IDs are both unset or equal
+func sameOrganization(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameEmail reports whether two optional email addresses are set and equal, ignoring case
+func sameEmail(a, b *string) bool {
+	return a != nil && b != nil && *a != "" && strings.EqualFold(*a, *b)
+}
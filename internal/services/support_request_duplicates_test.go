@@ -0,0 +1,213 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSupportRequestService_CreateSupportRequest_FlagsDuplicate(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{Window: time.Hour, Similarity: 0.8}, &recordingEventPublisher{}).(*supportRequestService)
+	service.now = func() time.Time { return now }
+
+	userEmail := "jane@example.com"
+	request := &models.CreateSupportRequestRequest{
+		Type:        models.SupportRequestTypeBugReport,
+		UserEmail:   &userEmail,
+		Message:     "The app crashes on launch!",
+		Platform:    models.PlatformIOS,
+		AppVersion:  "1.0.0",
+		DeviceModel: "iPhone 13",
+		App:         "test-app",
+	}
+	candidates := []*models.SupportRequest{
+		{ID: 7, Message: "How do I change my password?"},
+		{ID: 5, Message: "the app crashes on launch"},
+	}
+	mockRepo.On("FindDuplicateCandidates", mock.AnythingOfType("*models.SupportRequest"), now.Add(-time.Hour)).Return(candidates, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(nil)
+
+	// Act
+	response, err := service.CreateSupportRequest(request, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, response.DuplicateOfID) {
+		assert.Equal(t, uint(5), *response.DuplicateOfID)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_CreateSupportRequest_SkipsDuplicateCheck(t *testing.T) {
+	userEmail := "jane@example.com"
+	tests := []struct {
+		name      string
+		policy    DuplicatePolicy
+		userEmail *string
+	}{
+		{"disabled", DuplicatePolicy{}, &userEmail},
+		{"without email", DuplicatePolicy{Window: time.Hour, Similarity: 0.8}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSupportRequestRepository)
+			service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), tt.policy, &recordingEventPublisher{})
+			mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(nil)
+
+			// Act
+			response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, UserEmail: tt.userEmail, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Nil(t, response.DuplicateOfID)
+			mockRepo.AssertNotCalled(t, "FindDuplicateCandidates", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestService_MergeSupportRequest(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	events := &recordingEventPublisher{}
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, events).(*supportRequestService)
+	service.now = func() time.Time { return now }
+
+	sourceEmail := "Jane@Example.com"
+	targetEmail := "jane@example.com"
+	createdAt := now.Add(-time.Hour)
+	targetID := uint(5)
+	source := &models.SupportRequest{ID: 9, UserEmail: &sourceEmail, Message: "The app crashes on launch!", Status: models.StatusNew, DuplicateOfID: &targetID, CreatedAt: createdAt, Tags: []*models.Tag{{ID: 1, Name: "crash"}, {ID: 2, Name: "ios"}}}
+	target := &models.SupportRequest{ID: 5, UserEmail: &targetEmail, Message: "The app crashes on launch", Status: models.StatusInProgress, Tags: []*models.Tag{{ID: 2, Name: "ios"}}}
+	mockRepo.On("GetByID", uint(9), models.OrganizationScope{}).Return(source, nil)
+	mockRepo.On("GetByID", uint(5), models.OrganizationScope{}).Return(target, nil)
+
+	var merge *repositories.SupportRequestMerge
+	mockRepo.On("Merge", mock.AnythingOfType("*repositories.SupportRequestMerge")).Return(nil).Run(func(args mock.Arguments) {
+		merge = args.Get(0).(*repositories.SupportRequestMerge)
+	})
+
+	// Act
+	response, err := service.MergeSupportRequest(9, 5, models.OrganizationScope{}, testActor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), response.ID)
+	assert.Equal(t, []string{"crash", "ios"}, response.Tags)
+
+	assert.Equal(t, models.StatusClosed, merge.Source.Status)
+	assert.Equal(t, now, *merge.Source.ClosedAt)
+	assert.Equal(t, uint(5), *merge.Source.MergedIntoID)
+	assert.Empty(t, merge.Source.Tags)
+	assert.False(t, merge.InternalMessages)
+	assert.Equal(t, &models.SupportRequestMessage{SupportRequestID: 5, AuthorType: models.MessageAuthorSubmitter, Body: "The app crashes on launch!", Visibility: models.MessageVisibilityPublic, CreatedAt: createdAt}, merge.Message)
+	if assert.Len(t, merge.Events, 2) {
+		assert.Equal(t, models.AuditActionMerge, merge.Events[0].Action)
+		assert.Equal(t, uint(9), merge.Events[0].EntityID)
+		assert.Contains(t, merge.Events[0].Changes, `"merged_into_id":{"before":null,"after":5}`)
+		assert.Equal(t, uint(5), merge.Events[1].EntityID)
+		assert.Contains(t, merge.Events[1].Changes, `"tags"`)
+	}
+	assert.Equal(t, []models.WebhookEventType{
+		models.WebhookEventSupportRequestUpdated,
+		models.WebhookEventSupportRequestStatusChanged,
+		models.WebhookEventSupportRequestUpdated,
+	}, events.types())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_MergeSupportRequest_OtherSubmitter(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	otherEmail := "john@example.com"
+	mockRepo.On("GetByID", uint(9), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 9, UserEmail: &otherEmail, Message: "Crash", Status: models.StatusNew}, nil)
+	mockRepo.On("GetByID", uint(5), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 5, Message: "Crash", Status: models.StatusNew}, nil)
+	mockRepo.On("Merge", mock.MatchedBy(func(merge *repositories.SupportRequestMerge) bool {
+		return merge.InternalMessages && merge.Message.Visibility == models.MessageVisibilityInternal
+	})).Return(nil)
+
+	// Act
+	_, err := service.MergeSupportRequest(9, 5, models.OrganizationScope{}, testActor)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_MergeSupportRequest_Errors(t *testing.T) {
+	organizationID := uint(1)
+	otherOrganizationID := uint(2)
+	mergedInto := uint(3)
+
+	tests := []struct {
+		name        string
+		source      *models.SupportRequest
+		target      *models.SupportRequest
+		targetID    uint
+		expectedErr error
+	}{
+		{"itself", &models.SupportRequest{ID: 9}, nil, 9, ErrInvalidRequest},
+		{"source not found", nil, &models.SupportRequest{ID: 5}, 5, ErrSupportRequestNotFound},
+		{"target not found", &models.SupportRequest{ID: 9, Status: models.StatusNew}, nil, 5, ErrSupportRequestNotFound},
+		{"source merged", &models.SupportRequest{ID: 9, Status: models.StatusClosed, MergedIntoID: &mergedInto}, &models.SupportRequest{ID: 5}, 5, ErrSupportRequestMerged},
+		{"target merged", &models.SupportRequest{ID: 9, Status: models.StatusNew}, &models.SupportRequest{ID: 5, MergedIntoID: &mergedInto}, 5, ErrSupportRequestMerged},
+		{"other organization", &models.SupportRequest{ID: 9, Status: models.StatusNew, OrganizationID: &organizationID}, &models.SupportRequest{ID: 5, OrganizationID: &otherOrganizationID}, 5, ErrInvalidRequest},
+		{"target spam", &models.SupportRequest{ID: 9, Status: models.StatusNew}, &models.SupportRequest{ID: 5, Status: models.StatusSpam}, 5, ErrInvalidRequest},
+		{"source spam", &models.SupportRequest{ID: 9, Status: models.StatusSpam}, &models.SupportRequest{ID: 5, Status: models.StatusNew}, 5, ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSupportRequestRepository)
+			service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+			if tt.source != nil {
+				mockRepo.On("GetByID", uint(9), models.OrganizationScope{}).Return(tt.source, nil).Maybe()
+			} else {
+				mockRepo.On("GetByID", uint(9), models.OrganizationScope{}).Return(nil, errors.New("record not found")).Maybe()
+			}
+			if tt.target != nil {
+				mockRepo.On("GetByID", uint(5), models.OrganizationScope{}).Return(tt.target, nil).Maybe()
+			} else {
+				mockRepo.On("GetByID", uint(5), models.OrganizationScope{}).Return(nil, errors.New("record not found")).Maybe()
+			}
+
+			// Act
+			response, err := service.MergeSupportRequest(9, tt.targetID, models.OrganizationScope{}, testActor)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, response)
+			mockRepo.AssertNotCalled(t, "Merge", mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestService_UpdateSupportRequest_Merged(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	mergedInto := uint(5)
+	mockRepo.On("GetByID", uint(9), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 9, Status: models.StatusClosed, MergedIntoID: &mergedInto}, nil)
+	status := models.StatusReopened
+
+	// Act
+	response, err := service.UpdateSupportRequest(9, models.OrganizationScope{}, &models.UpdateSupportRequestRequest{Status: &status}, testActor)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrSupportRequestMerged)
+	assert.Nil(t, response)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
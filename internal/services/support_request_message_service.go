@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SupportRequestMessageService defines the interface for support request conversation business logic
+type SupportRequestMessageService interface {
+	ListMessages(supportRequestID uint, scope models.OrganizationScope, includeInternal bool) ([]*models.SupportRequestMessageResponse, error)
+	AddAgentReply(supportRequestID uint, scope models.OrganizationScope, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error)
+	AddSubmitterReply(supportRequestID uint, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error)
+}
+
+// supportRequestMessageService implements SupportRequestMessageService
+type supportRequestMessageService struct {
+	messageRepo repositories.SupportRequestMessageRepository
+	supportRepo repositories.SupportRequestRepository
+	replies     ReplyNotifier
+	events      EventPublisher
+	now         func() time.Time
+}
+
+// NewSupportRequestMessageService creates a new support request message service
+func NewSupportRequestMessageService(messageRepo repositories.SupportRequestMessageRepository, supportRepo repositories.SupportRequestRepository, replies ReplyNotifier, events EventPublisher) SupportRequestMessageService {
+	return &supportRequestMessageService{
+		messageRepo: messageRepo,
+		supportRepo: supportRepo,
+		replies:     replies,
+		events:      events,
+		now:         time.Now,
+	}
+}
+
+// ListMessages retrieves the conversation of a support request, optionally including internal notes
+func (s *supportRequestMessageService) ListMessages(supportRequestID uint, scope models.OrganizationScope, includeInternal bool) ([]*models.SupportRequestMessageResponse, error) {
+	if _, err := s.getSupportRequest(supportRequestID, scope); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageRepo.GetBySupportRequestID(supportRequestID, includeInternal)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.SupportRequestMessageResponse, len(messages))
+	for i, message := range messages {
+		responses[i] = message.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// AddAgentReply posts a reply or internal note written by the support agent acting
+func (s *supportRequestMessageService) AddAgentReply(supportRequestID uint, scope models.OrganizationScope, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	visibility := models.MessageVisibilityPublic
+	if req.Visibility != nil {
+		visibility = *req.Visibility
+	}
+
+	agentUserID := actor.UserID
+	message := &models.SupportRequestMessage{
+		SupportRequestID: supportRequestID,
+		AuthorType:       models.MessageAuthorAgent,
+		AuthorUserID:     &agentUserID,
+		Body:             req.Body,
+		Visibility:       visibility,
+	}
+
+	return s.addMessage(message, scope, actor)
+}
+
+// AddSubmitterReply posts a reply written by the person who submitted the support request.
+// Submitter replies are always public, and submitters aren't bound to an organization. Without a
+// username, the actor is recorded in the audit log under the submitter's email address.
+func (s *supportRequestMessageService) AddSubmitterReply(supportRequestID uint, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+	if req.Visibility != nil && *req.Visibility != models.MessageVisibilityPublic {
+		return nil, ErrInvalidRequest
+	}
+
+	message := &models.SupportRequestMessage{
+		SupportRequestID: supportRequestID,
+		AuthorType:       models.MessageAuthorSubmitter,
+		Body:             req.Body,
+		Visibility:       models.MessageVisibilityPublic,
+	}
+
+	return s.addMessage(message, models.OrganizationScope{}, actor)
+}
+
+// addMessage stores the message and keeps the parent support request's status and UpdatedAt in sync,
+// auditing changes to the request in the same transaction and publishing them once saved. Public agent
+// replies are passed on to the reply notifier. Merged requests take no new messages.
+func (s *supportRequestMessageService) addMessage(message *models.SupportRequestMessage, scope models.OrganizationScope, actor models.AuditActor) (*models.SupportRequestMessageResponse, error) {
+	supportRequest, err := s.getSupportRequest(message.SupportRequestID, scope)
+	if err != nil {
+		return nil, err
+	}
+	if supportRequest.IsMerged() {
+		return nil, ErrSupportRequestMerged
+	}
+	before := supportRequest.ToResponse()
+
+	// statusAfterMessage only picks transitions the workflow allows
+	now := s.now()
+	if err := transitionStatus(supportRequest, statusAfterMessage(supportRequest.Status, message), now); err != nil {
+		return nil, err
+	}
+
+	// The first public agent reply meets the first response SLA
+	if supportRequest.FirstRespondedAt == nil && message.AuthorType == models.MessageAuthorAgent && !message.IsInternal() {
+		supportRequest.FirstRespondedAt = &now
+	}
+
+	// Most messages only bump UpdatedAt, which isn't worth an audit event
+	var events []*models.AuditEvent
+	after := supportRequest.ToResponse()
+	changed := after.Status != before.Status || (before.FirstRespondedAt == nil && after.FirstRespondedAt != nil)
+	if changed {
+		if message.AuthorType == models.MessageAuthorSubmitter && actor.Username == "" && supportRequest.UserEmail != nil {
+			actor.Username = *supportRequest.UserEmail
+		}
+		event, err := newAuditEvent(actor, models.AuditActionUpdate, models.AuditEntitySupportRequest, supportRequest.ID, before, after)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := s.messageRepo.Create(message, supportRequest, events...); err != nil {
+		return nil, err
+	}
+
+	if changed {
+		publishSupportRequestUpdate(s.events, before, supportRequest.ToResponse())
+	}
+
+	if message.AuthorType == models.MessageAuthorAgent && !message.IsInternal() {
+		s.replies.NotifyReply(supportRequest, message)
+	}
+
+	return message.ToResponse(), nil
+}
+
+// getSupportRequest loads a support request within scope, mapping missing records to ErrSupportRequestNotFound
+func (s *supportRequestMessageService) getSupportRequest(id uint, scope models.OrganizationScope) (*models.SupportRequest, error) {
+	supportRequest, err := s.supportRepo.GetByID(id, scope)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSupportRequestNotFound
+		}
+		return nil, err
+	}
+	return supportRequest, nil
+}
+
+// statusAfterMessage returns the status a support request moves to when a message is posted:
+// a public agent reply picks up a new or reopened request, and a submitter reply resumes work on a
+// request waiting on them or reopens a resolved one. Closed requests stay closed. Internal notes
+// never change the status.
+func statusAfterMessage(current models.Status, message *models.SupportRequestMessage) models.Status {
+	if message.IsInternal() {
+		return current
+	}
+
+	switch message.AuthorType {
+	case models.MessageAuthorAgent:
+		if current == models.StatusNew || current == models.StatusReopened {
+			return models.StatusInProgress
+		}
+	case models.MessageAuthorSubmitter:
+		switch current {
+		case models.StatusWaitingOnCustomer:
+			return models.StatusInProgress
+		case models.StatusResolved:
+			return models.StatusReopened
+		}
+	}
+
+	return current
+}
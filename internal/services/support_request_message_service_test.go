@@ -0,0 +1,254 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockSupportRequestMessageRepository is a mock implementation of SupportRequestMessageRepository
+type MockSupportRequestMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockSupportRequestMessageRepository) Create(message *models.SupportRequestMessage, supportRequest *models.SupportRequest, events ...*models.AuditEvent) error {
+	args := m.Called(message, supportRequest, events)
+	return args.Error(0)
+}
+
+func (m *MockSupportRequestMessageRepository) GetBySupportRequestID(supportRequestID uint, includeInternal bool) ([]*models.SupportRequestMessage, error) {
+	args := m.Called(supportRequestID, includeInternal)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SupportRequestMessage), args.Error(1)
+}
+
+func TestSupportRequestMessageService_ListMessages(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, &recordingReplyNotifier{}, &recordingEventPublisher{})
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+	mockMessageRepo.On("GetBySupportRequestID", uint(1), false).Return([]*models.SupportRequestMessage{
+		{ID: 1, SupportRequestID: 1, AuthorType: models.MessageAuthorSubmitter, Body: "Hello"},
+	}, nil)
+
+	// Act
+	responses, err := service.ListMessages(1, models.OrganizationScope{}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, "Hello", responses[0].Body)
+	mockSupportRepo.AssertExpectations(t)
+	mockMessageRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestMessageService_ListMessages_NotFound(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, &recordingReplyNotifier{}, &recordingEventPublisher{})
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	responses, err := service.ListMessages(1, models.OrganizationScope{}, true)
+
+	// Assert
+	assert.Equal(t, ErrSupportRequestNotFound, err)
+	assert.Nil(t, responses)
+	mockMessageRepo.AssertNotCalled(t, "GetBySupportRequestID")
+}
+
+// recordingReplyNotifier is a ReplyNotifier that records the messages it is told about
+type recordingReplyNotifier struct {
+	messages []*models.SupportRequestMessage
+}
+
+func (n *recordingReplyNotifier) NotifyReply(supportRequest *models.SupportRequest, message *models.SupportRequestMessage) {
+	n.messages = append(n.messages, message)
+}
+
+func TestSupportRequestMessageService_AddAgentReply_PicksUpNewRequest(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	replies := &recordingReplyNotifier{}
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, replies, events)
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusNew}, nil)
+	mockMessageRepo.On("Create", mock.AnythingOfType("*models.SupportRequestMessage"), mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.Status == models.StatusInProgress && req.FirstRespondedAt != nil
+	}), mock.MatchedBy(func(events []*models.AuditEvent) bool {
+		return len(events) == 1 && events[0].ActorUsername == "agent" && events[0].EntityID == 1
+	})).Return(nil)
+
+	// Act
+	response, err := service.AddAgentReply(1, models.OrganizationScope{}, models.AuditActor{UserID: 5, Username: "agent"}, &models.CreateSupportRequestMessageRequest{Body: "Looking into it"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageAuthorAgent, response.AuthorType)
+	assert.Equal(t, models.MessageVisibilityPublic, response.Visibility)
+	assert.Equal(t, uint(5), *response.AuthorUserID)
+	assert.Len(t, replies.messages, 1, "the submitter is notified of public replies")
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestUpdated, models.WebhookEventSupportRequestStatusChanged}, events.types())
+	mockSupportRepo.AssertExpectations(t)
+	mockMessageRepo.AssertExpectations(t)
+	mockSupportRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSupportRequestMessageService_AddAgentReply_InternalNoteKeepsStatus(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	replies := &recordingReplyNotifier{}
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, replies, events)
+
+	internal := models.MessageVisibilityInternal
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusNew}, nil)
+	mockMessageRepo.On("Create", mock.AnythingOfType("*models.SupportRequestMessage"), mock.MatchedBy(func(req *models.SupportRequest) bool {
+		// Internal notes don't count as a first response either
+		return req.Status == models.StatusNew && req.FirstRespondedAt == nil
+	}), []*models.AuditEvent(nil)).Return(nil)
+
+	// Act
+	response, err := service.AddAgentReply(1, models.OrganizationScope{}, models.AuditActor{UserID: 5, Username: "agent"}, &models.CreateSupportRequestMessageRequest{Body: "Probably a duplicate", Visibility: &internal})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageVisibilityInternal, response.Visibility)
+	assert.Empty(t, replies.messages, "internal notes are never mailed")
+	assert.Empty(t, events.events, "nothing but UpdatedAt changed")
+	mockMessageRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestMessageService_AddSubmitterReply_ReopensResolvedRequest(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	replies := &recordingReplyNotifier{}
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, replies, events)
+
+	resolvedAt := time.Now().Add(-time.Hour)
+	email := "jane@example.com"
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, UserEmail: &email, Status: models.StatusResolved, ResolvedAt: &resolvedAt}, nil)
+	mockMessageRepo.On("Create", mock.AnythingOfType("*models.SupportRequestMessage"), mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.Status == models.StatusReopened && req.ResolvedAt == nil
+	}), mock.MatchedBy(func(events []*models.AuditEvent) bool {
+		// Anonymous submitters are audited under their email address
+		return len(events) == 1 && events[0].ActorUsername == "jane@example.com" && events[0].IPAddress == "203.0.113.7"
+	})).Return(nil)
+
+	// Act
+	response, err := service.AddSubmitterReply(1, models.AuditActor{IPAddress: "203.0.113.7"}, &models.CreateSupportRequestMessageRequest{Body: "Still broken"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageAuthorSubmitter, response.AuthorType)
+	assert.Nil(t, response.AuthorUserID)
+	assert.Empty(t, replies.messages)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestUpdated, models.WebhookEventSupportRequestStatusChanged}, events.types())
+	mockMessageRepo.AssertExpectations(t)
+}
+
+func TestStatusAfterMessage(t *testing.T) {
+	public := &models.SupportRequestMessage{AuthorType: models.MessageAuthorAgent, Visibility: models.MessageVisibilityPublic}
+	internal := &models.SupportRequestMessage{AuthorType: models.MessageAuthorAgent, Visibility: models.MessageVisibilityInternal}
+	submitter := &models.SupportRequestMessage{AuthorType: models.MessageAuthorSubmitter, Visibility: models.MessageVisibilityPublic}
+
+	tests := []struct {
+		current  models.Status
+		message  *models.SupportRequestMessage
+		expected models.Status
+	}{
+		{models.StatusNew, public, models.StatusInProgress},
+		{models.StatusReopened, public, models.StatusInProgress},
+		{models.StatusWaitingOnCustomer, public, models.StatusWaitingOnCustomer},
+		{models.StatusNew, internal, models.StatusNew},
+		{models.StatusWaitingOnCustomer, submitter, models.StatusInProgress},
+		{models.StatusResolved, submitter, models.StatusReopened},
+		{models.StatusClosed, submitter, models.StatusClosed},
+	}
+
+	for _, tt := range tests {
+		next := statusAfterMessage(tt.current, tt.message)
+		assert.Equal(t, tt.expected, next, "%s reply on %s", tt.message.AuthorType, tt.current)
+		// Every automatic change must be allowed by the workflow
+		assert.True(t, CanTransitionStatus(tt.current, next))
+	}
+}
+
+func TestSupportRequestMessageService_AddSubmitterReply_RejectsInternal(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, &recordingReplyNotifier{}, &recordingEventPublisher{})
+
+	internal := models.MessageVisibilityInternal
+
+	// Act
+	response, err := service.AddSubmitterReply(1, models.AuditActor{IPAddress: "203.0.113.7"}, &models.CreateSupportRequestMessageRequest{Body: "Sneaky", Visibility: &internal})
+
+	// Assert
+	assert.Equal(t, ErrInvalidRequest, err)
+	assert.Nil(t, response)
+	mockMessageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSupportRequestMessageService_AddAgentReply_NilRequest(t *testing.T) {
+	service := NewSupportRequestMessageService(new(MockSupportRequestMessageRepository), new(MockSupportRequestRepository), &recordingReplyNotifier{}, &recordingEventPublisher{})
+
+	response, err := service.AddAgentReply(1, models.OrganizationScope{}, models.AuditActor{UserID: 5, Username: "agent"}, nil)
+
+	assert.Equal(t, ErrInvalidRequest, err)
+	assert.Nil(t, response)
+}
+
+func TestSupportRequestMessageService_AddAgentReply_RepositoryError(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, &recordingReplyNotifier{}, events)
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusNew}, nil)
+	mockMessageRepo.On("Create", mock.AnythingOfType("*models.SupportRequestMessage"), mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	// Act
+	response, err := service.AddAgentReply(1, models.OrganizationScope{}, models.AuditActor{UserID: 5, Username: "agent"}, &models.CreateSupportRequestMessageRequest{Body: "Hello"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Empty(t, events.events, "nothing is published for changes that weren't saved")
+}
+
+func TestSupportRequestMessageService_AddAgentReply_MergedRequest(t *testing.T) {
+	// Arrange
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestMessageService(mockMessageRepo, mockSupportRepo, &recordingReplyNotifier{}, &recordingEventPublisher{})
+
+	targetID := uint(2)
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusClosed, MergedIntoID: &targetID}, nil)
+
+	// Act
+	response, err := service.AddAgentReply(1, models.OrganizationScope{}, models.AuditActor{UserID: 5, Username: "agent"}, &models.CreateSupportRequestMessageRequest{Body: "Hello"})
+
+	// Assert
+	assert.Equal(t, ErrSupportRequestMerged, err)
+	assert.Nil(t, response)
+	mockMessageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
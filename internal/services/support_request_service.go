@@ -2,42 +2,80 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"support-app-backend/internal/models"
 	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 var (
 	ErrSupportRequestNotFound = errors.New("support request not found")
 	ErrInvalidRequest         = errors.New("invalid request")
+	ErrInvalidFilter          = errors.New("invalid filter")
+	ErrSupportRequestMerged   = errors.New("support request was merged into another")
+)
+
+// maxSearchQueryLength bounds the size of full-text search queries
+const maxSearchQueryLength = 200
+
+const (
+	defaultStatsRange = 30 * 24 * time.Hour // Range of statistics without created_after
+	maxStatsBuckets   = 366                 // Upper bound of the series length, a year of days
 )
 
 // SupportRequestService defines the interface for support request business logic
 type SupportRequestService interface {
-	CreateSupportRequest(req *models.CreateSupportRequestRequest) (*models.SupportRequestResponse, error)
-	GetSupportRequest(id uint) (*models.SupportRequestResponse, error)
-	GetAllSupportRequests(page, pageSize int) ([]*models.SupportRequestResponse, int64, error)
-	UpdateSupportRequest(id uint, req *models.UpdateSupportRequestRequest) (*models.SupportRequestResponse, error)
-	DeleteSupportRequest(id uint) error
+	CreateSupportRequest(req *models.CreateSupportRequestRequest, keyApp *models.App, attachments ...*models.Attachment) (*models.SupportRequestResponse, error)
+	GetSupportRequest(id uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error)
+	GetAllSupportRequests(filter repositories.SupportRequestFilter, page, pageSize int) ([]*models.SupportRequestResponse, int64, error)
+	SearchSupportRequests(query string, filter repositories.SupportRequestFilter, page, pageSize int) ([]*models.SupportRequestSearchResult, int64, error)
+	GetSupportRequestStats(filter repositories.SupportRequestFilter, interval string) (*models.SupportRequestStatsResponse, error)
+	ExportSupportRequests(filter repositories.SupportRequestFilter, fn func(*models.SupportRequestResponse) error) error
+	UpdateSupportRequest(id uint, scope models.OrganizationScope, req *models.UpdateSupportRequestRequest, actor models.AuditActor) (*models.SupportRequestResponse, error)
+	DeleteSupportRequest(id uint, scope models.OrganizationScope, actor models.AuditActor) error
+	MergeSupportRequest(id, targetID uint, scope models.OrganizationScope, actor models.AuditActor) (*models.SupportRequestResponse, error)
 }
 
 // supportRequestService implements SupportRequestService
 type supportRequestService struct {
-	repo repositories.SupportRequestRepository
+	repo       repositories.SupportRequestRepository
+	appRepo    repositories.AppRepository
+	slaPolicy  SLAPolicy
+	duplicates DuplicatePolicy
+	events     EventPublisher
+	now        func() time.Time
 }
 
-// NewSupportRequestService creates a new support request service
-func NewSupportRequestService(repo repositories.SupportRequestRepository) SupportRequestService {
+// NewSupportRequestService creates a new support request service that flags new requests according
+// to duplicates and publishes lifecycle events to events
+func NewSupportRequestService(repo repositories.SupportRequestRepository, appRepo repositories.AppRepository, slaPolicy SLAPolicy, duplicates DuplicatePolicy, events EventPublisher) SupportRequestService {
 	return &supportRequestService{
-		repo: repo,
+		repo:       repo,
+		appRepo:    appRepo,
+		slaPolicy:  slaPolicy,
+		duplicates: duplicates,
+		events:     events,
+		now:        time.Now,
 	}
 }
 
-// CreateSupportRequest creates a new support request
-func (s *supportRequestService) CreateSupportRequest(req *models.CreateSupportRequestRequest) (*models.SupportRequestResponse, error) {
+// CreateSupportRequest creates a new support request for a registered, active app, owned by the
+// organization of the app if it has one. keyApp is the app identified by the intake key the client
+// sent, or nil when it sent none. The attachments, already in blob storage, are saved together with
+// the request.
+func (s *supportRequestService) CreateSupportRequest(req *models.CreateSupportRequestRequest, keyApp *models.App, attachments ...*models.Attachment) (*models.SupportRequestResponse, error) {
 	if req == nil {
 		return nil, ErrInvalidRequest
 	}
 
+	app, err := s.intakeApp(req, keyApp)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the support request model
 	supportRequest := &models.SupportRequest{
 		Type:        req.Type,
@@ -48,6 +86,22 @@ func (s *supportRequestService) CreateSupportRequest(req *models.CreateSupportRe
 		DeviceModel: req.DeviceModel,
 		App:         req.App, // Fix: Include the App field
 		Status:      models.StatusNew, // Always start with 'new' status
+		Priority:    models.PriorityNormal,
+		CreatedAt:   s.now(),
+	}
+	s.slaPolicy.ApplyDeadlines(supportRequest)
+	supportRequest.OrganizationID = app.OrganizationID
+
+	trackingToken, err := newTrackingToken()
+	if err != nil {
+		return nil, err
+	}
+	trackingTokenHash := hashToken(trackingToken)
+	supportRequest.TrackingTokenHash = &trackingTokenHash
+	supportRequest.Attachments = attachments
+
+	if err := s.flagDuplicate(supportRequest); err != nil {
+		return nil, err
 	}
 
 	// Save to repository
@@ -55,12 +109,48 @@ func (s *supportRequestService) CreateSupportRequest(req *models.CreateSupportRe
 		return nil, err
 	}
 
-	return supportRequest.ToResponse(), nil
+	s.events.Publish(models.WebhookEventSupportRequestCreated, supportRequest.ToResponse())
+
+	// Only the submitter gets the token and the attachment details, webhooks don't
+	response := supportRequest.ToResponse()
+	response.TrackingToken = trackingToken
+	for _, attachment := range supportRequest.Attachments {
+		response.Attachments = append(response.Attachments, attachment.ToResponse())
+	}
+	return response, nil
+}
+
+// intakeApp returns the app a new support request is submitted for. It must be registered and active,
+// allow the request's platform and, when the client sent an intake key, be the app of that key.
+func (s *supportRequestService) intakeApp(req *models.CreateSupportRequestRequest, keyApp *models.App) (*models.App, error) {
+	app := keyApp
+	if app != nil {
+		if app.Slug != req.App {
+			return nil, fmt.Errorf("%w: the key belongs to app %q", ErrInvalidAppKey, app.Slug)
+		}
+	} else {
+		var err error
+		app, err = s.appRepo.GetBySlug(req.App)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUnknownApp
+			}
+			return nil, err
+		}
+	}
+
+	if !app.IsActive {
+		return nil, ErrAppDisabled
+	}
+	if !app.AllowsPlatform(req.Platform) {
+		return nil, ErrPlatformNotAllowed
+	}
+	return app, nil
 }
 
 // GetSupportRequest retrieves a support request by ID
-func (s *supportRequestService) GetSupportRequest(id uint) (*models.SupportRequestResponse, error) {
-	supportRequest, err := s.repo.GetByID(id)
+func (s *supportRequestService) GetSupportRequest(id uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error) {
+	supportRequest, err := s.repo.GetByID(id, scope)
 	if err != nil {
 		return nil, ErrSupportRequestNotFound
 	}
@@ -68,8 +158,13 @@ func (s *supportRequestService) GetSupportRequest(id uint) (*models.SupportReque
 	return supportRequest.ToResponse(), nil
 }
 
-// GetAllSupportRequests retrieves all support requests with pagination
-func (s *supportRequestService) GetAllSupportRequests(page, pageSize int) ([]*models.SupportRequestResponse, int64, error) {
+// GetAllSupportRequests retrieves support requests matching the filter with pagination
+func (s *supportRequestService) GetAllSupportRequests(filter repositories.SupportRequestFilter, page, pageSize int) ([]*models.SupportRequestResponse, int64, error) {
+	if err := validateSupportRequestFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	s.applySLAWindow(&filter)
+
 	if page < 1 {
 		page = 1
 	}
@@ -79,7 +174,7 @@ func (s *supportRequestService) GetAllSupportRequests(page, pageSize int) ([]*mo
 
 	offset := (page - 1) * pageSize
 
-	supportRequests, total, err := s.repo.GetAll(offset, pageSize)
+	supportRequests, total, err := s.repo.GetAll(filter, offset, pageSize)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -92,41 +187,274 @@ func (s *supportRequestService) GetAllSupportRequests(page, pageSize int) ([]*mo
 	return responses, total, nil
 }
 
-// UpdateSupportRequest updates a support request
-func (s *supportRequestService) UpdateSupportRequest(id uint, req *models.UpdateSupportRequestRequest) (*models.SupportRequestResponse, error) {
+// SearchSupportRequests performs a ranked full-text search, narrowed by the filter, with pagination
+func (s *supportRequestService) SearchSupportRequests(query string, filter repositories.SupportRequestFilter, page, pageSize int) ([]*models.SupportRequestSearchResult, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, fmt.Errorf("%w: search query is required", ErrInvalidFilter)
+	}
+	if len(query) > maxSearchQueryLength {
+		return nil, 0, fmt.Errorf("%w: search query must be at most %d characters", ErrInvalidFilter, maxSearchQueryLength)
+	}
+	if err := validateSupportRequestFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	s.applySLAWindow(&filter)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20 // Default page size
+	}
+
+	offset := (page - 1) * pageSize
+
+	hits, total, err := s.repo.Search(query, filter, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*models.SupportRequestSearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = &models.SupportRequestSearchResult{
+			SupportRequestResponse: *hit.Request.ToResponse(),
+			Rank:                   hit.Rank,
+			Snippet:                hit.Snippet,
+		}
+	}
+
+	return results, total, nil
+}
+
+// GetSupportRequestStats aggregates the support requests matching the filter. The creation date
+// range defaults to the last 30 days and is split into buckets of interval (day by default).
+func (s *supportRequestService) GetSupportRequestStats(filter repositories.SupportRequestFilter, interval string) (*models.SupportRequestStatsResponse, error) {
+	if interval == "" {
+		interval = models.StatsIntervalDay
+	}
+	if !models.IsValidStatsInterval(interval) {
+		return nil, fmt.Errorf("%w: interval must be %s, %s or %s", ErrInvalidFilter, models.StatsIntervalDay, models.StatsIntervalWeek, models.StatsIntervalMonth)
+	}
+	if err := validateSupportRequestFilter(filter); err != nil {
+		return nil, err
+	}
+
+	to := s.now().UTC()
+	if filter.CreatedBefore != nil {
+		to = filter.CreatedBefore.UTC()
+	}
+	from := to.Add(-defaultStatsRange)
+	if filter.CreatedAfter != nil {
+		from = filter.CreatedAfter.UTC()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: created_after must be before created_before", ErrInvalidFilter)
+	}
+	buckets := statsBucketStarts(from, to, interval)
+	if len(buckets) > maxStatsBuckets {
+		return nil, fmt.Errorf("%w: the date range spans more than %d %s buckets, use a shorter range or a longer interval", ErrInvalidFilter, maxStatsBuckets, interval)
+	}
+	filter.CreatedAfter = &from
+	filter.CreatedBefore = &to
+	s.applySLAWindow(&filter)
+
+	stats, err := s.repo.Stats(filter, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(stats.Series))
+	for _, bucket := range stats.Series {
+		counts[bucket.Start] = bucket.Count
+	}
+	series := make([]models.SupportRequestBucket, len(buckets))
+	for i, start := range buckets {
+		series[i] = models.SupportRequestBucket{Start: start, Count: counts[start]}
+	}
+
+	var total int64
+	for _, count := range stats.ByStatus {
+		total += count.Count
+	}
+
+	return &models.SupportRequestStatsResponse{
+		From:                       from,
+		To:                         to,
+		Interval:                   interval,
+		Total:                      total,
+		ByStatus:                   stats.ByStatus,
+		ByType:                     stats.ByType,
+		ByPlatform:                 stats.ByPlatform,
+		ByApp:                      stats.ByApp,
+		ByAppVersion:               stats.ByAppVersion,
+		Series:                     series,
+		MedianFirstResponseSeconds: stats.MedianFirstResponseSeconds,
+		MedianResolutionSeconds:    stats.MedianResolutionSeconds,
+	}, nil
+}
+
+// ExportSupportRequests calls fn with every support request matching the filter, in the filter's
+// sort order, as they are read from the database. An error from fn stops the export and is returned.
+func (s *supportRequestService) ExportSupportRequests(filter repositories.SupportRequestFilter, fn func(*models.SupportRequestResponse) error) error {
+	if err := validateSupportRequestFilter(filter); err != nil {
+		return err
+	}
+	s.applySLAWindow(&filter)
+
+	return s.repo.Export(filter, func(request *models.SupportRequest) error {
+		return fn(request.ToResponse())
+	})
+}
+
+// statsBucketStarts lists the first day (YYYY-MM-DD, UTC) of every bucket of interval overlapping
+// [from, to). It stops one past maxStatsBuckets so oversized ranges are cheap to reject.
+func statsBucketStarts(from, to time.Time, interval string) []string {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case models.StatsIntervalWeek:
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7)) // Back to Monday
+	case models.StatsIntervalMonth:
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	var starts []string
+	for ; start.Before(to) && len(starts) <= maxStatsBuckets; start = nextStatsBucket(start, interval) {
+		starts = append(starts, start.Format("2006-01-02"))
+	}
+	return starts
+}
+
+// nextStatsBucket returns the start of the bucket following the one starting at start
+func nextStatsBucket(start time.Time, interval string) time.Time {
+	switch interval {
+	case models.StatsIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case models.StatsIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// UpdateSupportRequest updates a support request, recording the change in the audit log
+func (s *supportRequestService) UpdateSupportRequest(id uint, scope models.OrganizationScope, req *models.UpdateSupportRequestRequest, actor models.AuditActor) (*models.SupportRequestResponse, error) {
 	if req == nil {
 		return nil, ErrInvalidRequest
 	}
 
 	// Get existing support request
-	supportRequest, err := s.repo.GetByID(id)
+	supportRequest, err := s.repo.GetByID(id, scope)
 	if err != nil {
 		return nil, ErrSupportRequestNotFound
 	}
+	if supportRequest.IsMerged() {
+		return nil, ErrSupportRequestMerged
+	}
+	before := supportRequest.ToResponse()
 
 	// Update fields if provided
 	if req.Status != nil {
-		supportRequest.Status = *req.Status
+		if err := transitionStatus(supportRequest, *req.Status, s.now()); err != nil {
+			return nil, err
+		}
+	}
+	if req.Priority != nil && *req.Priority != supportRequest.Priority {
+		if !req.Priority.IsValid() {
+			return nil, ErrInvalidRequest
+		}
+		// Deadlines always count from creation, so raising the priority can breach the SLA immediately
+		supportRequest.Priority = *req.Priority
+		s.slaPolicy.ApplyDeadlines(supportRequest)
 	}
 	if req.AdminNotes != nil {
 		supportRequest.AdminNotes = req.AdminNotes
 	}
 
+	after := supportRequest.ToResponse()
+	event, err := newAuditEvent(actor, models.AuditActionUpdate, models.AuditEntitySupportRequest, supportRequest.ID, before, after)
+	if err != nil {
+		return nil, err
+	}
+
 	// Save updated request
-	if err := s.repo.Update(supportRequest); err != nil {
+	if err := s.repo.Update(supportRequest, event); err != nil {
 		return nil, err
 	}
 
-	return supportRequest.ToResponse(), nil
+	publishSupportRequestUpdate(s.events, before, after)
+	return after, nil
 }
 
-// DeleteSupportRequest deletes a support request
-func (s *supportRequestService) DeleteSupportRequest(id uint) error {
+// DeleteSupportRequest deletes a support request, recording the deletion in the audit log
+func (s *supportRequestService) DeleteSupportRequest(id uint, scope models.OrganizationScope, actor models.AuditActor) error {
 	// Check if the support request exists
-	_, err := s.repo.GetByID(id)
+	supportRequest, err := s.repo.GetByID(id, scope)
 	if err != nil {
 		return ErrSupportRequestNotFound
 	}
 
-	return s.repo.Delete(id)
+	before := supportRequest.ToResponse()
+	event, err := newAuditEvent(actor, models.AuditActionDelete, models.AuditEntitySupportRequest, id, before, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id, event); err != nil {
+		return err
+	}
+
+	s.events.Publish(models.WebhookEventSupportRequestDeleted, before)
+	return nil
+}
+
+// applySLAWindow fills in the reference time and due soon window of an SLA filter
+func (s *supportRequestService) applySLAWindow(filter *repositories.SupportRequestFilter) {
+	if filter.SLA == "" {
+		return
+	}
+	filter.SLAAsOf = s.now()
+	filter.SLADueSoon = s.slaPolicy.DueSoonWindow
+}
+
+// validateSupportRequestFilter rejects filters with unknown enum values, sort options or inverted date ranges
+func validateSupportRequestFilter(filter repositories.SupportRequestFilter) error {
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, status)
+		}
+	}
+	for _, requestType := range filter.Types {
+		if !requestType.IsValid() {
+			return fmt.Errorf("%w: unknown type %q", ErrInvalidFilter, requestType)
+		}
+	}
+	for _, platform := range filter.Platforms {
+		if !platform.IsValid() {
+			return fmt.Errorf("%w: unknown platform %q", ErrInvalidFilter, platform)
+		}
+	}
+	for _, priority := range filter.Priorities {
+		if !priority.IsValid() {
+			return fmt.Errorf("%w: unknown priority %q", ErrInvalidFilter, priority)
+		}
+	}
+	if filter.TagMatch != "" && filter.TagMatch != repositories.TagMatchAny && filter.TagMatch != repositories.TagMatchAll {
+		return fmt.Errorf("%w: tag_match must be %s or %s", ErrInvalidFilter, repositories.TagMatchAny, repositories.TagMatchAll)
+	}
+	if filter.SLA != "" && filter.SLA != repositories.SLAStateBreached && filter.SLA != repositories.SLAStateDueSoon {
+		return fmt.Errorf("%w: sla must be %s or %s", ErrInvalidFilter, repositories.SLAStateBreached, repositories.SLAStateDueSoon)
+	}
+	if filter.SortBy != "" && !repositories.IsValidSupportRequestSortField(filter.SortBy) {
+		return fmt.Errorf("%w: cannot sort by %q", ErrInvalidFilter, filter.SortBy)
+	}
+	if filter.SortOrder != "" && filter.SortOrder != repositories.SortOrderAsc && filter.SortOrder != repositories.SortOrderDesc {
+		return fmt.Errorf("%w: sort order must be %s or %s", ErrInvalidFilter, repositories.SortOrderAsc, repositories.SortOrderDesc)
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return fmt.Errorf("%w: created_after must be before created_before", ErrInvalidFilter)
+	}
+	if filter.UpdatedAfter != nil && filter.UpdatedBefore != nil && !filter.UpdatedAfter.Before(*filter.UpdatedBefore) {
+		return fmt.Errorf("%w: updated_after must be before updated_before", ErrInvalidFilter)
+	}
+	return nil
 }
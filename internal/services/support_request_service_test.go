@@ -2,11 +2,15 @@ package services
 
 import (
 	"errors"
+	"strings"
 	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // MockSupportRequestRepository is a mock implementation of SupportRequestRepository
@@ -19,33 +23,124 @@ func (m *MockSupportRequestRepository) Create(request *models.SupportRequest) er
 	return args.Error(0)
 }
 
-func (m *MockSupportRequestRepository) GetByID(id uint) (*models.SupportRequest, error) {
-	args := m.Called(id)
+func (m *MockSupportRequestRepository) GetByID(id uint, scope models.OrganizationScope) (*models.SupportRequest, error) {
+	args := m.Called(id, scope)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.SupportRequest), args.Error(1)
 }
 
-func (m *MockSupportRequestRepository) GetAll(offset, limit int) ([]*models.SupportRequest, int64, error) {
-	args := m.Called(offset, limit)
+func (m *MockSupportRequestRepository) GetAll(filter repositories.SupportRequestFilter, offset, limit int) ([]*models.SupportRequest, int64, error) {
+	args := m.Called(filter, offset, limit)
 	return args.Get(0).([]*models.SupportRequest), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockSupportRequestRepository) Update(request *models.SupportRequest) error {
-	args := m.Called(request)
+func (m *MockSupportRequestRepository) Search(query string, filter repositories.SupportRequestFilter, offset, limit int) ([]*repositories.SupportRequestSearchHit, int64, error) {
+	args := m.Called(query, filter, offset, limit)
+	return args.Get(0).([]*repositories.SupportRequestSearchHit), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSupportRequestRepository) Stats(filter repositories.SupportRequestFilter, interval string) (*repositories.SupportRequestStats, error) {
+	args := m.Called(filter, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.SupportRequestStats), args.Error(1)
+}
+
+func (m *MockSupportRequestRepository) GetByIDs(ids []uint, scope models.OrganizationScope) ([]*models.SupportRequest, error) {
+	args := m.Called(ids, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SupportRequest), args.Error(1)
+}
+
+func (m *MockSupportRequestRepository) GetByTrackingTokenHash(hash string) (*models.SupportRequest, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SupportRequest), args.Error(1)
+}
+
+func (m *MockSupportRequestRepository) ApplyChanges(changes []*repositories.SupportRequestChange) error {
+	args := m.Called(changes)
+	return args.Error(0)
+}
+
+func (m *MockSupportRequestRepository) FindDuplicateCandidates(request *models.SupportRequest, since time.Time) ([]*models.SupportRequest, error) {
+	args := m.Called(request, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SupportRequest), args.Error(1)
+}
+
+func (m *MockSupportRequestRepository) Merge(merge *repositories.SupportRequestMerge) error {
+	args := m.Called(merge)
 	return args.Error(0)
 }
 
-func (m *MockSupportRequestRepository) Delete(id uint) error {
-	args := m.Called(id)
+// Export passes each support request given to Return to fn, stopping at the first error
+func (m *MockSupportRequestRepository) Export(filter repositories.SupportRequestFilter, fn func(*models.SupportRequest) error) error {
+	args := m.Called(filter)
+	for _, request := range args.Get(0).([]*models.SupportRequest) {
+		if err := fn(request); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// Update expects one extra argument per audit event, so calls without events match On("Update", request)
+func (m *MockSupportRequestRepository) Update(request *models.SupportRequest, events ...*models.AuditEvent) error {
+	args := m.Called(withAuditEvents([]interface{}{request}, events)...)
 	return args.Error(0)
 }
 
+func (m *MockSupportRequestRepository) Delete(id uint, events ...*models.AuditEvent) error {
+	args := m.Called(withAuditEvents([]interface{}{id}, events)...)
+	return args.Error(0)
+}
+
+// publishedEvent is an event captured by recordingEventPublisher
+type publishedEvent struct {
+	Event models.WebhookEventType
+	Data  interface{}
+}
+
+// recordingEventPublisher is an EventPublisher that records every published event
+type recordingEventPublisher struct {
+	events []publishedEvent
+}
+
+func (p *recordingEventPublisher) Publish(event models.WebhookEventType, data interface{}) {
+	p.events = append(p.events, publishedEvent{Event: event, Data: data})
+}
+
+// types returns the published event types in order
+func (p *recordingEventPublisher) types() []models.WebhookEventType {
+	types := make([]models.WebhookEventType, len(p.events))
+	for i, event := range p.events {
+		types[i] = event.Event
+	}
+	return types
+}
+
+// registeredApps returns an app repository in which every app is registered, active and belongs to
+// no organization
+func registeredApps() *MockAppRepository {
+	mockAppRepo := new(MockAppRepository)
+	mockAppRepo.On("GetBySlug", mock.Anything).Return(&models.App{ID: 1, Slug: "test-app", DisplayName: "Test App", IsActive: true}, nil).Maybe()
+	return mockAppRepo
+}
+
 func TestSupportRequestService_CreateSupportRequest(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	userEmail := "test@example.com"
 	request := &models.CreateSupportRequestRequest{
@@ -65,7 +160,7 @@ func TestSupportRequestService_CreateSupportRequest(t *testing.T) {
 	})
 
 	// Act
-	response, err := service.CreateSupportRequest(request)
+	response, err := service.CreateSupportRequest(request, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -74,16 +169,206 @@ func TestSupportRequestService_CreateSupportRequest(t *testing.T) {
 	assert.Equal(t, models.SupportRequestTypeSupport, response.Type)
 	assert.Equal(t, "Test message", response.Message)
 	assert.Equal(t, models.StatusNew, response.Status)
+	assert.Equal(t, models.PriorityNormal, response.Priority)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_CreateSupportRequest_TrackingToken(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, events)
+
+	var stored *models.SupportRequest
+	mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.SupportRequest)
+	})
+
+	// Act
+	response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.TrackingToken, "trk_"))
+	assert.Equal(t, hashToken(response.TrackingToken), *stored.TrackingTokenHash, "only the hash is stored")
+	assert.Len(t, events.events, 1)
+	assert.Empty(t, events.events[0].Data.(*models.SupportRequestResponse).TrackingToken, "webhooks must not receive the token")
+}
+
+func TestSupportRequestService_CreateSupportRequest_Attachments(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	events := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, events)
+
+	attachment := &models.Attachment{FileName: "crash.log", ContentType: "text/plain", StorageKey: "attachments/abc"}
+	mockRepo.On("Create", mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return len(req.Attachments) == 1 && req.Attachments[0] == attachment
+	})).Return(nil).Run(func(args mock.Arguments) {
+		// The attachments are saved in the same insert
+		req := args.Get(0).(*models.SupportRequest)
+		req.ID = 1
+		req.Attachments[0].ID = 9
+		req.Attachments[0].SupportRequestID = 1
+	})
+
+	// Act
+	response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeBugReport, Message: "Crash", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil, attachment)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Attachments, 1)
+	assert.Equal(t, uint(9), response.Attachments[0].ID)
+	assert.Equal(t, uint(1), response.Attachments[0].SupportRequestID)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestCreated}, events.types())
 	mockRepo.AssertExpectations(t)
 }
 
+func TestSupportRequestService_CreateSupportRequest_OwnedApp(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	mockAppRepo := new(MockAppRepository)
+	service := NewSupportRequestService(mockRepo, mockAppRepo, defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	organizationID := uint(4)
+
+	mockAppRepo.On("GetBySlug", "acme-app").Return(&models.App{ID: 1, OrganizationID: &organizationID, Slug: "acme-app", IsActive: true}, nil)
+	mockRepo.On("Create", mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.OrganizationID != nil && *req.OrganizationID == 4
+	})).Return(nil)
+
+	// Act
+	response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "acme-app"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), *response.OrganizationID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_CreateSupportRequest_RejectedApps(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     *models.App
+		lookErr error
+		wantErr error
+	}{
+		{"unknown app", nil, gorm.ErrRecordNotFound, ErrUnknownApp},
+		{"disabled app", &models.App{ID: 1, Slug: "test-app", IsActive: false}, nil, ErrAppDisabled},
+		{"platform not allowed", &models.App{ID: 1, Slug: "test-app", Platforms: "Android", IsActive: true}, nil, ErrPlatformNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSupportRequestRepository)
+			mockAppRepo := new(MockAppRepository)
+			service := NewSupportRequestService(mockRepo, mockAppRepo, defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+			if tt.app != nil {
+				mockAppRepo.On("GetBySlug", "test-app").Return(tt.app, nil)
+			} else {
+				mockAppRepo.On("GetBySlug", "test-app").Return(nil, tt.lookErr)
+			}
+
+			// Act
+			response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, response)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestService_CreateSupportRequest_KeyApp(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	mockAppRepo := new(MockAppRepository)
+	service := NewSupportRequestService(mockRepo, mockAppRepo, defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	organizationID := uint(2)
+	keyApp := &models.App{ID: 3, OrganizationID: &organizationID, Slug: "test-app", IsActive: true}
+
+	mockRepo.On("Create", mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.OrganizationID != nil && *req.OrganizationID == 2
+	})).Return(nil)
+
+	// Act
+	response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, keyApp)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	mockAppRepo.AssertNotCalled(t, "GetBySlug", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_CreateSupportRequest_KeyForOtherApp(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	keyApp := &models.App{ID: 3, Slug: "other-app", IsActive: true}
+
+	// Act
+	response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, keyApp)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidAppKey)
+	assert.Nil(t, response)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSupportRequestService_GetSupportRequest_OutsideScope(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	scope := models.ScopeToOrganization(2)
+	mockRepo.On("GetByID", uint(1), scope).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	response, err := service.GetSupportRequest(1, scope)
+
+	// Assert
+	assert.Equal(t, ErrSupportRequestNotFound, err)
+	assert.Nil(t, response)
+}
+
+func TestSupportRequestService_CreateSupportRequest_SetsSLADeadlines(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	policy := defaultSLAPolicy()
+	policy.TypeOverrides = map[models.SupportRequestType]map[models.Priority]SLATarget{
+		models.SupportRequestTypeFeedback: {models.PriorityNormal: {FirstResponse: 48 * time.Hour}},
+	}
+	service := NewSupportRequestService(mockRepo, registeredApps(), policy, DuplicatePolicy{}, &recordingEventPublisher{}).(*supportRequestService)
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return createdAt }
+
+	mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(nil)
+
+	// Act
+	support, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+	assert.NoError(t, err)
+	feedback, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeFeedback, Message: "Nice", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+	assert.NoError(t, err)
+
+	// Assert - normal priority defaults to 24h / 72h
+	assert.Equal(t, createdAt.Add(24*time.Hour), *support.FirstResponseDueAt)
+	assert.Equal(t, createdAt.Add(72*time.Hour), *support.ResolutionDueAt)
+
+	// Type override without a resolution target has no resolution deadline
+	assert.Equal(t, createdAt.Add(48*time.Hour), *feedback.FirstResponseDueAt)
+	assert.Nil(t, feedback.ResolutionDueAt)
+}
+
 func TestSupportRequestService_CreateSupportRequest_NilRequest(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	// Act
-	response, err := service.CreateSupportRequest(nil)
+	response, err := service.CreateSupportRequest(nil, nil)
 
 	// Assert
 	assert.Error(t, err)
@@ -94,7 +379,7 @@ func TestSupportRequestService_CreateSupportRequest_NilRequest(t *testing.T) {
 func TestSupportRequestService_CreateSupportRequest_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	userEmail := "test@example.com"
 	request := &models.CreateSupportRequestRequest{
@@ -110,7 +395,7 @@ func TestSupportRequestService_CreateSupportRequest_RepositoryError(t *testing.T
 	mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(errors.New("database error"))
 
 	// Act
-	response, err := service.CreateSupportRequest(request)
+	response, err := service.CreateSupportRequest(request, nil)
 
 	// Assert
 	assert.Error(t, err)
@@ -122,7 +407,7 @@ func TestSupportRequestService_CreateSupportRequest_RepositoryError(t *testing.T
 func TestSupportRequestService_GetSupportRequest(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	userEmail := "test@example.com"
 	supportRequest := &models.SupportRequest{
@@ -137,10 +422,10 @@ func TestSupportRequestService_GetSupportRequest(t *testing.T) {
 		Status:      models.StatusNew,
 	}
 
-	mockRepo.On("GetByID", uint(1)).Return(supportRequest, nil)
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(supportRequest, nil)
 
 	// Act
-	response, err := service.GetSupportRequest(1)
+	response, err := service.GetSupportRequest(1, models.OrganizationScope{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -153,12 +438,12 @@ func TestSupportRequestService_GetSupportRequest(t *testing.T) {
 func TestSupportRequestService_GetSupportRequest_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetByID", uint(999)).Return(nil, errors.New("not found"))
+	mockRepo.On("GetByID", uint(999), models.OrganizationScope{}).Return(nil, errors.New("not found"))
 
 	// Act
-	response, err := service.GetSupportRequest(999)
+	response, err := service.GetSupportRequest(999, models.OrganizationScope{})
 
 	// Assert
 	assert.Error(t, err)
@@ -170,12 +455,12 @@ func TestSupportRequestService_GetSupportRequest_NotFound(t *testing.T) {
 func TestSupportRequestService_GetSupportRequest_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetByID", uint(1)).Return(nil, errors.New("database error"))
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(nil, errors.New("database error"))
 
 	// Act
-	response, err := service.GetSupportRequest(1)
+	response, err := service.GetSupportRequest(1, models.OrganizationScope{})
 
 	// Assert
 	assert.Error(t, err)
@@ -187,7 +472,7 @@ func TestSupportRequestService_GetSupportRequest_RepositoryError(t *testing.T) {
 func TestSupportRequestService_GetAllSupportRequests(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	supportRequests := []*models.SupportRequest{
 		{
@@ -212,10 +497,10 @@ func TestSupportRequestService_GetAllSupportRequests(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetAll", 0, 20).Return(supportRequests, int64(2), nil)
+	mockRepo.On("GetAll", repositories.SupportRequestFilter{}, 0, 20).Return(supportRequests, int64(2), nil)
 
 	// Act
-	responses, total, err := service.GetAllSupportRequests(1, 20)
+	responses, total, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{}, 1, 20)
 
 	// Assert
 	assert.NoError(t, err)
@@ -229,12 +514,12 @@ func TestSupportRequestService_GetAllSupportRequests(t *testing.T) {
 func TestSupportRequestService_GetAllSupportRequests_InvalidPagination(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetAll", 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
+	mockRepo.On("GetAll", repositories.SupportRequestFilter{}, 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
 
 	// Act - Test with invalid page and pageSize
-	responses, total, err := service.GetAllSupportRequests(0, 0)
+	responses, total, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{}, 0, 0)
 
 	// Assert
 	assert.NoError(t, err)
@@ -246,12 +531,12 @@ func TestSupportRequestService_GetAllSupportRequests_InvalidPagination(t *testin
 func TestSupportRequestService_GetAllSupportRequests_EmptyResult(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetAll", 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
+	mockRepo.On("GetAll", repositories.SupportRequestFilter{}, 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
 
 	// Act
-	responses, total, err := service.GetAllSupportRequests(1, 20)
+	responses, total, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{}, 1, 20)
 
 	// Assert
 	assert.NoError(t, err)
@@ -263,12 +548,12 @@ func TestSupportRequestService_GetAllSupportRequests_EmptyResult(t *testing.T) {
 func TestSupportRequestService_GetAllSupportRequests_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetAll", 0, 20).Return([]*models.SupportRequest(nil), int64(0), errors.New("database error"))
+	mockRepo.On("GetAll", repositories.SupportRequestFilter{}, 0, 20).Return([]*models.SupportRequest(nil), int64(0), errors.New("database error"))
 
 	// Act
-	responses, total, err := service.GetAllSupportRequests(1, 20)
+	responses, total, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{}, 1, 20)
 
 	// Assert
 	assert.Error(t, err)
@@ -281,13 +566,13 @@ func TestSupportRequestService_GetAllSupportRequests_RepositoryError(t *testing.
 func TestSupportRequestService_GetAllSupportRequests_LargePage(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	// Test with very large page size (should be capped to 20)
-	mockRepo.On("GetAll", 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
+	mockRepo.On("GetAll", repositories.SupportRequestFilter{}, 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
 
 	// Act
-	responses, total, err := service.GetAllSupportRequests(1, 1000)
+	responses, total, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{}, 1, 1000)
 
 	// Assert
 	assert.NoError(t, err)
@@ -299,13 +584,13 @@ func TestSupportRequestService_GetAllSupportRequests_LargePage(t *testing.T) {
 func TestSupportRequestService_GetAllSupportRequests_NegativePage(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	// Test with negative page (should be corrected to page 1)
-	mockRepo.On("GetAll", 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
+	mockRepo.On("GetAll", repositories.SupportRequestFilter{}, 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
 
 	// Act
-	responses, total, err := service.GetAllSupportRequests(-5, 20)
+	responses, total, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{}, -5, 20)
 
 	// Assert
 	assert.NoError(t, err)
@@ -317,7 +602,7 @@ func TestSupportRequestService_GetAllSupportRequests_NegativePage(t *testing.T)
 func TestSupportRequestService_UpdateSupportRequest(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	userEmail := "test@example.com"
 	originalRequest := &models.SupportRequest{
@@ -339,11 +624,14 @@ func TestSupportRequestService_UpdateSupportRequest(t *testing.T) {
 		AdminNotes: &adminNotes,
 	}
 
-	mockRepo.On("GetByID", uint(1)).Return(originalRequest, nil)
-	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest")).Return(nil)
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(originalRequest, nil)
+	var event *models.AuditEvent
+	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest"), mock.AnythingOfType("*models.AuditEvent")).
+		Run(func(args mock.Arguments) { event = args.Get(1).(*models.AuditEvent) }).
+		Return(nil)
 
 	// Act
-	response, err := service.UpdateSupportRequest(1, updateRequest)
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, updateRequest, testActor)
 
 	// Assert
 	assert.NoError(t, err)
@@ -351,22 +639,94 @@ func TestSupportRequestService_UpdateSupportRequest(t *testing.T) {
 	assert.Equal(t, models.StatusInProgress, response.Status)
 	assert.Equal(t, "Admin updated this", *response.AdminNotes)
 	mockRepo.AssertExpectations(t)
+
+	// The change is audited with the actor and the changed fields only
+	assert.Equal(t, models.AuditActionUpdate, event.Action)
+	assert.Equal(t, models.AuditEntitySupportRequest, event.EntityType)
+	assert.Equal(t, uint(1), event.EntityID)
+	assert.Equal(t, testActor.UserID, *event.ActorID)
+	assert.Equal(t, testActor.IPAddress, event.IPAddress)
+	changes := auditChanges(t, event)
+	assert.Equal(t, models.AuditChange{Before: "new", After: "in_progress"}, changes["status"])
+	assert.Equal(t, models.AuditChange{Before: nil, After: "Admin updated this"}, changes["admin_notes"])
+	assert.NotContains(t, changes, "message")
+}
+
+func TestSupportRequestService_UpdateSupportRequest_PriorityRecalculatesDeadlines(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	existing := &models.SupportRequest{ID: 1, Type: models.SupportRequestTypeBugReport, Status: models.StatusNew, Priority: models.PriorityNormal, CreatedAt: createdAt}
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(existing, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest"), mock.AnythingOfType("*models.AuditEvent")).Return(nil)
+
+	urgent := models.PriorityUrgent
+
+	// Act
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, &models.UpdateSupportRequestRequest{Priority: &urgent}, testActor)
+
+	// Assert - deadlines count from creation, not from the priority change
+	assert.NoError(t, err)
+	assert.Equal(t, models.PriorityUrgent, response.Priority)
+	assert.Equal(t, createdAt.Add(time.Hour), *response.FirstResponseDueAt)
+	assert.Equal(t, createdAt.Add(4*time.Hour), *response.ResolutionDueAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_UpdateSupportRequest_IllegalTransition(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusResolved}, nil)
+	newStatus := models.StatusNew
+
+	// Act
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, &models.UpdateSupportRequestRequest{Status: &newStatus}, testActor)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	assert.Nil(t, response)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSupportRequestService_UpdateSupportRequest_ResolveSetsResolvedAt(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{}).(*supportRequestService)
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusInProgress}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest"), mock.AnythingOfType("*models.AuditEvent")).Return(nil)
+	resolved := models.StatusResolved
+
+	// Act
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, &models.UpdateSupportRequestRequest{Status: &resolved}, testActor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusResolved, response.Status)
+	assert.Equal(t, now, *response.ResolvedAt)
+	assert.Nil(t, response.ClosedAt)
 }
 
 func TestSupportRequestService_UpdateSupportRequest_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	newStatus := models.StatusInProgress
 	updateRequest := &models.UpdateSupportRequestRequest{
 		Status: &newStatus,
 	}
 
-	mockRepo.On("GetByID", uint(999)).Return(nil, errors.New("not found"))
+	mockRepo.On("GetByID", uint(999), models.OrganizationScope{}).Return(nil, errors.New("not found"))
 
 	// Act
-	response, err := service.UpdateSupportRequest(999, updateRequest)
+	response, err := service.UpdateSupportRequest(999, models.OrganizationScope{}, updateRequest, testActor)
 
 	// Assert
 	assert.Error(t, err)
@@ -378,7 +738,7 @@ func TestSupportRequestService_UpdateSupportRequest_NotFound(t *testing.T) {
 func TestSupportRequestService_UpdateSupportRequest_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	userEmail := "test@example.com"
 	originalRequest := &models.SupportRequest{
@@ -398,11 +758,11 @@ func TestSupportRequestService_UpdateSupportRequest_RepositoryError(t *testing.T
 		Status: &newStatus,
 	}
 
-	mockRepo.On("GetByID", uint(1)).Return(originalRequest, nil)
-	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest")).Return(errors.New("database error"))
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(originalRequest, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest"), mock.AnythingOfType("*models.AuditEvent")).Return(errors.New("database error"))
 
 	// Act
-	response, err := service.UpdateSupportRequest(1, updateRequest)
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, updateRequest, testActor)
 
 	// Assert
 	assert.Error(t, err)
@@ -414,18 +774,18 @@ func TestSupportRequestService_UpdateSupportRequest_RepositoryError(t *testing.T
 func TestSupportRequestService_DeleteSupportRequest(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	supportRequest := &models.SupportRequest{
 		ID:     1,
 		Status: models.StatusNew,
 	}
 
-	mockRepo.On("GetByID", uint(1)).Return(supportRequest, nil)
-	mockRepo.On("Delete", uint(1)).Return(nil)
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(supportRequest, nil)
+	mockRepo.On("Delete", uint(1), mock.AnythingOfType("*models.AuditEvent")).Return(nil)
 
 	// Act
-	err := service.DeleteSupportRequest(1)
+	err := service.DeleteSupportRequest(1, models.OrganizationScope{}, testActor)
 
 	// Assert
 	assert.NoError(t, err)
@@ -435,12 +795,12 @@ func TestSupportRequestService_DeleteSupportRequest(t *testing.T) {
 func TestSupportRequestService_DeleteSupportRequest_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetByID", uint(999)).Return(nil, errors.New("not found"))
+	mockRepo.On("GetByID", uint(999), models.OrganizationScope{}).Return(nil, errors.New("not found"))
 
 	// Act
-	err := service.DeleteSupportRequest(999)
+	err := service.DeleteSupportRequest(999, models.OrganizationScope{}, testActor)
 
 	// Assert
 	assert.Error(t, err)
@@ -451,13 +811,13 @@ func TestSupportRequestService_DeleteSupportRequest_NotFound(t *testing.T) {
 func TestSupportRequestService_DeleteSupportRequest_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
-	mockRepo.On("GetByID", uint(1)).Return(&models.SupportRequest{ID: 1}, nil)
-	mockRepo.On("Delete", uint(1)).Return(errors.New("database error"))
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+	mockRepo.On("Delete", uint(1), mock.AnythingOfType("*models.AuditEvent")).Return(errors.New("database error"))
 
 	// Act
-	err := service.DeleteSupportRequest(1)
+	err := service.DeleteSupportRequest(1, models.OrganizationScope{}, testActor)
 
 	// Assert
 	assert.Error(t, err)
@@ -468,13 +828,395 @@ func TestSupportRequestService_DeleteSupportRequest_RepositoryError(t *testing.T
 func TestSupportRequestService_UpdateSupportRequest_NilRequest(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockSupportRequestRepository)
-	service := NewSupportRequestService(mockRepo)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
 
 	// Act
-	response, err := service.UpdateSupportRequest(1, nil)
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, nil, testActor)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, response)
 	assert.Equal(t, ErrInvalidRequest, err) // Service returns ErrInvalidRequest for nil requests
 }
+
+func TestSupportRequestService_GetAllSupportRequests_WithFilter(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	filter := repositories.SupportRequestFilter{
+		Statuses:  []models.Status{models.StatusNew},
+		Platforms: []models.Platform{models.PlatformIOS},
+		App:       "app-x",
+		SortBy:    "updated_at",
+		SortOrder: repositories.SortOrderAsc,
+	}
+	mockRepo.On("GetAll", filter, 20, 20).Return([]*models.SupportRequest{}, int64(21), nil)
+
+	// Act
+	responses, total, err := service.GetAllSupportRequests(filter, 2, 20)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, responses)
+	assert.Equal(t, int64(21), total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_GetAllSupportRequests_SLAFilter(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{}).(*supportRequestService)
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	expected := repositories.SupportRequestFilter{SLA: repositories.SLAStateDueSoon, SLAAsOf: now, SLADueSoon: 2 * time.Hour}
+	mockRepo.On("GetAll", expected, 0, 20).Return([]*models.SupportRequest{}, int64(0), nil)
+
+	// Act
+	_, _, err := service.GetAllSupportRequests(repositories.SupportRequestFilter{SLA: repositories.SLAStateDueSoon}, 1, 20)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_GetAllSupportRequests_InvalidFilter(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter repositories.SupportRequestFilter
+	}{
+		{"unknown status", repositories.SupportRequestFilter{Statuses: []models.Status{"open"}}},
+		{"unknown type", repositories.SupportRequestFilter{Types: []models.SupportRequestType{"question"}}},
+		{"unknown platform", repositories.SupportRequestFilter{Platforms: []models.Platform{"Windows"}}},
+		{"unknown priority", repositories.SupportRequestFilter{Priorities: []models.Priority{"critical"}}},
+		{"unknown sla state", repositories.SupportRequestFilter{SLA: "late"}},
+		{"unknown tag match", repositories.SupportRequestFilter{Tags: []string{"payments"}, TagMatch: "some"}},
+		{"unknown sort field", repositories.SupportRequestFilter{SortBy: "message"}},
+		{"unknown sort order", repositories.SupportRequestFilter{SortOrder: "sideways"}},
+		{"inverted created range", repositories.SupportRequestFilter{CreatedAfter: &now, CreatedBefore: &earlier}},
+		{"inverted updated range", repositories.SupportRequestFilter{UpdatedAfter: &now, UpdatedBefore: &earlier}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSupportRequestRepository)
+			service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+			// Act
+			responses, total, err := service.GetAllSupportRequests(tt.filter, 1, 20)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			assert.Nil(t, responses)
+			assert.Equal(t, int64(0), total)
+			mockRepo.AssertNotCalled(t, "GetAll")
+		})
+	}
+}
+
+func TestSupportRequestService_SearchSupportRequests(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	hits := []*repositories.SupportRequestSearchHit{
+		{
+			Request: &models.SupportRequest{ID: 7, Type: models.SupportRequestTypeBugReport, Message: "App crashes on launch", Status: models.StatusNew},
+			Rank:    0.5,
+			Snippet: "App <mark>crashes</mark> on <mark>launch</mark>",
+		},
+	}
+	filter := repositories.SupportRequestFilter{Platforms: []models.Platform{models.PlatformIOS}}
+	mockRepo.On("Search", "crash on launch", filter, 0, 20).Return(hits, int64(1), nil)
+
+	// Act
+	results, total, err := service.SearchSupportRequests("  crash on launch ", filter, 1, 20)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, results, 1)
+	assert.Equal(t, uint(7), results[0].ID)
+	assert.Equal(t, 0.5, results[0].Rank)
+	assert.Equal(t, "App <mark>crashes</mark> on <mark>launch</mark>", results[0].Snippet)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_SearchSupportRequests_InvalidQuery(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	// Act
+	_, _, emptyErr := service.SearchSupportRequests("   ", repositories.SupportRequestFilter{}, 1, 20)
+	_, _, longErr := service.SearchSupportRequests(strings.Repeat("a", 201), repositories.SupportRequestFilter{}, 1, 20)
+	_, _, filterErr := service.SearchSupportRequests("crash", repositories.SupportRequestFilter{SortBy: "message"}, 1, 20)
+
+	// Assert
+	assert.ErrorIs(t, emptyErr, ErrInvalidFilter)
+	assert.ErrorIs(t, longErr, ErrInvalidFilter)
+	assert.ErrorIs(t, filterErr, ErrInvalidFilter)
+	mockRepo.AssertNotCalled(t, "Search")
+}
+
+func TestSupportRequestService_SearchSupportRequests_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	mockRepo.On("Search", "crash", repositories.SupportRequestFilter{}, 0, 20).Return([]*repositories.SupportRequestSearchHit(nil), int64(0), errors.New("database error"))
+
+	// Act
+	results, total, err := service.SearchSupportRequests("crash", repositories.SupportRequestFilter{}, 1, 20)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, results)
+	assert.Equal(t, int64(0), total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_CreateSupportRequest_PublishesCreated(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	publisher := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, publisher)
+
+	mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(nil)
+
+	// Act
+	response, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestCreated}, publisher.types())
+	published := *response
+	published.TrackingToken = ""
+	assert.Equal(t, &published, publisher.events[0].Data)
+}
+
+func TestSupportRequestService_CreateSupportRequest_RepositoryErrorPublishesNothing(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	publisher := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, publisher)
+
+	mockRepo.On("Create", mock.AnythingOfType("*models.SupportRequest")).Return(errors.New("database error"))
+
+	// Act
+	_, err := service.CreateSupportRequest(&models.CreateSupportRequestRequest{Type: models.SupportRequestTypeSupport, Message: "Help", Platform: models.PlatformIOS, AppVersion: "1.0.0", DeviceModel: "iPhone 13", App: "test-app"}, nil)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, publisher.events)
+}
+
+func TestSupportRequestService_UpdateSupportRequest_PublishesStatusChanged(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	publisher := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, publisher)
+
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusNew, Priority: models.PriorityNormal}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest"), mock.AnythingOfType("*models.AuditEvent")).Return(nil)
+	newStatus := models.StatusInProgress
+
+	// Act
+	response, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, &models.UpdateSupportRequestRequest{Status: &newStatus}, testActor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestUpdated, models.WebhookEventSupportRequestStatusChanged}, publisher.types())
+	change := publisher.events[1].Data.(*models.SupportRequestStatusChange)
+	assert.Equal(t, response, change.SupportRequest)
+	assert.Equal(t, models.StatusNew, change.PreviousStatus)
+}
+
+func TestSupportRequestService_UpdateSupportRequest_SameStatusPublishesUpdatedOnly(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	publisher := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, publisher)
+
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusNew, Priority: models.PriorityNormal}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*models.SupportRequest"), mock.AnythingOfType("*models.AuditEvent")).Return(nil)
+	notes := "Looking into it"
+
+	// Act
+	_, err := service.UpdateSupportRequest(1, models.OrganizationScope{}, &models.UpdateSupportRequestRequest{AdminNotes: &notes}, testActor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestUpdated}, publisher.types())
+}
+
+func TestSupportRequestService_DeleteSupportRequest_PublishesDeleted(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	publisher := &recordingEventPublisher{}
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, publisher)
+
+	mockRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1, Status: models.StatusNew}, nil)
+	mockRepo.On("Delete", uint(1), mock.AnythingOfType("*models.AuditEvent")).Return(nil)
+
+	// Act
+	err := service.DeleteSupportRequest(1, models.OrganizationScope{}, testActor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestDeleted}, publisher.types())
+	assert.Equal(t, uint(1), publisher.events[0].Data.(*models.SupportRequestResponse).ID)
+}
+
+func TestSupportRequestService_GetSupportRequestStats(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{}).(*supportRequestService)
+	from := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC) // Wednesday
+	to := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+	median := 3600.0
+
+	mockRepo.On("Stats", mock.MatchedBy(func(filter repositories.SupportRequestFilter) bool {
+		return filter.CreatedAfter.Equal(from) && filter.CreatedBefore.Equal(to) && filter.App == "test-app"
+	}), models.StatsIntervalWeek).Return(&repositories.SupportRequestStats{
+		ByStatus:                   []models.SupportRequestCount{{Value: "new", Count: 3}, {Value: "resolved", Count: 2}},
+		Series:                     []models.SupportRequestBucket{{Start: "2025-06-09", Count: 5}},
+		MedianFirstResponseSeconds: &median,
+	}, nil)
+
+	// Act
+	stats, err := service.GetSupportRequestStats(repositories.SupportRequestFilter{App: "test-app", CreatedAfter: &from, CreatedBefore: &to}, models.StatsIntervalWeek)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Total)
+	assert.Equal(t, []models.SupportRequestBucket{
+		{Start: "2025-06-02", Count: 0},
+		{Start: "2025-06-09", Count: 5},
+		{Start: "2025-06-16", Count: 0},
+	}, stats.Series)
+	assert.Equal(t, &median, stats.MedianFirstResponseSeconds)
+	assert.Nil(t, stats.MedianResolutionSeconds)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_GetSupportRequestStats_DefaultRange(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{}).(*supportRequestService)
+	now := time.Date(2025, 6, 30, 15, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	mockRepo.On("Stats", mock.MatchedBy(func(filter repositories.SupportRequestFilter) bool {
+		return filter.CreatedAfter.Equal(now.AddDate(0, 0, -30)) && filter.CreatedBefore.Equal(now)
+	}), models.StatsIntervalDay).Return(&repositories.SupportRequestStats{}, nil)
+
+	// Act
+	stats, err := service.GetSupportRequestStats(repositories.SupportRequestFilter{}, "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatsIntervalDay, stats.Interval)
+	assert.Len(t, stats.Series, 31)
+	assert.Equal(t, "2025-05-31", stats.Series[0].Start)
+	assert.Equal(t, "2025-06-30", stats.Series[30].Start)
+}
+
+func TestSupportRequestService_GetSupportRequestStats_InvalidQuery(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		filter   repositories.SupportRequestFilter
+		interval string
+	}{
+		{"unknown interval", repositories.SupportRequestFilter{}, "year"},
+		{"unknown status", repositories.SupportRequestFilter{Statuses: []models.Status{"done"}}, models.StatsIntervalDay},
+		{"too many buckets", repositories.SupportRequestFilter{CreatedAfter: &from, CreatedBefore: &to}, models.StatsIntervalDay},
+		{"range in the future", repositories.SupportRequestFilter{CreatedAfter: &future}, models.StatsIntervalDay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSupportRequestRepository)
+			service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+			// Act
+			stats, err := service.GetSupportRequestStats(tt.filter, tt.interval)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			assert.Nil(t, stats)
+			mockRepo.AssertNotCalled(t, "Stats", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSupportRequestService_GetSupportRequestStats_MonthlyAcrossYears(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	from := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Stats", mock.Anything, models.StatsIntervalMonth).Return(&repositories.SupportRequestStats{}, nil)
+
+	// Act
+	stats, err := service.GetSupportRequestStats(repositories.SupportRequestFilter{CreatedAfter: &from, CreatedBefore: &to}, models.StatsIntervalMonth)
+
+	// Assert
+	assert.NoError(t, err)
+	starts := make([]string, len(stats.Series))
+	for i, bucket := range stats.Series {
+		starts[i] = bucket.Start
+	}
+	assert.Equal(t, []string{"2024-11-01", "2024-12-01", "2025-01-01"}, starts)
+}
+
+func TestSupportRequestService_ExportSupportRequests(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+	requests := []*models.SupportRequest{
+		{ID: 1, Message: "First", Tags: []*models.Tag{{Name: "payments"}}},
+		{ID: 2, Message: "Second"},
+	}
+	filter := repositories.SupportRequestFilter{App: "test-app"}
+	mockRepo.On("Export", filter).Return(requests, nil)
+
+	// Act
+	var exported []*models.SupportRequestResponse
+	err := service.ExportSupportRequests(filter, func(response *models.SupportRequestResponse) error {
+		exported = append(exported, response)
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, exported, 2)
+	assert.Equal(t, []string{"payments"}, exported[0].Tags)
+	assert.Equal(t, uint(2), exported[1].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestService_ExportSupportRequests_InvalidFilter(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSupportRequestRepository)
+	service := NewSupportRequestService(mockRepo, registeredApps(), defaultSLAPolicy(), DuplicatePolicy{}, &recordingEventPublisher{})
+
+	// Act
+	err := service.ExportSupportRequests(repositories.SupportRequestFilter{Statuses: []models.Status{"done"}}, func(*models.SupportRequestResponse) error {
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+	mockRepo.AssertNotCalled(t, "Export", mock.Anything)
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// trackingTokenPrefix starts every tracking token, so tokens are easy to recognize in links and logs
+const trackingTokenPrefix = "trk_"
+
+// SupportRequestTrackingService defines the interface for the submitter-facing view of support requests
+type SupportRequestTrackingService interface {
+	TrackSupportRequest(token string) (*models.SupportRequestTrackingResponse, error)
+	ReplyToSupportRequest(token string, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error)
+}
+
+// supportRequestTrackingService implements SupportRequestTrackingService
+type supportRequestTrackingService struct {
+	supportRepo    repositories.SupportRequestRepository
+	messageRepo    repositories.SupportRequestMessageRepository
+	messageService SupportRequestMessageService
+}
+
+// NewSupportRequestTrackingService creates a new support request tracking service
+func NewSupportRequestTrackingService(supportRepo repositories.SupportRequestRepository, messageRepo repositories.SupportRequestMessageRepository, messageService SupportRequestMessageService) SupportRequestTrackingService {
+	return &supportRequestTrackingService{
+		supportRepo:    supportRepo,
+		messageRepo:    messageRepo,
+		messageService: messageService,
+	}
+}
+
+// TrackSupportRequest returns the status and public replies of the support request a tracking token
+// was issued for, or of the request it was merged into. Unknown tokens are reported as
+// ErrSupportRequestNotFound.
+func (s *supportRequestTrackingService) TrackSupportRequest(token string) (*models.SupportRequestTrackingResponse, error) {
+	supportRequest, err := s.getByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageRepo.GetBySupportRequestID(supportRequest.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return supportRequest.ToTrackingResponse(messages), nil
+}
+
+// ReplyToSupportRequest posts a submitter reply on the support request a tracking token was issued
+// for, or on the request it was merged into
+func (s *supportRequestTrackingService) ReplyToSupportRequest(token string, actor models.AuditActor, req *models.CreateSupportRequestMessageRequest) (*models.SupportRequestMessageResponse, error) {
+	supportRequest, err := s.getByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return s.messageService.AddSubmitterReply(supportRequest.ID, actor, req)
+}
+
+// getByToken loads the support request a tracking token was issued for, following merges
+func (s *supportRequestTrackingService) getByToken(token string) (*models.SupportRequest, error) {
+	if !strings.HasPrefix(token, trackingTokenPrefix) {
+		return nil, ErrSupportRequestNotFound
+	}
+
+	supportRequest, err := s.supportRepo.GetByTrackingTokenHash(hashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSupportRequestNotFound
+		}
+		return nil, err
+	}
+	if supportRequest.IsMerged() {
+		return s.mergedTarget(supportRequest)
+	}
+	return supportRequest, nil
+}
+
+// mergedTarget returns the request a merged one was merged into when both have the same submitter,
+// so the token keeps working, and the closed stub otherwise, not to show another submitter's request
+func (s *supportRequestTrackingService) mergedTarget(supportRequest *models.SupportRequest) (*models.SupportRequest, error) {
+	target, err := s.supportRepo.GetByID(*supportRequest.MergedIntoID, models.OrganizationScope{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return supportRequest, nil
+		}
+		return nil, err
+	}
+	if !sameEmail(supportRequest.UserEmail, target.UserEmail) {
+		return supportRequest, nil
+	}
+	return target, nil
+}
+
+// newTrackingToken generates the random token a submitter uses to follow a support request
+func newTrackingToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return trackingTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
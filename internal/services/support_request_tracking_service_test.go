@@ -0,0 +1,150 @@
+package services
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// newTestTrackingService creates a tracking service posting replies through a real message service
+func newTestTrackingService(supportRepo *MockSupportRequestRepository, messageRepo *MockSupportRequestMessageRepository) SupportRequestTrackingService {
+	messageService := NewSupportRequestMessageService(messageRepo, supportRepo, &recordingReplyNotifier{}, &recordingEventPublisher{})
+	return NewSupportRequestTrackingService(supportRepo, messageRepo, messageService)
+}
+
+func TestSupportRequestTrackingService_TrackSupportRequest(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	service := newTestTrackingService(mockSupportRepo, mockMessageRepo)
+
+	token := "trk_abc"
+	email := "jane.doe@example.com"
+	notes := "Refund approved"
+	mockSupportRepo.On("GetByTrackingTokenHash", hashToken(token)).Return(&models.SupportRequest{ID: 7, UserEmail: &email, AdminNotes: &notes, Status: models.StatusWaitingOnCustomer}, nil)
+	mockMessageRepo.On("GetBySupportRequestID", uint(7), false).Return([]*models.SupportRequestMessage{
+		{ID: 1, AuthorType: models.MessageAuthorAgent, Body: "Could you send a screenshot?", Visibility: models.MessageVisibilityPublic},
+	}, nil)
+
+	// Act
+	response, err := service.TrackSupportRequest(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, models.StatusWaitingOnCustomer, response.Status)
+	assert.Equal(t, "j***@example.com", *response.UserEmail)
+	assert.Len(t, response.Replies, 1)
+	assert.Equal(t, "Could you send a screenshot?", response.Replies[0].Body)
+	mockSupportRepo.AssertExpectations(t)
+	mockMessageRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestTrackingService_TrackSupportRequest_UnknownToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"unknown token", "trk_unknown"},
+		{"not a tracking token", "ak_0123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSupportRepo := new(MockSupportRequestRepository)
+			service := newTestTrackingService(mockSupportRepo, new(MockSupportRequestMessageRepository))
+			mockSupportRepo.On("GetByTrackingTokenHash", hashToken("trk_unknown")).Return(nil, gorm.ErrRecordNotFound).Maybe()
+
+			// Act
+			response, err := service.TrackSupportRequest(tt.token)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrSupportRequestNotFound)
+			assert.Nil(t, response)
+		})
+	}
+}
+
+func TestSupportRequestTrackingService_TrackSupportRequest_Merged(t *testing.T) {
+	sourceEmail := "jane.doe@example.com"
+	sameEmail := "Jane.Doe@example.com"
+	otherEmail := "john@example.com"
+
+	tests := []struct {
+		name        string
+		targetEmail *string
+		expectedID  uint
+	}{
+		{"same submitter", &sameEmail, 5},
+		{"other submitter", &otherEmail, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSupportRepo := new(MockSupportRequestRepository)
+			mockMessageRepo := new(MockSupportRequestMessageRepository)
+			service := newTestTrackingService(mockSupportRepo, mockMessageRepo)
+
+			token := "trk_abc"
+			targetID := uint(5)
+			mockSupportRepo.On("GetByTrackingTokenHash", hashToken(token)).Return(&models.SupportRequest{ID: 7, UserEmail: &sourceEmail, Status: models.StatusClosed, MergedIntoID: &targetID}, nil)
+			mockSupportRepo.On("GetByID", targetID, models.OrganizationScope{}).Return(&models.SupportRequest{ID: 5, UserEmail: tt.targetEmail, Status: models.StatusInProgress}, nil)
+			mockMessageRepo.On("GetBySupportRequestID", tt.expectedID, false).Return([]*models.SupportRequestMessage{}, nil)
+
+			// Act
+			response, err := service.TrackSupportRequest(token)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, response.ID)
+			mockMessageRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSupportRequestTrackingService_ReplyToSupportRequest(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	service := newTestTrackingService(mockSupportRepo, mockMessageRepo)
+
+	token := "trk_abc"
+	email := "jane.doe@example.com"
+	mockSupportRepo.On("GetByTrackingTokenHash", hashToken(token)).Return(&models.SupportRequest{ID: 7, UserEmail: &email, Status: models.StatusWaitingOnCustomer}, nil)
+	mockSupportRepo.On("GetByID", uint(7), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 7, UserEmail: &email, Status: models.StatusWaitingOnCustomer}, nil)
+	mockMessageRepo.On("Create", mock.MatchedBy(func(message *models.SupportRequestMessage) bool {
+		return message.SupportRequestID == 7 && message.AuthorType == models.MessageAuthorSubmitter
+	}), mock.MatchedBy(func(req *models.SupportRequest) bool {
+		return req.Status == models.StatusInProgress
+	}), mock.Anything).Return(nil)
+
+	// Act
+	response, err := service.ReplyToSupportRequest(token, models.AuditActor{IPAddress: "203.0.113.7"}, &models.CreateSupportRequestMessageRequest{Body: "Here is the screenshot"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.MessageAuthorSubmitter, response.AuthorType)
+	assert.Equal(t, models.MessageVisibilityPublic, response.Visibility)
+	mockMessageRepo.AssertExpectations(t)
+}
+
+func TestSupportRequestTrackingService_ReplyToSupportRequest_UnknownToken(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	mockMessageRepo := new(MockSupportRequestMessageRepository)
+	service := newTestTrackingService(mockSupportRepo, mockMessageRepo)
+	mockSupportRepo.On("GetByTrackingTokenHash", hashToken("trk_unknown")).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	response, err := service.ReplyToSupportRequest("trk_unknown", models.AuditActor{}, &models.CreateSupportRequestMessageRequest{Body: "Hello"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrSupportRequestNotFound)
+	assert.Nil(t, response)
+	mockMessageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagAlreadyExists = errors.New("tag already exists")
+	ErrInvalidTagName   = errors.New("invalid tag name")
+)
+
+// TagService defines the interface for tag business logic
+type TagService interface {
+	ListTags() ([]*models.TagResponse, error)
+	CreateTag(req *models.CreateTagRequest) (*models.TagResponse, error)
+	UpdateTag(id uint, req *models.UpdateTagRequest) (*models.TagResponse, error)
+	DeleteTag(id uint) error
+	AddTagsToSupportRequest(supportRequestID uint, scope models.OrganizationScope, names []string) (*models.SupportRequestResponse, error)
+	RemoveTagFromSupportRequest(supportRequestID uint, scope models.OrganizationScope, name string) (*models.SupportRequestResponse, error)
+}
+
+// tagService implements TagService
+type tagService struct {
+	tagRepo     repositories.TagRepository
+	supportRepo repositories.SupportRequestRepository
+}
+
+// NewTagService creates a new tag service
+func NewTagService(tagRepo repositories.TagRepository, supportRepo repositories.SupportRequestRepository) TagService {
+	return &tagService{
+		tagRepo:     tagRepo,
+		supportRepo: supportRepo,
+	}
+}
+
+// ListTags retrieves every tag ordered by name
+func (s *tagService) ListTags() ([]*models.TagResponse, error) {
+	tags, err := s.tagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = tag.ToResponse()
+	}
+	return responses, nil
+}
+
+// CreateTag creates a tag with a normalized, unique name
+func (s *tagService) CreateTag(req *models.CreateTagRequest) (*models.TagResponse, error) {
+	name, err := s.availableName(req.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &models.Tag{
+		Name:  name,
+		Color: normalizeColor(req.Color),
+	}
+	if err := s.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+
+	return tag.ToResponse(), nil
+}
+
+// UpdateTag renames or recolors a tag. Renaming keeps the tag on every support request it is attached to.
+func (s *tagService) UpdateTag(id uint, req *models.UpdateTagRequest) (*models.TagResponse, error) {
+	tag, err := s.getTag(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name, err := s.availableName(*req.Name, tag.ID)
+		if err != nil {
+			return nil, err
+		}
+		tag.Name = name
+	}
+	if req.Color != nil {
+		tag.Color = normalizeColor(req.Color)
+	}
+
+	if err := s.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+
+	return tag.ToResponse(), nil
+}
+
+// DeleteTag deletes a tag and removes it from every support request
+func (s *tagService) DeleteTag(id uint) error {
+	if _, err := s.getTag(id); err != nil {
+		return err
+	}
+	return s.tagRepo.Delete(id)
+}
+
+// AddTagsToSupportRequest attaches existing tags to a support request. Every name must refer to an
+// existing tag; tags the request already carries are ignored.
+func (s *tagService) AddTagsToSupportRequest(supportRequestID uint, scope models.OrganizationScope, names []string) (*models.SupportRequestResponse, error) {
+	supportRequest, err := s.getSupportRequest(supportRequestID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized = append(normalized, models.NormalizeTagName(name))
+	}
+
+	tags, err := s.tagRepo.GetByNames(normalized)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingTagNames(normalized, tags); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTagNotFound, strings.Join(missing, ", "))
+	}
+
+	if err := s.tagRepo.AddToSupportRequest(supportRequest, tags); err != nil {
+		return nil, err
+	}
+
+	return s.reload(supportRequestID, scope)
+}
+
+// RemoveTagFromSupportRequest detaches a tag from a support request
+func (s *tagService) RemoveTagFromSupportRequest(supportRequestID uint, scope models.OrganizationScope, name string) (*models.SupportRequestResponse, error) {
+	supportRequest, err := s.getSupportRequest(supportRequestID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tagRepo.GetByName(models.NormalizeTagName(name))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.tagRepo.RemoveFromSupportRequest(supportRequest, tag); err != nil {
+		return nil, err
+	}
+
+	return s.reload(supportRequestID, scope)
+}
+
+// availableName normalizes and validates name, making sure no tag other than exceptID uses it
+func (s *tagService) availableName(name string, exceptID uint) (string, error) {
+	name = models.NormalizeTagName(name)
+	if !models.IsValidTagName(name) {
+		return "", fmt.Errorf("%w: use up to 50 lowercase letters, digits, '.', '_' or '-'", ErrInvalidTagName)
+	}
+
+	existing, err := s.tagRepo.GetByName(name)
+	if err == nil && existing.ID != exceptID {
+		return "", ErrTagAlreadyExists
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	return name, nil
+}
+
+// getTag loads a tag, mapping missing records to ErrTagNotFound
+func (s *tagService) getTag(id uint) (*models.Tag, error) {
+	tag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// getSupportRequest loads a support request within scope, mapping missing records to ErrSupportRequestNotFound
+func (s *tagService) getSupportRequest(id uint, scope models.OrganizationScope) (*models.SupportRequest, error) {
+	supportRequest, err := s.supportRepo.GetByID(id, scope)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSupportRequestNotFound
+		}
+		return nil, err
+	}
+	return supportRequest, nil
+}
+
+// reload fetches a support request again so the response reflects its current tags
+func (s *tagService) reload(id uint, scope models.OrganizationScope) (*models.SupportRequestResponse, error) {
+	supportRequest, err := s.getSupportRequest(id, scope)
+	if err != nil {
+		return nil, err
+	}
+	return supportRequest.ToResponse(), nil
+}
+
+// missingTagNames returns the names that have no matching tag
+func missingTagNames(names []string, tags []*models.Tag) []string {
+	found := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		found[tag.Name] = true
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !found[name] {
+			missing = append(missing, name)
+			found[name] = true // report each name once
+		}
+	}
+	return missing
+}
+
+// normalizeColor lower-cases a hex color so equal colors compare equal
+func normalizeColor(color *string) *string {
+	if color == nil {
+		return nil
+	}
+	normalized := strings.ToLower(*color)
+	return &normalized
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"support-app-backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTagRepository is a mock implementation of TagRepository
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(tag *models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) GetByID(id uint) (*models.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByName(name string) (*models.Tag, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByNames(names []string) ([]*models.Tag, error) {
+	args := m.Called(names)
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetAll() ([]*models.Tag, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(tag *models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) AddToSupportRequest(request *models.SupportRequest, tags []*models.Tag) error {
+	args := m.Called(request, tags)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) RemoveFromSupportRequest(request *models.SupportRequest, tag *models.Tag) error {
+	args := m.Called(request, tag)
+	return args.Error(0)
+}
+
+func TestTagService_CreateTag(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	service := NewTagService(mockTagRepo, new(MockSupportRequestRepository))
+
+	color := "#FF8800"
+	mockTagRepo.On("GetByName", "payments").Return(nil, gorm.ErrRecordNotFound)
+	mockTagRepo.On("Create", mock.MatchedBy(func(tag *models.Tag) bool {
+		return tag.Name == "payments" && *tag.Color == "#ff8800"
+	})).Return(nil)
+
+	// Act
+	result, err := service.CreateTag(&models.CreateTagRequest{Name: " Payments ", Color: &color})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", result.Name)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagService_CreateTag_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagName     string
+		existing    *models.Tag
+		expectedErr error
+	}{
+		{"invalid name", "has space", nil, ErrInvalidTagName},
+		{"duplicate name", "payments", &models.Tag{ID: 1, Name: "payments"}, ErrTagAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTagRepo := new(MockTagRepository)
+			service := NewTagService(mockTagRepo, new(MockSupportRequestRepository))
+			if tt.existing != nil {
+				mockTagRepo.On("GetByName", tt.tagName).Return(tt.existing, nil)
+			}
+
+			// Act
+			result, err := service.CreateTag(&models.CreateTagRequest{Name: tt.tagName})
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, result)
+			mockTagRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestTagService_UpdateTag(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	service := NewTagService(mockTagRepo, new(MockSupportRequestRepository))
+
+	name := "billing"
+	mockTagRepo.On("GetByID", uint(1)).Return(&models.Tag{ID: 1, Name: "payments"}, nil)
+	mockTagRepo.On("GetByName", "billing").Return(nil, gorm.ErrRecordNotFound)
+	mockTagRepo.On("Update", mock.MatchedBy(func(tag *models.Tag) bool {
+		return tag.Name == "billing"
+	})).Return(nil)
+
+	// Act
+	result, err := service.UpdateTag(1, &models.UpdateTagRequest{Name: &name})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", result.Name)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagService_UpdateTag_KeepsOwnName(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	service := NewTagService(mockTagRepo, new(MockSupportRequestRepository))
+
+	name := "Payments"
+	tag := &models.Tag{ID: 1, Name: "payments"}
+	mockTagRepo.On("GetByID", uint(1)).Return(tag, nil)
+	mockTagRepo.On("GetByName", "payments").Return(tag, nil)
+	mockTagRepo.On("Update", tag).Return(nil)
+
+	// Act
+	_, err := service.UpdateTag(1, &models.UpdateTagRequest{Name: &name})
+
+	// Assert
+	assert.NoError(t, err)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagService_DeleteTag_NotFound(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	service := NewTagService(mockTagRepo, new(MockSupportRequestRepository))
+	mockTagRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := service.DeleteTag(9)
+
+	// Assert
+	assert.Equal(t, ErrTagNotFound, err)
+	mockTagRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestTagService_AddTagsToSupportRequest(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewTagService(mockTagRepo, mockSupportRepo)
+
+	supportRequest := &models.SupportRequest{ID: 1, Status: models.StatusNew}
+	tags := []*models.Tag{{ID: 1, Name: "login"}, {ID: 2, Name: "payments"}}
+	tagged := &models.SupportRequest{ID: 1, Status: models.StatusNew, Tags: tags}
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(supportRequest, nil).Once()
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(tagged, nil).Once()
+	mockTagRepo.On("GetByNames", []string{"payments", "login"}).Return(tags, nil)
+	mockTagRepo.On("AddToSupportRequest", supportRequest, tags).Return(nil)
+
+	// Act
+	result, err := service.AddTagsToSupportRequest(1, models.OrganizationScope{}, []string{"Payments", "login"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"login", "payments"}, result.Tags)
+	mockTagRepo.AssertExpectations(t)
+	mockSupportRepo.AssertExpectations(t)
+}
+
+func TestTagService_AddTagsToSupportRequest_UnknownTag(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewTagService(mockTagRepo, mockSupportRepo)
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+	mockTagRepo.On("GetByNames", []string{"payments", "nope"}).Return([]*models.Tag{{ID: 2, Name: "payments"}}, nil)
+
+	// Act
+	result, err := service.AddTagsToSupportRequest(1, models.OrganizationScope{}, []string{"payments", "nope"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTagNotFound)
+	assert.Contains(t, err.Error(), "nope")
+	assert.Nil(t, result)
+	mockTagRepo.AssertNotCalled(t, "AddToSupportRequest", mock.Anything, mock.Anything)
+}
+
+func TestTagService_AddTagsToSupportRequest_SupportRequestNotFound(t *testing.T) {
+	// Arrange
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewTagService(new(MockTagRepository), mockSupportRepo)
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	result, err := service.AddTagsToSupportRequest(1, models.OrganizationScope{}, []string{"payments"})
+
+	// Assert
+	assert.Equal(t, ErrSupportRequestNotFound, err)
+	assert.Nil(t, result)
+}
+
+func TestTagService_RemoveTagFromSupportRequest(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewTagService(mockTagRepo, mockSupportRepo)
+
+	supportRequest := &models.SupportRequest{ID: 1, Tags: []*models.Tag{{ID: 2, Name: "payments"}}}
+	tag := &models.Tag{ID: 2, Name: "payments"}
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(supportRequest, nil).Once()
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil).Once()
+	mockTagRepo.On("GetByName", "payments").Return(tag, nil)
+	mockTagRepo.On("RemoveFromSupportRequest", supportRequest, tag).Return(nil)
+
+	// Act
+	result, err := service.RemoveTagFromSupportRequest(1, models.OrganizationScope{}, "Payments")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.Tags)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagService_RemoveTagFromSupportRequest_UnknownTag(t *testing.T) {
+	// Arrange
+	mockTagRepo := new(MockTagRepository)
+	mockSupportRepo := new(MockSupportRequestRepository)
+	service := NewTagService(mockTagRepo, mockSupportRepo)
+
+	mockSupportRepo.On("GetByID", uint(1), models.OrganizationScope{}).Return(&models.SupportRequest{ID: 1}, nil)
+	mockTagRepo.On("GetByName", "nope").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	result, err := service.RemoveTagFromSupportRequest(1, models.OrganizationScope{}, "nope")
+
+	// Assert
+	assert.Equal(t, ErrTagNotFound, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature" // "sha256=" followed by the hex HMAC-SHA256 of the body
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookEventIDHeader   = "X-Webhook-Event-Id" // Shared by redeliveries, so receivers can deduplicate
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// webhookDueBatchSize bounds how many due deliveries are loaded at once
+const webhookDueBatchSize = 50
+
+// maxWebhookErrorLength bounds the error message stored for a failed attempt
+const maxWebhookErrorLength = 500
+
+// WebhookDispatcher persists webhook deliveries for published events and sends them, retrying failed
+// attempts with exponential backoff. It implements EventPublisher.
+type WebhookDispatcher struct {
+	webhooks   repositories.WebhookRepository
+	deliveries repositories.WebhookDeliveryRepository
+	config     config.WebhookConfig
+	client     *http.Client
+	now        func() time.Time
+	wake       chan struct{}
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher. Deliveries are only sent while Run is active.
+func NewWebhookDispatcher(webhooks repositories.WebhookRepository, deliveries repositories.WebhookDeliveryRepository, cfg config.WebhookConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		config:     cfg,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		now:        time.Now,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Publish queues a delivery of event to every active webhook subscribed to it
+func (d *WebhookDispatcher) Publish(event models.WebhookEventType, data interface{}) {
+	if err := d.enqueue(event, data); err != nil {
+		log.Printf("Warning: Failed to queue %s webhooks: %v", event, err)
+		return
+	}
+	d.Wake()
+}
+
+// enqueue stores one pending delivery per subscribed webhook
+func (d *WebhookDispatcher) enqueue(event models.WebhookEventType, data interface{}) error {
+	webhooks, err := d.webhooks.GetActiveByEvent(event)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		return err
+	}
+	now := d.now()
+	payload, err := json.Marshal(&models.WebhookPayload{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*models.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = &models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+	}
+	return d.deliveries.Create(deliveries...)
+}
+
+// Wake makes a running dispatcher look for due deliveries without waiting for the next poll
+func (d *WebhookDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default: // a wake up is already pending
+	}
+}
+
+// Run sends due deliveries until ctx is cancelled, polling for retries every PollInterval
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil {
+			log.Printf("Warning: Failed to deliver webhooks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// DeliverDue attempts every delivery that is due and returns how many were attempted
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for ctx.Err() == nil {
+		now := d.now()
+		due, err := d.deliveries.GetDue(now, webhookDueBatchSize)
+		if err != nil {
+			return attempted, err
+		}
+
+		claimedAny := false
+		for _, delivery := range due {
+			// Lease the delivery for longer than an attempt can take, so a crashed attempt is retried
+			claimed, err := d.deliveries.Claim(delivery, now, now.Add(2*d.config.Timeout))
+			if err != nil {
+				return attempted, err
+			}
+			if !claimed {
+				continue // another worker got there first
+			}
+			claimedAny = true
+
+			if err := d.attempt(ctx, delivery); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+
+		if len(due) < webhookDueBatchSize || !claimedAny {
+			return attempted, nil
+		}
+	}
+	return attempted, ctx.Err()
+}
+
+// attempt sends a delivery once and records the outcome, scheduling a retry when it failed
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	webhook, err := d.webhooks.GetByID(delivery.WebhookID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	var statusCode int
+	var sendErr error
+	switch {
+	case webhook == nil:
+		sendErr = errors.New("webhook has been deleted")
+	case !webhook.IsActive:
+		sendErr = errors.New("webhook is inactive")
+	default:
+		statusCode, sendErr = d.send(ctx, webhook, delivery)
+	}
+
+	now := d.now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	if statusCode != 0 {
+		delivery.ResponseStatus = &statusCode
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+	case webhook == nil || !webhook.IsActive || delivery.Attempts >= d.config.MaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = webhookErrorMessage(sendErr)
+	default:
+		next := now.Add(d.retryDelay(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.LastError = webhookErrorMessage(sendErr)
+	}
+
+	return d.deliveries.Update(delivery)
+}
+
+// send posts the signed payload to the webhook. Any non-2xx response is an error.
+func (d *WebhookDispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "support-app-webhooks/1.0")
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, body))
+	req.Header.Set(WebhookEventHeader, string(delivery.Event))
+	req.Header.Set(WebhookEventIDHeader, delivery.EventID)
+	req.Header.Set(WebhookDeliveryHeader, fmt.Sprint(delivery.ID))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryDelay returns the backoff after the given number of failed attempts: RetryBackoff, doubled for every
+// attempt after the first
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.config.RetryBackoff
+	for i := 1; i < attempts && delay < 24*time.Hour; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+// SignWebhookPayload returns the signature header value of body: "sha256=" followed by the hex encoded
+// HMAC-SHA256 of body keyed with secret
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookEventID generates a random event ID
+func newWebhookEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// webhookErrorMessage truncates err for the delivery log
+func webhookErrorMessage(err error) *string {
+	message := err.Error()
+	if len(message) > maxWebhookErrorLength {
+		message = message[:maxWebhookErrorLength]
+	}
+	return &message
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"support-app-backend/internal/config"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testWebhookConfig = config.WebhookConfig{
+	MaxAttempts:  3,
+	RetryBackoff: time.Minute,
+	Timeout:      5 * time.Second,
+	PollInterval: time.Second,
+}
+
+// receivedWebhook is a request captured by the test receiver
+type receivedWebhook struct {
+	Headers http.Header
+	Body    []byte
+}
+
+// newWebhookReceiver starts an httptest server answering every request with status
+func newWebhookReceiver(t *testing.T, status int) (*httptest.Server, *[]receivedWebhook) {
+	var received []receivedWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, receivedWebhook{Headers: r.Header.Clone(), Body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func newTestWebhookDispatcher(now time.Time) (*WebhookDispatcher, *MockWebhookRepository, *MockWebhookDeliveryRepository) {
+	webhookRepo := new(MockWebhookRepository)
+	deliveryRepo := new(MockWebhookDeliveryRepository)
+	dispatcher := NewWebhookDispatcher(webhookRepo, deliveryRepo, testWebhookConfig)
+	dispatcher.now = func() time.Time { return now }
+	return dispatcher, webhookRepo, deliveryRepo
+}
+
+// expectDue makes the delivery repository hand out delivery once and lets it be claimed
+func expectDue(deliveryRepo *MockWebhookDeliveryRepository, now time.Time, delivery *models.WebhookDelivery) {
+	deliveryRepo.On("GetDue", now, webhookDueBatchSize).Return([]*models.WebhookDelivery{delivery}, nil).Once()
+	deliveryRepo.On("Claim", delivery, now, now.Add(2*testWebhookConfig.Timeout)).Return(true, nil)
+	deliveryRepo.On("Update", delivery).Return(nil)
+}
+
+func TestWebhookDispatcher_Publish_QueuesSubscribedWebhooks(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	dispatcher, webhookRepo, deliveryRepo := newTestWebhookDispatcher(now)
+
+	webhookRepo.On("GetActiveByEvent", models.WebhookEventSupportRequestCreated).
+		Return([]*models.Webhook{{ID: 1}, {ID: 2}}, nil)
+	var queued []*models.WebhookDelivery
+	deliveryRepo.On("Create", mock.Anything).
+		Run(func(args mock.Arguments) { queued = args.Get(0).([]*models.WebhookDelivery) }).
+		Return(nil)
+
+	// Act
+	dispatcher.Publish(models.WebhookEventSupportRequestCreated, &models.SupportRequestResponse{ID: 7})
+
+	// Assert
+	assert.Len(t, queued, 2)
+	assert.Equal(t, uint(1), queued[0].WebhookID)
+	assert.Equal(t, uint(2), queued[1].WebhookID)
+	assert.Equal(t, queued[0].EventID, queued[1].EventID)
+	assert.Equal(t, models.WebhookDeliveryPending, queued[0].Status)
+	assert.Equal(t, now, *queued[0].NextAttemptAt)
+
+	var payload struct {
+		ID    string                        `json:"id"`
+		Event models.WebhookEventType       `json:"event"`
+		Data  models.SupportRequestResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(queued[0].Payload), &payload))
+	assert.Equal(t, queued[0].EventID, payload.ID)
+	assert.Equal(t, models.WebhookEventSupportRequestCreated, payload.Event)
+	assert.Equal(t, uint(7), payload.Data.ID)
+	assert.Len(t, dispatcher.wake, 1)
+}
+
+func TestWebhookDispatcher_Publish_NoSubscribers(t *testing.T) {
+	// Arrange
+	dispatcher, webhookRepo, deliveryRepo := newTestWebhookDispatcher(time.Now())
+	webhookRepo.On("GetActiveByEvent", models.WebhookEventSupportRequestDeleted).Return([]*models.Webhook{}, nil)
+
+	// Act
+	dispatcher.Publish(models.WebhookEventSupportRequestDeleted, &models.SupportRequestResponse{ID: 7})
+
+	// Assert
+	deliveryRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestWebhookDispatcher_DeliverDue_SignsAndSucceeds(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	dispatcher, webhookRepo, deliveryRepo := newTestWebhookDispatcher(now)
+	server, received := newWebhookReceiver(t, http.StatusNoContent)
+
+	webhook := &models.Webhook{ID: 1, URL: server.URL, Secret: "s3cr3t-signing-key-123", IsActive: true}
+	delivery := &models.WebhookDelivery{ID: 9, WebhookID: 1, EventID: "evt", Event: models.WebhookEventSupportRequestCreated, Payload: `{"id":"evt"}`, Status: models.WebhookDeliveryPending, NextAttemptAt: &now}
+	webhookRepo.On("GetByID", uint(1)).Return(webhook, nil)
+	expectDue(deliveryRepo, now, delivery)
+
+	// Act
+	attempted, err := dispatcher.DeliverDue(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	if assert.Len(t, *received, 1) {
+		request := (*received)[0]
+		assert.Equal(t, `{"id":"evt"}`, string(request.Body))
+		assert.Equal(t, SignWebhookPayload("s3cr3t-signing-key-123", request.Body), request.Headers.Get(WebhookSignatureHeader))
+		assert.Equal(t, "support_request.created", request.Headers.Get(WebhookEventHeader))
+		assert.Equal(t, "evt", request.Headers.Get(WebhookEventIDHeader))
+		assert.Equal(t, "9", request.Headers.Get(WebhookDeliveryHeader))
+		assert.Equal(t, "application/json", request.Headers.Get("Content-Type"))
+	}
+	assert.Equal(t, models.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, *delivery.ResponseStatus)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.Nil(t, delivery.LastError)
+}
+
+func TestWebhookDispatcher_DeliverDue_RetriesWithBackoff(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	dispatcher, webhookRepo, deliveryRepo := newTestWebhookDispatcher(now)
+	server, _ := newWebhookReceiver(t, http.StatusInternalServerError)
+
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1, URL: server.URL, Secret: "s3cr3t-signing-key-123", IsActive: true}, nil)
+	delivery := &models.WebhookDelivery{ID: 9, WebhookID: 1, Payload: "{}", Status: models.WebhookDeliveryPending, Attempts: 1, NextAttemptAt: &now}
+	expectDue(deliveryRepo, now, delivery)
+
+	// Act
+	_, err := dispatcher.DeliverDue(context.Background())
+
+	// Assert - the second failed attempt waits twice the base backoff
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, now.Add(2*time.Minute), *delivery.NextAttemptAt)
+	assert.Equal(t, http.StatusInternalServerError, *delivery.ResponseStatus)
+	assert.Equal(t, "unexpected status 500", *delivery.LastError)
+}
+
+func TestWebhookDispatcher_DeliverDue_FailsAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	dispatcher, webhookRepo, deliveryRepo := newTestWebhookDispatcher(now)
+	server, _ := newWebhookReceiver(t, http.StatusBadGateway)
+
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1, URL: server.URL, Secret: "s3cr3t-signing-key-123", IsActive: true}, nil)
+	delivery := &models.WebhookDelivery{ID: 9, WebhookID: 1, Payload: "{}", Status: models.WebhookDeliveryPending, Attempts: 2, NextAttemptAt: &now}
+	expectDue(deliveryRepo, now, delivery)
+
+	// Act
+	_, err := dispatcher.DeliverDue(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookDispatcher_DeliverDue_DeletedWebhookFails(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	dispatcher, webhookRepo, deliveryRepo := newTestWebhookDispatcher(now)
+
+	webhookRepo.On("GetByID", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	delivery := &models.WebhookDelivery{ID: 9, WebhookID: 1, Payload: "{}", Status: models.WebhookDeliveryPending, NextAttemptAt: &now}
+	expectDue(deliveryRepo, now, delivery)
+
+	// Act
+	_, err := dispatcher.DeliverDue(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, "webhook has been deleted", *delivery.LastError)
+}
+
+func TestWebhookDispatcher_DeliverDue_SkipsClaimedDeliveries(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	dispatcher, _, deliveryRepo := newTestWebhookDispatcher(now)
+
+	delivery := &models.WebhookDelivery{ID: 9, WebhookID: 1, Status: models.WebhookDeliveryPending, NextAttemptAt: &now}
+	deliveryRepo.On("GetDue", now, webhookDueBatchSize).Return([]*models.WebhookDelivery{delivery}, nil)
+	deliveryRepo.On("Claim", delivery, now, mock.Anything).Return(false, nil)
+
+	// Act
+	attempted, err := dispatcher.DeliverDue(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+	deliveryRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestWebhookDispatcher_DeliverDue_RepositoryError(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	dispatcher, _, deliveryRepo := newTestWebhookDispatcher(now)
+	deliveryRepo.On("GetDue", now, webhookDueBatchSize).Return([]*models.WebhookDelivery{}, errors.New("database error"))
+
+	// Act
+	_, err := dispatcher.DeliverDue(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "database error")
+}
+
+func TestWebhookDispatcher_RetryDelay(t *testing.T) {
+	dispatcher := NewWebhookDispatcher(nil, nil, testWebhookConfig)
+
+	assert.Equal(t, time.Minute, dispatcher.retryDelay(1))
+	assert.Equal(t, 2*time.Minute, dispatcher.retryDelay(2))
+	assert.Equal(t, 8*time.Minute, dispatcher.retryDelay(4))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Well-known HMAC-SHA256 example value
+	assert.Equal(t,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		SignWebhookPayload("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"support-app-backend/internal/models"
+	"support-app-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhook          = errors.New("invalid webhook")
+)
+
+// WebhookService defines the interface for webhook subscription management
+type WebhookService interface {
+	ListWebhooks() ([]*models.WebhookResponse, error)
+	GetWebhook(id uint) (*models.WebhookResponse, error)
+	CreateWebhook(req *models.CreateWebhookRequest) (*models.WebhookResponse, error)
+	UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.WebhookResponse, error)
+	DeleteWebhook(id uint) error
+	ListDeliveries(webhookID uint, page, pageSize int) ([]*models.WebhookDeliveryResponse, int64, error)
+	Redeliver(webhookID, deliveryID uint) (*models.WebhookDeliveryResponse, error)
+}
+
+// webhookService implements WebhookService
+type webhookService struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	wake         func()
+	now          func() time.Time
+}
+
+// NewWebhookService creates a new webhook service. wake is called after a redelivery is queued so it is
+// sent right away (see WebhookDispatcher.Wake).
+func NewWebhookService(webhookRepo repositories.WebhookRepository, deliveryRepo repositories.WebhookDeliveryRepository, wake func()) WebhookService {
+	return &webhookService{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		wake:         wake,
+		now:          time.Now,
+	}
+}
+
+// ListWebhooks retrieves every webhook
+func (s *webhookService) ListWebhooks() ([]*models.WebhookResponse, error) {
+	webhooks, err := s.webhookRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		responses[i] = webhook.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (s *webhookService) GetWebhook(id uint) (*models.WebhookResponse, error) {
+	webhook, err := s.getWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	return webhook.ToResponse(), nil
+}
+
+// CreateWebhook creates an active webhook. A signing secret is generated when none is given; the response
+// is the only place the secret is ever returned.
+func (s *webhookService) CreateWebhook(req *models.CreateWebhookRequest) (*models.WebhookResponse, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook := &models.Webhook{
+		URL:      req.URL,
+		Secret:   secret,
+		IsActive: true,
+	}
+	webhook.SetEventTypes(events)
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	response := webhook.ToResponse()
+	response.Secret = secret
+	return response, nil
+}
+
+// UpdateWebhook changes a webhook's URL, secret, events or active flag
+func (s *webhookService) UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.WebhookResponse, error) {
+	webhook, err := s.getWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.Events != nil {
+		events, err := normalizeWebhookEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.SetEventTypes(events)
+	}
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook.ToResponse(), nil
+}
+
+// DeleteWebhook deletes a webhook. Its pending deliveries fail on their next attempt.
+func (s *webhookService) DeleteWebhook(id uint) error {
+	if _, err := s.getWebhook(id); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(id)
+}
+
+// ListDeliveries retrieves the delivery log of a webhook with pagination, newest first
+func (s *webhookService) ListDeliveries(webhookID uint, page, pageSize int) ([]*models.WebhookDeliveryResponse, int64, error) {
+	if _, err := s.getWebhook(webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20 // Default page size
+	}
+
+	offset := (page - 1) * pageSize
+
+	deliveries, total, err := s.deliveryRepo.GetByWebhookID(webhookID, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*models.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = delivery.ToResponse()
+	}
+	return responses, total, nil
+}
+
+// Redeliver queues a new delivery with the same event and payload as an earlier one. The original
+// delivery is left untouched in the log.
+func (s *webhookService) Redeliver(webhookID, deliveryID uint) (*models.WebhookDeliveryResponse, error) {
+	webhook, err := s.getWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.IsActive {
+		return nil, fmt.Errorf("%w: webhook is inactive", ErrInvalidWebhook)
+	}
+
+	original, err := s.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	if original.WebhookID != webhook.ID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	now := s.now()
+	delivery := &models.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}
+	if err := s.deliveryRepo.Create(delivery); err != nil {
+		return nil, err
+	}
+
+	s.wake()
+	return delivery.ToResponse(), nil
+}
+
+// getWebhook loads a webhook, mapping missing records to ErrWebhookNotFound
+func (s *webhookService) getWebhook(id uint) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// validateWebhookURL only accepts absolute http and https URLs
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// normalizeWebhookEvents rejects unknown event types and drops duplicates
+func normalizeWebhookEvents(events []models.WebhookEventType) ([]models.WebhookEventType, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+
+	seen := make(map[models.WebhookEventType]bool, len(events))
+	normalized := make([]models.WebhookEventType, 0, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// newWebhookSecret generates a random signing secret
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"errors"
+	"support-app-backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockWebhookRepository is a mock implementation of WebhookRepository
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(webhook *models.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetByID(id uint) (*models.Webhook, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetAll() ([]*models.Webhook, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetActiveByEvent(event models.WebhookEventType) ([]*models.Webhook, error) {
+	args := m.Called(event)
+	return args.Get(0).([]*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(webhook *models.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockWebhookDeliveryRepository is a mock implementation of WebhookDeliveryRepository
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveryRepository) Create(deliveries ...*models.WebhookDelivery) error {
+	args := m.Called(deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) GetByID(id uint) (*models.WebhookDelivery, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) GetByWebhookID(webhookID uint, offset, limit int) ([]*models.WebhookDelivery, int64, error) {
+	args := m.Called(webhookID, offset, limit)
+	return args.Get(0).([]*models.WebhookDelivery), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookDeliveryRepository) GetDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) Claim(delivery *models.WebhookDelivery, now, leaseUntil time.Time) (bool, error) {
+	args := m.Called(delivery, now, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) Update(delivery *models.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func TestWebhookService_CreateWebhook_GeneratesSecret(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	service := NewWebhookService(webhookRepo, new(MockWebhookDeliveryRepository), func() {})
+
+	var created *models.Webhook
+	webhookRepo.On("Create", mock.AnythingOfType("*models.Webhook")).
+		Run(func(args mock.Arguments) { created = args.Get(0).(*models.Webhook) }).
+		Return(nil)
+
+	// Act
+	response, err := service.CreateWebhook(&models.CreateWebhookRequest{
+		URL:    "https://hooks.example.com/support",
+		Events: []models.WebhookEventType{models.WebhookEventSupportRequestCreated, models.WebhookEventSupportRequestCreated},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Secret, 64)
+	assert.Equal(t, response.Secret, created.Secret)
+	assert.True(t, created.IsActive)
+	assert.Equal(t, "support_request.created", created.Events)
+	webhookRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_CreateWebhook_Invalid(t *testing.T) {
+	service := NewWebhookService(new(MockWebhookRepository), new(MockWebhookDeliveryRepository), func() {})
+
+	requests := []*models.CreateWebhookRequest{
+		{URL: "ftp://hooks.example.com", Events: []models.WebhookEventType{models.WebhookEventSupportRequestCreated}},
+		{URL: "/relative", Events: []models.WebhookEventType{models.WebhookEventSupportRequestCreated}},
+		{URL: "https://hooks.example.com", Events: []models.WebhookEventType{"support_request.viewed"}},
+		{URL: "https://hooks.example.com"},
+	}
+	for _, req := range requests {
+		_, err := service.CreateWebhook(req)
+		assert.ErrorIs(t, err, ErrInvalidWebhook, req.URL)
+	}
+}
+
+func TestWebhookService_UpdateWebhook(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	service := NewWebhookService(webhookRepo, new(MockWebhookDeliveryRepository), func() {})
+
+	webhook := &models.Webhook{ID: 1, URL: "https://hooks.example.com/old", Secret: "old-secret-value-123", Events: "support_request.created", IsActive: true}
+	webhookRepo.On("GetByID", uint(1)).Return(webhook, nil)
+	webhookRepo.On("Update", webhook).Return(nil)
+	inactive := false
+
+	// Act
+	response, err := service.UpdateWebhook(1, &models.UpdateWebhookRequest{
+		Events:   []models.WebhookEventType{models.WebhookEventSupportRequestDeleted},
+		IsActive: &inactive,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, response.IsActive)
+	assert.Equal(t, []models.WebhookEventType{models.WebhookEventSupportRequestDeleted}, response.Events)
+	assert.Empty(t, response.Secret)
+	assert.Equal(t, "old-secret-value-123", webhook.Secret)
+	webhookRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_UpdateWebhook_NotFound(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	service := NewWebhookService(webhookRepo, new(MockWebhookDeliveryRepository), func() {})
+	webhookRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	_, err := service.UpdateWebhook(9, &models.UpdateWebhookRequest{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
+
+func TestWebhookService_DeleteWebhook(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	service := NewWebhookService(webhookRepo, new(MockWebhookDeliveryRepository), func() {})
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1}, nil)
+	webhookRepo.On("Delete", uint(1)).Return(nil)
+
+	// Act
+	err := service.DeleteWebhook(1)
+
+	// Assert
+	assert.NoError(t, err)
+	webhookRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	deliveryRepo := new(MockWebhookDeliveryRepository)
+	service := NewWebhookService(webhookRepo, deliveryRepo, func() {})
+
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1}, nil)
+	deliveryRepo.On("GetByWebhookID", uint(1), 20, 20).Return([]*models.WebhookDelivery{{ID: 5, WebhookID: 1}}, int64(21), nil)
+
+	// Act
+	deliveries, total, err := service.ListDeliveries(1, 2, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), total)
+	assert.Equal(t, uint(5), deliveries[0].ID)
+	deliveryRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	deliveryRepo := new(MockWebhookDeliveryRepository)
+	woken := false
+	service := NewWebhookService(webhookRepo, deliveryRepo, func() { woken = true })
+
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1, IsActive: true}, nil)
+	original := &models.WebhookDelivery{ID: 5, WebhookID: 1, EventID: "evt", Event: models.WebhookEventSupportRequestDeleted, Payload: `{"id":"evt"}`, Status: models.WebhookDeliveryFailed, Attempts: 5}
+	deliveryRepo.On("GetByID", uint(5)).Return(original, nil)
+	var queued *models.WebhookDelivery
+	deliveryRepo.On("Create", mock.Anything).
+		Run(func(args mock.Arguments) { queued = args.Get(0).([]*models.WebhookDelivery)[0] }).
+		Return(nil)
+
+	// Act
+	response, err := service.Redeliver(1, 5)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryPending, response.Status)
+	assert.Equal(t, "evt", queued.EventID)
+	assert.Equal(t, original.Payload, queued.Payload)
+	assert.Equal(t, 0, queued.Attempts)
+	assert.NotNil(t, queued.NextAttemptAt)
+	assert.Equal(t, models.WebhookDeliveryFailed, original.Status)
+	assert.True(t, woken)
+}
+
+func TestWebhookService_Redeliver_OtherWebhooksDelivery(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	deliveryRepo := new(MockWebhookDeliveryRepository)
+	service := NewWebhookService(webhookRepo, deliveryRepo, func() {})
+
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1, IsActive: true}, nil)
+	deliveryRepo.On("GetByID", uint(5)).Return(&models.WebhookDelivery{ID: 5, WebhookID: 2}, nil)
+
+	// Act
+	_, err := service.Redeliver(1, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrWebhookDeliveryNotFound)
+	deliveryRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestWebhookService_Redeliver_InactiveWebhook(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	service := NewWebhookService(webhookRepo, new(MockWebhookDeliveryRepository), func() {})
+	webhookRepo.On("GetByID", uint(1)).Return(&models.Webhook{ID: 1, IsActive: false}, nil)
+
+	// Act
+	_, err := service.Redeliver(1, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidWebhook)
+}
+
+func TestWebhookService_Redeliver_RepositoryError(t *testing.T) {
+	// Arrange
+	webhookRepo := new(MockWebhookRepository)
+	service := NewWebhookService(webhookRepo, new(MockWebhookDeliveryRepository), func() {})
+	webhookRepo.On("GetByID", uint(1)).Return(nil, errors.New("database error"))
+
+	// Act
+	_, err := service.Redeliver(1, 5)
+
+	// Assert
+	assert.EqualError(t, err, "database error")
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_PutOpenDelete(t *testing.T) {
+	// Arrange
+	store := NewLocalStorage(t.TempDir())
+
+	// Act
+	err := store.Put("support-requests/1/abc", strings.NewReader("log contents"))
+	require.NoError(t, err)
+
+	reader, err := store.Open("support-requests/1/abc")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	reader.Close()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "log contents", string(content))
+
+	require.NoError(t, store.Delete("support-requests/1/abc"))
+	_, err = store.Open("support-requests/1/abc")
+	assert.Equal(t, ErrBlobNotFound, err)
+}
+
+func TestLocalStorage_PutReplacesExistingBlob(t *testing.T) {
+	store := NewLocalStorage(t.TempDir())
+
+	require.NoError(t, store.Put("a/b", strings.NewReader("first")))
+	require.NoError(t, store.Put("a/b", strings.NewReader("second")))
+
+	reader, err := store.Open("a/b")
+	require.NoError(t, err)
+	defer reader.Close()
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, "second", string(content))
+}
+
+func TestLocalStorage_OpenMissingBlob(t *testing.T) {
+	store := NewLocalStorage(t.TempDir())
+
+	_, err := store.Open("missing")
+
+	assert.Equal(t, ErrBlobNotFound, err)
+}
+
+func TestLocalStorage_DeleteMissingBlob(t *testing.T) {
+	store := NewLocalStorage(t.TempDir())
+
+	assert.NoError(t, store.Delete("missing"))
+}
+
+func TestLocalStorage_RejectsInvalidKeys(t *testing.T) {
+	store := NewLocalStorage(t.TempDir())
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "a/../../b", "a//b", "a\\b"} {
+		assert.Equal(t, ErrInvalidBlobKey, store.Put(key, strings.NewReader("x")), "key %q", key)
+		_, err := store.Open(key)
+		assert.Equal(t, ErrInvalidBlobKey, err, "key %q", key)
+		assert.Equal(t, ErrInvalidBlobKey, store.Delete(key), "key %q", key)
+	}
+}
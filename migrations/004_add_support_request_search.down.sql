@@ -0,0 +1,2 @@
+-- Remove full-text search index from support_requests table
+DROP INDEX IF EXISTS idx_support_requests_search;
@@ -0,0 +1,5 @@
+-- Full-text search over support request messages and admin notes.
+-- The expression must match searchDocument in internal/repositories/support_request_search.go
+-- so PostgreSQL can use this index for @@ queries.
+CREATE INDEX IF NOT EXISTS idx_support_requests_search ON support_requests
+    USING GIN (to_tsvector('english', coalesce(message, '') || ' ' || coalesce(admin_notes, '')));
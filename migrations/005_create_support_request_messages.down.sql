@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_support_request_messages_deleted_at;
+DROP INDEX IF EXISTS idx_support_request_messages_author_user_id;
+DROP INDEX IF EXISTS idx_support_request_messages_support_request_id;
+DROP TABLE IF EXISTS support_request_messages;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS support_request_messages (
+    id SERIAL PRIMARY KEY,
+    support_request_id INTEGER NOT NULL REFERENCES support_requests(id) ON DELETE CASCADE,
+    author_type VARCHAR(20) NOT NULL CHECK (author_type IN ('agent', 'submitter')),
+    author_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public' CHECK (visibility IN ('public', 'internal')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_support_request_messages_support_request_id ON support_request_messages(support_request_id);
+CREATE INDEX IF NOT EXISTS idx_support_request_messages_author_user_id ON support_request_messages(author_user_id);
+CREATE INDEX IF NOT EXISTS idx_support_request_messages_deleted_at ON support_request_messages(deleted_at);
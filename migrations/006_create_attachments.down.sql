@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_attachments_deleted_at;
+DROP INDEX IF EXISTS idx_attachments_support_request_id;
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id SERIAL PRIMARY KEY,
+    support_request_id INTEGER NOT NULL REFERENCES support_requests(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_attachments_support_request_id ON attachments(support_request_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_deleted_at ON attachments(deleted_at);
@@ -0,0 +1,3 @@
+-- Remove assignee column from support_requests table
+DROP INDEX IF EXISTS idx_support_requests_assignee_id;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS assignee_id;
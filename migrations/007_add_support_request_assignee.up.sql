@@ -0,0 +1,5 @@
+-- Add assignee column to support_requests table
+ALTER TABLE support_requests ADD COLUMN assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- Create index for the assignee column to speed up "my queue" listings
+CREATE INDEX IF NOT EXISTS idx_support_requests_assignee_id ON support_requests(assignee_id);
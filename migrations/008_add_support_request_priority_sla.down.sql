@@ -0,0 +1,8 @@
+-- Remove priority and SLA tracking columns from support_requests table
+DROP INDEX IF EXISTS idx_support_requests_resolution_due_at;
+DROP INDEX IF EXISTS idx_support_requests_first_response_due_at;
+DROP INDEX IF EXISTS idx_support_requests_priority;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS first_responded_at;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS resolution_due_at;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS first_response_due_at;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS priority;
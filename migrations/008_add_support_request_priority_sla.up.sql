@@ -0,0 +1,10 @@
+-- Add priority and SLA tracking columns to support_requests table
+ALTER TABLE support_requests ADD COLUMN priority VARCHAR(20) NOT NULL DEFAULT 'normal';
+ALTER TABLE support_requests ADD COLUMN first_response_due_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE support_requests ADD COLUMN resolution_due_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE support_requests ADD COLUMN first_responded_at TIMESTAMP WITH TIME ZONE;
+
+-- Create indexes for the priority filter and the breached / due soon listings
+CREATE INDEX IF NOT EXISTS idx_support_requests_priority ON support_requests(priority);
+CREATE INDEX IF NOT EXISTS idx_support_requests_first_response_due_at ON support_requests(first_response_due_at);
+CREATE INDEX IF NOT EXISTS idx_support_requests_resolution_due_at ON support_requests(resolution_due_at);
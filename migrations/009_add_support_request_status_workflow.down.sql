@@ -0,0 +1,11 @@
+-- Remove resolution and closing timestamps
+ALTER TABLE support_requests DROP COLUMN IF EXISTS closed_at;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS resolved_at;
+
+-- Map workflow statuses back onto the original three before restoring the constraint
+UPDATE support_requests SET status = 'in_progress' WHERE status IN ('waiting_on_customer', 'reopened');
+UPDATE support_requests SET status = 'resolved' WHERE status IN ('closed', 'spam');
+
+ALTER TABLE support_requests DROP CONSTRAINT IF EXISTS support_requests_status_check;
+ALTER TABLE support_requests ADD CONSTRAINT support_requests_status_check
+    CHECK (status IN ('new', 'in_progress', 'resolved'));
@@ -0,0 +1,11 @@
+-- Allow the statuses of the support request workflow
+ALTER TABLE support_requests DROP CONSTRAINT IF EXISTS support_requests_status_check;
+ALTER TABLE support_requests ADD CONSTRAINT support_requests_status_check
+    CHECK (status IN ('new', 'in_progress', 'waiting_on_customer', 'resolved', 'closed', 'reopened', 'spam'));
+
+-- Record when requests are resolved and closed
+ALTER TABLE support_requests ADD COLUMN resolved_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE support_requests ADD COLUMN closed_at TIMESTAMP WITH TIME ZONE;
+
+-- Backfill resolved requests with their last update time
+UPDATE support_requests SET resolved_at = updated_at WHERE status = 'resolved';
@@ -0,0 +1,3 @@
+-- Drop tags and their support request links
+DROP TABLE IF EXISTS support_request_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,17 @@
+-- Create tags and the join table linking them to support requests
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    color VARCHAR(7),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS support_request_tags (
+    support_request_id INTEGER NOT NULL REFERENCES support_requests(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (support_request_id, tag_id)
+);
+
+-- Look up the requests carrying a tag when filtering by tag
+CREATE INDEX IF NOT EXISTS idx_support_request_tags_tag_id ON support_request_tags(tag_id);
@@ -0,0 +1,4 @@
+-- Drop the audit log
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS prevent_audit_event_changes();
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,3 @@
+-- Drop webhook subscriptions and their delivery log
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
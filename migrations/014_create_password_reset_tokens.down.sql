@@ -0,0 +1,2 @@
+-- Drop password reset tokens
+DROP TABLE IF EXISTS password_reset_tokens;
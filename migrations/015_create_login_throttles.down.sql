@@ -0,0 +1,2 @@
+-- Drop login throttles
+DROP TABLE IF EXISTS login_throttles;
@@ -0,0 +1,6 @@
+-- Drop custom roles and demote users without an original role
+DROP TABLE IF EXISTS roles;
+
+UPDATE users SET role = 'user' WHERE role NOT IN ('admin', 'user');
+ALTER TABLE users ALTER COLUMN role TYPE VARCHAR(20);
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'user'));
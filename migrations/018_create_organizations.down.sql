@@ -0,0 +1,7 @@
+-- Drop organizations, their memberships and apps
+DROP INDEX IF EXISTS idx_support_requests_organization_id;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS apps;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,12 @@
+-- Drop app intake keys and the registry columns. Apps without an organization are removed, since
+-- the previous schema requires one.
+DROP TABLE IF EXISTS app_keys;
+
+ALTER TABLE apps DROP COLUMN IF EXISTS is_active;
+ALTER TABLE apps DROP COLUMN IF EXISTS platforms;
+ALTER TABLE apps DROP COLUMN IF EXISTS display_name;
+
+DELETE FROM apps WHERE organization_id IS NULL;
+ALTER TABLE apps DROP CONSTRAINT IF EXISTS apps_organization_id_fkey;
+ALTER TABLE apps ADD CONSTRAINT apps_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE apps ALTER COLUMN organization_id SET NOT NULL;
@@ -0,0 +1,29 @@
+-- Turn apps into a registry of apps allowed to submit support requests. Apps may exist without an
+-- owning organization, and deleting an organization keeps its apps registered.
+ALTER TABLE apps ALTER COLUMN organization_id DROP NOT NULL;
+ALTER TABLE apps DROP CONSTRAINT IF EXISTS apps_organization_id_fkey;
+ALTER TABLE apps ADD CONSTRAINT apps_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE SET NULL;
+
+-- platforms is a comma-separated list of allowed platforms, empty for any
+ALTER TABLE apps ADD COLUMN display_name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE apps ADD COLUMN platforms VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE apps ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+UPDATE apps SET display_name = slug WHERE display_name = '';
+
+-- Register every app that already submitted support requests, so existing clients keep working
+INSERT INTO apps (slug, display_name)
+SELECT DISTINCT app, app FROM support_requests WHERE app <> ''
+ON CONFLICT (slug) DO NOTHING;
+
+-- Create app intake keys. Only a SHA-256 hash of each key is stored.
+CREATE TABLE IF NOT EXISTS app_keys (
+    id SERIAL PRIMARY KEY,
+    app_id INTEGER NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_keys_app_id ON app_keys(app_id);
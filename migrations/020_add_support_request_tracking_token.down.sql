@@ -0,0 +1,3 @@
+-- Remove submitter tracking tokens
+DROP INDEX IF EXISTS idx_support_requests_tracking_token_hash;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS tracking_token_hash;
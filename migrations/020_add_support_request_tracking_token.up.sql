@@ -0,0 +1,5 @@
+-- Let submitters follow their support requests with a tracking token. Only a SHA-256 hash of each
+-- token is stored; requests submitted before this migration have none.
+ALTER TABLE support_requests ADD COLUMN tracking_token_hash VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_support_requests_tracking_token_hash ON support_requests(tracking_token_hash);
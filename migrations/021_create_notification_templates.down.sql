@@ -0,0 +1,3 @@
+-- Drop notification templates and submitter opt-outs
+DROP TABLE IF EXISTS notification_templates;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS unsubscribed_at;
@@ -0,0 +1,15 @@
+-- Record when a submitter opts out of email notifications about their support request
+ALTER TABLE support_requests ADD COLUMN unsubscribed_at TIMESTAMP WITH TIME ZONE;
+
+-- Create per-app overrides of the notification emails sent to submitters
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id SERIAL PRIMARY KEY,
+    app_id INTEGER NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    event VARCHAR(30) NOT NULL CHECK (event IN ('status_changed', 'reply')),
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_templates_app_event ON notification_templates(app_id, event);
@@ -0,0 +1,11 @@
+-- Drop the record of received emails
+DROP INDEX IF EXISTS idx_inbound_emails_support_request_id;
+DROP INDEX IF EXISTS idx_inbound_emails_message_id;
+DROP TABLE IF EXISTS inbound_emails;
+
+-- Map email requests onto Web before restoring the constraint
+UPDATE support_requests SET platform = 'Web' WHERE platform = 'Email';
+
+ALTER TABLE support_requests DROP CONSTRAINT IF EXISTS support_requests_platform_check;
+ALTER TABLE support_requests ADD CONSTRAINT support_requests_platform_check
+    CHECK (platform IN ('iOS', 'Android', 'Web'));
@@ -0,0 +1,18 @@
+-- Accept support requests received by email. Web was accepted by the API before, but missing here.
+ALTER TABLE support_requests DROP CONSTRAINT IF EXISTS support_requests_platform_check;
+ALTER TABLE support_requests ADD CONSTRAINT support_requests_platform_check
+    CHECK (platform IN ('iOS', 'Android', 'Web', 'Email'));
+
+-- Record received emails, so that redelivered messages are only processed once
+CREATE TABLE IF NOT EXISTS inbound_emails (
+    id SERIAL PRIMARY KEY,
+    message_id VARCHAR(998),
+    sender VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('created', 'replied', 'ignored')),
+    support_request_id INTEGER REFERENCES support_requests(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inbound_emails_message_id ON inbound_emails(message_id);
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_support_request_id ON inbound_emails(support_request_id);
@@ -0,0 +1,14 @@
+-- Store the responses to support request submissions with an Idempotency-Key, so that retries don't create duplicates
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    scope VARCHAR(50) NOT NULL DEFAULT '',
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64),
+    response_status INTEGER NOT NULL DEFAULT 0,
+    response_body TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_scope_key ON idempotency_keys(scope, key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_support_requests_duplicate_lookup;
+DROP INDEX IF EXISTS idx_support_requests_merged_into_id;
+DROP INDEX IF EXISTS idx_support_requests_duplicate_of_id;
+
+ALTER TABLE support_requests DROP COLUMN IF EXISTS merged_into_id;
+ALTER TABLE support_requests DROP COLUMN IF EXISTS duplicate_of_id;
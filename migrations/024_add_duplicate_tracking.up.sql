@@ -0,0 +1,9 @@
+-- Flag suspected duplicate submissions and link merged requests to the request they were merged into
+ALTER TABLE support_requests ADD COLUMN IF NOT EXISTS duplicate_of_id INTEGER REFERENCES support_requests(id) ON DELETE SET NULL;
+ALTER TABLE support_requests ADD COLUMN IF NOT EXISTS merged_into_id INTEGER REFERENCES support_requests(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_support_requests_duplicate_of_id ON support_requests(duplicate_of_id);
+CREATE INDEX IF NOT EXISTS idx_support_requests_merged_into_id ON support_requests(merged_into_id);
+
+-- Duplicates are looked up by submitter and app within a recent window
+CREATE INDEX IF NOT EXISTS idx_support_requests_duplicate_lookup ON support_requests(app, LOWER(user_email), created_at);
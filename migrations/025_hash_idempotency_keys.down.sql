@@ -0,0 +1,2 @@
+-- Keys stored hashed can't be replayed in the old format
+DELETE FROM idempotency_keys;
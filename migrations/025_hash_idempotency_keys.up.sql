@@ -0,0 +1,3 @@
+-- Store idempotency keys hashed, with their responses encrypted by the key.
+-- Stored keys can't be replayed in the new format, so they are dropped.
+DELETE FROM idempotency_keys;
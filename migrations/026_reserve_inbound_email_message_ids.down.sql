@@ -0,0 +1,4 @@
+DELETE FROM inbound_emails WHERE action = 'processing';
+ALTER TABLE inbound_emails DROP CONSTRAINT IF EXISTS inbound_emails_action_check;
+ALTER TABLE inbound_emails ADD CONSTRAINT inbound_emails_action_check
+    CHECK (action IN ('created', 'replied', 'ignored'));
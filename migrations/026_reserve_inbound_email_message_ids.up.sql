@@ -0,0 +1,5 @@
+-- Reserve the Message-ID of a received email while it is processed, so that concurrent deliveries of
+-- the same email are only processed once
+ALTER TABLE inbound_emails DROP CONSTRAINT IF EXISTS inbound_emails_action_check;
+ALTER TABLE inbound_emails ADD CONSTRAINT inbound_emails_action_check
+    CHECK (action IN ('created', 'replied', 'ignored', 'processing'));